@@ -0,0 +1,198 @@
+package eventschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/validate"
+)
+
+var /*const*/ ErrInvalidEventData = errors.New("event data does not conform to the schema of the event type")
+var /*const*/ ErrSchemaNotFound = errors.New("event schema not found")
+
+// ProjectSchemaDirectory is the directory of the project resources containing the schemas of the project, named
+// after the event type, e.g. event-schemas/sh.keptn.event.securityscan.finished.json
+const ProjectSchemaDirectory = "event-schemas"
+
+const schemaFileExtension = ".json"
+
+// maxCachedSchemas limits the number of cached schemas, since event types are chosen by the senders of events
+const maxCachedSchemas = 1000
+
+// event types are used as file names and resource URIs of schemas
+var eventTypePattern = regexp.MustCompile(`^[a-zA-Z0-9][-_.a-zA-Z0-9]*$`)
+
+//go:generate moq -pkg eventschema_mock --skip-ensure -out ./fake/projectschemaretriever_mock.go . ProjectSchemaRetriever:ProjectSchemaRetrieverMock
+
+// ProjectSchemaRetriever retrieves the content of the schemas stored as resources of a project
+type ProjectSchemaRetriever interface {
+	// GetProjects returns the names of the existing projects
+	GetProjects() ([]string, error)
+	// GetProjectSchema returns the schema for the event type or ErrSchemaNotFound if the project has no such schema
+	GetProjectSchema(project string, eventType string) ([]byte, error)
+}
+
+type cachedSchema struct {
+	schema   *spec.Schema
+	loadedAt time.Time
+}
+
+// Registry holds the JSON schemas of the data of event types. A schema stored as resource of the project of an event
+// takes precedence over a global schema in the schema directory of the API service. Schemas are only retrieved for
+// existing projects. Schemas and the list of projects are cached and reloaded after the cache TTL
+type Registry struct {
+	projectSchemas   ProjectSchemaRetriever
+	globalDir        string
+	cacheTTL         time.Duration
+	theClock         clock.Clock
+	cache            map[string]cachedSchema
+	projects         map[string]bool
+	projectsLoadedAt time.Time
+	mutex            sync.Mutex
+}
+
+// NewRegistry creates a Registry. Without projectSchemas only global schemas are used, without globalDir only the
+// schemas of projects
+func NewRegistry(projectSchemas ProjectSchemaRetriever, globalDir string, cacheTTL time.Duration, theClock clock.Clock) *Registry {
+	return &Registry{
+		projectSchemas: projectSchemas,
+		globalDir:      globalDir,
+		cacheTTL:       cacheTTL,
+		theClock:       theClock,
+		cache:          map[string]cachedSchema{},
+	}
+}
+
+// Validate validates the data of an event against the schema of its type. Events without schema are valid. If the
+// data does not conform to the schema, an error wrapping ErrInvalidEventData is returned. Other errors indicate that
+// the schema could not be retrieved
+func (r *Registry) Validate(project string, eventType string, data interface{}) error {
+	schema, err := r.Get(project, eventType)
+	if err != nil {
+		return err
+	}
+	if schema == nil {
+		return nil
+	}
+	if err := validate.AgainstSchema(schema, data, strfmt.Default); err != nil {
+		return fmt.Errorf("%w %s: %v", ErrInvalidEventData, eventType, err)
+	}
+	return nil
+}
+
+// Get returns the schema for the event type, or nil if there is none
+func (r *Registry) Get(project string, eventType string) (*spec.Schema, error) {
+	if !eventTypePattern.MatchString(eventType) {
+		return nil, nil
+	}
+	if project != "" && r.projectSchemas != nil {
+		exists, err := r.projectExists(project)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return r.getGlobal(eventType)
+		}
+		schema, err := r.cached("project/"+project+"/"+eventType, func() ([]byte, error) {
+			return r.projectSchemas.GetProjectSchema(project, eventType)
+		})
+		if err != nil || schema != nil {
+			return schema, err
+		}
+	}
+	return r.getGlobal(eventType)
+}
+
+func (r *Registry) getGlobal(eventType string) (*spec.Schema, error) {
+	if r.globalDir == "" {
+		return nil, nil
+	}
+	return r.cached("global/"+eventType, func() ([]byte, error) {
+		content, err := os.ReadFile(filepath.Join(r.globalDir, eventType+schemaFileExtension))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrSchemaNotFound
+		}
+		return content, err
+	})
+}
+
+func (r *Registry) cached(key string, load func() ([]byte, error)) (*spec.Schema, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := r.theClock.Now()
+	if cached, ok := r.cache[key]; ok && now.Sub(cached.loadedAt) < r.cacheTTL {
+		return cached.schema, nil
+	}
+
+	var schema *spec.Schema
+	content, err := load()
+	switch {
+	case errors.Is(err, ErrSchemaNotFound):
+		// remember that there is no schema, so it is not retrieved again for every event
+	case err != nil:
+		return nil, fmt.Errorf("could not retrieve event schema %s: %w", key, err)
+	default:
+		if schema, err = parseSchema(content); err != nil {
+			return nil, fmt.Errorf("invalid event schema %s: %w", key, err)
+		}
+	}
+	r.store(key, cachedSchema{schema: schema, loadedAt: now})
+	return schema, nil
+}
+
+// store adds the schema to the cache. If the cache is full, the expired schemas are removed first. The caller must
+// hold the mutex
+func (r *Registry) store(key string, cached cachedSchema) {
+	if len(r.cache) >= maxCachedSchemas {
+		for k, c := range r.cache {
+			if cached.loadedAt.Sub(c.loadedAt) >= r.cacheTTL {
+				delete(r.cache, k)
+			}
+		}
+	}
+	// if the cache is still full, the schema is retrieved again for the next event
+	if len(r.cache) < maxCachedSchemas {
+		r.cache[key] = cached
+	}
+}
+
+// projectExists checks whether the project exists, so that no schemas are retrieved for unknown projects
+func (r *Registry) projectExists(project string) (bool, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := r.theClock.Now()
+	if r.projects == nil || now.Sub(r.projectsLoadedAt) >= r.cacheTTL {
+		projects, err := r.projectSchemas.GetProjects()
+		if err != nil {
+			return false, fmt.Errorf("could not retrieve projects: %w", err)
+		}
+		r.projects = map[string]bool{}
+		for _, p := range projects {
+			r.projects[p] = true
+		}
+		r.projectsLoadedAt = now
+	}
+	return r.projects[project], nil
+}
+
+func parseSchema(content []byte) (*spec.Schema, error) {
+	schema := &spec.Schema{}
+	if err := json.Unmarshal(content, schema); err != nil {
+		return nil, err
+	}
+	if err := spec.ExpandSchema(schema, schema, nil); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
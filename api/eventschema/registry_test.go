@@ -0,0 +1,221 @@
+package eventschema
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	eventschema_mock "github.com/keptn/keptn/api/eventschema/fake"
+)
+
+const securityScanSchema = `{
+  "type": "object",
+  "required": ["project", "securityscan"],
+  "properties": {
+    "project": {"type": "string"},
+    "securityscan": {
+      "type": "object",
+      "required": ["vulnerabilities"],
+      "properties": {
+        "vulnerabilities": {"type": "integer", "minimum": 0},
+        "severity": {"$ref": "#/definitions/severity"}
+      }
+    }
+  },
+  "definitions": {
+    "severity": {"type": "string", "enum": ["low", "medium", "high"]}
+  }
+}`
+
+const globalSchema = `{
+  "type": "object",
+  "required": ["securityscan"],
+  "properties": {
+    "securityscan": {"type": "object"}
+  }
+}`
+
+func TestRegistry_Validate(t *testing.T) {
+	globalDir := t.TempDir()
+	require.NoError(t, os.WriteFile(
+		filepath.Join(globalDir, "sh.keptn.event.securityscan.finished.json"), []byte(globalSchema), 0644,
+	))
+
+	retriever := &eventschema_mock.ProjectSchemaRetrieverMock{
+		GetProjectsFunc: func() ([]string, error) {
+			return []string{"sockshop", "podtato-head", "broken"}, nil
+		},
+		GetProjectSchemaFunc: func(project string, eventType string) ([]byte, error) {
+			if project == "sockshop" && eventType == "sh.keptn.event.securityscan.finished" {
+				return []byte(securityScanSchema), nil
+			}
+			if project == "broken" {
+				return nil, errors.New("resource-service not available")
+			}
+			return nil, ErrSchemaNotFound
+		},
+	}
+	registry := NewRegistry(retriever, globalDir, time.Minute, clock.NewMock())
+
+	tests := []struct {
+		name       string
+		project    string
+		eventType  string
+		data       interface{}
+		wantErr    error
+		wantAnyErr bool
+	}{
+		{
+			name:      "valid data of project schema",
+			project:   "sockshop",
+			eventType: "sh.keptn.event.securityscan.finished",
+			data: map[string]interface{}{
+				"project":      "sockshop",
+				"securityscan": map[string]interface{}{"vulnerabilities": 3.0, "severity": "high"},
+			},
+		},
+		{
+			name:      "missing property of project schema",
+			project:   "sockshop",
+			eventType: "sh.keptn.event.securityscan.finished",
+			data: map[string]interface{}{
+				"project":      "sockshop",
+				"securityscan": map[string]interface{}{"severity": "high"},
+			},
+			wantErr: ErrInvalidEventData,
+		},
+		{
+			name:      "invalid referenced property of project schema",
+			project:   "sockshop",
+			eventType: "sh.keptn.event.securityscan.finished",
+			data: map[string]interface{}{
+				"project":      "sockshop",
+				"securityscan": map[string]interface{}{"vulnerabilities": 3.0, "severity": "critical"},
+			},
+			wantErr: ErrInvalidEventData,
+		},
+		{
+			name:      "global schema for project without schema",
+			project:   "podtato-head",
+			eventType: "sh.keptn.event.securityscan.finished",
+			data:      map[string]interface{}{"project": "podtato-head"},
+			wantErr:   ErrInvalidEventData,
+		},
+		{
+			name:      "global schema for unknown project",
+			project:   "unknown",
+			eventType: "sh.keptn.event.securityscan.finished",
+			data:      map[string]interface{}{"project": "unknown"},
+			wantErr:   ErrInvalidEventData,
+		},
+		{
+			name:      "global schema for event without project",
+			eventType: "sh.keptn.event.securityscan.finished",
+			data:      map[string]interface{}{"securityscan": map[string]interface{}{}},
+		},
+		{
+			name:      "event type without schema",
+			project:   "sockshop",
+			eventType: "sh.keptn.event.deployment.finished",
+			data:      map[string]interface{}{"anything": true},
+		},
+		{
+			name:      "event type that cannot name a schema",
+			project:   "sockshop",
+			eventType: "../../secrets",
+			data:      map[string]interface{}{},
+		},
+		{
+			name:       "project schema not retrievable",
+			project:    "broken",
+			eventType:  "sh.keptn.event.securityscan.finished",
+			data:       map[string]interface{}{},
+			wantAnyErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := registry.Validate(tt.project, tt.eventType, tt.data)
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.wantAnyErr:
+				assert.Error(t, err)
+				assert.NotErrorIs(t, err, ErrInvalidEventData)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	// schemas are only retrieved for existing projects
+	for _, call := range retriever.GetProjectSchemaCalls() {
+		assert.NotEqual(t, "unknown", call.Project)
+	}
+	assert.Len(t, retriever.GetProjectsCalls(), 1)
+}
+
+func TestRegistry_Cache(t *testing.T) {
+	mockClock := clock.NewMock()
+	schema := ""
+	retriever := &eventschema_mock.ProjectSchemaRetrieverMock{
+		GetProjectsFunc: func() ([]string, error) {
+			return []string{"sockshop"}, nil
+		},
+		GetProjectSchemaFunc: func(project string, eventType string) ([]byte, error) {
+			if schema == "" {
+				return nil, ErrSchemaNotFound
+			}
+			return []byte(schema), nil
+		},
+	}
+	registry := NewRegistry(retriever, "", time.Minute, mockClock)
+	data := map[string]interface{}{"project": "sockshop"}
+
+	require.NoError(t, registry.Validate("sockshop", "sh.keptn.event.securityscan.finished", data))
+	require.NoError(t, registry.Validate("sockshop", "sh.keptn.event.securityscan.finished", data))
+	assert.Len(t, retriever.GetProjectSchemaCalls(), 1)
+
+	// a schema added to the project is used once the cache TTL has passed
+	schema = securityScanSchema
+	mockClock.Add(time.Minute)
+	assert.ErrorIs(t, registry.Validate("sockshop", "sh.keptn.event.securityscan.finished", data), ErrInvalidEventData)
+	assert.Len(t, retriever.GetProjectSchemaCalls(), 2)
+
+	// invalid schemas are reported
+	schema = `{"type": `
+	mockClock.Add(time.Minute)
+	err := registry.Validate("sockshop", "sh.keptn.event.securityscan.finished", data)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrInvalidEventData)
+}
+
+func TestRegistry_CacheLimit(t *testing.T) {
+	mockClock := clock.NewMock()
+	retriever := &eventschema_mock.ProjectSchemaRetrieverMock{
+		GetProjectsFunc: func() ([]string, error) {
+			return []string{"sockshop"}, nil
+		},
+		GetProjectSchemaFunc: func(project string, eventType string) ([]byte, error) {
+			return nil, ErrSchemaNotFound
+		},
+	}
+	registry := NewRegistry(retriever, "", time.Minute, mockClock)
+
+	for i := 0; i < maxCachedSchemas+10; i++ {
+		require.NoError(t, registry.Validate("sockshop", fmt.Sprintf("sh.keptn.event.task%d.finished", i), nil))
+	}
+	assert.Len(t, registry.cache, maxCachedSchemas)
+
+	// expired schemas are removed once the cache is full
+	mockClock.Add(time.Minute)
+	require.NoError(t, registry.Validate("sockshop", "sh.keptn.event.deployment.finished", nil))
+	assert.Len(t, registry.cache, 1)
+}
@@ -0,0 +1,44 @@
+package eventschema
+
+import (
+	"errors"
+
+	apiutils "github.com/keptn/go-utils/pkg/api/utils"
+
+	"github.com/keptn/keptn/api/importer/execute"
+)
+
+// ResourceServiceSchemaRetriever retrieves the projects from the control plane and their schemas from the resource-service
+type ResourceServiceSchemaRetriever struct {
+	endpointProvider execute.KeptnEndpointProvider
+}
+
+func NewResourceServiceSchemaRetriever(provider execute.KeptnEndpointProvider) *ResourceServiceSchemaRetriever {
+	return &ResourceServiceSchemaRetriever{endpointProvider: provider}
+}
+
+func (r *ResourceServiceSchemaRetriever) GetProjects() ([]string, error) {
+	projects, err := apiutils.NewProjectHandler(r.endpointProvider.GetControlPlaneEndpoint()).GetAllProjects()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(projects))
+	for _, project := range projects {
+		names = append(names, project.ProjectName)
+	}
+	return names, nil
+}
+
+func (r *ResourceServiceSchemaRetriever) GetProjectSchema(project string, eventType string) ([]byte, error) {
+	resourceHandler := apiutils.NewResourceHandler(r.endpointProvider.GetConfigurationServiceEndpoint())
+	resource, err := resourceHandler.GetResource(
+		*apiutils.NewResourceScope().Project(project).Resource(ProjectSchemaDirectory + "/" + eventType + schemaFileExtension),
+	)
+	if errors.Is(err, apiutils.ResourceNotFoundError) {
+		return nil, ErrSchemaNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []byte(resource.ResourceContent), nil
+}
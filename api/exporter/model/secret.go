@@ -0,0 +1,10 @@
+package model
+
+// Secret contains the metadata of a secret bound to a project. The values of the secret are never exported
+type Secret struct {
+	Name    string   `json:"name"`
+	Scope   string   `json:"scope,omitempty"`
+	Project string   `json:"project,omitempty"`
+	Stage   string   `json:"stage,omitempty"`
+	Keys    []string `json:"keys"`
+}
@@ -0,0 +1,331 @@
+package exporter
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"gopkg.in/yaml.v3"
+
+	exportmodel "github.com/keptn/keptn/api/exporter/model"
+	"github.com/keptn/keptn/api/importer/model"
+)
+
+//go:generate moq -pkg fake --skip-ensure -out ./fake/retriever_mock.go . ProjectDataRetriever:MockProjectDataRetriever
+
+// ProjectDataRetriever retrieves the configuration of a project from the Keptn services
+type ProjectDataRetriever interface {
+	GetProject(project string) (*apimodels.Project, error)
+	GetShipyard(project string) ([]byte, error)
+	GetStageResourceURIs(project string, stage string) ([]string, error)
+	GetStageResource(project string, stage string, resourceURI string) ([]byte, error)
+	GetWebhookSubscriptions(project string) ([]apimodels.EventSubscription, error)
+	GetSecrets(project string) ([]exportmodel.Secret, error)
+}
+
+const manifestAPIVersion = "v1beta1"
+const manifestFileName = "manifest.yaml"
+const shipyardFileName = "shipyard.yaml"
+const metadataFileName = "metadata.yaml"
+const apiTaskType = "api"
+const resourceTaskType = "resource"
+
+// ProjectExporter walks through the configuration of a project and writes it as an import package, i.e. a zip archive
+// containing a manifest with the tasks and the payloads needed to replay the configuration with POST /import
+type ProjectExporter struct {
+	retriever ProjectDataRetriever
+}
+
+// NewProjectExporter instantiates a new ProjectExporter retrieving the project configuration using retriever
+func NewProjectExporter(retriever ProjectDataRetriever) *ProjectExporter {
+	return &ProjectExporter{
+		retriever: retriever,
+	}
+}
+
+// exportPackage holds the manifest and the files of an import package while it is being assembled
+type exportPackage struct {
+	manifest  model.ImportManifest
+	fileNames []string
+	files     map[string][]byte
+}
+
+func newExportPackage() *exportPackage {
+	return &exportPackage{
+		manifest: model.ImportManifest{ApiVersion: manifestAPIVersion},
+		files:    map[string][]byte{},
+	}
+}
+
+func (ep *exportPackage) addFile(name string, content []byte) {
+	if _, ok := ep.files[name]; !ok {
+		ep.fileNames = append(ep.fileNames, name)
+	}
+	ep.files[name] = content
+}
+
+func (ep *exportPackage) addAPITask(id string, name string, action string, payload any) error {
+	payloadBytes, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling payload of task %s: %w", id, err)
+	}
+	payloadFile := path.Join("api", id+".json")
+	ep.addFile(payloadFile, append(payloadBytes, '\n'))
+	ep.manifest.Tasks = append(
+		ep.manifest.Tasks, &model.ManifestTask{
+			APITask: &model.APITask{
+				Action:      action,
+				PayloadFile: payloadFile,
+			},
+			ID:   id,
+			Type: apiTaskType,
+			Name: name,
+		},
+	)
+	return nil
+}
+
+// Export writes the import package of the given project to w.
+// Project names in the exported payloads are replaced by a template, so that the package can be imported into a
+// project with a different name. Secrets are exported with their keys but without values, which have to be filled in
+// before importing the package
+func (pe *ProjectExporter) Export(project string, w io.Writer) error {
+	p, err := pe.retriever.GetProject(project)
+	if err != nil {
+		return fmt.Errorf("error retrieving project %s: %w", project, err)
+	}
+
+	ep := newExportPackage()
+
+	shipyard, err := pe.retriever.GetShipyard(project)
+	if err != nil {
+		return fmt.Errorf("error retrieving shipyard of project %s: %w", project, err)
+	}
+	ep.addFile(shipyardFileName, shipyard)
+
+	services := getServiceNames(p)
+	for i, service := range services {
+		err := ep.addAPITask(
+			fmt.Sprintf("create_service_%d", i+1), "Create service "+service, model.CreateServiceAction,
+			map[string]string{"serviceName": service},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := pe.exportSecrets(project, ep); err != nil {
+		return err
+	}
+
+	subscriptionTasks, err := pe.exportWebhookSubscriptions(project, ep)
+	if err != nil {
+		return err
+	}
+
+	if err := pe.exportResources(project, p, services, subscriptionTasks, ep); err != nil {
+		return err
+	}
+
+	return ep.write(w)
+}
+
+func (pe *ProjectExporter) exportSecrets(project string, ep *exportPackage) error {
+	secrets, err := pe.retriever.GetSecrets(project)
+	if err != nil {
+		return fmt.Errorf("error retrieving secrets of project %s: %w", project, err)
+	}
+
+	for i, secret := range secrets {
+		data := map[string]string{}
+		for _, key := range secret.Keys {
+			data[key] = ""
+		}
+		payload := map[string]any{
+			"name":    secret.Name,
+			"scope":   secret.Scope,
+			"project": "[[ .Project ]]",
+			"data":    data,
+		}
+		if secret.Stage != "" {
+			payload["stage"] = secret.Stage
+		}
+		err := ep.addAPITask(
+			fmt.Sprintf("create_secret_%d", i+1), "Create secret "+secret.Name, model.CreateSecretAction, payload,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportWebhookSubscriptions adds a task for each webhook subscription of the project and returns the IDs of the
+// exported subscriptions mapped to the IDs of the tasks creating them
+func (pe *ProjectExporter) exportWebhookSubscriptions(project string, ep *exportPackage) (map[string]string, error) {
+	subscriptions, err := pe.retriever.GetWebhookSubscriptions(project)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving webhook subscriptions of project %s: %w", project, err)
+	}
+
+	subscriptionTasks := map[string]string{}
+	for i, subscription := range subscriptions {
+		taskID := fmt.Sprintf("create_webhook_subscription_%d", i+1)
+		payload := map[string]any{
+			"event": subscription.Event,
+			"filter": map[string][]string{
+				"projects": {"[[ .Project ]]"},
+				"stages":   nonNilStrings(subscription.Filter.Stages),
+				"services": nonNilStrings(subscription.Filter.Services),
+			},
+		}
+		err := ep.addAPITask(
+			taskID, "Create webhook subscription for "+subscription.Event, model.CreateWebhookAction, payload,
+		)
+		if err != nil {
+			return nil, err
+		}
+		subscriptionTasks[subscription.ID] = taskID
+	}
+	return subscriptionTasks, nil
+}
+
+func (pe *ProjectExporter) exportResources(
+	project string, p *apimodels.Project, services []string, subscriptionTasks map[string]string, ep *exportPackage,
+) error {
+	taskCount := 0
+	for _, stage := range p.Stages {
+		resourceURIs, err := pe.retriever.GetStageResourceURIs(project, stage.StageName)
+		if err != nil {
+			return fmt.Errorf("error retrieving resources of stage %s: %w", stage.StageName, err)
+		}
+		sort.Strings(resourceURIs)
+
+		for _, resourceURI := range resourceURIs {
+			resourceURI = strings.TrimPrefix(resourceURI, "/")
+			service, serviceResourceURI := splitServiceResourceURI(resourceURI, services)
+			if path.Base(resourceURI) == metadataFileName || (service == "" && resourceURI == shipyardFileName) {
+				continue
+			}
+
+			content, err := pe.retriever.GetStageResource(project, stage.StageName, resourceURI)
+			if err != nil {
+				return fmt.Errorf("error retrieving resource %s of stage %s: %w", resourceURI, stage.StageName, err)
+			}
+			content, context := templateResource(content, subscriptionTasks)
+
+			taskCount++
+			resourceFile := path.Join("resources", stage.StageName, resourceURI)
+			ep.addFile(resourceFile, content)
+			ep.manifest.Tasks = append(
+				ep.manifest.Tasks, &model.ManifestTask{
+					ResourceTask: &model.ResourceTask{
+						File:      resourceFile,
+						RemoteURI: serviceResourceURI,
+						Stage:     stage.StageName,
+						Service:   service,
+					},
+					ID:      fmt.Sprintf("push_resource_%d", taskCount),
+					Type:    resourceTaskType,
+					Name:    fmt.Sprintf("Push resource %s to stage %s", resourceURI, stage.StageName),
+					Context: context,
+				},
+			)
+		}
+	}
+	return nil
+}
+
+func (ep *exportPackage) write(w io.Writer) error {
+	manifest := new(bytes.Buffer)
+	encoder := yaml.NewEncoder(manifest)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(ep.manifest); err != nil {
+		return fmt.Errorf("error marshalling manifest: %w", err)
+	}
+
+	zw := zip.NewWriter(w)
+	if err := writeZipEntry(zw, manifestFileName, manifest.Bytes()); err != nil {
+		return err
+	}
+	for _, name := range ep.fileNames {
+		if err := writeZipEntry(zw, name, ep.files[name]); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("error closing export archive: %w", err)
+	}
+	return nil
+}
+
+func writeZipEntry(zw *zip.Writer, name string, content []byte) error {
+	fw, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("error adding %s to export archive: %w", name, err)
+	}
+	if _, err := fw.Write(content); err != nil {
+		return fmt.Errorf("error writing %s to export archive: %w", name, err)
+	}
+	return nil
+}
+
+// getServiceNames returns the sorted names of the services in all stages of the project
+func getServiceNames(p *apimodels.Project) []string {
+	serviceSet := map[string]bool{}
+	for _, stage := range p.Stages {
+		for _, service := range stage.Services {
+			serviceSet[service.ServiceName] = true
+		}
+	}
+	services := make([]string, 0, len(serviceSet))
+	for service := range serviceSet {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	return services
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// splitServiceResourceURI returns the service a resource of a stage belongs to and its URI within the service.
+// For resources of the stage itself an empty service is returned
+func splitServiceResourceURI(resourceURI string, services []string) (string, string) {
+	for _, service := range services {
+		if strings.HasPrefix(resourceURI, service+"/") {
+			return service, strings.TrimPrefix(resourceURI, service+"/")
+		}
+	}
+	return "", resourceURI
+}
+
+// templateResource escapes the template delimiters in the content of a resource, as the import renders every resource
+// as a template, and replaces the IDs of exported webhook subscriptions by the IDs of the subscriptions created during
+// the import. The returned context maps the placeholders to the responses of the corresponding tasks
+func templateResource(content []byte, subscriptionTasks map[string]string) ([]byte, map[string]string) {
+	content = bytes.ReplaceAll(content, []byte("[["), []byte(`[[ "[[" ]]`))
+
+	var context map[string]string
+	for subscriptionID, taskID := range subscriptionTasks {
+		if subscriptionID == "" || !bytes.Contains(content, []byte(subscriptionID)) {
+			continue
+		}
+		if context == nil {
+			context = map[string]string{}
+		}
+		content = bytes.ReplaceAll(content, []byte(subscriptionID), []byte("[[ .Context."+taskID+" ]]"))
+		context[taskID] = "[[ .Tasks." + taskID + ".Response.id ]]"
+	}
+	return content, context
+}
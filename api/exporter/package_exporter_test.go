@@ -0,0 +1,207 @@
+package exporter
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn/keptn/api/exporter/fake"
+	exportmodel "github.com/keptn/keptn/api/exporter/model"
+	"github.com/keptn/keptn/api/importer"
+	importerfake "github.com/keptn/keptn/api/importer/fake"
+	"github.com/keptn/keptn/api/importer/model"
+)
+
+const exportedSubscriptionID = "0f2e6a9c-3b41-4a0e-9d5e-7c1f9c2b8e11"
+
+func newRetrieverMock() *fake.MockProjectDataRetriever {
+	return &fake.MockProjectDataRetriever{
+		GetProjectFunc: func(project string) (*apimodels.Project, error) {
+			return &apimodels.Project{
+				ProjectName: project,
+				Stages: []*apimodels.Stage{
+					{StageName: "dev", Services: []*apimodels.Service{{ServiceName: "carts"}}},
+					{StageName: "prod", Services: []*apimodels.Service{{ServiceName: "carts"}, {ServiceName: "db"}}},
+				},
+			}, nil
+		},
+		GetShipyardFunc: func(project string) ([]byte, error) {
+			return []byte("apiVersion: spec.keptn.sh/0.2.2\nkind: Shipyard\n"), nil
+		},
+		GetStageResourceURIsFunc: func(project string, stage string) ([]string, error) {
+			if stage == "dev" {
+				return []string{"webhook/webhook.yaml", "metadata.yaml", "shipyard.yaml", "carts/slo.yaml", "carts/metadata.yaml"}, nil
+			}
+			return []string{"/db/helm/values.yaml"}, nil
+		},
+		GetStageResourceFunc: func(project string, stage string, resourceURI string) ([]byte, error) {
+			switch resourceURI {
+			case "webhook/webhook.yaml":
+				return []byte("subscriptionID: " + exportedSubscriptionID + "\ncommand: echo [[ not a template ]]\n"), nil
+			default:
+				return []byte(stage + "/" + resourceURI), nil
+			}
+		},
+		GetWebhookSubscriptionsFunc: func(project string) ([]apimodels.EventSubscription, error) {
+			return []apimodels.EventSubscription{
+				{
+					ID:    exportedSubscriptionID,
+					Event: "sh.keptn.event.deployment.triggered",
+					Filter: apimodels.EventSubscriptionFilter{
+						Projects: []string{project},
+						Stages:   []string{"dev"},
+					},
+				},
+			}, nil
+		},
+		GetSecretsFunc: func(project string) ([]exportmodel.Secret, error) {
+			return []exportmodel.Secret{{Name: "slack", Scope: "keptn-webhook-service", Project: project, Keys: []string{"token"}}}, nil
+		},
+	}
+}
+
+// zipPackage gives access to the files of an exported package
+type zipPackage struct {
+	reader *zip.Reader
+}
+
+func newZipPackage(t *testing.T, content []byte) *zipPackage {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+	return &zipPackage{reader: reader}
+}
+
+func (z *zipPackage) GetResource(resourceName string) (io.ReadCloser, error) {
+	return z.reader.Open(resourceName)
+}
+
+func (z *zipPackage) ResourceExists(resourceName string) (bool, error) {
+	_, err := z.reader.Open(resourceName)
+	return err == nil, err
+}
+
+func (z *zipPackage) Close() error {
+	return nil
+}
+
+func TestExportedPackageCanBeImported(t *testing.T) {
+	retriever := newRetrieverMock()
+	sut := NewProjectExporter(retriever)
+
+	buf := new(bytes.Buffer)
+	err := sut.Export("source-project", buf)
+	require.NoError(t, err)
+
+	zp := newZipPackage(t, buf.Bytes())
+	exists, _ := zp.ResourceExists(shipyardFileName)
+	assert.True(t, exists)
+
+	var actions []string
+	payloads := map[string]map[string]any{}
+	var pushes []model.ResourcePush
+	pushedContents := map[string]string{}
+	executor := &importerfake.TaskExecutorMock{
+		ActionSupportedFunc: func(actionName string) bool {
+			return true
+		},
+		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, model.CompensatingAction, error) {
+			actions = append(actions, ate.EndpointID)
+			payload := map[string]any{}
+			require.NoError(t, json.NewDecoder(ate.Payload).Decode(&payload))
+			payloads[ate.Context.Task.ID] = payload
+			return map[string]any{"id": "new-subscription-id"}, nil, nil
+		},
+		PushResourceFunc: func(rp model.ResourcePush) (any, model.CompensatingAction, error) {
+			content, err := io.ReadAll(rp.Content)
+			require.NoError(t, err)
+			pushes = append(pushes, rp)
+			pushedContents[rp.Stage+"/"+rp.Service+"/"+rp.ResourceURI] = string(content)
+			return nil, nil, nil
+		},
+	}
+
+	processor := importer.NewImportPackageProcessor(new(model.YAMLManifestUnMarshaler), executor, &importerfake.MockStageRetriever{})
+	_, err = processor.Process("target-project", zp)
+	require.NoError(t, err)
+
+	assert.Equal(
+		t, []string{
+			model.CreateServiceAction, model.CreateServiceAction, model.CreateSecretAction, model.CreateWebhookAction,
+		}, actions,
+	)
+	assert.Equal(t, "carts", payloads["create_service_1"]["serviceName"])
+	assert.Equal(t, "db", payloads["create_service_2"]["serviceName"])
+	assert.Equal(
+		t, map[string]any{
+			"name":    "slack",
+			"scope":   "keptn-webhook-service",
+			"project": "target-project",
+			"data":    map[string]any{"token": ""},
+		}, payloads["create_secret_1"],
+	)
+	assert.Equal(
+		t, map[string]any{
+			"projects": []any{"target-project"},
+			"stages":   []any{"dev"},
+			"services": []any{},
+		}, payloads["create_webhook_subscription_1"]["filter"],
+	)
+	assert.NotContains(t, payloads["create_webhook_subscription_1"], "id")
+
+	require.Len(t, pushes, 3)
+	assert.Equal(
+		t, map[string]string{
+			"dev/carts/slo.yaml":        "dev/carts/slo.yaml",
+			"dev//webhook/webhook.yaml": "subscriptionID: new-subscription-id\ncommand: echo [[ not a template ]]\n",
+			"prod/db/helm/values.yaml":  "prod/db/helm/values.yaml",
+		}, pushedContents,
+	)
+}
+
+func TestExportFailsIfProjectCannotBeRetrieved(t *testing.T) {
+	retriever := newRetrieverMock()
+	retriever.GetProjectFunc = func(project string) (*apimodels.Project, error) {
+		return nil, errors.New("project not found")
+	}
+	sut := NewProjectExporter(retriever)
+
+	buf := new(bytes.Buffer)
+	err := sut.Export("source-project", buf)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "project not found")
+	assert.Zero(t, buf.Len())
+	assert.Empty(t, retriever.GetStageResourceURIsCalls())
+}
+
+func TestExportFailsIfResourceCannotBeRetrieved(t *testing.T) {
+	retriever := newRetrieverMock()
+	retriever.GetStageResourceFunc = func(project string, stage string, resourceURI string) ([]byte, error) {
+		return nil, errors.New("resource-service unavailable")
+	}
+	sut := NewProjectExporter(retriever)
+
+	buf := new(bytes.Buffer)
+	err := sut.Export("source-project", buf)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "resource-service unavailable")
+	assert.Zero(t, buf.Len())
+}
+
+func TestSplitServiceResourceURI(t *testing.T) {
+	services := []string{"carts", "carts-db"}
+
+	service, resourceURI := splitServiceResourceURI("carts-db/helm/values.yaml", services)
+	assert.Equal(t, "carts-db", service)
+	assert.Equal(t, "helm/values.yaml", resourceURI)
+
+	service, resourceURI = splitServiceResourceURI("carts.yaml", services)
+	assert.Empty(t, service)
+	assert.Equal(t, "carts.yaml", resourceURI)
+}
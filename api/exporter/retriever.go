@@ -0,0 +1,146 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"k8s.io/utils/strings/slices"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	apiutils "github.com/keptn/go-utils/pkg/api/utils"
+
+	exportmodel "github.com/keptn/keptn/api/exporter/model"
+	"github.com/keptn/keptn/api/importer/execute"
+)
+
+const webhookIntegrationName = "webhook-service"
+
+// KeptnProjectDataRetriever retrieves the configuration of a project from the control plane, the resource-service and
+// the secret-service
+type KeptnProjectDataRetriever struct {
+	endpointProvider execute.KeptnEndpointProvider
+	client           *http.Client
+}
+
+// NewKeptnProjectDataRetriever instantiates a new KeptnProjectDataRetriever using the Keptn services available at the
+// endpoints returned by provider
+func NewKeptnProjectDataRetriever(provider execute.KeptnEndpointProvider) *KeptnProjectDataRetriever {
+	return &KeptnProjectDataRetriever{
+		endpointProvider: provider,
+		client:           &http.Client{},
+	}
+}
+
+// GetProject returns the project including its stages and services
+func (r *KeptnProjectDataRetriever) GetProject(project string) (*apimodels.Project, error) {
+	projectHandler := apiutils.NewProjectHandler(r.endpointProvider.GetControlPlaneEndpoint())
+	p, kErr := projectHandler.GetProject(apimodels.Project{ProjectName: project})
+	if kErr != nil {
+		return nil, kErr.ToError()
+	}
+	return p, nil
+}
+
+// GetShipyard returns the content of the shipyard of the project
+func (r *KeptnProjectDataRetriever) GetShipyard(project string) ([]byte, error) {
+	resourceHandler := apiutils.NewResourceHandler(r.endpointProvider.GetConfigurationServiceEndpoint())
+	resource, err := resourceHandler.GetProjectResource(project, shipyardFileName)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(resource.ResourceContent), nil
+}
+
+// GetStageResourceURIs returns the URIs of all resources of a stage, including the resources of its services
+func (r *KeptnProjectDataRetriever) GetStageResourceURIs(project string, stage string) ([]string, error) {
+	resourceHandler := apiutils.NewResourceHandler(r.endpointProvider.GetConfigurationServiceEndpoint())
+	resources, err := resourceHandler.GetAllStageResources(project, stage)
+	if err != nil {
+		return nil, err
+	}
+	resourceURIs := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		if resource.ResourceURI != nil {
+			resourceURIs = append(resourceURIs, *resource.ResourceURI)
+		}
+	}
+	return resourceURIs, nil
+}
+
+// GetStageResource returns the decoded content of a resource of a stage
+func (r *KeptnProjectDataRetriever) GetStageResource(project string, stage string, resourceURI string) ([]byte, error) {
+	resourceHandler := apiutils.NewResourceHandler(r.endpointProvider.GetConfigurationServiceEndpoint())
+	resource, err := resourceHandler.GetResource(
+		*apiutils.NewResourceScope().Project(project).Stage(stage).Resource(resourceURI),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(resource.ResourceContent), nil
+}
+
+// GetWebhookSubscriptions returns the subscriptions of the webhook-service filtering for events of the project
+func (r *KeptnProjectDataRetriever) GetWebhookSubscriptions(project string) ([]apimodels.EventSubscription, error) {
+	var integrations []apimodels.Integration
+	err := r.getJSON(
+		fmt.Sprintf(
+			"%s/v1/uniform/registration?name=%s", r.endpointProvider.GetControlPlaneEndpoint(),
+			url.QueryEscape(webhookIntegrationName),
+		), &integrations,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving integrations: %w", err)
+	}
+
+	subscriptions := []apimodels.EventSubscription{}
+	knownSubscriptions := map[string]bool{}
+	for _, integration := range integrations {
+		for _, subscription := range integration.Subscriptions {
+			if knownSubscriptions[subscription.ID] || !slices.Contains(subscription.Filter.Projects, project) {
+				continue
+			}
+			knownSubscriptions[subscription.ID] = true
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	return subscriptions, nil
+}
+
+// GetSecrets returns the metadata of the secrets bound to the project
+func (r *KeptnProjectDataRetriever) GetSecrets(project string) ([]exportmodel.Secret, error) {
+	secrets := struct {
+		Secrets []exportmodel.Secret `json:"Secrets"`
+	}{}
+	err := r.getJSON(
+		fmt.Sprintf("%s/v1/secret?project=%s", r.endpointProvider.GetSecretsServiceEndpoint(), url.QueryEscape(project)),
+		&secrets,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving secrets: %w", err)
+	}
+	return secrets.Secrets, nil
+}
+
+func (r *KeptnProjectDataRetriever) getJSON(requestURL string, target any) error {
+	response, err := r.client.Get(requestURL)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("got unsuccessful status <%d: %s>", response.StatusCode, response.Status)
+	}
+
+	bodyBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+	if err := json.Unmarshal(bodyBytes, target); err != nil {
+		return fmt.Errorf("error unmarshalling response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package exporter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	exportmodel "github.com/keptn/keptn/api/exporter/model"
+	"github.com/keptn/keptn/api/importer/execute/fake"
+)
+
+func newEndpointProvider(endpoint string) *fake.KeptnEndpointProviderMock {
+	return &fake.KeptnEndpointProviderMock{
+		GetControlPlaneEndpointFunc: func() string {
+			return endpoint
+		},
+		GetConfigurationServiceEndpointFunc: func() string {
+			return endpoint
+		},
+		GetSecretsServiceEndpointFunc: func() string {
+			return endpoint
+		},
+	}
+}
+
+func TestKeptnProjectDataRetriever_GetWebhookSubscriptions(t *testing.T) {
+	var requestedName string
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				requestedName = r.URL.Query().Get("name")
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write(
+					[]byte(`[
+					  {
+					    "id": "integration-1",
+					    "name": "webhook-service",
+					    "subscriptions": [
+					      {"id": "sub-1", "event": "sh.keptn.event.deployment.triggered", "filter": {"projects": ["my-project"]}},
+					      {"id": "sub-2", "event": "sh.keptn.event.test.triggered", "filter": {"projects": ["other-project"]}}
+					    ]
+					  },
+					  {
+					    "id": "integration-2",
+					    "name": "webhook-service",
+					    "subscriptions": [
+					      {"id": "sub-1", "event": "sh.keptn.event.deployment.triggered", "filter": {"projects": ["my-project"]}},
+					      {"id": "sub-3", "event": "sh.keptn.event.release.triggered", "filter": {"projects": ["other-project", "my-project"]}}
+					    ]
+					  }
+					]`),
+				)
+			},
+		),
+	)
+	defer ts.Close()
+
+	sut := NewKeptnProjectDataRetriever(newEndpointProvider(ts.URL))
+	subscriptions, err := sut.GetWebhookSubscriptions("my-project")
+	require.NoError(t, err)
+
+	assert.Equal(t, webhookIntegrationName, requestedName)
+	require.Len(t, subscriptions, 2)
+	assert.Equal(t, "sub-1", subscriptions[0].ID)
+	assert.Equal(t, "sub-3", subscriptions[1].ID)
+}
+
+func TestKeptnProjectDataRetriever_GetSecrets(t *testing.T) {
+	var requestedProject string
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				requestedProject = r.URL.Query().Get("project")
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write(
+					[]byte(`{"Secrets": [{"name": "slack", "scope": "keptn-webhook-service", "project": "my-project", "keys": ["token"]}]}`),
+				)
+			},
+		),
+	)
+	defer ts.Close()
+
+	sut := NewKeptnProjectDataRetriever(newEndpointProvider(ts.URL))
+	secrets, err := sut.GetSecrets("my-project")
+	require.NoError(t, err)
+
+	assert.Equal(t, "my-project", requestedProject)
+	assert.Equal(
+		t, []exportmodel.Secret{
+			{Name: "slack", Scope: "keptn-webhook-service", Project: "my-project", Keys: []string{"token"}},
+		}, secrets,
+	)
+}
+
+func TestKeptnProjectDataRetriever_GetSecretsUnsuccessfulStatus(t *testing.T) {
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+		),
+	)
+	defer ts.Close()
+
+	sut := NewKeptnProjectDataRetriever(newEndpointProvider(ts.URL))
+	_, err := sut.GetSecrets("my-project")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "500")
+}
+
+func TestKeptnProjectDataRetriever_GetStageResources(t *testing.T) {
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.EscapedPath() {
+				case "/v1/project/my-project/stage/dev/resource":
+					_, _ = w.Write([]byte(`{"resources": [{"resourceURI": "carts/slo.yaml"}], "nextPageKey": "0"}`))
+				case "/v1/project/my-project/stage/dev/resource/carts%2Fslo.yaml":
+					_, _ = w.Write([]byte(`{"resourceURI": "carts/slo.yaml", "resourceContent": "c3BlY192ZXJzaW9uOiAxLjA="}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			},
+		),
+	)
+	defer ts.Close()
+
+	sut := NewKeptnProjectDataRetriever(newEndpointProvider(ts.URL))
+	resourceURIs, err := sut.GetStageResourceURIs("my-project", "dev")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"carts/slo.yaml"}, resourceURIs)
+
+	content, err := sut.GetStageResource("my-project", "dev", "carts/slo.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "spec_version: 1.0", string(content))
+}
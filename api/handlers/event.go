@@ -18,6 +18,8 @@ import (
 	"github.com/google/uuid"
 
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/api/eventschema"
+	custommiddleware "github.com/keptn/keptn/api/middleware"
 	"github.com/keptn/keptn/api/models"
 	"github.com/keptn/keptn/api/restapi/operations/event"
 )
@@ -27,6 +29,17 @@ type eventPublisher interface {
 	Publish(event apimodels.KeptnContextExtendedCE) error
 }
 
+//go:generate moq -pkg handlers_mock --skip-ensure -out ./fake/eventschemavalidator_mock.go . eventSchemaValidator:EventSchemaValidatorMock
+type eventSchemaValidator interface {
+	Validate(project string, eventType string, data interface{}) error
+}
+
+//go:generate moq -pkg handlers_mock --skip-ensure -out ./fake/eventidempotencystore_mock.go . eventIdempotencyStore:EventIdempotencyStoreMock
+type eventIdempotencyStore interface {
+	Reserve(key string, keptnContext string) (string, bool, error)
+	Release(key string) error
+}
+
 const defaultEventSource = "https://github.com/keptn/keptn/api"
 
 var eventHandlerInstance *EventHandler
@@ -34,19 +47,28 @@ var eventHandlerInstance *EventHandler
 type EventHandler struct {
 	EventPublisher         eventPublisher
 	EventValidationEnabled bool
+	// SchemaValidator validates the data of events against the schema registered for their type, if set
+	SchemaValidator eventSchemaValidator
+	// IdempotencyStore prevents that events received again within the idempotency window are forwarded, if set
+	IdempotencyStore eventIdempotencyStore
 }
 
-func GetEventHandlerInstance(eventValidation bool) *EventHandler {
+func GetEventHandlerInstance(eventValidation bool, schemaValidator eventSchemaValidator, idempotencyStore eventIdempotencyStore) *EventHandler {
 	if eventHandlerInstance == nil {
 		eventHandlerInstance = &EventHandler{
 			EventPublisher:         nats.NewFromEnv(),
 			EventValidationEnabled: eventValidation,
+			SchemaValidator:        schemaValidator,
+			IdempotencyStore:       idempotencyStore,
 		}
 	}
 	return eventHandlerInstance
 }
 
-func (eh *EventHandler) PostEvent(event models.KeptnContextExtendedCE) (*models.EventContext, error) {
+// PostEvent forwards the event. Duplicates of an event sent by the same principal to the same project, identified by
+// the idempotency key or otherwise by the source and id of the event, are not forwarded again but the keptn context of
+// the original event is returned
+func (eh *EventHandler) PostEvent(event models.KeptnContextExtendedCE, idempotencyKey string, principalName string) (*models.EventContext, error) {
 	logger.Debugf("API received a Keptn event with ID %s", event.ID)
 	if eh.EventValidationEnabled {
 		if err := Validate(event); err != nil {
@@ -54,10 +76,30 @@ func (eh *EventHandler) PostEvent(event models.KeptnContextExtendedCE) (*models.
 			return nil, err
 		}
 	}
+	if eh.SchemaValidator != nil {
+		if err := eh.validateSchema(event); err != nil {
+			logger.Warnf("Received Keptn event with invalid data: %v", err)
+			return nil, err
+		}
+	}
 
 	// create or reuse context id
 	keptnContext := createOrApplyKeptnContext(event.Shkeptncontext)
 
+	dedupKey := eventDedupKey(event, idempotencyKey, principalName)
+	if eh.IdempotencyStore != nil && dedupKey != "" {
+		originalContext, reserved, err := eh.IdempotencyStore.Reserve(dedupKey, keptnContext)
+		switch {
+		case err != nil:
+			// events are not rejected only because duplicates cannot be detected
+			logger.Errorf("Could not check idempotency of event %s: %v", event.ID, err)
+			dedupKey = ""
+		case !reserved:
+			logger.Infof("Received duplicate of event with keptn context %s, not forwarding it again", originalContext)
+			return &models.EventContext{KeptnContext: &originalContext}, nil
+		}
+	}
+
 	// determine source value
 	source := defaultEventSource
 	if event.Source != nil && len(*event.Source) > 0 {
@@ -71,6 +113,7 @@ func (eh *EventHandler) PostEvent(event models.KeptnContextExtendedCE) (*models.
 
 	outEvent := &apimodels.KeptnContextExtendedCE{}
 	if err := keptnv2.Decode(event, outEvent); err != nil {
+		eh.releaseDedupKey(dedupKey)
 		return nil, fmt.Errorf("could not parse event: %w", err)
 	}
 
@@ -81,6 +124,7 @@ func (eh *EventHandler) PostEvent(event models.KeptnContextExtendedCE) (*models.
 	outEvent.Shkeptncontext = keptnContext
 
 	if err := eh.EventPublisher.Publish(*outEvent); err != nil {
+		eh.releaseDedupKey(dedupKey)
 		return nil, err
 	}
 
@@ -88,9 +132,54 @@ func (eh *EventHandler) PostEvent(event models.KeptnContextExtendedCE) (*models.
 	return eventContext, nil
 }
 
-func PostEventHandlerFunc(eventValidation bool) func(event.PostEventParams, *models.Principal) middleware.Responder {
+// eventDedupKey returns the key identifying duplicates of the event, which is the idempotency key if given, otherwise
+// the source and id of the event, which identify a CloudEvent. Keys are scoped by the principal and the project of the
+// event, so that senders cannot suppress the events of other senders or projects
+func eventDedupKey(e models.KeptnContextExtendedCE, idempotencyKey string, principalName string) string {
+	var eventData keptnv2.EventData
+	_ = keptnv2.Decode(e.Data, &eventData)
+	scope := principalName + "/" + eventData.Project
+
+	if idempotencyKey != "" {
+		return "key/" + scope + "/" + idempotencyKey
+	}
+	if e.ID != "" && e.Source != nil {
+		return "id/" + scope + "/" + *e.Source + "/" + e.ID
+	}
+	return ""
+}
+
+// releaseDedupKey forgets the key of an event that could not be forwarded, so it can be sent again
+func (eh *EventHandler) releaseDedupKey(dedupKey string) {
+	if eh.IdempotencyStore == nil || dedupKey == "" {
+		return
+	}
+	if err := eh.IdempotencyStore.Release(dedupKey); err != nil {
+		logger.Errorf("Could not release idempotency key: %v", err)
+	}
+}
+
+// validateSchema validates the data of the event against the schema of its type. Events are only rejected if their
+// data does not conform to the schema, not if the schema cannot be retrieved
+func (eh *EventHandler) validateSchema(e models.KeptnContextExtendedCE) error {
+	var eventData keptnv2.EventData
+	_ = keptnv2.Decode(e.Data, &eventData)
+
+	err := eh.SchemaValidator.Validate(eventData.Project, *e.Type, e.Data)
+	if errors.Is(err, eventschema.ErrInvalidEventData) {
+		return &EventValidationError{Msg: err.Error()}
+	}
+	if err != nil {
+		logger.Errorf("Could not validate data of event %s: %v", *e.Type, err)
+	}
+	return nil
+}
+
+func PostEventHandlerFunc(eventValidation bool, schemaValidator eventSchemaValidator, idempotencyStore eventIdempotencyStore) func(event.PostEventParams, *models.Principal) middleware.Responder {
 	return func(params event.PostEventParams, principal *models.Principal) middleware.Responder {
-		keptnContext, err := GetEventHandlerInstance(eventValidation).PostEvent(*params.Body)
+		keptnContext, err := GetEventHandlerInstance(eventValidation, schemaValidator, idempotencyStore).PostEvent(
+			*params.Body, swag.StringValue(params.IdempotencyKey), custommiddleware.PrincipalName(principal),
+		)
 		if err != nil {
 			if errors.As(err, &EventValidationError{}) {
 				return sendBadRequestErrorForPost(err)
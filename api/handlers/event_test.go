@@ -9,10 +9,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/benbjohnson/clock"
 	"github.com/go-openapi/strfmt"
 	"github.com/google/uuid"
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/api/eventschema"
 	handlers_mock "github.com/keptn/keptn/api/handlers/fake"
+	"github.com/keptn/keptn/api/idempotency"
 	"github.com/nats-io/nats-server/v2/server"
 	natstest "github.com/nats-io/nats-server/v2/test"
 	nats2 "github.com/nats-io/nats.go"
@@ -129,7 +132,7 @@ func TestPostEventHandlerFunc(t *testing.T) {
 		},
 	}
 
-	got := PostEventHandlerFunc(false)(params, nil)
+	got := PostEventHandlerFunc(false, nil, nil)(params, nil)
 
 	verifyHTTPResponse(got, http.StatusOK, t)
 
@@ -155,7 +158,7 @@ func TestPostEventHandlerFunc_NoNatsConnection(t *testing.T) {
 		},
 	}
 
-	got := PostEventHandlerFunc(false)(params, nil)
+	got := PostEventHandlerFunc(false, nil, nil)(params, nil)
 
 	verifyHTTPResponse(got, http.StatusInternalServerError, t)
 }
@@ -241,7 +244,7 @@ func TestEventHandler_ReceivingInvalidEvents(t *testing.T) {
 				EventPublisher:         tt.fields.EventPublisher,
 				EventValidationEnabled: true,
 			}
-			got, err := eh.PostEvent(tt.args.event)
+			got, err := eh.PostEvent(tt.args.event, "", "")
 			if !tt.wantErr(t, err, fmt.Sprintf("PostEvent(%v)", tt.args.event)) {
 				return
 			}
@@ -272,7 +275,7 @@ func TestEventHandler_PostEvent(t *testing.T) {
 		Triggeredid: "triggeredid",
 	}
 
-	got, err := eh.PostEvent(testEvent)
+	got, err := eh.PostEvent(testEvent, "", "")
 
 	require.Nil(t, err)
 	require.NotNil(t, got)
@@ -310,7 +313,7 @@ func TestEventHandler_PostEvent_UseAvailableKeptnContext(t *testing.T) {
 		Triggeredid:    "triggeredid",
 	}
 
-	got, err := eh.PostEvent(testEvent)
+	got, err := eh.PostEvent(testEvent, "", "")
 
 	require.Nil(t, err)
 	require.NotNil(t, got)
@@ -346,7 +349,7 @@ func TestEventHandler_PostEvent_SetDefaultSource(t *testing.T) {
 		Triggeredid: "triggeredid",
 	}
 
-	got, err := eh.PostEvent(testEvent)
+	got, err := eh.PostEvent(testEvent, "", "")
 
 	require.Nil(t, err)
 	require.NotNil(t, got)
@@ -381,7 +384,7 @@ func TestEventHandler_PostEvent_ReplaceInvalidWithDefaultSource(t *testing.T) {
 		Triggeredid: "triggeredid",
 	}
 
-	got, err := eh.PostEvent(testEvent)
+	got, err := eh.PostEvent(testEvent, "", "")
 
 	require.Nil(t, err)
 	require.NotNil(t, got)
@@ -416,10 +419,179 @@ func TestEventHandler_PostEvent_SendFails(t *testing.T) {
 		Triggeredid: "triggeredid",
 	}
 
-	got, err := eh.PostEvent(testEvent)
+	got, err := eh.PostEvent(testEvent, "", "")
 
 	require.NotNil(t, err)
 	require.Nil(t, got)
 
 	require.Len(t, mockPublisher.PublishCalls(), 1)
 }
+
+func TestEventHandler_PostEvent_SchemaValidation(t *testing.T) {
+	eventType := "sh.keptn.event.securityscan.finished"
+	tests := []struct {
+		name          string
+		validationErr error
+		wantErr       bool
+	}{
+		{
+			name: "valid data",
+		},
+		{
+			name:          "data not conforming to schema",
+			validationErr: fmt.Errorf("%w %s: securityscan.vulnerabilities in body is required", eventschema.ErrInvalidEventData, eventType),
+			wantErr:       true,
+		},
+		{
+			name:          "schema not available",
+			validationErr: errors.New("could not retrieve event schema"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPublisher := &handlers_mock.EventPublisherMock{
+				PublishFunc: func(event apimodels.KeptnContextExtendedCE) error {
+					return nil
+				},
+			}
+			mockValidator := &handlers_mock.EventSchemaValidatorMock{
+				ValidateFunc: func(project string, eventType string, data interface{}) error {
+					return tt.validationErr
+				},
+			}
+			eh := &EventHandler{
+				EventPublisher:  mockPublisher,
+				SchemaValidator: mockValidator,
+			}
+
+			testEvent := models.KeptnContextExtendedCE{
+				Contenttype: "application/json",
+				Data:        map[string]interface{}{"project": "pr", "stage": "st", "service": "svc", "securityscan": map[string]interface{}{}},
+				Source:      stringp("test-source"),
+				Specversion: "1.0",
+				Type:        &eventType,
+				Triggeredid: "triggeredid",
+			}
+
+			got, err := eh.PostEvent(testEvent, "", "")
+
+			require.Len(t, mockValidator.ValidateCalls(), 1)
+			require.Equal(t, "pr", mockValidator.ValidateCalls()[0].Project)
+			require.Equal(t, eventType, mockValidator.ValidateCalls()[0].EventType)
+			if tt.wantErr {
+				require.ErrorAs(t, err, &EventValidationError{})
+				require.Nil(t, got)
+				require.Empty(t, mockPublisher.PublishCalls())
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, mockPublisher.PublishCalls(), 1)
+		})
+	}
+}
+
+func TestEventHandler_PostEvent_Idempotency(t *testing.T) {
+	publishErr := errors.New("oops")
+	mockPublisher := &handlers_mock.EventPublisherMock{
+		PublishFunc: func(event apimodels.KeptnContextExtendedCE) error {
+			if event.Source != nil && *event.Source == "failing-source" {
+				return publishErr
+			}
+			return nil
+		},
+	}
+	eh := &EventHandler{
+		EventPublisher:   mockPublisher,
+		IdempotencyStore: idempotency.NewMemoryStore(10*time.Minute, clock.NewMock()),
+	}
+
+	eventType := "sh.keptn.event.dev.delivery.triggered"
+	newEvent := func(source string, id string) models.KeptnContextExtendedCE {
+		return models.KeptnContextExtendedCE{
+			Contenttype: "application/json",
+			Data:        map[string]interface{}{"project": "pr", "stage": "dev", "service": "svc"},
+			ID:          id,
+			Source:      stringp(source),
+			Specversion: "1.0",
+			Type:        &eventType,
+		}
+	}
+
+	// duplicates with the same idempotency key are not forwarded
+	first, err := eh.PostEvent(newEvent("ci", ""), "retried-ci-job", "ci-token")
+	require.NoError(t, err)
+	retried, err := eh.PostEvent(newEvent("ci", ""), "retried-ci-job", "ci-token")
+	require.NoError(t, err)
+	require.Equal(t, *first.KeptnContext, *retried.KeptnContext)
+	require.Len(t, mockPublisher.PublishCalls(), 1)
+
+	// idempotency keys are scoped by the principal and the project of the event
+	other, err := eh.PostEvent(newEvent("ci", ""), "retried-ci-job", "other-token")
+	require.NoError(t, err)
+	require.NotEqual(t, *first.KeptnContext, *other.KeptnContext)
+	otherProjectEvent := newEvent("ci", "")
+	otherProjectEvent.Data = map[string]interface{}{"project": "other-project", "stage": "dev", "service": "svc"}
+	_, err = eh.PostEvent(otherProjectEvent, "retried-ci-job", "ci-token")
+	require.NoError(t, err)
+	require.Len(t, mockPublisher.PublishCalls(), 3)
+
+	// without idempotency key, duplicates are identified by source and id
+	first, err = eh.PostEvent(newEvent("ci", "event-id"), "", "")
+	require.NoError(t, err)
+	retried, err = eh.PostEvent(newEvent("ci", "event-id"), "", "")
+	require.NoError(t, err)
+	require.Equal(t, *first.KeptnContext, *retried.KeptnContext)
+	require.Len(t, mockPublisher.PublishCalls(), 4)
+
+	other, err = eh.PostEvent(newEvent("other-source", "event-id"), "", "")
+	require.NoError(t, err)
+	require.NotEqual(t, *first.KeptnContext, *other.KeptnContext)
+	require.Len(t, mockPublisher.PublishCalls(), 5)
+
+	// events without id and idempotency key are always forwarded
+	_, err = eh.PostEvent(newEvent("ci", ""), "", "")
+	require.NoError(t, err)
+	_, err = eh.PostEvent(newEvent("ci", ""), "", "")
+	require.NoError(t, err)
+	require.Len(t, mockPublisher.PublishCalls(), 7)
+
+	// events that could not be forwarded can be sent again
+	_, err = eh.PostEvent(newEvent("failing-source", "event-id"), "", "")
+	require.ErrorIs(t, err, publishErr)
+	_, err = eh.PostEvent(newEvent("failing-source", "event-id"), "", "")
+	require.ErrorIs(t, err, publishErr)
+	require.Len(t, mockPublisher.PublishCalls(), 9)
+}
+
+func TestEventHandler_PostEvent_IdempotencyStoreNotAvailable(t *testing.T) {
+	mockPublisher := &handlers_mock.EventPublisherMock{
+		PublishFunc: func(event apimodels.KeptnContextExtendedCE) error {
+			return nil
+		},
+	}
+	mockStore := &handlers_mock.EventIdempotencyStoreMock{
+		ReserveFunc: func(key string, keptnContext string) (string, bool, error) {
+			return "", false, errors.New("bucket not available")
+		},
+	}
+	eh := &EventHandler{
+		EventPublisher:   mockPublisher,
+		IdempotencyStore: mockStore,
+	}
+
+	eventType := "sh.keptn.event.dev.delivery.triggered"
+	testEvent := models.KeptnContextExtendedCE{
+		Contenttype: "application/json",
+		Data:        map[string]interface{}{"project": "pr", "stage": "dev", "service": "svc"},
+		Source:      stringp("ci"),
+		Specversion: "1.0",
+		Type:        &eventType,
+	}
+
+	got, err := eh.PostEvent(testEvent, "retried-ci-job", "ci-token")
+
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	require.Len(t, mockPublisher.PublishCalls(), 1)
+	require.Equal(t, "key/ci-token/pr/retried-ci-job", mockStore.ReserveCalls()[0].Key)
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/restapi/operations/export"
+
+	logger "github.com/sirupsen/logrus"
+)
+
+//go:generate moq -pkg handlers_mock --skip-ensure -out ./fake/projectexporter_mock.go . projectExporter:MockProjectExporter
+
+type projectExporter interface {
+	Export(project string, w io.Writer) error
+}
+
+// ExportHandler is the rest handler for the /export endpoint
+type ExportHandler struct {
+	checker  projectChecker
+	exporter projectExporter
+}
+
+// GetExportHandlerFunc will instantiate a configured ExportHandler and return the method that can be used for
+// handling http requests to the endpoint. See restapi.configureAPI for usage
+func GetExportHandlerFunc(
+	checker projectChecker, exporter projectExporter,
+) func(
+	params export.ExportParams, principal *models.Principal,
+) middleware.Responder {
+	eh := &ExportHandler{
+		checker:  checker,
+		exporter: exporter,
+	}
+	return eh.HandleExport
+}
+
+// HandleExport is the method invoked when a GET request is received on the export endpoint.
+// This method will check that the project passed as parameter exists in Keptn (
+// return a 404 immediately if that is not the case) and respond with the zipped import package of the project.
+// The package is assembled completely before responding, so that a failure can still be reported as error
+func (eh *ExportHandler) HandleExport(
+	params export.ExportParams, principal *models.Principal,
+) middleware.Responder {
+
+	// Check if the project exists
+	projectExists, err := eh.checker.ProjectExists(params.Project)
+
+	if err != nil {
+		message := fmt.Sprintf("error checking for project %s existence : %v", params.Project, err)
+		mError := models.Error{
+			Code:    http.StatusFailedDependency,
+			Message: &message,
+		}
+		return export.NewExportFailedDependency().WithPayload(&mError)
+	}
+
+	if !projectExists {
+		message := fmt.Sprintf("project %s does not exist", params.Project)
+
+		mError := models.Error{
+			Code:    http.StatusNotFound,
+			Message: &message,
+		}
+		return export.NewExportNotFound().WithPayload(&mError)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := eh.exporter.Export(params.Project, buf); err != nil {
+		logger.Errorf("Error exporting project %s: %v", params.Project, err)
+		message := fmt.Sprintf("Error exporting project %s: %s", params.Project, err)
+		mError := models.Error{
+			Code:    http.StatusFailedDependency,
+			Message: &message,
+		}
+		return export.NewExportFailedDependency().WithPayload(&mError)
+	}
+
+	return export.NewExportOK().
+		WithContentDisposition(fmt.Sprintf("attachment; filename=%q", params.Project+defaultImportArchiveExtension)).
+		WithPayload(io.NopCloser(buf))
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	handlers_mock "github.com/keptn/keptn/api/handlers/fake"
+	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/restapi/operations/export"
+)
+
+func TestExportNonExistingProject(t *testing.T) {
+	mockedprojectChecker := &handlers_mock.ProjectCheckerMock{
+		ProjectExistsFunc: func(projectName string) (bool, error) {
+			return false, nil
+		},
+	}
+	mockedExporter := &handlers_mock.MockProjectExporter{}
+
+	sut := GetExportHandlerFunc(mockedprojectChecker, mockedExporter)
+	actualResponder := sut(export.ExportParams{Project: "this_project_doesn't_exist"}, new(models.Principal))
+
+	require.IsType(t, &export.ExportNotFound{}, actualResponder)
+	actualPayload := actualResponder.(*export.ExportNotFound).Payload
+	assert.NotEmpty(t, actualPayload.Message)
+	assert.Equal(t, int64(http.StatusNotFound), actualPayload.Code)
+	assert.Empty(t, mockedExporter.ExportCalls())
+}
+
+func TestExportUnableToCheckProject(t *testing.T) {
+	mockedprojectChecker := &handlers_mock.ProjectCheckerMock{
+		ProjectExistsFunc: func(projectName string) (bool, error) {
+			return false, errors.New("some obscure project checker error")
+		},
+	}
+	mockedExporter := &handlers_mock.MockProjectExporter{}
+
+	sut := GetExportHandlerFunc(mockedprojectChecker, mockedExporter)
+	actualResponder := sut(export.ExportParams{Project: "my-project"}, new(models.Principal))
+
+	require.IsType(t, &export.ExportFailedDependency{}, actualResponder)
+	actualPayload := actualResponder.(*export.ExportFailedDependency).Payload
+	assert.Equal(t, int64(http.StatusFailedDependency), actualPayload.Code)
+	assert.Contains(t, *actualPayload.Message, "some obscure project checker error")
+	assert.Empty(t, mockedExporter.ExportCalls())
+}
+
+func TestExportFailed(t *testing.T) {
+	mockedprojectChecker := &handlers_mock.ProjectCheckerMock{
+		ProjectExistsFunc: func(projectName string) (bool, error) {
+			return true, nil
+		},
+	}
+	mockedExporter := &handlers_mock.MockProjectExporter{
+		ExportFunc: func(project string, w io.Writer) error {
+			_, _ = w.Write([]byte("partial content"))
+			return errors.New("resource-service unavailable")
+		},
+	}
+
+	sut := GetExportHandlerFunc(mockedprojectChecker, mockedExporter)
+	actualResponder := sut(export.ExportParams{Project: "my-project"}, new(models.Principal))
+
+	require.IsType(t, &export.ExportFailedDependency{}, actualResponder)
+	actualPayload := actualResponder.(*export.ExportFailedDependency).Payload
+	assert.Contains(t, *actualPayload.Message, "resource-service unavailable")
+}
+
+func TestExportProject(t *testing.T) {
+	mockedprojectChecker := &handlers_mock.ProjectCheckerMock{
+		ProjectExistsFunc: func(projectName string) (bool, error) {
+			return true, nil
+		},
+	}
+	mockedExporter := &handlers_mock.MockProjectExporter{
+		ExportFunc: func(project string, w io.Writer) error {
+			_, err := w.Write([]byte("zipped package"))
+			return err
+		},
+	}
+
+	sut := GetExportHandlerFunc(mockedprojectChecker, mockedExporter)
+	actualResponder := sut(export.ExportParams{Project: "my-project"}, new(models.Principal))
+
+	require.IsType(t, &export.ExportOK{}, actualResponder)
+	exportOK := actualResponder.(*export.ExportOK)
+	assert.Equal(t, `attachment; filename="my-project.zip"`, exportOK.ContentDisposition)
+	content, err := io.ReadAll(exportOK.Payload)
+	require.NoError(t, err)
+	assert.Equal(t, "zipped package", string(content))
+	require.Len(t, mockedExporter.ExportCalls(), 1)
+	assert.Equal(t, "my-project", mockedExporter.ExportCalls()[0].Project)
+}
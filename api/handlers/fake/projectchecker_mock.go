@@ -76,6 +76,9 @@ func (mock *ProjectCheckerMock) ProjectExistsCalls() []struct {
 //
 // 		// make and configure a mocked handlers.importPackageProcessor
 // 		mockedimportPackageProcessor := &MockImportPackageProcessor{
+// 			DryRunFunc: func(project string, ip importer.ImportPackage) (*model.ManifestExecution, error) {
+// 				panic("mock out the DryRun method")
+// 			},
 // 			ProcessFunc: func(project string, ip importer.ImportPackage) (*model.ManifestExecution, error) {
 // 				panic("mock out the Process method")
 // 			},
@@ -86,22 +89,68 @@ func (mock *ProjectCheckerMock) ProjectExistsCalls() []struct {
 //
 // 	}
 type MockImportPackageProcessor struct {
+	// DryRunFunc mocks the DryRun method.
+	DryRunFunc func(project string, ip importer.ImportPackage) (*model.ManifestExecution, error)
+
 	// ProcessFunc mocks the Process method.
 	ProcessFunc func(project string, ip importer.ImportPackage) (*model.ManifestExecution, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// DryRun holds details about calls to the DryRun method.
+		DryRun []struct {
+			// Project is the project argument value.
+			Project string
+			// Ip is the ip argument value.
+			Ip importer.ImportPackage
+		}
 		// Process holds details about calls to the Process method.
 		Process []struct {
 			// Project is the project argument value.
 			Project string
-			// IP is the ip argument value.
-			IP importer.ImportPackage
+			// Ip is the ip argument value.
+			Ip importer.ImportPackage
 		}
 	}
+	lockDryRun  sync.RWMutex
 	lockProcess sync.RWMutex
 }
 
+// DryRun calls DryRunFunc.
+func (mock *MockImportPackageProcessor) DryRun(project string, ip importer.ImportPackage) (*model.ManifestExecution, error) {
+	if mock.DryRunFunc == nil {
+		panic("MockImportPackageProcessor.DryRunFunc: method is nil but importPackageProcessor.DryRun was just called")
+	}
+	callInfo := struct {
+		Project string
+		Ip      importer.ImportPackage
+	}{
+		Project: project,
+		Ip:      ip,
+	}
+	mock.lockDryRun.Lock()
+	mock.calls.DryRun = append(mock.calls.DryRun, callInfo)
+	mock.lockDryRun.Unlock()
+	return mock.DryRunFunc(project, ip)
+}
+
+// DryRunCalls gets all the calls that were made to DryRun.
+// Check the length with:
+//     len(mockedimportPackageProcessor.DryRunCalls())
+func (mock *MockImportPackageProcessor) DryRunCalls() []struct {
+	Project string
+	Ip      importer.ImportPackage
+} {
+	var calls []struct {
+		Project string
+		Ip      importer.ImportPackage
+	}
+	mock.lockDryRun.RLock()
+	calls = mock.calls.DryRun
+	mock.lockDryRun.RUnlock()
+	return calls
+}
+
 // Process calls ProcessFunc.
 func (mock *MockImportPackageProcessor) Process(project string, ip importer.ImportPackage) (*model.ManifestExecution, error) {
 	if mock.ProcessFunc == nil {
@@ -109,10 +158,10 @@ func (mock *MockImportPackageProcessor) Process(project string, ip importer.Impo
 	}
 	callInfo := struct {
 		Project string
-		IP      importer.ImportPackage
+		Ip      importer.ImportPackage
 	}{
 		Project: project,
-		IP:      ip,
+		Ip:      ip,
 	}
 	mock.lockProcess.Lock()
 	mock.calls.Process = append(mock.calls.Process, callInfo)
@@ -125,11 +174,11 @@ func (mock *MockImportPackageProcessor) Process(project string, ip importer.Impo
 //     len(mockedimportPackageProcessor.ProcessCalls())
 func (mock *MockImportPackageProcessor) ProcessCalls() []struct {
 	Project string
-	IP      importer.ImportPackage
+	Ip      importer.ImportPackage
 } {
 	var calls []struct {
 		Project string
-		IP      importer.ImportPackage
+		Ip      importer.ImportPackage
 	}
 	mock.lockProcess.RLock()
 	calls = mock.calls.Process
@@ -8,6 +8,7 @@ import (
 	"os"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
 
 	"github.com/keptn/keptn/api/importer"
 	"github.com/keptn/keptn/api/importer/model"
@@ -27,6 +28,7 @@ type projectChecker interface {
 
 type importPackageProcessor interface {
 	Process(project string, ip importer.ImportPackage) (*model.ManifestExecution, error)
+	DryRun(project string, ip importer.ImportPackage) (*model.ManifestExecution, error)
 }
 
 // parseArchiveFunction is the function called to parse the uploaded file
@@ -72,6 +74,8 @@ func getImportHandlerInstance(
 // This method will check that the project passed as parameter already exists in Keptn (
 // return a 404 immediately if that is not the case),
 // save the import package on the scratch storage and parse its contents.
+// If the import fails after executing some tasks, the summary with the outcome of each task is returned as 422.
+// With the dryRun parameter set, the package is only validated and no changes are applied.
 func (ih *ImportHandler) HandleImport(
 	params import_operations.ImportParams, principal *models.Principal,
 ) middleware.Responder {
@@ -150,7 +154,19 @@ func (ih *ImportHandler) HandleImport(
 		}
 	}()
 
-	mExec, err := ih.processor.Process(params.Project, m)
+	process := ih.processor.Process
+	if swag.BoolValue(params.DryRun) {
+		process = ih.processor.DryRun
+	}
+
+	mExec, err := process(params.Project, m)
+	if err != nil && mExec != nil {
+		logger.Errorf("Error processing import archive: %v", err)
+		summary := mapManifestExecution(mExec)
+		summary.Outcome = models.ImportSummaryOutcomeFailure
+		summary.Message = err.Error()
+		return import_operations.NewImportUnprocessableEntity().WithPayload(summary)
+	}
 	if err != nil {
 		logger.Errorf("Error processing import archive: %v", err)
 		message := fmt.Sprintf("Error processing import archive: %s", err)
@@ -168,6 +184,7 @@ func mapManifestExecution(exec *model.ManifestExecution) *models.ImportSummary {
 	ret := new(models.ImportSummary)
 	ret.Outcome = models.ImportSummaryOutcomeSuccess
 	if exec != nil {
+		ret.DryRun = exec.DryRun
 		ret.Tasks = make([]*models.Task, len(exec.TaskSequence))
 		for i, tid := range exec.TaskSequence {
 			t, ok := exec.Tasks[tid]
@@ -175,6 +192,8 @@ func mapManifestExecution(exec *model.ManifestExecution) *models.ImportSummary {
 				mt := new(models.Task)
 				mt.Task = t.TaskContext
 				mt.Response = t.Response
+				mt.Outcome = t.Outcome
+				mt.Message = t.Message
 				ret.Tasks[i] = mt
 			}
 		}
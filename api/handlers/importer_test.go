@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/go-openapi/swag"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -290,6 +291,105 @@ func TestImportHandlerHappyPath(t *testing.T) {
 	assert.Len(t, mockedProcessor.ProcessCalls(), 1)
 }
 
+func TestImportHandlerFailedTaskReturnsSummary(t *testing.T) {
+	tempDir := t.TempDir()
+
+	mockedprojectChecker := &handlers_mock.ProjectCheckerMock{
+		ProjectExistsFunc: func(projectName string) (bool, error) {
+			return true, nil
+		},
+	}
+
+	parsingPackage := func(file string, maxSize uint64) (*ZippedPackage, error) {
+		return &ZippedPackage{}, nil
+	}
+
+	mockedProcessor := &handlers_mock.MockImportPackageProcessor{
+		ProcessFunc: func(
+			project string, ip importer.ImportPackage,
+		) (*model.ManifestExecution, error) {
+			return &model.ManifestExecution{
+				Tasks: map[string]model.TaskExecution{
+					"create_service": {Outcome: model.TaskOutcomeRolledBack},
+					"push_resource":  {Outcome: model.TaskOutcomeFailure, Message: "resource-service unavailable"},
+				},
+				TaskSequence: []string{"create_service", "push_resource"},
+			}, errors.New("resource task id push_resource failed: resource-service unavailable")
+		},
+	}
+
+	importHandlerFunc := getImportHandlerInstance(
+		tempDir, mockedprojectChecker, testArchiveSize20MB, parsingPackage, mockedProcessor,
+	).HandleImport
+
+	actualResponder := importHandlerFunc(
+		import_operations.ImportParams{
+			ConfigPackage: io.NopCloser(bytes.NewReader([]byte("some payload bytes here"))),
+			Project:       "projectName",
+		},
+		new(models.Principal),
+	)
+
+	require.IsType(t, &import_operations.ImportUnprocessableEntity{}, actualResponder)
+	summary := actualResponder.(*import_operations.ImportUnprocessableEntity).Payload
+	assert.Equal(t, models.ImportSummaryOutcomeFailure, summary.Outcome)
+	assert.Contains(t, summary.Message, "resource-service unavailable")
+	require.Len(t, summary.Tasks, 2)
+	assert.Equal(t, models.TaskOutcomeRolledBack, summary.Tasks[0].Outcome)
+	assert.Equal(t, models.TaskOutcomeFailure, summary.Tasks[1].Outcome)
+	assert.Equal(t, "resource-service unavailable", summary.Tasks[1].Message)
+}
+
+func TestImportHandlerDryRun(t *testing.T) {
+	tempDir := t.TempDir()
+
+	mockedprojectChecker := &handlers_mock.ProjectCheckerMock{
+		ProjectExistsFunc: func(projectName string) (bool, error) {
+			return true, nil
+		},
+	}
+
+	parsingPackage := func(file string, maxSize uint64) (*ZippedPackage, error) {
+		return &ZippedPackage{}, nil
+	}
+
+	mockedProcessor := &handlers_mock.MockImportPackageProcessor{
+		DryRunFunc: func(
+			project string, ip importer.ImportPackage,
+		) (*model.ManifestExecution, error) {
+			return &model.ManifestExecution{
+				Tasks: map[string]model.TaskExecution{
+					"create_service": {Outcome: model.TaskOutcomeSuccess},
+				},
+				TaskSequence: []string{"create_service"},
+				DryRun:       true,
+			}, nil
+		},
+	}
+
+	importHandlerFunc := getImportHandlerInstance(
+		tempDir, mockedprojectChecker, testArchiveSize20MB, parsingPackage, mockedProcessor,
+	).HandleImport
+
+	actualResponder := importHandlerFunc(
+		import_operations.ImportParams{
+			ConfigPackage: io.NopCloser(bytes.NewReader([]byte("some payload bytes here"))),
+			DryRun:        swag.Bool(true),
+			Project:       "projectName",
+		},
+		new(models.Principal),
+	)
+
+	require.IsType(t, &import_operations.ImportOK{}, actualResponder)
+	summary := actualResponder.(*import_operations.ImportOK).Payload
+	assert.True(t, summary.DryRun)
+	assert.Equal(t, models.ImportSummaryOutcomeSuccess, summary.Outcome)
+	require.Len(t, summary.Tasks, 1)
+	assert.Equal(t, models.TaskOutcomeSuccess, summary.Tasks[0].Outcome)
+	assert.Len(t, mockedProcessor.DryRunCalls(), 1)
+	assert.Empty(t, mockedProcessor.ProcessCalls())
+}
+
 func Test_mapManifestExecution(t *testing.T) {
 
 	taskaExecution := model.TaskExecution{
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/restapi/operations/token"
+	"github.com/keptn/keptn/api/tokens"
+
+	logger "github.com/sirupsen/logrus"
+)
+
+//go:generate moq -pkg handlers_mock --skip-ensure -out ./fake/tokenmanager_mock.go . tokenManager:MockTokenManager
+
+type tokenManager interface {
+	Create(name string, role tokens.Role, projects []string, expiresAt *time.Time) (tokens.APIToken, string, error)
+	Revoke(name string) error
+	List() ([]tokens.APIToken, error)
+}
+
+// TokensHandler is the rest handler for the /tokens endpoints
+type TokensHandler struct {
+	manager tokenManager
+}
+
+// NewTokensHandler creates a TokensHandler whose methods can be used for handling http requests to the endpoints.
+// See restapi.configureAPI for usage
+func NewTokensHandler(manager tokenManager) *TokensHandler {
+	return &TokensHandler{manager: manager}
+}
+
+// HandleCreateToken creates a named API token and responds with the token, including its value
+func (th *TokensHandler) HandleCreateToken(
+	params token.CreateTokenParams, principal *models.Principal,
+) middleware.Responder {
+	var expiresAt *time.Time
+	if !time.Time(params.Body.ExpiresAt).IsZero() {
+		expiry := time.Time(params.Body.ExpiresAt)
+		expiresAt = &expiry
+	}
+
+	apiToken, value, err := th.manager.Create(
+		*params.Body.Name, tokens.Role(*params.Body.Role), params.Body.Projects, expiresAt,
+	)
+	if err != nil {
+		message := fmt.Sprintf("could not create token %s: %v", *params.Body.Name, err)
+		switch {
+		case errors.Is(err, tokens.ErrInvalidToken):
+			return token.NewCreateTokenBadRequest().WithPayload(newError(http.StatusBadRequest, message))
+		case errors.Is(err, tokens.ErrTokenAlreadyExists):
+			return token.NewCreateTokenConflict().WithPayload(newError(http.StatusConflict, message))
+		}
+		logger.Errorf("Error creating token %s: %v", *params.Body.Name, err)
+		return token.NewCreateTokenFailedDependency().WithPayload(newError(http.StatusFailedDependency, message))
+	}
+
+	payload := mapAPIToken(apiToken)
+	payload.Token = value
+	return token.NewCreateTokenCreated().WithPayload(payload)
+}
+
+// HandleListTokens responds with all named API tokens, without their values
+func (th *TokensHandler) HandleListTokens(
+	params token.ListTokensParams, principal *models.Principal,
+) middleware.Responder {
+	apiTokens, err := th.manager.List()
+	if err != nil {
+		logger.Errorf("Error listing tokens: %v", err)
+		message := fmt.Sprintf("could not list tokens: %v", err)
+		return token.NewListTokensFailedDependency().WithPayload(newError(http.StatusFailedDependency, message))
+	}
+
+	payload := &models.APITokens{Tokens: make([]*models.APIToken, 0, len(apiTokens))}
+	for _, apiToken := range apiTokens {
+		payload.Tokens = append(payload.Tokens, mapAPIToken(apiToken))
+	}
+	return token.NewListTokensOK().WithPayload(payload)
+}
+
+// HandleRevokeToken revokes a named API token
+func (th *TokensHandler) HandleRevokeToken(
+	params token.RevokeTokenParams, principal *models.Principal,
+) middleware.Responder {
+	if err := th.manager.Revoke(params.TokenName); err != nil {
+		message := fmt.Sprintf("could not revoke token %s: %v", params.TokenName, err)
+		if errors.Is(err, tokens.ErrTokenNotFound) {
+			return token.NewRevokeTokenNotFound().WithPayload(newError(http.StatusNotFound, message))
+		}
+		logger.Errorf("Error revoking token %s: %v", params.TokenName, err)
+		return token.NewRevokeTokenFailedDependency().WithPayload(newError(http.StatusFailedDependency, message))
+	}
+	return token.NewRevokeTokenNoContent()
+}
+
+func mapAPIToken(apiToken tokens.APIToken) *models.APIToken {
+	mapped := &models.APIToken{
+		Name:      swag.String(apiToken.Name),
+		Role:      swag.String(string(apiToken.Role)),
+		Projects:  apiToken.Projects,
+		CreatedAt: strfmt.DateTime(apiToken.CreatedAt),
+	}
+	if apiToken.ExpiresAt != nil {
+		mapped.ExpiresAt = strfmt.DateTime(*apiToken.ExpiresAt)
+	}
+	return mapped
+}
+
+func newError(code int64, message string) *models.Error {
+	return &models.Error{
+		Code:    code,
+		Message: &message,
+	}
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	handlers_mock "github.com/keptn/keptn/api/handlers/fake"
+	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/restapi/operations/token"
+	"github.com/keptn/keptn/api/tokens"
+)
+
+func TestHandleCreateToken(t *testing.T) {
+	expiry := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	mockedManager := &handlers_mock.MockTokenManager{
+		CreateFunc: func(name string, role tokens.Role, projects []string, expiresAt *time.Time) (tokens.APIToken, string, error) {
+			return tokens.APIToken{Name: name, Role: role, Projects: projects, ExpiresAt: expiresAt}, "secret-value", nil
+		},
+	}
+
+	sut := NewTokensHandler(mockedManager)
+	actualResponder := sut.HandleCreateToken(token.CreateTokenParams{
+		Body: &models.CreateTokenRequest{
+			Name:      swag.String("ci-pipeline"),
+			Role:      swag.String("trigger-only"),
+			Projects:  []string{"sockshop"},
+			ExpiresAt: strfmt.DateTime(expiry),
+		},
+	}, new(models.Principal))
+
+	require.IsType(t, &token.CreateTokenCreated{}, actualResponder)
+	actualPayload := actualResponder.(*token.CreateTokenCreated).Payload
+	assert.Equal(t, "ci-pipeline", *actualPayload.Name)
+	assert.Equal(t, "secret-value", actualPayload.Token)
+	assert.Equal(t, []string{"sockshop"}, actualPayload.Projects)
+	assert.Equal(t, strfmt.DateTime(expiry), actualPayload.ExpiresAt)
+	require.Len(t, mockedManager.CreateCalls(), 1)
+	assert.Equal(t, tokens.RoleTriggerOnly, mockedManager.CreateCalls()[0].Role)
+	assert.Equal(t, expiry, *mockedManager.CreateCalls()[0].ExpiresAt)
+}
+
+func TestHandleCreateTokenErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "invalid token", err: fmt.Errorf("%w: unknown role", tokens.ErrInvalidToken), wantStatus: http.StatusBadRequest},
+		{name: "existing token", err: tokens.ErrTokenAlreadyExists, wantStatus: http.StatusConflict},
+		{name: "store unavailable", err: tokens.ErrStoreUnavailable, wantStatus: http.StatusFailedDependency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedManager := &handlers_mock.MockTokenManager{
+				CreateFunc: func(name string, role tokens.Role, projects []string, expiresAt *time.Time) (tokens.APIToken, string, error) {
+					return tokens.APIToken{}, "", tt.err
+				},
+			}
+
+			sut := NewTokensHandler(mockedManager)
+			actualResponder := sut.HandleCreateToken(token.CreateTokenParams{
+				Body: &models.CreateTokenRequest{Name: swag.String("ci-pipeline"), Role: swag.String("admin")},
+			}, new(models.Principal))
+
+			verifyHTTPResponse(actualResponder, tt.wantStatus, t)
+			assert.Nil(t, mockedManager.CreateCalls()[0].ExpiresAt)
+		})
+	}
+}
+
+func TestHandleListTokens(t *testing.T) {
+	mockedManager := &handlers_mock.MockTokenManager{
+		ListFunc: func() ([]tokens.APIToken, error) {
+			return []tokens.APIToken{
+				{Name: "ci-pipeline", Role: tokens.RoleTriggerOnly, Hash: "hash"},
+				{Name: "admin", Role: tokens.RoleAdmin, Hash: "other-hash"},
+			}, nil
+		},
+	}
+
+	sut := NewTokensHandler(mockedManager)
+	actualResponder := sut.HandleListTokens(token.ListTokensParams{}, new(models.Principal))
+
+	require.IsType(t, &token.ListTokensOK{}, actualResponder)
+	actualPayload := actualResponder.(*token.ListTokensOK).Payload
+	require.Len(t, actualPayload.Tokens, 2)
+	assert.Equal(t, "ci-pipeline", *actualPayload.Tokens[0].Name)
+	assert.Empty(t, actualPayload.Tokens[0].Token)
+
+	mockedManager.ListFunc = func() ([]tokens.APIToken, error) {
+		return nil, tokens.ErrStoreUnavailable
+	}
+	verifyHTTPResponse(sut.HandleListTokens(token.ListTokensParams{}, new(models.Principal)), http.StatusFailedDependency, t)
+}
+
+func TestHandleRevokeToken(t *testing.T) {
+	mockedManager := &handlers_mock.MockTokenManager{
+		RevokeFunc: func(name string) error {
+			if name == "ci-pipeline" {
+				return nil
+			}
+			if name == "unknown" {
+				return tokens.ErrTokenNotFound
+			}
+			return errors.New("unreachable")
+		},
+	}
+
+	sut := NewTokensHandler(mockedManager)
+	verifyHTTPResponse(sut.HandleRevokeToken(token.RevokeTokenParams{TokenName: "ci-pipeline"}, new(models.Principal)), http.StatusNoContent, t)
+	verifyHTTPResponse(sut.HandleRevokeToken(token.RevokeTokenParams{TokenName: "unknown"}, new(models.Principal)), http.StatusNotFound, t)
+	verifyHTTPResponse(sut.HandleRevokeToken(token.RevokeTokenParams{TokenName: "other"}, new(models.Principal)), http.StatusFailedDependency, t)
+}
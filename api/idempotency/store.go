@@ -0,0 +1,131 @@
+package idempotency
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/nats-io/nats.go"
+)
+
+// Store remembers the keptn contexts of the events received by the API within the idempotency window
+type Store interface {
+	// Reserve stores the keptn context for the key and returns true if the key is not known yet. Otherwise, the keptn
+	// context stored for the key is returned
+	Reserve(key string, keptnContext string) (string, bool, error)
+	// Release forgets the key, e.g. because the event could not be forwarded and may be sent again
+	Release(key string) error
+}
+
+type reservation struct {
+	keptnContext string
+	expiry       time.Time
+}
+
+// MemoryStore remembers the keys of the events received by one replica of the API service
+type MemoryStore struct {
+	theClock     clock.Clock
+	window       time.Duration
+	reservations map[string]reservation
+	mutex        sync.Mutex
+}
+
+func NewMemoryStore(window time.Duration, theClock clock.Clock) *MemoryStore {
+	s := &MemoryStore{
+		theClock:     theClock,
+		window:       window,
+		reservations: map[string]reservation{},
+	}
+
+	ticker := s.theClock.Ticker(1 * time.Minute)
+	go func() {
+		for {
+			<-ticker.C
+			s.cleanExpiredReservations()
+		}
+	}()
+
+	return s
+}
+
+func (s *MemoryStore) Reserve(key string, keptnContext string) (string, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	now := s.theClock.Now()
+	if existing, ok := s.reservations[key]; ok && now.Before(existing.expiry) {
+		return existing.keptnContext, false, nil
+	}
+	s.reservations[key] = reservation{keptnContext: keptnContext, expiry: now.Add(s.window)}
+	return keptnContext, true, nil
+}
+
+func (s *MemoryStore) Release(key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.reservations, key)
+	return nil
+}
+
+func (s *MemoryStore) cleanExpiredReservations() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	now := s.theClock.Now()
+	for key, existing := range s.reservations {
+		if !now.Before(existing.expiry) {
+			delete(s.reservations, key)
+		}
+	}
+}
+
+// NatsStore remembers the keys of the events in a NATS JetStream key-value bucket, which is shared by all replicas of
+// the API service. Keys are removed by NATS once the TTL of the bucket, i.e. the idempotency window, has passed
+type NatsStore struct {
+	kv nats.KeyValue
+}
+
+// NewNatsStore returns a store using the given bucket, which is created with the window as TTL if it does not exist
+func NewNatsStore(js nats.JetStreamContext, bucket string, window time.Duration) (*NatsStore, error) {
+	kv, err := js.KeyValue(bucket)
+	if errors.Is(err, nats.ErrBucketNotFound) {
+		kv, err = js.CreateKeyValue(&nats.KeyValueConfig{
+			Bucket:      bucket,
+			Description: "idempotency keys of the events sent to the Keptn API",
+			TTL:         window,
+			History:     1,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not open idempotency bucket %s: %w", bucket, err)
+	}
+	return &NatsStore{kv: kv}, nil
+}
+
+func (s *NatsStore) Reserve(key string, keptnContext string) (string, bool, error) {
+	kvKey := hashKey(key)
+	if _, err := s.kv.Create(kvKey, []byte(keptnContext)); err == nil {
+		return keptnContext, true, nil
+	}
+	// the key exists already, or the bucket is not available
+	entry, err := s.kv.Get(kvKey)
+	if err != nil {
+		return "", false, fmt.Errorf("could not read idempotency key: %w", err)
+	}
+	return string(entry.Value()), false, nil
+}
+
+func (s *NatsStore) Release(key string) error {
+	if err := s.kv.Delete(hashKey(key)); err != nil {
+		return fmt.Errorf("could not release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// hashKey hashes the key, since keys of NATS key-value buckets are restricted to few characters
+func hashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,108 @@
+package idempotency
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/nats-io/nats-server/v2/server"
+	natstest "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	mockClock := clock.NewMock()
+	store := NewMemoryStore(10*time.Minute, mockClock)
+
+	keptnContext, reserved, err := store.Reserve("key", "context-1")
+	require.NoError(t, err)
+	require.True(t, reserved)
+	require.Equal(t, "context-1", keptnContext)
+
+	// duplicates within the window get the keptn context of the original event
+	mockClock.Add(9 * time.Minute)
+	keptnContext, reserved, err = store.Reserve("key", "context-2")
+	require.NoError(t, err)
+	require.False(t, reserved)
+	require.Equal(t, "context-1", keptnContext)
+
+	_, reserved, err = store.Reserve("other-key", "context-3")
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	// released keys can be reserved again
+	require.NoError(t, store.Release("other-key"))
+	keptnContext, reserved, err = store.Reserve("other-key", "context-4")
+	require.NoError(t, err)
+	require.True(t, reserved)
+	require.Equal(t, "context-4", keptnContext)
+
+	// keys expire after the window
+	mockClock.Add(1 * time.Minute)
+	keptnContext, reserved, err = store.Reserve("key", "context-5")
+	require.NoError(t, err)
+	require.True(t, reserved)
+	require.Equal(t, "context-5", keptnContext)
+
+	// proceed the internal clock of the store and check if the expired reservations are being cleaned up
+	mockClock.Add(11 * time.Minute)
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	require.Empty(t, store.reservations)
+}
+
+func TestNatsStore(t *testing.T) {
+	opts := natstest.DefaultTestOptions
+	opts.Port = server.RANDOM_PORT
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	svr := natstest.RunServer(&opts)
+	defer svr.Shutdown()
+
+	conn, err := nats.Connect(svr.ClientURL())
+	require.NoError(t, err)
+	defer conn.Close()
+	js, err := conn.JetStream()
+	require.NoError(t, err)
+
+	// two replicas share the keys of the bucket
+	replicas := make([]*NatsStore, 2)
+	for i := range replicas {
+		replicas[i], err = NewNatsStore(js, "idempotency-keys", time.Minute)
+		require.NoError(t, err)
+	}
+
+	// only one of the concurrently received duplicates is reserved
+	wg := &sync.WaitGroup{}
+	mutex := &sync.Mutex{}
+	var reservedContexts []string
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(store *NatsStore, keptnContext string) {
+			defer wg.Done()
+			_, reserved, err := store.Reserve("key/retried-ci-job", keptnContext)
+			require.NoError(t, err)
+			if reserved {
+				mutex.Lock()
+				reservedContexts = append(reservedContexts, keptnContext)
+				mutex.Unlock()
+			}
+		}(replicas[i%2], string(rune('a'+i)))
+	}
+	wg.Wait()
+	require.Len(t, reservedContexts, 1)
+
+	keptnContext, reserved, err := replicas[1].Reserve("key/retried-ci-job", "other")
+	require.NoError(t, err)
+	require.False(t, reserved)
+	require.Equal(t, reservedContexts[0], keptnContext)
+
+	// released keys can be reserved again
+	require.NoError(t, replicas[0].Release("key/retried-ci-job"))
+	keptnContext, reserved, err = replicas[1].Reserve("key/retried-ci-job", "retry")
+	require.NoError(t, err)
+	require.True(t, reserved)
+	require.Equal(t, "retry", keptnContext)
+}
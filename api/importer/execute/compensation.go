@@ -0,0 +1,112 @@
+package execute
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+var /*const*/ ErrCompensationFailed = errors.New("compensation failed")
+var /*const*/ ErrEndpointUnavailable = errors.New("endpoint unavailable")
+
+// compensationRequestFactory creates the request reverting a successfully executed request, given its payload and the
+// parsed response
+type compensationRequestFactory interface {
+	CreateCompensationRequest(executed *http.Request, payload []byte, response any) (*http.Request, error)
+}
+
+// deleteServiceRequestFactory deletes the service created by POST /v1/project/{project}/service
+type deleteServiceRequestFactory struct{}
+
+func (deleteServiceRequestFactory) CreateCompensationRequest(
+	executed *http.Request, payload []byte, _ any,
+) (*http.Request, error) {
+	service := struct {
+		ServiceName string `json:"serviceName"`
+	}{}
+	if err := json.Unmarshal(payload, &service); err != nil {
+		return nil, fmt.Errorf("error unmarshalling service payload: %w", err)
+	}
+	if service.ServiceName == "" {
+		return nil, errors.New("service name not found in payload")
+	}
+	return http.NewRequest(http.MethodDelete, executed.URL.String()+"/"+url.PathEscape(service.ServiceName), nil)
+}
+
+// deleteSecretRequestFactory deletes the secret created by POST /v1/secret
+type deleteSecretRequestFactory struct{}
+
+func (deleteSecretRequestFactory) CreateCompensationRequest(
+	executed *http.Request, payload []byte, _ any,
+) (*http.Request, error) {
+	secret := struct {
+		Name  string `json:"name"`
+		Scope string `json:"scope"`
+	}{}
+	if err := json.Unmarshal(payload, &secret); err != nil {
+		return nil, fmt.Errorf("error unmarshalling secret payload: %w", err)
+	}
+	if secret.Name == "" || secret.Scope == "" {
+		return nil, errors.New("secret name or scope not found in payload")
+	}
+	deleteURL := *executed.URL
+	deleteURL.RawQuery = url.Values{"name": {secret.Name}, "scope": {secret.Scope}}.Encode()
+	return http.NewRequest(http.MethodDelete, deleteURL.String(), nil)
+}
+
+// deleteSubscriptionRequestFactory deletes the subscription created by
+// POST /v1/uniform/registration/{integrationID}/subscription, using the id returned in the response
+type deleteSubscriptionRequestFactory struct{}
+
+func (deleteSubscriptionRequestFactory) CreateCompensationRequest(
+	executed *http.Request, _ []byte, response any,
+) (*http.Request, error) {
+	responseMap, ok := response.(map[string]any)
+	if !ok {
+		return nil, errors.New("subscription id not found in response")
+	}
+	id, ok := responseMap["id"].(string)
+	if !ok || id == "" {
+		return nil, errors.New("subscription id not found in response")
+	}
+	return http.NewRequest(http.MethodDelete, executed.URL.String()+"/"+url.PathEscape(id), nil)
+}
+
+func doCompensationRequest(doer httpdoer, request *http.Request) error {
+	response, err := doer.Do(request)
+	if err != nil {
+		return fmt.Errorf("error executing compensation request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf(
+			"received unsuccessful http status <%d: %s> for %s %s: %w", response.StatusCode, response.Status,
+			request.Method, request.URL, ErrCompensationFailed,
+		)
+	}
+	return nil
+}
+
+// checkHealth verifies that the Keptn service reachable at endpoint reports being healthy
+func checkHealth(doer httpdoer, endpoint string) error {
+	request, err := http.NewRequest(http.MethodGet, endpoint+"/health", nil)
+	if err != nil {
+		return fmt.Errorf("error creating health check request: %w", err)
+	}
+	response, err := doer.Do(request)
+	if err != nil {
+		return fmt.Errorf("error checking health of %s: %w", endpoint, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf(
+			"received unsuccessful http status <%d: %s> checking health of %s: %w", response.StatusCode,
+			response.Status, endpoint, ErrEndpointUnavailable,
+		)
+	}
+	return nil
+}
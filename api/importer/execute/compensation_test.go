@@ -0,0 +1,257 @@
+package execute
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn/keptn/api/importer/execute/fake"
+	"github.com/keptn/keptn/api/importer/model"
+)
+
+func newResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		Status:     http.StatusText(statusCode),
+		StatusCode: statusCode,
+		Header:     map[string][]string{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestCompensationRequestFactories(t *testing.T) {
+	tests := []struct {
+		name        string
+		factory     compensationRequestFactory
+		executedURL string
+		payload     string
+		response    any
+		wantURL     string
+		wantErr     bool
+	}{
+		{
+			name:        "Delete created service",
+			factory:     deleteServiceRequestFactory{},
+			executedURL: "http://shipyard-controller:8080/v1/project/my-project/service",
+			payload:     `{"serviceName": "carts"}`,
+			wantURL:     "http://shipyard-controller:8080/v1/project/my-project/service/carts",
+		},
+		{
+			name:        "Service name missing",
+			factory:     deleteServiceRequestFactory{},
+			executedURL: "http://shipyard-controller:8080/v1/project/my-project/service",
+			payload:     `{}`,
+			wantErr:     true,
+		},
+		{
+			name:        "Delete created secret",
+			factory:     deleteSecretRequestFactory{},
+			executedURL: "http://secret-service:8080/v1/secret",
+			payload:     `{"name": "slack", "scope": "keptn-webhook-service", "data": {"token": "abc"}}`,
+			wantURL:     "http://secret-service:8080/v1/secret?name=slack&scope=keptn-webhook-service",
+		},
+		{
+			name:        "Delete created subscription",
+			factory:     deleteSubscriptionRequestFactory{},
+			executedURL: "http://shipyard-controller:8080/v1/uniform/registration/integration-id/subscription",
+			response:    map[string]any{"id": "subscription-id"},
+			wantURL:     "http://shipyard-controller:8080/v1/uniform/registration/integration-id/subscription/subscription-id",
+		},
+		{
+			name:        "Subscription id missing",
+			factory:     deleteSubscriptionRequestFactory{},
+			executedURL: "http://shipyard-controller:8080/v1/uniform/registration/integration-id/subscription",
+			response:    "not a json object",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				executed, err := http.NewRequest(http.MethodPost, tt.executedURL, nil)
+				require.NoError(t, err)
+
+				got, err := tt.factory.CreateCompensationRequest(executed, []byte(tt.payload), tt.response)
+				if tt.wantErr {
+					assert.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, http.MethodDelete, got.Method)
+				assert.Equal(t, tt.wantURL, got.URL.String())
+			},
+		)
+	}
+}
+
+func TestDefaultEndpointHandler_CompensatingAction(t *testing.T) {
+	var requests []string
+	doer := &fake.MockHTTPDoer{
+		DoFunc: func(r *http.Request) (*http.Response, error) {
+			requests = append(requests, r.Method+" "+r.URL.String())
+			if r.Method == http.MethodDelete {
+				return newResponse(http.StatusNotFound, `{"message": "service not found"}`), nil
+			}
+			return newResponse(http.StatusOK, `{}`), nil
+		},
+	}
+	ep := &defaultEndpointHandler{
+		requestFactory: &projectRenderRequestFactory{
+			httpMethod: http.MethodPost,
+			path:       "/v1/project/[[project]]/service",
+		},
+		endpoint:     "http://shipyard-controller:8080",
+		compensation: deleteServiceRequestFactory{},
+	}
+
+	_, compensation, err := ep.ExecuteAPI(
+		doer, model.APITaskExecution{
+			Payload: io.NopCloser(strings.NewReader(`{"serviceName": "carts"}`)),
+			Context: model.TaskContext{Project: "my-project", Task: &model.ManifestTask{ID: "create_service"}},
+		},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, compensation)
+
+	err = compensation()
+	assert.ErrorIs(t, err, ErrCompensationFailed)
+	assert.Equal(
+		t, []string{
+			"POST http://shipyard-controller:8080/v1/project/my-project/service",
+			"DELETE http://shipyard-controller:8080/v1/project/my-project/service/carts",
+		}, requests,
+	)
+}
+
+func TestDefaultEndpointHandler_CheckAvailability(t *testing.T) {
+	tests := []struct {
+		name     string
+		response *http.Response
+		doerErr  error
+		wantErr  error
+	}{
+		{
+			name:     "Healthy endpoint",
+			response: newResponse(http.StatusOK, ""),
+		},
+		{
+			name:     "Unhealthy endpoint",
+			response: newResponse(http.StatusServiceUnavailable, ""),
+			wantErr:  ErrEndpointUnavailable,
+		},
+		{
+			name:    "Unreachable endpoint",
+			doerErr: errors.New("connection refused"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				doer := &fake.MockHTTPDoer{
+					DoFunc: func(r *http.Request) (*http.Response, error) {
+						assert.Equal(t, "http://secret-service:8080/health", r.URL.String())
+						return tt.response, tt.doerErr
+					},
+				}
+				ep := &defaultEndpointHandler{
+					requestFactory: &projectRenderRequestFactory{httpMethod: http.MethodPost, path: "/v1/secret"},
+					endpoint:       "http://secret-service:8080",
+				}
+
+				err := ep.CheckAvailability(doer, model.APITaskExecution{Context: model.TaskContext{Task: &model.ManifestTask{}}})
+				switch {
+				case tt.wantErr != nil:
+					assert.ErrorIs(t, err, tt.wantErr)
+				case tt.doerErr != nil:
+					assert.ErrorIs(t, err, tt.doerErr)
+				default:
+					assert.NoError(t, err)
+				}
+			},
+		)
+	}
+}
+
+func TestKeptnResourcePusher_PrepareRevert(t *testing.T) {
+	mockKeptnEndpointProvider := &fake.KeptnEndpointProviderMock{
+		GetConfigurationServiceEndpointFunc: func() string {
+			return "http://resource-service:8080"
+		},
+	}
+
+	t.Run(
+		"New resource is deleted", func(t *testing.T) {
+			var requests []string
+			doer := &fake.MockHTTPDoer{
+				DoFunc: func(r *http.Request) (*http.Response, error) {
+					requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+					if r.Method == http.MethodGet {
+						return newResponse(http.StatusNotFound, `{"message": "resource not found"}`), nil
+					}
+					return newResponse(http.StatusOK, `{}`), nil
+				},
+			}
+			sut := &KeptnResourcePusher{endpointProvider: mockKeptnEndpointProvider, doer: doer}
+
+			revert, err := sut.PrepareRevert("my-project", "dev", "carts", "helm/values.yaml")
+			require.NoError(t, err)
+			require.NoError(t, revert())
+			assert.Equal(
+				t, []string{
+					"GET /v1/project/my-project/stage/dev/service/carts/resource/helm%2Fvalues.yaml",
+					"DELETE /v1/project/my-project/stage/dev/service/carts/resource/helm%2Fvalues.yaml",
+				}, requests,
+			)
+		},
+	)
+
+	t.Run(
+		"Existing resource is restored", func(t *testing.T) {
+			var restored resourceRequest
+			doer := &fake.MockHTTPDoer{
+				DoFunc: func(r *http.Request) (*http.Response, error) {
+					if r.Method == http.MethodGet {
+						assert.Equal(t, "/v1/project/my-project/stage/dev/resource/webhook%2Fwebhook.yaml", r.URL.EscapedPath())
+						return newResponse(
+							http.StatusOK, `{"resourceURI": "webhook/webhook.yaml", "resourceContent": "b2xkIGNvbnRlbnQ="}`,
+						), nil
+					}
+					assert.Equal(t, http.MethodPost, r.Method)
+					assert.Equal(t, "/v1/project/my-project/stage/dev/resource", r.URL.Path)
+					bodyBytes, err := io.ReadAll(r.Body)
+					require.NoError(t, err)
+					require.NoError(t, json.Unmarshal(bodyBytes, &restored))
+					return newResponse(http.StatusCreated, `{"commitID": "1234"}`), nil
+				},
+			}
+			sut := &KeptnResourcePusher{endpointProvider: mockKeptnEndpointProvider, doer: doer}
+
+			revert, err := sut.PrepareRevert("my-project", "dev", "", "webhook/webhook.yaml")
+			require.NoError(t, err)
+			require.NoError(t, revert())
+			require.Len(t, restored.Resources, 1)
+			assert.Equal(t, "webhook/webhook.yaml", *restored.Resources[0].ResourceURI)
+			assert.Equal(t, "b2xkIGNvbnRlbnQ=", restored.Resources[0].ResourceContent)
+		},
+	)
+
+	t.Run(
+		"Error retrieving resource", func(t *testing.T) {
+			doer := &fake.MockHTTPDoer{
+				DoFunc: func(r *http.Request) (*http.Response, error) {
+					return newResponse(http.StatusInternalServerError, `{}`), nil
+				},
+			}
+			sut := &KeptnResourcePusher{endpointProvider: mockKeptnEndpointProvider, doer: doer}
+
+			revert, err := sut.PrepareRevert("my-project", "dev", "", "webhook/webhook.yaml")
+			assert.ErrorIs(t, err, ErrTaskFailed)
+			assert.Nil(t, revert)
+		},
+	)
+}
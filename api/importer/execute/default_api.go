@@ -1,6 +1,7 @@
 package execute
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,6 +20,9 @@ import (
 
 var /*const*/ ErrTaskFailed = errors.New("task failed")
 
+// ErrTaskConflict is returned when a task fails because the object to create already exists
+var /*const*/ ErrTaskConflict = fmt.Errorf("%w: conflict", ErrTaskFailed)
+
 type otelWrappedHttpClient struct {
 	client http.Client
 }
@@ -63,21 +67,31 @@ func (rf *projectRenderRequestFactory) CreateRequest(
 type defaultEndpointHandler struct {
 	requestFactory
 	endpoint string
+	// compensation creates the request reverting a successful call, no compensating action is returned if nil
+	compensation compensationRequestFactory
 }
 
-func (ep *defaultEndpointHandler) ExecuteAPI(doer httpdoer, ate model.APITaskExecution) (any, error) {
+func (ep *defaultEndpointHandler) ExecuteAPI(doer httpdoer, ate model.APITaskExecution) (any, model.CompensatingAction, error) {
+	var body io.Reader
+	var payload []byte
 	if ate.Payload != nil {
 		defer ate.Payload.Close()
+		var err error
+		payload, err = io.ReadAll(ate.Payload)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading payload: %w", err)
+		}
+		body = bytes.NewReader(payload)
 	}
 
-	request, err := ep.CreateRequest(ate.Context, ep.endpoint, ate.Payload)
+	request, err := ep.CreateRequest(ate.Context, ep.endpoint, body)
 	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+		return nil, nil, fmt.Errorf("error creating request: %w", err)
 	}
 	response, err := doer.Do(request)
 
 	if err != nil {
-		return nil, fmt.Errorf("error executing request: %w", err)
+		return nil, nil, fmt.Errorf("error executing request: %w", err)
 	}
 
 	defer response.Body.Close()
@@ -100,11 +114,39 @@ func (ep *defaultEndpointHandler) ExecuteAPI(doer httpdoer, ate model.APITaskExe
 	}
 
 	if response.StatusCode >= 200 && response.StatusCode < 300 {
-		return responseBody, nil
+		return responseBody, ep.newCompensatingAction(doer, ate.Context, request, payload, *responseBody), nil
 	}
 
-	return responseBody, fmt.Errorf(
+	taskErr := ErrTaskFailed
+	if response.StatusCode == http.StatusConflict {
+		taskErr = ErrTaskConflict
+	}
+	return responseBody, nil, fmt.Errorf(
 		"received unsuccessful http status <%d: %s>: %w", response.StatusCode,
-		response.Status, ErrTaskFailed,
+		response.Status, taskErr,
 	)
 }
+
+func (ep *defaultEndpointHandler) newCompensatingAction(
+	doer httpdoer, tCtx model.TaskContext, executed *http.Request, payload []byte, response any,
+) model.CompensatingAction {
+	if ep.compensation == nil {
+		return nil
+	}
+	return func() error {
+		request, err := ep.compensation.CreateCompensationRequest(executed, payload, response)
+		if err != nil {
+			return fmt.Errorf("error creating compensation request for task %s: %w", tCtx.Task.ID, err)
+		}
+		return doCompensationRequest(doer, request)
+	}
+}
+
+// CheckAvailability verifies that the request of the task can be created, e.g. that the integration receiving a
+// webhook subscription exists, and that the endpoint is healthy
+func (ep *defaultEndpointHandler) CheckAvailability(doer httpdoer, ate model.APITaskExecution) error {
+	if _, err := ep.CreateRequest(ate.Context, ep.endpoint, nil); err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	return checkHealth(doer, ep.endpoint)
+}
@@ -550,7 +550,7 @@ func Test_defaultEndpointHandler_ExecuteAPI(t *testing.T) {
 					requestFactory: tt.fields.requestFactory,
 					endpoint:       tt.fields.endpoint,
 				}
-				got, err := ep.ExecuteAPI(tt.args.doer, tt.args.ate)
+				got, _, err := ep.ExecuteAPI(tt.args.doer, tt.args.ate)
 				if tt.wantErr {
 					assert.Error(t, err)
 					if tt.errorContains != "" {
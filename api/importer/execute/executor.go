@@ -17,12 +17,15 @@ type httpdoer interface {
 }
 
 type endpointHandler interface {
-	ExecuteAPI(doer httpdoer, ate model.APITaskExecution) (any, error)
+	ExecuteAPI(doer httpdoer, ate model.APITaskExecution) (any, model.CompensatingAction, error)
+	CheckAvailability(doer httpdoer, ate model.APITaskExecution) error
 }
 
 type resourcePusher interface {
 	PushToStage(project string, stage string, content io.ReadCloser, resourceURI string) (any, error)
 	PushToService(project string, stage string, service string, content io.ReadCloser, resourceURI string) (any, error)
+	PrepareRevert(project string, stage string, service string, resourceURI string) (model.CompensatingAction, error)
+	CheckAvailability() error
 }
 
 var /*const*/ ErrEndpointNotDefined = errors.New("endpoint not defined")
@@ -72,43 +75,129 @@ func newKeptnExecutor(kep KeptnEndpointProvider, hd httpdoer) *KeptnAPIExecutor
 }
 
 func (kae *KeptnAPIExecutor) registerEndpoints(kep KeptnEndpointProvider) {
-	kae.endpointMappings[model.CreateServiceAction] = &defaultEndpointHandler{
+	createService := &defaultEndpointHandler{
 		requestFactory: &projectRenderRequestFactory{
 			httpMethod: http.MethodPost,
 			path:       `/v1/project/[[project]]/service`,
 		},
-		endpoint: kep.GetControlPlaneEndpoint(),
+		endpoint:     kep.GetControlPlaneEndpoint(),
+		compensation: deleteServiceRequestFactory{},
 	}
+	kae.endpointMappings[model.CreateServiceAction] = createService
 
-	kae.endpointMappings[model.CreateSecretAction] = &defaultEndpointHandler{
+	createSecret := &defaultEndpointHandler{
 		requestFactory: &projectRenderRequestFactory{
 			httpMethod: http.MethodPost,
 			path:       "/v1/secret",
 		},
-		endpoint: kep.GetSecretsServiceEndpoint(),
+		endpoint:     kep.GetSecretsServiceEndpoint(),
+		compensation: deleteSecretRequestFactory{},
+	}
+	kae.endpointMappings[model.CreateSecretAction] = createSecret
+
+	idRetriever := NewKeptnIntegrationIdRetriever(kep)
+	kae.endpointMappings[model.CreateWebhookAction] = &defaultEndpointHandler{
+		requestFactory: NewWebhookSubscriptionHandler(idRetriever),
+		endpoint:       kep.GetControlPlaneEndpoint(),
+		compensation:   deleteSubscriptionRequestFactory{},
+	}
+
+	kae.endpointMappings[model.CreateSubscriptionAction] = &defaultEndpointHandler{
+		requestFactory: subscriptionRequestFactory{idRetriever: idRetriever},
+		endpoint:       kep.GetControlPlaneEndpoint(),
+		compensation:   deleteSubscriptionRequestFactory{},
+	}
+
+	kae.endpointMappings[model.UpdateSubscriptionAction] = &defaultEndpointHandler{
+		requestFactory: subscriptionRequestFactory{idRetriever: idRetriever, update: true},
+		endpoint:       kep.GetControlPlaneEndpoint(),
+	}
+
+	kae.endpointMappings[model.UpdateProjectAction] = &defaultEndpointHandler{
+		requestFactory: &projectRenderRequestFactory{
+			httpMethod: http.MethodPut,
+			path:       "/v1/project",
+		},
+		endpoint: kep.GetControlPlaneEndpoint(),
 	}
 
-	kae.endpointMappings["keptn-api-v1-uniform-create-webhook-subscription"] = &defaultEndpointHandler{
-		requestFactory: NewWebhookSubscriptionHandler(NewKeptnIntegrationIdRetriever(kep)),
+	kae.endpointMappings[model.UpdateShipyardAction] = &defaultEndpointHandler{
+		requestFactory: shipyardRequestFactory{},
 		endpoint:       kep.GetControlPlaneEndpoint(),
 	}
+
+	kae.endpointMappings[model.TriggerSequenceAction] = &sequenceTriggerHandler{
+		endpoint: kep.GetControlPlaneEndpoint(),
+	}
+
+	kae.endpointMappings[model.UpsertServiceAction] = &upsertEndpointHandler{
+		create: createService,
+	}
+
+	kae.endpointMappings[model.UpsertSecretAction] = &upsertEndpointHandler{
+		create: createSecret,
+		update: &defaultEndpointHandler{
+			requestFactory: &projectRenderRequestFactory{
+				httpMethod: http.MethodPut,
+				path:       "/v1/secret",
+			},
+			endpoint: kep.GetSecretsServiceEndpoint(),
+		},
+	}
+
+	kae.endpointMappings[model.UpsertWebhookAction] = newUpsertSubscriptionHandler(
+		idRetriever, webhookIntegrationName, kep.GetControlPlaneEndpoint(),
+	)
+
+	kae.endpointMappings[model.UpsertSubscriptionAction] = newUpsertSubscriptionHandler(
+		idRetriever, "", kep.GetControlPlaneEndpoint(),
+	)
 }
 
-func (kae *KeptnAPIExecutor) ExecuteAPI(ate model.APITaskExecution) (any, error) {
+func (kae *KeptnAPIExecutor) ExecuteAPI(ate model.APITaskExecution) (any, model.CompensatingAction, error) {
 
 	endpointHandler, ok := kae.endpointMappings[ate.EndpointID]
 	if !ok {
-		return nil, fmt.Errorf("error executing api call for endpoint %s: %w", ate.EndpointID, ErrEndpointNotDefined)
+		return nil, nil, fmt.Errorf("error executing api call for endpoint %s: %w", ate.EndpointID, ErrEndpointNotDefined)
 	}
 
 	return endpointHandler.ExecuteAPI(kae.doer, ate)
 }
 
-func (kae *KeptnAPIExecutor) PushResource(rp model.ResourcePush) (any, error) {
+// CheckAPI verifies that the endpoint of an API task is available without executing the task
+func (kae *KeptnAPIExecutor) CheckAPI(ate model.APITaskExecution) error {
+	endpointHandler, ok := kae.endpointMappings[ate.EndpointID]
+	if !ok {
+		return fmt.Errorf("error checking api call for endpoint %s: %w", ate.EndpointID, ErrEndpointNotDefined)
+	}
+
+	return endpointHandler.CheckAvailability(kae.doer, ate)
+}
+
+// PushResource pushes a resource to a stage or service. The returned compensating action restores the previous
+// version of the resource, or deletes it if it did not exist before
+func (kae *KeptnAPIExecutor) PushResource(rp model.ResourcePush) (any, model.CompensatingAction, error) {
+	revert, err := kae.resourcePusher.PrepareRevert(rp.Context.Project, rp.Stage, rp.Service, rp.ResourceURI)
+	if err != nil {
+		rp.Content.Close()
+		return nil, nil, fmt.Errorf("error preparing revert of resource %s: %w", rp.ResourceURI, err)
+	}
+
+	var response any
 	if rp.Service == "" {
-		return kae.resourcePusher.PushToStage(rp.Context.Project, rp.Stage, rp.Content, rp.ResourceURI)
+		response, err = kae.resourcePusher.PushToStage(rp.Context.Project, rp.Stage, rp.Content, rp.ResourceURI)
+	} else {
+		response, err = kae.resourcePusher.PushToService(rp.Context.Project, rp.Stage, rp.Service, rp.Content, rp.ResourceURI)
+	}
+	if err != nil {
+		return response, nil, err
 	}
-	return kae.resourcePusher.PushToService(rp.Context.Project, rp.Stage, rp.Service, rp.Content, rp.ResourceURI)
+	return response, revert, nil
+}
+
+// CheckResourcePush verifies that the configuration service receiving a resource is available without pushing it
+func (kae *KeptnAPIExecutor) CheckResourcePush(rp model.ResourcePush) error {
+	return kae.resourcePusher.CheckAvailability()
 }
 
 func (kae *KeptnAPIExecutor) ActionSupported(actionName string) bool {
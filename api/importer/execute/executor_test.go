@@ -47,7 +47,7 @@ func TestKeptnAPIExecutor_ErrorUnknownEndpointID(t *testing.T) {
 		},
 	}
 
-	taskResult, err := kae.ExecuteAPI(ate)
+	taskResult, _, err := kae.ExecuteAPI(ate)
 
 	assert.ErrorIs(t, err, ErrEndpointNotDefined)
 	assert.Nil(t, taskResult)
@@ -213,7 +213,7 @@ func TestKeptnAPIExecutor_Execute(t *testing.T) {
 
 				kae := newKeptnExecutor(mockKeptnEndpointProvider, nil)
 
-				got, err := kae.ExecuteAPI(tt.args.ate)
+				got, _, err := kae.ExecuteAPI(tt.args.ate)
 				if !tt.wantErr(t, err, fmt.Sprintf("ExecuteAPI(%v)", tt.args.ate)) {
 					return
 				}
@@ -266,6 +266,11 @@ func TestKeptnAPIExecutor_PushResource(t *testing.T) {
 				resourceReader := io.NopCloser(strings.NewReader(resourceContent))
 
 				pusher := &fake.MockResourcePusher{
+					PrepareRevertFunc: func(
+						project string, stage string, service string, resourceURI string,
+					) (model.CompensatingAction, error) {
+						return func() error { return nil }, nil
+					},
 					PushToServiceFunc: func(
 						project string, stage string, service string, content io.ReadCloser, resourceURI string,
 					) (any, error) {
@@ -319,8 +324,11 @@ func TestKeptnAPIExecutor_PushResource(t *testing.T) {
 					},
 				}
 
-				kae.PushResource(rp)
+				_, revert, err := kae.PushResource(rp)
+				require.NoError(t, err)
+				assert.NotNil(t, revert)
 
+				assert.Len(t, pusher.PrepareRevertCalls(), 1)
 				assert.Len(t, pusher.PushToStageCalls(), tt.expectations.pushToStageCalls)
 				assert.Len(t, pusher.PushToServiceCalls(), tt.expectations.pushToServiceCalls)
 
@@ -361,6 +369,51 @@ func TestKeptnAPIExecutor_ActionSupported(t *testing.T) {
 			action:    model.CreateSecretAction,
 			supported: true,
 		},
+		{
+			name:      "create_subscription_action_allowed",
+			action:    model.CreateSubscriptionAction,
+			supported: true,
+		},
+		{
+			name:      "update_subscription_action_allowed",
+			action:    model.UpdateSubscriptionAction,
+			supported: true,
+		},
+		{
+			name:      "update_project_action_allowed",
+			action:    model.UpdateProjectAction,
+			supported: true,
+		},
+		{
+			name:      "update_shipyard_action_allowed",
+			action:    model.UpdateShipyardAction,
+			supported: true,
+		},
+		{
+			name:      "trigger_sequence_action_allowed",
+			action:    model.TriggerSequenceAction,
+			supported: true,
+		},
+		{
+			name:      "upsert_service_action_allowed",
+			action:    model.UpsertServiceAction,
+			supported: true,
+		},
+		{
+			name:      "upsert_secret_action_allowed",
+			action:    model.UpsertSecretAction,
+			supported: true,
+		},
+		{
+			name:      "upsert_webhook_action_allowed",
+			action:    model.UpsertWebhookAction,
+			supported: true,
+		},
+		{
+			name:      "upsert_subscription_action_allowed",
+			action:    model.UpsertSubscriptionAction,
+			supported: true,
+		},
 		{
 			name:      "invalid_action_not_allowed",
 			action:    "create_invalid_resource",
@@ -373,6 +426,9 @@ func TestKeptnAPIExecutor_ActionSupported(t *testing.T) {
 			tt.name, func(t *testing.T) {
 				supported := kae.ActionSupported(tt.action)
 				assert.Equal(t, tt.supported, supported, fmt.Sprintf("Action support for %s should be %t but is %t", tt.action, tt.supported, supported))
+				if tt.supported {
+					assert.Contains(t, kae.endpointMappings, tt.action)
+				}
 			})
 	}
 }
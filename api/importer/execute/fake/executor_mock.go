@@ -4,6 +4,7 @@
 package fake
 
 import (
+	"github.com/keptn/keptn/api/importer/model"
 	"io"
 	"net/http"
 	"sync"
@@ -202,6 +203,12 @@ func (mock *MockHTTPDoer) DoCalls() []struct {
 //
 // 		// make and configure a mocked execute.resourcePusher
 // 		mockedresourcePusher := &MockResourcePusher{
+// 			CheckAvailabilityFunc: func() error {
+// 				panic("mock out the CheckAvailability method")
+// 			},
+// 			PrepareRevertFunc: func(project string, stage string, service string, resourceURI string) (model.CompensatingAction, error) {
+// 				panic("mock out the PrepareRevert method")
+// 			},
 // 			PushToServiceFunc: func(project string, stage string, service string, content io.ReadCloser, resourceURI string) (any, error) {
 // 				panic("mock out the PushToService method")
 // 			},
@@ -215,6 +222,12 @@ func (mock *MockHTTPDoer) DoCalls() []struct {
 //
 // 	}
 type MockResourcePusher struct {
+	// CheckAvailabilityFunc mocks the CheckAvailability method.
+	CheckAvailabilityFunc func() error
+
+	// PrepareRevertFunc mocks the PrepareRevert method.
+	PrepareRevertFunc func(project string, stage string, service string, resourceURI string) (model.CompensatingAction, error)
+
 	// PushToServiceFunc mocks the PushToService method.
 	PushToServiceFunc func(project string, stage string, service string, content io.ReadCloser, resourceURI string) (any, error)
 
@@ -223,6 +236,20 @@ type MockResourcePusher struct {
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// CheckAvailability holds details about calls to the CheckAvailability method.
+		CheckAvailability []struct {
+		}
+		// PrepareRevert holds details about calls to the PrepareRevert method.
+		PrepareRevert []struct {
+			// Project is the project argument value.
+			Project string
+			// Stage is the stage argument value.
+			Stage string
+			// Service is the service argument value.
+			Service string
+			// ResourceURI is the resourceURI argument value.
+			ResourceURI string
+		}
 		// PushToService holds details about calls to the PushToService method.
 		PushToService []struct {
 			// Project is the project argument value.
@@ -248,8 +275,79 @@ type MockResourcePusher struct {
 			ResourceURI string
 		}
 	}
-	lockPushToService sync.RWMutex
-	lockPushToStage   sync.RWMutex
+	lockCheckAvailability sync.RWMutex
+	lockPrepareRevert     sync.RWMutex
+	lockPushToService     sync.RWMutex
+	lockPushToStage       sync.RWMutex
+}
+
+// CheckAvailability calls CheckAvailabilityFunc.
+func (mock *MockResourcePusher) CheckAvailability() error {
+	if mock.CheckAvailabilityFunc == nil {
+		panic("MockResourcePusher.CheckAvailabilityFunc: method is nil but resourcePusher.CheckAvailability was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockCheckAvailability.Lock()
+	mock.calls.CheckAvailability = append(mock.calls.CheckAvailability, callInfo)
+	mock.lockCheckAvailability.Unlock()
+	return mock.CheckAvailabilityFunc()
+}
+
+// CheckAvailabilityCalls gets all the calls that were made to CheckAvailability.
+// Check the length with:
+//     len(mockedresourcePusher.CheckAvailabilityCalls())
+func (mock *MockResourcePusher) CheckAvailabilityCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockCheckAvailability.RLock()
+	calls = mock.calls.CheckAvailability
+	mock.lockCheckAvailability.RUnlock()
+	return calls
+}
+
+// PrepareRevert calls PrepareRevertFunc.
+func (mock *MockResourcePusher) PrepareRevert(project string, stage string, service string, resourceURI string) (model.CompensatingAction, error) {
+	if mock.PrepareRevertFunc == nil {
+		panic("MockResourcePusher.PrepareRevertFunc: method is nil but resourcePusher.PrepareRevert was just called")
+	}
+	callInfo := struct {
+		Project     string
+		Stage       string
+		Service     string
+		ResourceURI string
+	}{
+		Project:     project,
+		Stage:       stage,
+		Service:     service,
+		ResourceURI: resourceURI,
+	}
+	mock.lockPrepareRevert.Lock()
+	mock.calls.PrepareRevert = append(mock.calls.PrepareRevert, callInfo)
+	mock.lockPrepareRevert.Unlock()
+	return mock.PrepareRevertFunc(project, stage, service, resourceURI)
+}
+
+// PrepareRevertCalls gets all the calls that were made to PrepareRevert.
+// Check the length with:
+//     len(mockedresourcePusher.PrepareRevertCalls())
+func (mock *MockResourcePusher) PrepareRevertCalls() []struct {
+	Project     string
+	Stage       string
+	Service     string
+	ResourceURI string
+} {
+	var calls []struct {
+		Project     string
+		Stage       string
+		Service     string
+		ResourceURI string
+	}
+	mock.lockPrepareRevert.RLock()
+	calls = mock.calls.PrepareRevert
+	mock.lockPrepareRevert.RUnlock()
+	return calls
 }
 
 // PushToService calls PushToServiceFunc.
@@ -4,6 +4,7 @@
 package fake
 
 import (
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
 	"sync"
 )
 
@@ -16,6 +17,9 @@ import (
 // 			GetIntegrationIDsByNameFunc: func(name string) ([]string, error) {
 // 				panic("mock out the GetIntegrationIDsByName method")
 // 			},
+// 			GetIntegrationsByNameFunc: func(name string) ([]apimodels.Integration, error) {
+// 				panic("mock out the GetIntegrationsByName method")
+// 			},
 // 		}
 //
 // 		// use mockedintegrationIdRetriever in code that requires execute.integrationIdRetriever
@@ -26,6 +30,9 @@ type MockIntegrationIdRetriever struct {
 	// GetIntegrationIDsByNameFunc mocks the GetIntegrationIDsByName method.
 	GetIntegrationIDsByNameFunc func(name string) ([]string, error)
 
+	// GetIntegrationsByNameFunc mocks the GetIntegrationsByName method.
+	GetIntegrationsByNameFunc func(name string) ([]apimodels.Integration, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// GetIntegrationIDsByName holds details about calls to the GetIntegrationIDsByName method.
@@ -33,8 +40,14 @@ type MockIntegrationIdRetriever struct {
 			// Name is the name argument value.
 			Name string
 		}
+		// GetIntegrationsByName holds details about calls to the GetIntegrationsByName method.
+		GetIntegrationsByName []struct {
+			// Name is the name argument value.
+			Name string
+		}
 	}
 	lockGetIntegrationIDsByName sync.RWMutex
+	lockGetIntegrationsByName   sync.RWMutex
 }
 
 // GetIntegrationIDsByName calls GetIntegrationIDsByNameFunc.
@@ -67,3 +80,34 @@ func (mock *MockIntegrationIdRetriever) GetIntegrationIDsByNameCalls() []struct
 	mock.lockGetIntegrationIDsByName.RUnlock()
 	return calls
 }
+
+// GetIntegrationsByName calls GetIntegrationsByNameFunc.
+func (mock *MockIntegrationIdRetriever) GetIntegrationsByName(name string) ([]apimodels.Integration, error) {
+	if mock.GetIntegrationsByNameFunc == nil {
+		panic("MockIntegrationIdRetriever.GetIntegrationsByNameFunc: method is nil but integrationIdRetriever.GetIntegrationsByName was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockGetIntegrationsByName.Lock()
+	mock.calls.GetIntegrationsByName = append(mock.calls.GetIntegrationsByName, callInfo)
+	mock.lockGetIntegrationsByName.Unlock()
+	return mock.GetIntegrationsByNameFunc(name)
+}
+
+// GetIntegrationsByNameCalls gets all the calls that were made to GetIntegrationsByName.
+// Check the length with:
+//     len(mockedintegrationIdRetriever.GetIntegrationsByNameCalls())
+func (mock *MockIntegrationIdRetriever) GetIntegrationsByNameCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockGetIntegrationsByName.RLock()
+	calls = mock.calls.GetIntegrationsByName
+	mock.lockGetIntegrationsByName.RUnlock()
+	return calls
+}
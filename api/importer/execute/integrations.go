@@ -15,6 +15,21 @@ type keptnIntegrationIdRetriever struct {
 }
 
 func (k keptnIntegrationIdRetriever) GetIntegrationIDsByName(name string) ([]string, error) {
+	integrations, err := k.GetIntegrationsByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	retVal := make([]string, len(integrations))
+	for i, integration := range integrations {
+		retVal[i] = integration.ID
+	}
+	return retVal, nil
+}
+
+// GetIntegrationsByName returns the registrations of the integrations with the given name, including their
+// subscriptions
+func (k keptnIntegrationIdRetriever) GetIntegrationsByName(name string) ([]apimodels.Integration, error) {
 	const getIntegrationByNameUrlPath = "%s/v1/uniform/registration?name=%s"
 	response, err := http.Get(
 		fmt.Sprintf(
@@ -45,12 +60,7 @@ func (k keptnIntegrationIdRetriever) GetIntegrationIDsByName(name string) ([]str
 	if err != nil {
 		return nil, fmt.Errorf("error unmarshalling get integrations response: %w", err)
 	}
-
-	retVal := make([]string, len(integrations))
-	for i, integration := range integrations {
-		retVal[i] = integration.ID
-	}
-	return retVal, nil
+	return integrations, nil
 }
 
 func NewKeptnIntegrationIdRetriever(provider KeptnControlPlaneEndpointProvider) *keptnIntegrationIdRetriever {
@@ -0,0 +1,49 @@
+package execute
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/keptn/keptn/api/importer/model"
+)
+
+// shipyardRequestFactory creates the request updating the shipyard of the import project with the shipyard
+// contained in the payload
+type shipyardRequestFactory struct{}
+
+func (shipyardRequestFactory) CreateRequest(
+	tCtx model.TaskContext, host string, body io.Reader,
+) (*http.Request, error) {
+	var projectBody io.Reader
+	if body != nil {
+		shipyard, err := io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("error reading shipyard: %w", err)
+		}
+		project := struct {
+			Name     string `json:"name"`
+			Shipyard string `json:"shipyard"`
+		}{
+			Name:     tCtx.Project,
+			Shipyard: base64.StdEncoding.EncodeToString(shipyard),
+		}
+		projectBytes, err := json.Marshal(project)
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling project: %w", err)
+		}
+		projectBody = bytes.NewReader(projectBytes)
+	}
+
+	request, err := http.NewRequest(http.MethodPut, host+"/v1/project", projectBody)
+	if err != nil {
+		return nil, fmt.Errorf("error creating HTTP request: %w", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	return request, nil
+}
@@ -0,0 +1,32 @@
+package execute
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn/keptn/api/importer/model"
+)
+
+func TestShipyardRequestFactory_CreateRequest(t *testing.T) {
+	request, err := shipyardRequestFactory{}.CreateRequest(
+		model.TaskContext{Project: "my-project"}, "http://shipyard-controller:8080",
+		strings.NewReader("apiVersion: spec.keptn.sh/0.2.3\nkind: Shipyard\n"),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.MethodPut, request.Method)
+	assert.Equal(t, "http://shipyard-controller:8080/v1/project", request.URL.String())
+	assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+	body, err := io.ReadAll(request.Body)
+	require.NoError(t, err)
+	assert.JSONEq(
+		t,
+		`{"name": "my-project", "shipyard": "YXBpVmVyc2lvbjogc3BlYy5rZXB0bi5zaC8wLjIuMwpraW5kOiBTaGlweWFyZAo="}`,
+		string(body),
+	)
+}
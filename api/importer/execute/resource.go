@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
 	logger "github.com/sirupsen/logrus"
+
+	"github.com/keptn/keptn/api/importer/model"
 )
 
 type KeptnResourcePusher struct {
@@ -89,16 +92,79 @@ func (p *KeptnResourcePusher) pushContent(url string, content io.ReadCloser, res
 	)
 }
 
+func (p *KeptnResourcePusher) resourcesURL(project string, stage string, service string) string {
+	dstUrl := p.endpointProvider.GetConfigurationServiceEndpoint() + "/v1/project/" + project + "/stage/" + stage
+	if service != "" {
+		dstUrl += "/service/" + service
+	}
+	return dstUrl + "/resource"
+}
+
+// PrepareRevert retrieves the current version of a resource before it is overwritten and returns the action restoring
+// it. If the resource does not exist yet, the returned action deletes it
+func (p *KeptnResourcePusher) PrepareRevert(
+	project string, stage string, service string, resourceURI string,
+) (model.CompensatingAction, error) {
+	resourcesURL := p.resourcesURL(project, stage, service)
+	resourceURL := resourcesURL + "/" + url.QueryEscape(resourceURI)
+
+	request, err := http.NewRequest(http.MethodGet, resourceURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating get resource request: %w", err)
+	}
+	response, err := p.doer.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error performing get resource request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return func() error {
+			request, err := http.NewRequest(http.MethodDelete, resourceURL, nil)
+			if err != nil {
+				return fmt.Errorf("error creating delete resource request: %w", err)
+			}
+			return doCompensationRequest(p.doer, request)
+		}, nil
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, fmt.Errorf(
+			"received unsuccessful http status <%d: %s> retrieving resource %s: %w", response.StatusCode,
+			response.Status, resourceURI, ErrTaskFailed,
+		)
+	}
+
+	resource := new(apimodels.Resource)
+	if err := json.NewDecoder(response.Body).Decode(resource); err != nil {
+		return nil, fmt.Errorf("error unmarshalling resource %s: %w", resourceURI, err)
+	}
+	previousContent, err := base64.StdEncoding.DecodeString(resource.ResourceContent)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding content of resource %s: %w", resourceURI, err)
+	}
+
+	return func() error {
+		_, err := p.pushContent(resourcesURL, io.NopCloser(bytes.NewReader(previousContent)), resourceURI)
+		if err != nil {
+			return fmt.Errorf("error restoring resource %s: %w", resourceURI, err)
+		}
+		return nil
+	}, nil
+}
+
+// CheckAvailability verifies that the configuration service is healthy
+func (p *KeptnResourcePusher) CheckAvailability() error {
+	return checkHealth(p.doer, p.endpointProvider.GetConfigurationServiceEndpoint())
+}
+
 func (p *KeptnResourcePusher) PushToService(
 	project string, stage string, service string, content io.ReadCloser, resourceURI string,
 ) (any, error) {
-	dstUrl := p.endpointProvider.GetConfigurationServiceEndpoint() + "/v1/project/" + project + "/stage/" + stage + "/service/" + service + "/resource"
-	return p.pushContent(dstUrl, content, resourceURI)
+	return p.pushContent(p.resourcesURL(project, stage, service), content, resourceURI)
 }
 
 func (p *KeptnResourcePusher) PushToStage(
 	project string, stage string, content io.ReadCloser, resourceURI string,
 ) (any, error) {
-	dstUrl := p.endpointProvider.GetConfigurationServiceEndpoint() + "/v1/project/" + project + "/stage/" + stage + "/resource"
-	return p.pushContent(dstUrl, content, resourceURI)
+	return p.pushContent(p.resourcesURL(project, stage, ""), content, resourceURI)
 }
@@ -0,0 +1,142 @@
+package execute
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+
+	"github.com/keptn/keptn/api/importer/model"
+)
+
+const defaultSequenceTriggerSource = "https://github.com/keptn/keptn/api/importer"
+
+// sequenceTriggerHandler sends the sequence triggered event contained in the payload to the control plane. The
+// response contains the keptn context and the id of the sent event, the compensating action aborts the sequence
+type sequenceTriggerHandler struct {
+	endpoint string
+}
+
+func (s *sequenceTriggerHandler) ExecuteAPI(
+	doer httpdoer, ate model.APITaskExecution,
+) (any, model.CompensatingAction, error) {
+	if ate.Payload == nil {
+		return nil, nil, fmt.Errorf("no event found in payload: %w", ErrTaskFailed)
+	}
+	defer ate.Payload.Close()
+
+	event := apimodels.KeptnContextExtendedCE{}
+	if err := json.NewDecoder(ate.Payload).Decode(&event); err != nil {
+		return nil, nil, fmt.Errorf("error unmarshalling event payload: %w", err)
+	}
+	project, err := completeSequenceTriggeredEvent(&event, ate.Context.Project)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error marshalling event: %w", err)
+	}
+	request, err := http.NewRequest(http.MethodPost, s.endpoint+"/v1/event", bytes.NewReader(eventBytes))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := doer.Do(request)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing request: %w", err)
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, nil, fmt.Errorf(
+			"received unsuccessful http status <%d: %s>: %w", response.StatusCode,
+			response.Status, ErrTaskFailed,
+		)
+	}
+
+	result := map[string]any{
+		"keptnContext": event.Shkeptncontext,
+		"id":           event.ID,
+	}
+	return result, s.newAbortAction(doer, project, event.Shkeptncontext), nil
+}
+
+func (s *sequenceTriggerHandler) newAbortAction(doer httpdoer, project, keptnContext string) model.CompensatingAction {
+	return func() error {
+		command, err := json.Marshal(apimodels.SequenceControlCommand{State: apimodels.AbortSequence})
+		if err != nil {
+			return fmt.Errorf("error marshalling sequence control command: %w", err)
+		}
+		request, err := http.NewRequest(
+			http.MethodPost,
+			fmt.Sprintf(
+				"%s/v1/sequence/%s/%s/control", s.endpoint, url.PathEscape(project), url.PathEscape(keptnContext),
+			),
+			bytes.NewReader(command),
+		)
+		if err != nil {
+			return fmt.Errorf("error creating sequence abort request: %w", err)
+		}
+		request.Header.Set("Content-Type", "application/json")
+		return doCompensationRequest(doer, request)
+	}
+}
+
+// CheckAvailability verifies that the control plane receiving the event is healthy
+func (s *sequenceTriggerHandler) CheckAvailability(doer httpdoer, _ model.APITaskExecution) error {
+	return checkHealth(doer, s.endpoint)
+}
+
+// completeSequenceTriggeredEvent verifies that event is a sequence triggered event and fills in the properties that
+// are not set in the package, using the import project if the event data has no project. The project of the event
+// is returned
+func completeSequenceTriggeredEvent(event *apimodels.KeptnContextExtendedCE, project string) (string, error) {
+	if event.Type == nil || !keptnv2.IsSequenceEventType(*event.Type) || !keptnv2.IsTriggeredEventType(*event.Type) {
+		return "", fmt.Errorf("event payload is not a sequence triggered event: %w", ErrTaskFailed)
+	}
+
+	data := map[string]any{}
+	if event.Data != nil {
+		if err := event.DataAs(&data); err != nil {
+			return "", fmt.Errorf("error reading event data: %w", err)
+		}
+	}
+	if eventProject, ok := data["project"].(string); ok && eventProject != "" {
+		project = eventProject
+	} else {
+		data["project"] = project
+	}
+	event.Data = data
+
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	if event.Shkeptncontext == "" {
+		event.Shkeptncontext = uuid.NewString()
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	if event.Source == nil || *event.Source == "" {
+		source := defaultSequenceTriggerSource
+		event.Source = &source
+	}
+	if event.Specversion == "" {
+		event.Specversion = "1.0"
+	}
+	if event.Contenttype == "" {
+		event.Contenttype = "application/json"
+	}
+	return project, nil
+}
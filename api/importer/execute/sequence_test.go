@@ -0,0 +1,120 @@
+package execute
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn/keptn/api/importer/execute/fake"
+	"github.com/keptn/keptn/api/importer/model"
+)
+
+func TestSequenceTriggerHandler_ExecuteAPI(t *testing.T) {
+	var sentEvent apimodels.KeptnContextExtendedCE
+	var requests []string
+	doer := &fake.MockHTTPDoer{
+		DoFunc: func(r *http.Request) (*http.Response, error) {
+			requests = append(requests, r.Method+" "+r.URL.String())
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			if strings.HasSuffix(r.URL.Path, "/v1/event") {
+				require.NoError(t, json.Unmarshal(body, &sentEvent))
+			} else {
+				assert.JSONEq(t, `{"state": "abort", "stage": ""}`, string(body))
+			}
+			return &http.Response{
+				Status:     http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	}
+	sut := &sequenceTriggerHandler{endpoint: "http://shipyard-controller:8080"}
+
+	response, compensation, err := sut.ExecuteAPI(
+		doer, model.APITaskExecution{
+			Payload: io.NopCloser(
+				strings.NewReader(
+					`{"type": "sh.keptn.event.dev.delivery.triggered", "data": {"stage": "dev", "service": "carts"}}`,
+				),
+			),
+			Context: model.TaskContext{Project: "my-project", Task: &model.ManifestTask{ID: "trigger_delivery"}},
+		},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, compensation)
+
+	assert.NoError(t, sentEvent.Validate())
+	assert.NotEmpty(t, sentEvent.Shkeptncontext)
+	assert.Equal(t, "1.0", sentEvent.Specversion)
+	assert.Equal(
+		t, map[string]any{"project": "my-project", "stage": "dev", "service": "carts"}, sentEvent.Data,
+	)
+	assert.Equal(t, map[string]any{"keptnContext": sentEvent.Shkeptncontext, "id": sentEvent.ID}, response)
+
+	require.NoError(t, compensation())
+	assert.Equal(
+		t, []string{
+			"POST http://shipyard-controller:8080/v1/event",
+			"POST http://shipyard-controller:8080/v1/sequence/my-project/" + sentEvent.Shkeptncontext + "/control",
+		}, requests,
+	)
+}
+
+func TestSequenceTriggerHandler_ExecuteAPIErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		payload    string
+		statusCode int
+	}{
+		{
+			name:    "Task event is rejected",
+			payload: `{"type": "sh.keptn.event.deployment.triggered", "data": {}}`,
+		},
+		{
+			name:    "Sequence finished event is rejected",
+			payload: `{"type": "sh.keptn.event.dev.delivery.finished", "data": {}}`,
+		},
+		{
+			name:    "Missing type is rejected",
+			payload: `{"data": {}}`,
+		},
+		{
+			name:       "Unsuccessful status",
+			payload:    `{"type": "sh.keptn.event.dev.delivery.triggered", "data": {}}`,
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				doer := &fake.MockHTTPDoer{
+					DoFunc: func(r *http.Request) (*http.Response, error) {
+						return newResponse(tt.statusCode, `{"message": "invalid event"}`), nil
+					},
+				}
+				sut := &sequenceTriggerHandler{endpoint: "http://shipyard-controller:8080"}
+
+				response, compensation, err := sut.ExecuteAPI(
+					doer, model.APITaskExecution{
+						Payload: io.NopCloser(strings.NewReader(tt.payload)),
+						Context: model.TaskContext{Project: "my-project", Task: &model.ManifestTask{ID: "trigger"}},
+					},
+				)
+				assert.ErrorIs(t, err, ErrTaskFailed)
+				assert.Nil(t, response)
+				assert.Nil(t, compensation)
+				if tt.statusCode == 0 {
+					assert.Empty(t, doer.DoCalls())
+				}
+			},
+		)
+	}
+}
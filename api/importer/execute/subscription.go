@@ -0,0 +1,164 @@
+package execute
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+
+	"github.com/keptn/keptn/api/importer/model"
+)
+
+// subscriptionRequestFactory creates the requests registering a new subscription or updating an existing one for an
+// integration. The integration is taken from the task context unless integrationName is set
+type subscriptionRequestFactory struct {
+	idRetriever     integrationIdRetriever
+	integrationName string
+	update          bool
+}
+
+func (s subscriptionRequestFactory) CreateRequest(
+	tCtx model.TaskContext, host string, body io.Reader,
+) (*http.Request, error) {
+	integrationName, err := s.getIntegrationName(tCtx)
+	if err != nil {
+		return nil, err
+	}
+	integrationID, err := getIntegrationID(s.idRetriever, integrationName)
+	if err != nil {
+		return nil, err
+	}
+
+	method := http.MethodPost
+	requestURL := fmt.Sprintf("%s/v1/uniform/registration/%s/subscription", host, url.PathEscape(integrationID))
+	if s.update {
+		subscriptionID := tCtx.Context[model.SubscriptionIDContextKey]
+		if subscriptionID == "" {
+			return nil, fmt.Errorf("no subscription id found in context key %s", model.SubscriptionIDContextKey)
+		}
+		method = http.MethodPut
+		requestURL += "/" + url.PathEscape(subscriptionID)
+	}
+
+	request, err := http.NewRequest(method, requestURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("error creating HTTP request: %w", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	return request, nil
+}
+
+func (s subscriptionRequestFactory) getIntegrationName(tCtx model.TaskContext) (string, error) {
+	if s.integrationName != "" {
+		return s.integrationName, nil
+	}
+	integrationName := tCtx.Context[model.IntegrationContextKey]
+	if integrationName == "" {
+		return "", fmt.Errorf("no integration name found in context key %s", model.IntegrationContextKey)
+	}
+	return integrationName, nil
+}
+
+func getIntegrationID(retriever integrationIdRetriever, integrationName string) (string, error) {
+	integrationIDs, err := retriever.GetIntegrationIDsByName(integrationName)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving integration id for name %s: %w", integrationName, err)
+	}
+	if len(integrationIDs) == 0 {
+		return "", fmt.Errorf("no integration found for name %s", integrationName)
+	}
+	return integrationIDs[0], nil
+}
+
+// upsertSubscriptionHandler creates a subscription only if the integration does not already have a subscription to
+// the same event with the same filter. Otherwise, the id of the existing subscription is returned and nothing is changed
+type upsertSubscriptionHandler struct {
+	subscriptionRequestFactory
+	create *defaultEndpointHandler
+}
+
+func newUpsertSubscriptionHandler(
+	retriever integrationIdRetriever, integrationName string, endpoint string,
+) *upsertSubscriptionHandler {
+	factory := subscriptionRequestFactory{idRetriever: retriever, integrationName: integrationName}
+	return &upsertSubscriptionHandler{
+		subscriptionRequestFactory: factory,
+		create: &defaultEndpointHandler{
+			requestFactory: factory,
+			endpoint:       endpoint,
+			compensation:   deleteSubscriptionRequestFactory{},
+		},
+	}
+}
+
+func (u *upsertSubscriptionHandler) ExecuteAPI(
+	doer httpdoer, ate model.APITaskExecution,
+) (any, model.CompensatingAction, error) {
+	if ate.Payload == nil {
+		return nil, nil, fmt.Errorf("no subscription found in payload: %w", ErrTaskFailed)
+	}
+	defer ate.Payload.Close()
+	payload, err := io.ReadAll(ate.Payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading payload: %w", err)
+	}
+
+	subscription := apimodels.EventSubscription{}
+	if err := json.Unmarshal(payload, &subscription); err != nil {
+		return nil, nil, fmt.Errorf("error unmarshalling subscription payload: %w", err)
+	}
+
+	integrationName, err := u.getIntegrationName(ate.Context)
+	if err != nil {
+		return nil, nil, err
+	}
+	integrations, err := u.idRetriever.GetIntegrationsByName(integrationName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving integration for name %s: %w", integrationName, err)
+	}
+	if len(integrations) == 0 {
+		return nil, nil, fmt.Errorf("no integration found for name %s", integrationName)
+	}
+
+	for _, existing := range integrations[0].Subscriptions {
+		if existing.Event == subscription.Event && sameFilter(existing.Filter, subscription.Filter) {
+			return map[string]any{"id": existing.ID}, nil, nil
+		}
+	}
+
+	ate.Payload = io.NopCloser(bytes.NewReader(payload))
+	return u.create.ExecuteAPI(doer, ate)
+}
+
+func (u *upsertSubscriptionHandler) CheckAvailability(doer httpdoer, ate model.APITaskExecution) error {
+	return u.create.CheckAvailability(doer, ate)
+}
+
+func sameFilter(a, b apimodels.EventSubscriptionFilter) bool {
+	return sameElements(a.Projects, b.Projects) &&
+		sameElements(a.Stages, b.Stages) &&
+		sameElements(a.Services, b.Services)
+}
+
+func sameElements(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]string{}, a...)
+	sortedB := append([]string{}, b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,188 @@
+package execute
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn/keptn/api/importer/execute/fake"
+	"github.com/keptn/keptn/api/importer/model"
+)
+
+func TestSubscriptionRequestFactory_CreateRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		factory    subscriptionRequestFactory
+		context    map[string]string
+		wantMethod string
+		wantURL    string
+		wantErr    string
+	}{
+		{
+			name:       "Create subscription for integration in context",
+			factory:    subscriptionRequestFactory{},
+			context:    map[string]string{model.IntegrationContextKey: "job-executor-service"},
+			wantMethod: http.MethodPost,
+			wantURL:    "http://shipyard-controller:8080/v1/uniform/registration/job-executor-service-id/subscription",
+		},
+		{
+			name:       "Create subscription for fixed integration",
+			factory:    subscriptionRequestFactory{integrationName: "webhook-service"},
+			wantMethod: http.MethodPost,
+			wantURL:    "http://shipyard-controller:8080/v1/uniform/registration/webhook-service-id/subscription",
+		},
+		{
+			name:    "Update subscription",
+			factory: subscriptionRequestFactory{update: true},
+			context: map[string]string{
+				model.IntegrationContextKey:    "job-executor-service",
+				model.SubscriptionIDContextKey: "subscription-id",
+			},
+			wantMethod: http.MethodPut,
+			wantURL:    "http://shipyard-controller:8080/v1/uniform/registration/job-executor-service-id/subscription/subscription-id",
+		},
+		{
+			name:    "Error if integration is missing",
+			factory: subscriptionRequestFactory{},
+			wantErr: "no integration name found in context key integration",
+		},
+		{
+			name:    "Error if subscription id is missing",
+			factory: subscriptionRequestFactory{update: true},
+			context: map[string]string{model.IntegrationContextKey: "job-executor-service"},
+			wantErr: "no subscription id found in context key subscriptionID",
+		},
+		{
+			name:    "Error if integration does not exist",
+			factory: subscriptionRequestFactory{},
+			context: map[string]string{model.IntegrationContextKey: "unknown-service"},
+			wantErr: "no integration found for name unknown-service",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				tt.factory.idRetriever = &fake.MockIntegrationIdRetriever{
+					GetIntegrationIDsByNameFunc: func(name string) ([]string, error) {
+						if name == "unknown-service" {
+							return []string{}, nil
+						}
+						return []string{name + "-id"}, nil
+					},
+				}
+				request, err := tt.factory.CreateRequest(
+					model.TaskContext{Project: "my-project", Context: tt.context}, "http://shipyard-controller:8080",
+					strings.NewReader("{}"),
+				)
+				if tt.wantErr != "" {
+					assert.ErrorContains(t, err, tt.wantErr)
+					assert.Nil(t, request)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantMethod, request.Method)
+				assert.Equal(t, tt.wantURL, request.URL.String())
+				assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+			},
+		)
+	}
+}
+
+func TestUpsertSubscriptionHandler_ExecuteAPI(t *testing.T) {
+	existing := apimodels.EventSubscription{
+		ID:    "existing-id",
+		Event: "sh.keptn.event.deployment.triggered",
+		Filter: apimodels.EventSubscriptionFilter{
+			Projects: []string{"my-project"},
+			Stages:   []string{"dev", "prod"},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		payload          string
+		wantResponse     any
+		wantCreated      bool
+		wantCompensation bool
+	}{
+		{
+			name:         "Existing subscription is returned",
+			payload:      `{"event": "sh.keptn.event.deployment.triggered", "filter": {"projects": ["my-project"], "stages": ["prod", "dev"]}}`,
+			wantResponse: map[string]any{"id": "existing-id"},
+		},
+		{
+			name:             "Subscription with different filter is created",
+			payload:          `{"event": "sh.keptn.event.deployment.triggered", "filter": {"projects": ["my-project"], "stages": ["dev"]}}`,
+			wantResponse:     map[string]any{"id": "new-id"},
+			wantCreated:      true,
+			wantCompensation: true,
+		},
+		{
+			name:             "Subscription to different event is created",
+			payload:          `{"event": "sh.keptn.event.test.triggered", "filter": {"projects": ["my-project"], "stages": ["dev", "prod"]}}`,
+			wantResponse:     map[string]any{"id": "new-id"},
+			wantCreated:      true,
+			wantCompensation: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				retriever := &fake.MockIntegrationIdRetriever{
+					GetIntegrationIDsByNameFunc: func(name string) ([]string, error) {
+						return []string{"integration-id"}, nil
+					},
+					GetIntegrationsByNameFunc: func(name string) ([]apimodels.Integration, error) {
+						return []apimodels.Integration{
+							{ID: "integration-id", Name: name, Subscriptions: []apimodels.EventSubscription{existing}},
+						}, nil
+					},
+				}
+				var createdBody string
+				doer := &fake.MockHTTPDoer{
+					DoFunc: func(r *http.Request) (*http.Response, error) {
+						assert.Equal(t, http.MethodPost, r.Method)
+						assert.Equal(
+							t, "http://shipyard-controller:8080/v1/uniform/registration/integration-id/subscription",
+							r.URL.String(),
+						)
+						body, _ := io.ReadAll(r.Body)
+						createdBody = string(body)
+						return newResponse(http.StatusCreated, `{"id": "new-id"}`), nil
+					},
+				}
+				sut := newUpsertSubscriptionHandler(retriever, "", "http://shipyard-controller:8080")
+
+				response, compensation, err := sut.ExecuteAPI(
+					doer, model.APITaskExecution{
+						Payload: io.NopCloser(strings.NewReader(tt.payload)),
+						Context: model.TaskContext{
+							Project: "my-project",
+							Task:    &model.ManifestTask{ID: "subscription"},
+							Context: map[string]string{model.IntegrationContextKey: "job-executor-service"},
+						},
+					},
+				)
+				require.NoError(t, err)
+				if tt.wantCreated {
+					require.Len(t, doer.DoCalls(), 1)
+					assert.Equal(t, tt.payload, createdBody)
+					assert.Equal(t, tt.wantResponse, *(response.(*any)))
+				} else {
+					assert.Empty(t, doer.DoCalls())
+					assert.Equal(t, tt.wantResponse, response)
+				}
+				assert.Equal(t, tt.wantCompensation, compensation != nil)
+				require.Len(t, retriever.GetIntegrationsByNameCalls(), 1)
+				assert.Equal(t, "job-executor-service", retriever.GetIntegrationsByNameCalls()[0].Name)
+			},
+		)
+	}
+}
@@ -0,0 +1,53 @@
+package execute
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/keptn/keptn/api/importer/model"
+)
+
+// upsertEndpointHandler executes the create call of a task and, if the object already exists, the update call
+// instead. Without an update call an existing object is left as is. Only the creation of a new object can be reverted
+type upsertEndpointHandler struct {
+	create *defaultEndpointHandler
+	update *defaultEndpointHandler
+}
+
+func (u *upsertEndpointHandler) ExecuteAPI(
+	doer httpdoer, ate model.APITaskExecution,
+) (any, model.CompensatingAction, error) {
+	var payload []byte
+	if ate.Payload != nil {
+		defer ate.Payload.Close()
+		var err error
+		payload, err = io.ReadAll(ate.Payload)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading payload: %w", err)
+		}
+	}
+
+	response, compensation, err := u.create.ExecuteAPI(doer, withPayload(ate, payload))
+	if !errors.Is(err, ErrTaskConflict) {
+		return response, compensation, err
+	}
+
+	if u.update == nil {
+		return map[string]any{}, nil, nil
+	}
+	response, _, err = u.update.ExecuteAPI(doer, withPayload(ate, payload))
+	return response, nil, err
+}
+
+func (u *upsertEndpointHandler) CheckAvailability(doer httpdoer, ate model.APITaskExecution) error {
+	return u.create.CheckAvailability(doer, ate)
+}
+
+func withPayload(ate model.APITaskExecution, payload []byte) model.APITaskExecution {
+	if payload != nil {
+		ate.Payload = io.NopCloser(bytes.NewReader(payload))
+	}
+	return ate
+}
@@ -0,0 +1,99 @@
+package execute
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn/keptn/api/importer/execute/fake"
+	"github.com/keptn/keptn/api/importer/model"
+)
+
+func TestUpsertEndpointHandler_ExecuteAPI(t *testing.T) {
+	tests := []struct {
+		name             string
+		createStatus     int
+		withUpdate       bool
+		wantRequests     []string
+		wantCompensation bool
+		wantErr          bool
+	}{
+		{
+			name:             "Object is created",
+			createStatus:     http.StatusOK,
+			withUpdate:       true,
+			wantRequests:     []string{"POST /v1/secret"},
+			wantCompensation: true,
+		},
+		{
+			name:         "Existing object is updated",
+			createStatus: http.StatusConflict,
+			withUpdate:   true,
+			wantRequests: []string{"POST /v1/secret", "PUT /v1/secret"},
+		},
+		{
+			name:         "Existing object is kept without update",
+			createStatus: http.StatusConflict,
+			wantRequests: []string{"POST /v1/secret"},
+		},
+		{
+			name:         "Other errors are returned",
+			createStatus: http.StatusBadRequest,
+			withUpdate:   true,
+			wantRequests: []string{"POST /v1/secret"},
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				var requests []string
+				doer := &fake.MockHTTPDoer{
+					DoFunc: func(r *http.Request) (*http.Response, error) {
+						requests = append(requests, r.Method+" "+r.URL.Path)
+						body, err := io.ReadAll(r.Body)
+						require.NoError(t, err)
+						assert.JSONEq(t, `{"name": "slack", "scope": "keptn-webhook-service"}`, string(body))
+						if r.Method == http.MethodPost {
+							return newResponse(tt.createStatus, `{}`), nil
+						}
+						return newResponse(http.StatusOK, `{}`), nil
+					},
+				}
+				sut := &upsertEndpointHandler{
+					create: &defaultEndpointHandler{
+						requestFactory: &projectRenderRequestFactory{httpMethod: http.MethodPost, path: "/v1/secret"},
+						endpoint:       "http://secret-service:8080",
+						compensation:   deleteSecretRequestFactory{},
+					},
+				}
+				if tt.withUpdate {
+					sut.update = &defaultEndpointHandler{
+						requestFactory: &projectRenderRequestFactory{httpMethod: http.MethodPut, path: "/v1/secret"},
+						endpoint:       "http://secret-service:8080",
+					}
+				}
+
+				_, compensation, err := sut.ExecuteAPI(
+					doer, model.APITaskExecution{
+						Payload: io.NopCloser(strings.NewReader(`{"name": "slack", "scope": "keptn-webhook-service"}`)),
+						Context: model.TaskContext{Project: "my-project", Task: &model.ManifestTask{ID: "secret"}},
+					},
+				)
+				if tt.wantErr {
+					assert.ErrorIs(t, err, ErrTaskFailed)
+					assert.NotErrorIs(t, err, ErrTaskConflict)
+				} else {
+					assert.NoError(t, err)
+				}
+				assert.Equal(t, tt.wantCompensation, compensation != nil)
+				assert.Equal(t, tt.wantRequests, requests)
+			},
+		)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"io"
 	"net/http"
 
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+
 	"github.com/keptn/keptn/api/importer/model"
 )
 
@@ -12,8 +14,11 @@ import (
 
 type integrationIdRetriever interface {
 	GetIntegrationIDsByName(name string) ([]string, error)
+	GetIntegrationsByName(name string) ([]apimodels.Integration, error)
 }
 
+const webhookIntegrationName = "webhook-service"
+
 func NewWebhookSubscriptionHandler(retriever integrationIdRetriever) *webhookSubscriptionHandler {
 	return &webhookSubscriptionHandler{
 		idRetriever: retriever,
@@ -27,20 +32,16 @@ type webhookSubscriptionHandler struct {
 func (w webhookSubscriptionHandler) CreateRequest(
 	_ model.TaskContext, host string, body io.Reader,
 ) (*http.Request, error) {
-	const webhookIntegrationName = "webhook-service"
-	subscriptionIds, err := w.idRetriever.GetIntegrationIDsByName(webhookIntegrationName)
+	integrationID, err := getIntegrationID(w.idRetriever, webhookIntegrationName)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving integration id for name %s: %w", webhookIntegrationName, err)
-	}
-	if len(subscriptionIds) == 0 {
-		return nil, fmt.Errorf("no integration found for name %s", webhookIntegrationName)
+		return nil, err
 	}
 
 	request, err := http.NewRequest(
 		http.MethodPost, fmt.Sprintf(
 			"%s/v1/uniform/registration/%s/subscription",
 			host,
-			subscriptionIds[0],
+			integrationID,
 		), body,
 	)
 	if err != nil {
@@ -197,10 +197,19 @@ func (mock *ManifestParserMock) ParseCalls() []struct {
 //
 // 		// make and configure a mocked importer.TaskExecutor
 // 		mockedTaskExecutor := &TaskExecutorMock{
-// 			ExecuteAPIFunc: func(ate model.APITaskExecution) (any, error) {
+// 			ActionSupportedFunc: func(actionName string) bool {
+// 				panic("mock out the ActionSupported method")
+// 			},
+// 			CheckAPIFunc: func(ate model.APITaskExecution) error {
+// 				panic("mock out the CheckAPI method")
+// 			},
+// 			CheckResourcePushFunc: func(rp model.ResourcePush) error {
+// 				panic("mock out the CheckResourcePush method")
+// 			},
+// 			ExecuteAPIFunc: func(ate model.APITaskExecution) (any, model.CompensatingAction, error) {
 // 				panic("mock out the ExecuteAPI method")
 // 			},
-// 			PushResourceFunc: func(rp model.ResourcePush) (any, error) {
+// 			PushResourceFunc: func(rp model.ResourcePush) (any, model.CompensatingAction, error) {
 // 				panic("mock out the PushResource method")
 // 			},
 // 		}
@@ -210,17 +219,38 @@ func (mock *ManifestParserMock) ParseCalls() []struct {
 //
 // 	}
 type TaskExecutorMock struct {
+	// ActionSupportedFunc mocks the ActionSupported method.
+	ActionSupportedFunc func(actionName string) bool
+
+	// CheckAPIFunc mocks the CheckAPI method.
+	CheckAPIFunc func(ate model.APITaskExecution) error
+
+	// CheckResourcePushFunc mocks the CheckResourcePush method.
+	CheckResourcePushFunc func(rp model.ResourcePush) error
+
 	// ExecuteAPIFunc mocks the ExecuteAPI method.
-	ExecuteAPIFunc func(ate model.APITaskExecution) (any, error)
+	ExecuteAPIFunc func(ate model.APITaskExecution) (any, model.CompensatingAction, error)
 
 	// PushResourceFunc mocks the PushResource method.
-	PushResourceFunc func(rp model.ResourcePush) (any, error)
-
-	// ActionSupportedFunc mocks the ActionSupported method.
-	ActionSupportedFunc func(actionName string) bool
+	PushResourceFunc func(rp model.ResourcePush) (any, model.CompensatingAction, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// ActionSupported holds details about calls to the ActionSupported method.
+		ActionSupported []struct {
+			// ActionName is the actionName argument value.
+			ActionName string
+		}
+		// CheckAPI holds details about calls to the CheckAPI method.
+		CheckAPI []struct {
+			// Ate is the ate argument value.
+			Ate model.APITaskExecution
+		}
+		// CheckResourcePush holds details about calls to the CheckResourcePush method.
+		CheckResourcePush []struct {
+			// Rp is the rp argument value.
+			Rp model.ResourcePush
+		}
 		// ExecuteAPI holds details about calls to the ExecuteAPI method.
 		ExecuteAPI []struct {
 			// Ate is the ate argument value.
@@ -231,18 +261,109 @@ type TaskExecutorMock struct {
 			// Rp is the rp argument value.
 			Rp model.ResourcePush
 		}
-		// ActionSupported holds details about calls to the ActionSupported method.
-		ActionSupported []struct {
-			// ActionName is the action argument value.
-			ActionName string
-		}
 	}
-	lockExecuteAPI   sync.RWMutex
-	lockPushResource sync.RWMutex
+	lockActionSupported   sync.RWMutex
+	lockCheckAPI          sync.RWMutex
+	lockCheckResourcePush sync.RWMutex
+	lockExecuteAPI        sync.RWMutex
+	lockPushResource      sync.RWMutex
+}
+
+// ActionSupported calls ActionSupportedFunc.
+func (mock *TaskExecutorMock) ActionSupported(actionName string) bool {
+	if mock.ActionSupportedFunc == nil {
+		panic("TaskExecutorMock.ActionSupportedFunc: method is nil but TaskExecutor.ActionSupported was just called")
+	}
+	callInfo := struct {
+		ActionName string
+	}{
+		ActionName: actionName,
+	}
+	mock.lockActionSupported.Lock()
+	mock.calls.ActionSupported = append(mock.calls.ActionSupported, callInfo)
+	mock.lockActionSupported.Unlock()
+	return mock.ActionSupportedFunc(actionName)
+}
+
+// ActionSupportedCalls gets all the calls that were made to ActionSupported.
+// Check the length with:
+//     len(mockedTaskExecutor.ActionSupportedCalls())
+func (mock *TaskExecutorMock) ActionSupportedCalls() []struct {
+	ActionName string
+} {
+	var calls []struct {
+		ActionName string
+	}
+	mock.lockActionSupported.RLock()
+	calls = mock.calls.ActionSupported
+	mock.lockActionSupported.RUnlock()
+	return calls
+}
+
+// CheckAPI calls CheckAPIFunc.
+func (mock *TaskExecutorMock) CheckAPI(ate model.APITaskExecution) error {
+	if mock.CheckAPIFunc == nil {
+		panic("TaskExecutorMock.CheckAPIFunc: method is nil but TaskExecutor.CheckAPI was just called")
+	}
+	callInfo := struct {
+		Ate model.APITaskExecution
+	}{
+		Ate: ate,
+	}
+	mock.lockCheckAPI.Lock()
+	mock.calls.CheckAPI = append(mock.calls.CheckAPI, callInfo)
+	mock.lockCheckAPI.Unlock()
+	return mock.CheckAPIFunc(ate)
+}
+
+// CheckAPICalls gets all the calls that were made to CheckAPI.
+// Check the length with:
+//     len(mockedTaskExecutor.CheckAPICalls())
+func (mock *TaskExecutorMock) CheckAPICalls() []struct {
+	Ate model.APITaskExecution
+} {
+	var calls []struct {
+		Ate model.APITaskExecution
+	}
+	mock.lockCheckAPI.RLock()
+	calls = mock.calls.CheckAPI
+	mock.lockCheckAPI.RUnlock()
+	return calls
+}
+
+// CheckResourcePush calls CheckResourcePushFunc.
+func (mock *TaskExecutorMock) CheckResourcePush(rp model.ResourcePush) error {
+	if mock.CheckResourcePushFunc == nil {
+		panic("TaskExecutorMock.CheckResourcePushFunc: method is nil but TaskExecutor.CheckResourcePush was just called")
+	}
+	callInfo := struct {
+		Rp model.ResourcePush
+	}{
+		Rp: rp,
+	}
+	mock.lockCheckResourcePush.Lock()
+	mock.calls.CheckResourcePush = append(mock.calls.CheckResourcePush, callInfo)
+	mock.lockCheckResourcePush.Unlock()
+	return mock.CheckResourcePushFunc(rp)
+}
+
+// CheckResourcePushCalls gets all the calls that were made to CheckResourcePush.
+// Check the length with:
+//     len(mockedTaskExecutor.CheckResourcePushCalls())
+func (mock *TaskExecutorMock) CheckResourcePushCalls() []struct {
+	Rp model.ResourcePush
+} {
+	var calls []struct {
+		Rp model.ResourcePush
+	}
+	mock.lockCheckResourcePush.RLock()
+	calls = mock.calls.CheckResourcePush
+	mock.lockCheckResourcePush.RUnlock()
+	return calls
 }
 
 // ExecuteAPI calls ExecuteAPIFunc.
-func (mock *TaskExecutorMock) ExecuteAPI(ate model.APITaskExecution) (any, error) {
+func (mock *TaskExecutorMock) ExecuteAPI(ate model.APITaskExecution) (any, model.CompensatingAction, error) {
 	if mock.ExecuteAPIFunc == nil {
 		panic("TaskExecutorMock.ExecuteAPIFunc: method is nil but TaskExecutor.ExecuteAPI was just called")
 	}
@@ -273,7 +394,7 @@ func (mock *TaskExecutorMock) ExecuteAPICalls() []struct {
 }
 
 // PushResource calls PushResourceFunc.
-func (mock *TaskExecutorMock) PushResource(rp model.ResourcePush) (any, error) {
+func (mock *TaskExecutorMock) PushResource(rp model.ResourcePush) (any, model.CompensatingAction, error) {
 	if mock.PushResourceFunc == nil {
 		panic("TaskExecutorMock.PushResourceFunc: method is nil but TaskExecutor.PushResource was just called")
 	}
@@ -302,34 +423,3 @@ func (mock *TaskExecutorMock) PushResourceCalls() []struct {
 	mock.lockPushResource.RUnlock()
 	return calls
 }
-
-// PushResource calls PushResourceFunc.
-func (mock *TaskExecutorMock) ActionSupported(actionName string) bool {
-	if mock.ActionSupportedFunc == nil {
-		panic("TaskExecutorMock.ActionSupportedFunc: method is nil but TaskExecutor.ActionSupported was just called")
-	}
-	callInfo := struct {
-		ActionName string
-	}{
-		ActionName: actionName,
-	}
-	mock.lockPushResource.Lock()
-	mock.calls.ActionSupported = append(mock.calls.ActionSupported, callInfo)
-	mock.lockPushResource.Unlock()
-	return mock.ActionSupportedFunc(actionName)
-}
-
-// PushResourceCalls gets all the calls that were made to PushResource.
-// Check the length with:
-//     len(mockedTaskExecutor.PushResourceCalls())
-func (mock *TaskExecutorMock) ActionSupportedCalls() []struct {
-	ActionName string
-} {
-	var calls []struct {
-		ActionName string
-	}
-	mock.lockPushResource.RLock()
-	calls = mock.calls.ActionSupported
-	mock.lockPushResource.RUnlock()
-	return calls
-}
@@ -27,22 +27,56 @@ type ResourcePush struct {
 type TaskExecution struct {
 	TaskContext
 	Response any
+	Outcome  string
+	Message  string
 }
 
+// CompensatingAction reverts the changes made by a successfully executed task
+type CompensatingAction func() error
+
+const (
+	TaskOutcomeSuccess        = "success"
+	TaskOutcomeFailure        = "failure"
+	TaskOutcomeRolledBack     = "rolledBack"
+	TaskOutcomeRollbackFailed = "rollbackFailed"
+)
+
 const projectInputContextKey = "project"
 
 const (
-	CreateServiceAction = "keptn-api-v1-create-service"
-	CreateSecretAction  = "keptn-api-v1-uniform-create-secret"
-	CreateWebhookAction = "keptn-api-v1-uniform-create-webhook-subscription"
+	CreateServiceAction      = "keptn-api-v1-create-service"
+	CreateSecretAction       = "keptn-api-v1-uniform-create-secret"
+	CreateWebhookAction      = "keptn-api-v1-uniform-create-webhook-subscription"
+	CreateSubscriptionAction = "keptn-api-v1-uniform-create-subscription"
+	UpdateSubscriptionAction = "keptn-api-v1-uniform-update-subscription"
+	UpdateProjectAction      = "keptn-api-v1-update-project"
+	UpdateShipyardAction     = "keptn-api-v1-update-shipyard"
+	TriggerSequenceAction    = "keptn-api-v1-trigger-sequence"
+	UpsertServiceAction      = "keptn-api-v1-upsert-service"
+	UpsertSecretAction       = "keptn-api-v1-uniform-upsert-secret"
+	UpsertWebhookAction      = "keptn-api-v1-uniform-upsert-webhook-subscription"
+	UpsertSubscriptionAction = "keptn-api-v1-uniform-upsert-subscription"
 )
 
-var AllActions = []string{CreateServiceAction, CreateSecretAction, CreateWebhookAction}
+var AllActions = []string{
+	CreateServiceAction, CreateSecretAction, CreateWebhookAction, CreateSubscriptionAction, UpdateSubscriptionAction,
+	UpdateProjectAction, UpdateShipyardAction, TriggerSequenceAction, UpsertServiceAction, UpsertSecretAction,
+	UpsertWebhookAction, UpsertSubscriptionAction,
+}
+
+// Keys of the task context used as parameters by the subscription actions
+const (
+	// IntegrationContextKey holds the name of the integration whose subscriptions are created or updated
+	IntegrationContextKey = "integration"
+	// SubscriptionIDContextKey holds the id of the subscription to update
+	SubscriptionIDContextKey = "subscriptionID"
+)
 
 type ManifestExecution struct {
 	Inputs       map[string]string
 	Tasks        map[string]TaskExecution
 	TaskSequence []string
+	DryRun       bool
 }
 
 func (mc ManifestExecution) GetProject() string {
@@ -8,8 +8,8 @@ type APITask struct {
 type ResourceTask struct {
 	File      string `yaml:"resource"`
 	RemoteURI string `yaml:"resourceUri"`
-	Stage     string `yaml:"stage"`
-	Service   string `yaml:"service"`
+	Stage     string `yaml:"stage,omitempty"`
+	Service   string `yaml:"service,omitempty"`
 }
 
 type ManifestTask struct {
@@ -18,7 +18,7 @@ type ManifestTask struct {
 	ID            string            `yaml:"id"`
 	Type          string            `yaml:"type"`
 	Name          string            `yaml:"name"`
-	Context       map[string]string `yaml:"context"`
+	Context       map[string]string `yaml:"context,omitempty"`
 }
 
 type ImportManifest struct {
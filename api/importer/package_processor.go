@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"strings"
+
+	logger "github.com/sirupsen/logrus"
 
 	"github.com/keptn/keptn/api/importer/model"
 )
@@ -28,8 +31,10 @@ type ManifestParser interface {
 }
 
 type TaskExecutor interface {
-	ExecuteAPI(ate model.APITaskExecution) (any, error)
-	PushResource(rp model.ResourcePush) (any, error)
+	ExecuteAPI(ate model.APITaskExecution) (any, model.CompensatingAction, error)
+	PushResource(rp model.ResourcePush) (any, model.CompensatingAction, error)
+	CheckAPI(ate model.APITaskExecution) error
+	CheckResourcePush(rp model.ResourcePush) error
 	ActionSupported(actionName string) bool
 }
 
@@ -42,12 +47,16 @@ type ImportPackageProcessor struct {
 	executor       TaskExecutor
 	stageRetriever ProjectStageRetriever
 	renderer       Renderer
+	// dryRunRenderer renders the templates during a dry run, where the responses of previous tasks are not known
+	dryRunRenderer Renderer
 }
 
 func NewImportPackageProcessor(
 	mp ManifestParser, ex TaskExecutor, retriever ProjectStageRetriever,
 ) *ImportPackageProcessor {
-	return newImportPackageProcessor(mp, ex, retriever, &templateRenderer{})
+	ipp := newImportPackageProcessor(mp, ex, retriever, &templateRenderer{})
+	ipp.dryRunRenderer = &templateRenderer{lenient: true}
+	return ipp
 }
 
 func newImportPackageProcessor(
@@ -58,6 +67,7 @@ func newImportPackageProcessor(
 		executor:       ex,
 		stageRetriever: retriever,
 		renderer:       renderer,
+		dryRunRenderer: renderer,
 	}
 }
 
@@ -65,7 +75,23 @@ const manifestFileName = "manifest.yaml"
 const apiTaskType = "api"
 const resourceTaskType = "resource"
 
+// Process executes the tasks of the import package in order. If a task fails, the changes made by the previously
+// executed tasks are rolled back in reverse order and the outcome of each task is recorded in the returned
+// manifest execution
 func (ipp *ImportPackageProcessor) Process(project string, ip ImportPackage) (*model.ManifestExecution, error) {
+	return ipp.process(project, ip, false)
+}
+
+// DryRun validates the manifest of the import package, renders the templates of all tasks and checks that the
+// endpoints needed by the tasks are available, without making any changes. All tasks are checked even if some fail,
+// the outcome of each task is recorded in the returned manifest execution
+func (ipp *ImportPackageProcessor) DryRun(project string, ip ImportPackage) (*model.ManifestExecution, error) {
+	return ipp.process(project, ip, true)
+}
+
+func (ipp *ImportPackageProcessor) process(
+	project string, ip ImportPackage, dryRun bool,
+) (*model.ManifestExecution, error) {
 
 	defer ip.Close()
 
@@ -89,26 +115,95 @@ func (ipp *ImportPackageProcessor) Process(project string, ip ImportPackage) (*m
 	}
 
 	mCtx := model.NewManifestExecution(project)
+	mCtx.DryRun = dryRun
+	plan := new(rollbackPlan)
+	var failedTasks []string
 
 	for _, task := range manifest.Tasks {
 		mCtx.TaskSequence = append(mCtx.TaskSequence, task.ID)
 		switch task.Type {
 		case apiTaskType:
-			if err = ipp.processAPITask(mCtx, ip, task); err != nil {
-				return mCtx, err
-			}
+			err = ipp.processAPITask(mCtx, ip, task, plan)
 		case resourceTaskType:
-			if err = ipp.processResourceTask(mCtx, ip, task); err != nil {
-				return mCtx, err
-			}
+			err = ipp.processResourceTask(mCtx, ip, task, plan)
 		default:
-			return mCtx, fmt.Errorf("task of type %s not implemented", task.Type)
+			err = fmt.Errorf("task of type %s not implemented", task.Type)
+		}
+
+		if err == nil {
+			continue
 		}
+
+		recordTaskFailure(mCtx, task, err)
+		if !dryRun {
+			if rollbackErr := plan.rollback(mCtx); rollbackErr != nil {
+				return mCtx, fmt.Errorf("%w (%v)", err, rollbackErr)
+			}
+			return mCtx, err
+		}
+		failedTasks = append(failedTasks, task.ID)
+	}
+
+	if len(failedTasks) > 0 {
+		return mCtx, fmt.Errorf("dry run failed for tasks %s", strings.Join(failedTasks, ", "))
 	}
 
 	return mCtx, nil
 }
 
+// recordTaskFailure stores the failure of a task in the manifest execution. During a dry run an empty response is
+// recorded as well, so that the templates of later tasks referring to it can still be rendered
+func recordTaskFailure(mCtx *model.ManifestExecution, task *model.ManifestTask, err error) {
+	te := mCtx.Tasks[task.ID]
+	if te.Task == nil {
+		te.TaskContext = model.TaskContext{Project: mCtx.GetProject(), Task: task}
+	}
+	if mCtx.DryRun {
+		te.Response = map[string]any{}
+	}
+	te.Outcome = model.TaskOutcomeFailure
+	te.Message = err.Error()
+	mCtx.Tasks[task.ID] = te
+}
+
+// rollbackPlan collects the compensating actions of the executed tasks, to revert them if a later task fails
+type rollbackPlan struct {
+	taskIDs []string
+	actions []model.CompensatingAction
+}
+
+func (rp *rollbackPlan) register(taskID string, action model.CompensatingAction) {
+	if action == nil {
+		return
+	}
+	rp.taskIDs = append(rp.taskIDs, taskID)
+	rp.actions = append(rp.actions, action)
+}
+
+// rollback runs the compensating actions in reverse order and records the outcome of the reverted tasks.
+// A failing compensating action does not stop the rollback of the remaining tasks
+func (rp *rollbackPlan) rollback(mCtx *model.ManifestExecution) error {
+	var failedTasks []string
+	for i := len(rp.actions) - 1; i >= 0; i-- {
+		taskID := rp.taskIDs[i]
+		te := mCtx.Tasks[taskID]
+		if err := rp.actions[i](); err != nil {
+			logger.Errorf("Error rolling back task %s: %v", taskID, err)
+			te.Outcome = model.TaskOutcomeRollbackFailed
+			te.Message = err.Error()
+			failedTasks = append(failedTasks, taskID)
+		} else if te.Outcome == model.TaskOutcomeSuccess {
+			te.Outcome = model.TaskOutcomeRolledBack
+		}
+		mCtx.Tasks[taskID] = te
+	}
+
+	if len(failedTasks) > 0 {
+		return fmt.Errorf("rollback failed for tasks %s", strings.Join(failedTasks, ", "))
+	}
+	return nil
+}
+
 func (ipp *ImportPackageProcessor) validateManifest(
 	manifest *model.ImportManifest, ip ImportPackage) error {
 	re := regexp.MustCompile("^[a-zA-Z0-9_]*$")
@@ -171,7 +266,7 @@ func (ipp *ImportPackageProcessor) validateManifest(
 }
 
 func (ipp *ImportPackageProcessor) processResourceTask(
-	mCtx *model.ManifestExecution, ip ImportPackage, task *model.ManifestTask,
+	mCtx *model.ManifestExecution, ip ImportPackage, task *model.ManifestTask, plan *rollbackPlan,
 ) error {
 	if task.ResourceTask == nil {
 		return fmt.Errorf("malformed task of type resource: %+v", task)
@@ -192,21 +287,35 @@ func (ipp *ImportPackageProcessor) processResourceTask(
 		if err != nil {
 			return fmt.Errorf("error setting up resource push for task ID %s: %w", task.ID, err)
 		}
-		response, err := ipp.executor.PushResource(resourcePush)
+		if mCtx.DryRun {
+			resourcePush.Content.Close()
+			if err := ipp.executor.CheckResourcePush(resourcePush); err != nil {
+				return fmt.Errorf("resource task id %s cannot be executed: %w", task.ID, err)
+			}
+			mCtx.Tasks[task.ID] = model.TaskExecution{
+				TaskContext: resourcePush.Context,
+				Response:    map[string]any{},
+				Outcome:     model.TaskOutcomeSuccess,
+			}
+			continue
+		}
+		response, compensation, err := ipp.executor.PushResource(resourcePush)
 		if err != nil {
 			return fmt.Errorf("resource task id %s failed: %w", task.ID, err)
 		}
+		plan.register(task.ID, compensation)
 		// TODO what should we store for multiple stage resources upload ?
 		mCtx.Tasks[task.ID] = model.TaskExecution{
 			TaskContext: resourcePush.Context,
 			Response:    response,
+			Outcome:     model.TaskOutcomeSuccess,
 		}
 	}
 	return nil
 }
 
 func (ipp *ImportPackageProcessor) processAPITask(
-	mCtx *model.ManifestExecution, ip ImportPackage, task *model.ManifestTask,
+	mCtx *model.ManifestExecution, ip ImportPackage, task *model.ManifestTask, plan *rollbackPlan,
 ) error {
 	if task.APITask == nil {
 		return fmt.Errorf("malformed task of type api: %+v", task)
@@ -215,20 +324,42 @@ func (ipp *ImportPackageProcessor) processAPITask(
 	if err != nil {
 		return fmt.Errorf("error setting up API task ID %s: %w", task.ID, err)
 	}
-	response, err := ipp.executor.ExecuteAPI(apiTaskExecution)
+	if mCtx.DryRun {
+		apiTaskExecution.Payload.Close()
+		if err := ipp.executor.CheckAPI(apiTaskExecution); err != nil {
+			return fmt.Errorf("task %s cannot be executed: %w", task.ID, err)
+		}
+		mCtx.Tasks[task.ID] = model.TaskExecution{
+			TaskContext: apiTaskExecution.Context,
+			Response:    map[string]any{},
+			Outcome:     model.TaskOutcomeSuccess,
+		}
+		return nil
+	}
+
+	response, compensation, err := ipp.executor.ExecuteAPI(apiTaskExecution)
 	if err != nil {
 		return fmt.Errorf("execution of task %s failed: %w", task.ID, err)
 	}
+	plan.register(task.ID, compensation)
 
 	// store task context and response into the manifest context
 	mCtx.Tasks[task.ID] = model.TaskExecution{
 		TaskContext: apiTaskExecution.Context,
 		Response:    response,
+		Outcome:     model.TaskOutcomeSuccess,
 	}
 
 	return nil
 }
 
+func (ipp *ImportPackageProcessor) getRenderer(mCtx *model.ManifestExecution) Renderer {
+	if mCtx.DryRun {
+		return ipp.dryRunRenderer
+	}
+	return ipp.renderer
+}
+
 func (ipp *ImportPackageProcessor) mapResourcePush(
 	mCtx *model.ManifestExecution, stage string, ip ImportPackage,
 	task *model.ManifestTask,
@@ -247,7 +378,7 @@ func (ipp *ImportPackageProcessor) mapResourcePush(
 		)
 	}
 
-	renderedResource, err := ipp.renderer.RenderContent(resource, taskContext)
+	renderedResource, err := ipp.getRenderer(mCtx).RenderContent(resource, taskContext)
 	if err != nil {
 		return model.ResourcePush{}, fmt.Errorf("error rendering resource content: %w", err)
 	}
@@ -285,7 +416,7 @@ func (ipp *ImportPackageProcessor) mapAPITask(
 		)
 	}
 
-	renderedPayload, err := ipp.renderer.RenderContent(payload, taskContext)
+	renderedPayload, err := ipp.getRenderer(mCtx).RenderContent(payload, taskContext)
 	if err != nil {
 		return model.APITaskExecution{}, fmt.Errorf(
 			"error rendering payload %s: %w", task.APITask.PayloadFile,
@@ -323,7 +454,7 @@ func (ipp *ImportPackageProcessor) renderContext(
 ) (map[string]string, error) {
 	renderedContext := map[string]string{}
 	for k, v := range context {
-		renderedValue, err := ipp.renderer.RenderString(v, mCtx)
+		renderedValue, err := ipp.getRenderer(mCtx).RenderString(v, mCtx)
 		if err != nil {
 			return nil, fmt.Errorf("error rendering value for context key %s: %w", k, err)
 		}
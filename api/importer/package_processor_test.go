@@ -28,8 +28,8 @@ func TestImportPackageEmptyManifestRetrievedAndPackageClosed(t *testing.T) {
 	}
 
 	taskExecutor := &fake.TaskExecutorMock{
-		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, error) {
-			return nil, nil
+		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, model.CompensatingAction, error) {
+			return nil, nil, nil
 		},
 		ActionSupportedFunc: func(actionName string) bool {
 			return true
@@ -70,8 +70,8 @@ func TestErrorImportPackageWhenManifestCannotBeRetrieved(t *testing.T) {
 	}
 
 	taskExecutor := &fake.TaskExecutorMock{
-		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, error) {
-			return nil, nil
+		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, model.CompensatingAction, error) {
+			return nil, nil, nil
 		},
 		ActionSupportedFunc: func(actionName string) bool {
 			return true
@@ -120,8 +120,8 @@ func TestErrorImportPackageWhenManifestCannotBeParsed(t *testing.T) {
 	}
 
 	taskExecutor := &fake.TaskExecutorMock{
-		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, error) {
-			return nil, nil
+		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, model.CompensatingAction, error) {
+			return nil, nil, nil
 		},
 		ActionSupportedFunc: func(actionName string) bool {
 			return true
@@ -180,8 +180,8 @@ func TestErrorImportPackageWhenManifestResourceNotFound(t *testing.T) {
 	}
 
 	taskExecutor := &fake.TaskExecutorMock{
-		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, error) {
-			return nil, nil
+		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, model.CompensatingAction, error) {
+			return nil, nil, nil
 		},
 		ActionSupportedFunc: func(actionName string) bool {
 			return true
@@ -242,8 +242,8 @@ func TestErrorImportPackageWhenUnknownManifestTaskType(t *testing.T) {
 	}
 
 	taskExecutor := &fake.TaskExecutorMock{
-		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, error) {
-			return nil, nil
+		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, model.CompensatingAction, error) {
+			return nil, nil, nil
 		},
 	}
 
@@ -328,13 +328,13 @@ func TestErrorImportPackageWhenTaskFails(t *testing.T) {
 	var apiTasksExecuted []string
 
 	taskExecutor := &fake.TaskExecutorMock{
-		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, error) {
+		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, model.CompensatingAction, error) {
 			apiTasksExecuted = append(apiTasksExecuted, ate.Context.Task.ID)
 			if ate.Context.Task.Type == "api" && ate.Context.Task.APITask.Action == "keptn-api-v1-uniform-create-webhook-subscription" {
-				return nil, taskError
+				return nil, nil, taskError
 			}
 
-			return nil, nil
+			return nil, nil, nil
 		},
 		ActionSupportedFunc: func(actionName string) bool {
 			return true
@@ -404,7 +404,7 @@ func TestImportPackageProcessor_Process_ResourceTask(t *testing.T) {
 	resourceContentString := "some fancy binary content here"
 
 	taskExecutor := &fake.TaskExecutorMock{
-		PushResourceFunc: func(rp model.ResourcePush) (any, error) {
+		PushResourceFunc: func(rp model.ResourcePush) (any, model.CompensatingAction, error) {
 			assert.Equal(t, resourceTask.ResourceTask.Service, rp.Service)
 			assert.Equal(t, resourceTask.ResourceTask.Stage, rp.Stage)
 			assert.Equal(t, resourceTask.ResourceTask.RemoteURI, rp.ResourceURI)
@@ -414,7 +414,7 @@ func TestImportPackageProcessor_Process_ResourceTask(t *testing.T) {
 			require.NoError(t, err)
 			assert.Equal(t, []byte(resourceContentString), actualResourceContent)
 
-			return &struct{}{}, nil
+			return &struct{}{}, nil, nil
 		},
 	}
 
@@ -485,10 +485,10 @@ func TestImportPackageProcessor_Process_ResourceTask_AllStages(t *testing.T) {
 	var actualStages []string
 
 	taskExecutor := &fake.TaskExecutorMock{
-		PushResourceFunc: func(rp model.ResourcePush) (any, error) {
+		PushResourceFunc: func(rp model.ResourcePush) (any, model.CompensatingAction, error) {
 			assert.NotEmpty(t, rp.Stage)
 			actualStages = append(actualStages, rp.Stage)
-			return &struct{}{}, nil
+			return &struct{}{}, nil, nil
 		},
 	}
 
@@ -578,7 +578,7 @@ func TestImportPackageProcessor_Process_WebhookConfigWithTemplating(t *testing.T
 	const project = "somekeptnproject"
 
 	taskExecutor := &fake.TaskExecutorMock{
-		PushResourceFunc: func(rp model.ResourcePush) (any, error) {
+		PushResourceFunc: func(rp model.ResourcePush) (any, model.CompensatingAction, error) {
 			assert.NotNil(t, rp.Content)
 			defer rp.Content.Close()
 
@@ -592,7 +592,7 @@ func TestImportPackageProcessor_Process_WebhookConfigWithTemplating(t *testing.T
 			// For debugging purposes it may be easier to look at the YAML/JSON comparison
 			// assert.YAMLEq(t, string(expectedRenderedBytes), string(renderedBytes))
 			// assert.JSONEq(t, string(expectedRenderedBytes), string(renderedBytes))
-			return nil, nil
+			return nil, nil, nil
 		},
 	}
 
@@ -693,7 +693,7 @@ func TestImportPackageProcessor_Process_APITaskWithTemplating(t *testing.T) {
 				const project = "somekeptnproject"
 
 				taskExecutor := &fake.TaskExecutorMock{
-					ExecuteAPIFunc: func(ate model.APITaskExecution) (any, error) {
+					ExecuteAPIFunc: func(ate model.APITaskExecution) (any, model.CompensatingAction, error) {
 						assert.NotNil(t, ate.Payload)
 						defer ate.Payload.Close()
 
@@ -707,7 +707,7 @@ func TestImportPackageProcessor_Process_APITaskWithTemplating(t *testing.T) {
 						// For debugging purposes it may be easier to look at the YAML/JSON comparison
 						// assert.YAMLEq(t, string(expectedRenderedBytes), string(renderedBytes))
 						// assert.JSONEq(t, string(expectedRenderedBytes), string(renderedBytes))
-						return nil, nil
+						return nil, nil, nil
 					},
 					ActionSupportedFunc: func(actionName string) bool {
 						return true
@@ -846,8 +846,8 @@ func TestImportPackageProcessor_Process_ResourceTask_ErrorExecutingTask(t *testi
 	resourceContentString := "some fancy binary content here"
 
 	taskExecutor := &fake.TaskExecutorMock{
-		PushResourceFunc: func(rp model.ResourcePush) (any, error) {
-			return nil, errors.New("error executing resource push")
+		PushResourceFunc: func(rp model.ResourcePush) (any, model.CompensatingAction, error) {
+			return nil, nil, errors.New("error executing resource push")
 		},
 	}
 
@@ -1130,7 +1130,7 @@ func TestImportPackageProcessor_Process_FullManifestRendering(t *testing.T) {
 				require.NoError(t, err)
 
 				taskExecutor := &fake.TaskExecutorMock{
-					ExecuteAPIFunc: func(ate model.APITaskExecution) (any, error) {
+					ExecuteAPIFunc: func(ate model.APITaskExecution) (any, model.CompensatingAction, error) {
 						t.Logf("Handling API execution %+v", ate)
 						expectations, ok := inputs.TestData.API[ate.EndpointID]
 						if !ok || len(expectations) == 0 {
@@ -1164,9 +1164,9 @@ func TestImportPackageProcessor_Process_FullManifestRendering(t *testing.T) {
 								expectation.Action, expectation.Response,
 							)
 						}
-						return retval, nil
+						return retval, nil, nil
 					},
-					PushResourceFunc: func(rp model.ResourcePush) (any, error) {
+					PushResourceFunc: func(rp model.ResourcePush) (any, model.CompensatingAction, error) {
 						t.Logf("Handling resource push %+v", rp)
 						expectations, ok := inputs.TestData.Resource[rp.ResourceURI]
 						if !ok || len(expectations) == 0 {
@@ -1195,7 +1195,7 @@ func TestImportPackageProcessor_Process_FullManifestRendering(t *testing.T) {
 						// assert.YAMLEq(t, string(expectedRenderedBytes), string(renderedBytes))
 						// assert.JSONEq(t, string(expectedRenderedBytes), string(renderedBytes))
 
-						return nil, nil
+						return nil, nil, nil
 					},
 					ActionSupportedFunc: func(actionName string) bool {
 						return true
@@ -1252,8 +1252,8 @@ func TestPackageValidationEmptyId(t *testing.T) {
 	}
 
 	taskExecutor := &fake.TaskExecutorMock{
-		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, error) {
-			return nil, nil
+		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, model.CompensatingAction, error) {
+			return nil, nil, nil
 		},
 		ActionSupportedFunc: func(actionName string) bool {
 			return true
@@ -1333,8 +1333,8 @@ func TestPackageValidationIdAlphaNumeric(t *testing.T) {
 	}
 
 	taskExecutor := &fake.TaskExecutorMock{
-		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, error) {
-			return nil, nil
+		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, model.CompensatingAction, error) {
+			return nil, nil, nil
 		},
 		ActionSupportedFunc: func(actionName string) bool {
 			return true
@@ -1420,8 +1420,8 @@ func TestPackageValidationInvalidType(t *testing.T) {
 	}
 
 	taskExecutor := &fake.TaskExecutorMock{
-		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, error) {
-			return nil, nil
+		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, model.CompensatingAction, error) {
+			return nil, nil, nil
 		},
 		ActionSupportedFunc: func(actionName string) bool {
 			return true
@@ -1499,8 +1499,8 @@ func TestPackageValidationApiAndResourceTaskNotBothSet(t *testing.T) {
 	}
 
 	taskExecutor := &fake.TaskExecutorMock{
-		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, error) {
-			return nil, nil
+		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, model.CompensatingAction, error) {
+			return nil, nil, nil
 		},
 		ActionSupportedFunc: func(actionName string) bool {
 			return true
@@ -1583,8 +1583,8 @@ func TestPackageValidationResourceUriEmpty(t *testing.T) {
 	}
 
 	taskExecutor := &fake.TaskExecutorMock{
-		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, error) {
-			return nil, nil
+		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, model.CompensatingAction, error) {
+			return nil, nil, nil
 		},
 		ActionSupportedFunc: func(actionName string) bool {
 			return true
@@ -1642,8 +1642,8 @@ func TestPackageValidationEmptyTaskNotAllowed(t *testing.T) {
 	}
 
 	taskExecutor := &fake.TaskExecutorMock{
-		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, error) {
-			return nil, nil
+		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, model.CompensatingAction, error) {
+			return nil, nil, nil
 		},
 		ActionSupportedFunc: func(actionName string) bool {
 			return true
@@ -1697,3 +1697,224 @@ func TestPackageValidationEmptyTaskNotAllowed(t *testing.T) {
 			})
 	}
 }
+
+func newInMemoryImportPackage(files map[string]string) *fake.ImportPackageMock {
+	return &fake.ImportPackageMock{
+		CloseFunc: func() error {
+			return nil
+		},
+		GetResourceFunc: func(resourceName string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(files[resourceName])), nil
+		},
+		ResourceExistsFunc: func(resourceName string) (bool, error) {
+			_, ok := files[resourceName]
+			return ok, nil
+		},
+	}
+}
+
+func newRollbackTestManifest() *fake.ManifestParserMock {
+	return &fake.ManifestParserMock{
+		ParseFunc: func(input io.Reader) (*model.ImportManifest, error) {
+			return &model.ImportManifest{
+				ApiVersion: "v1beta1",
+				Tasks: []*model.ManifestTask{
+					{
+						APITask: &model.APITask{Action: model.CreateServiceAction, PayloadFile: "api/service.json"},
+						ID:      "create_service",
+						Type:    "api",
+					},
+					{
+						APITask: &model.APITask{Action: model.CreateWebhookAction, PayloadFile: "api/subscription.json"},
+						ID:      "create_subscription",
+						Type:    "api",
+					},
+					{
+						ResourceTask: &model.ResourceTask{File: "webhook.yaml", RemoteURI: "webhook/webhook.yaml", Stage: "dev"},
+						ID:           "push_webhook",
+						Type:         "resource",
+						Context:      map[string]string{"subscription": "[[ .Tasks.create_subscription.Response.id ]]"},
+					},
+				},
+			}, nil
+		},
+	}
+}
+
+var rollbackTestPackageFiles = map[string]string{
+	manifestFileName:        "",
+	"api/service.json":      `{"serviceName": "carts"}`,
+	"api/subscription.json": `{"event": "sh.keptn.event.deployment.triggered", "filter": {"projects": ["[[ .Project ]]"]}}`,
+	"webhook.yaml":          "subscriptionID: [[ .Context.subscription ]]",
+}
+
+func TestImportPackageProcessor_Process_RollbackOnFailure(t *testing.T) {
+	var rolledBack []string
+	taskExecutor := &fake.TaskExecutorMock{
+		ActionSupportedFunc: func(actionName string) bool {
+			return true
+		},
+		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, model.CompensatingAction, error) {
+			taskID := ate.Context.Task.ID
+			return map[string]any{"id": "subscription-id"}, func() error {
+				rolledBack = append(rolledBack, taskID)
+				return nil
+			}, nil
+		},
+		PushResourceFunc: func(rp model.ResourcePush) (any, model.CompensatingAction, error) {
+			return nil, nil, errors.New("resource-service unavailable")
+		},
+	}
+
+	sut := NewImportPackageProcessor(newRollbackTestManifest(), taskExecutor, &fake.MockStageRetriever{})
+	mExec, err := sut.Process("project", newInMemoryImportPackage(rollbackTestPackageFiles))
+
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "resource-service unavailable")
+	assert.Equal(t, []string{"create_subscription", "create_service"}, rolledBack)
+
+	require.NotNil(t, mExec)
+	assert.Equal(t, []string{"create_service", "create_subscription", "push_webhook"}, mExec.TaskSequence)
+	assert.Equal(t, model.TaskOutcomeRolledBack, mExec.Tasks["create_service"].Outcome)
+	assert.Equal(t, model.TaskOutcomeRolledBack, mExec.Tasks["create_subscription"].Outcome)
+	assert.Equal(t, model.TaskOutcomeFailure, mExec.Tasks["push_webhook"].Outcome)
+	assert.Contains(t, mExec.Tasks["push_webhook"].Message, "resource-service unavailable")
+}
+
+func TestImportPackageProcessor_Process_RollbackContinuesAfterFailingCompensation(t *testing.T) {
+	var rolledBack []string
+	taskExecutor := &fake.TaskExecutorMock{
+		ActionSupportedFunc: func(actionName string) bool {
+			return true
+		},
+		ExecuteAPIFunc: func(ate model.APITaskExecution) (any, model.CompensatingAction, error) {
+			taskID := ate.Context.Task.ID
+			return map[string]any{"id": "subscription-id"}, func() error {
+				rolledBack = append(rolledBack, taskID)
+				if taskID == "create_subscription" {
+					return errors.New("subscription not found")
+				}
+				return nil
+			}, nil
+		},
+		PushResourceFunc: func(rp model.ResourcePush) (any, model.CompensatingAction, error) {
+			return nil, nil, errors.New("resource-service unavailable")
+		},
+	}
+
+	sut := NewImportPackageProcessor(newRollbackTestManifest(), taskExecutor, &fake.MockStageRetriever{})
+	mExec, err := sut.Process("project", newInMemoryImportPackage(rollbackTestPackageFiles))
+
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "rollback failed for tasks create_subscription")
+	assert.Equal(t, []string{"create_subscription", "create_service"}, rolledBack)
+	assert.Equal(t, model.TaskOutcomeRolledBack, mExec.Tasks["create_service"].Outcome)
+	assert.Equal(t, model.TaskOutcomeRollbackFailed, mExec.Tasks["create_subscription"].Outcome)
+	assert.Equal(t, "subscription not found", mExec.Tasks["create_subscription"].Message)
+}
+
+func TestImportPackageProcessor_Process_RollbackPartiallyPushedResource(t *testing.T) {
+	parserMock := &fake.ManifestParserMock{
+		ParseFunc: func(input io.Reader) (*model.ImportManifest, error) {
+			return &model.ImportManifest{
+				ApiVersion: "v1beta1",
+				Tasks: []*model.ManifestTask{
+					{
+						ResourceTask: &model.ResourceTask{File: "webhook.yaml", RemoteURI: "webhook/webhook.yaml"},
+						ID:           "push_webhook",
+						Type:         "resource",
+					},
+				},
+			}, nil
+		},
+	}
+
+	var reverted []string
+	taskExecutor := &fake.TaskExecutorMock{
+		PushResourceFunc: func(rp model.ResourcePush) (any, model.CompensatingAction, error) {
+			if rp.Stage == "prod" {
+				return nil, nil, errors.New("stage prod not found")
+			}
+			stage := rp.Stage
+			return nil, func() error {
+				reverted = append(reverted, stage)
+				return nil
+			}, nil
+		},
+	}
+	stageRetriever := &fake.MockStageRetriever{
+		GetStagesFunc: func(project string) ([]string, error) {
+			return []string{"dev", "hardening", "prod"}, nil
+		},
+	}
+
+	sut := NewImportPackageProcessor(parserMock, taskExecutor, stageRetriever)
+	mExec, err := sut.Process("project", newInMemoryImportPackage(map[string]string{"webhook.yaml": "content"}))
+
+	require.Error(t, err)
+	assert.Equal(t, []string{"hardening", "dev"}, reverted)
+	assert.Equal(t, model.TaskOutcomeFailure, mExec.Tasks["push_webhook"].Outcome)
+}
+
+func TestImportPackageProcessor_DryRun(t *testing.T) {
+	taskExecutor := &fake.TaskExecutorMock{
+		ActionSupportedFunc: func(actionName string) bool {
+			return true
+		},
+		CheckAPIFunc: func(ate model.APITaskExecution) error {
+			return nil
+		},
+		CheckResourcePushFunc: func(rp model.ResourcePush) error {
+			return nil
+		},
+	}
+
+	sut := NewImportPackageProcessor(newRollbackTestManifest(), taskExecutor, &fake.MockStageRetriever{})
+	mExec, err := sut.DryRun("project", newInMemoryImportPackage(rollbackTestPackageFiles))
+
+	require.NoError(t, err)
+	assert.True(t, mExec.DryRun)
+	assert.Empty(t, taskExecutor.ExecuteAPICalls())
+	assert.Empty(t, taskExecutor.PushResourceCalls())
+	require.Len(t, taskExecutor.CheckAPICalls(), 2)
+	require.Len(t, taskExecutor.CheckResourcePushCalls(), 1)
+	assert.Equal(t, "dev", taskExecutor.CheckResourcePushCalls()[0].Rp.Stage)
+	for _, taskID := range mExec.TaskSequence {
+		assert.Equal(t, model.TaskOutcomeSuccess, mExec.Tasks[taskID].Outcome, taskID)
+	}
+}
+
+func TestImportPackageProcessor_DryRunReportsAllFailingTasks(t *testing.T) {
+	taskExecutor := &fake.TaskExecutorMock{
+		ActionSupportedFunc: func(actionName string) bool {
+			return true
+		},
+		CheckAPIFunc: func(ate model.APITaskExecution) error {
+			if ate.EndpointID == model.CreateWebhookAction {
+				return errors.New("no integration found for name webhook-service")
+			}
+			return nil
+		},
+		CheckResourcePushFunc: func(rp model.ResourcePush) error {
+			return nil
+		},
+	}
+
+	files := map[string]string{}
+	for name, content := range rollbackTestPackageFiles {
+		files[name] = content
+	}
+	files["webhook.yaml"] = "subscriptionID: [[ .Context.unknown.key ]]"
+
+	sut := NewImportPackageProcessor(newRollbackTestManifest(), taskExecutor, &fake.MockStageRetriever{})
+	mExec, err := sut.DryRun("project", newInMemoryImportPackage(files))
+
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "dry run failed for tasks create_subscription, push_webhook")
+	assert.Empty(t, taskExecutor.ExecuteAPICalls())
+	assert.Equal(t, model.TaskOutcomeSuccess, mExec.Tasks["create_service"].Outcome)
+	assert.Equal(t, model.TaskOutcomeFailure, mExec.Tasks["create_subscription"].Outcome)
+	assert.Contains(t, mExec.Tasks["create_subscription"].Message, "no integration found")
+	assert.Equal(t, model.TaskOutcomeFailure, mExec.Tasks["push_webhook"].Outcome)
+	assert.Contains(t, mExec.Tasks["push_webhook"].Message, "error rendering")
+}
@@ -7,7 +7,10 @@ import (
 	"text/template"
 )
 
-type templateRenderer struct{}
+// templateRenderer renders templates delimited by [[ ]]. Unless lenient, referencing a missing key is an error
+type templateRenderer struct {
+	lenient bool
+}
 
 func (tr *templateRenderer) RenderContent(raw io.ReadCloser, context any) (io.ReadCloser, error) {
 	defer raw.Close()
@@ -25,10 +28,14 @@ func (tr *templateRenderer) RenderContent(raw io.ReadCloser, context any) (io.Re
 	return io.NopCloser(strings.NewReader(rendered)), nil
 }
 func (tr *templateRenderer) RenderString(raw string, context any) (string, error) {
+	missingKeyOption := "missingkey=error"
+	if tr.lenient {
+		missingKeyOption = "missingkey=zero"
+	}
 
 	t, err := template.New("template").
 		Delims("[[", "]]").
-		Option("missingkey=error").
+		Option(missingKeyOption).
 		Parse(raw)
 
 	if err != nil {
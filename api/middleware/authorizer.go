@@ -0,0 +1,174 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	openapierrors "github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime/middleware"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
+
+	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/tokens"
+)
+
+const (
+	authOperation   = "POST /auth"
+	eventOperation  = "POST /event"
+	importOperation = "POST /import"
+	exportOperation = "GET /export"
+)
+
+// operationRoles lists the roles that can use an operation, all other operations require the admin role
+var operationRoles = map[string][]tokens.Role{
+	authOperation:   tokens.AllRoles,
+	"GET /metadata": tokens.AllRoles,
+	eventOperation:  {tokens.RoleTriggerOnly, tokens.RoleAdmin},
+	exportOperation: {tokens.RoleReadOnly, tokens.RoleAdmin},
+	importOperation: {tokens.RoleAdmin},
+}
+
+// projectOperations return the project affected by an operation. Tokens scoped to projects can only use these
+// operations for their projects, as well as the operations that are allowed for all roles. The other admin operations,
+// e.g. managing tokens, cannot be restricted to a project and require a token that is not scoped
+var projectOperations = map[string]func(req *http.Request) string{
+	eventOperation:  eventProject,
+	importOperation: queryProject,
+	exportOperation: queryProject,
+}
+
+// Headers set by the API gateway on the requests to the auth endpoint that authenticate a request to another service
+const (
+	OriginalMethodHeader = "X-Original-Method"
+	OriginalURIHeader    = "X-Original-URI"
+)
+
+// TokenAuthorizer restricts the operations of the API service that can be used with named API tokens according to
+// their role and projects. The SECRET_TOKEN can be used for all operations
+type TokenAuthorizer struct {
+	tokens tokenLookup
+}
+
+func NewTokenAuthorizer(tokens tokenLookup) *TokenAuthorizer {
+	return &TokenAuthorizer{tokens: tokens}
+}
+
+func (a *TokenAuthorizer) Authorize(req *http.Request, principal interface{}) error {
+	p, _ := principal.(*models.Principal)
+	name, ok := managedTokenName(p)
+	if !ok {
+		return nil
+	}
+	token, err := a.tokens.Get(name)
+	if err != nil {
+		log.WithError(err).Error("Could not look up API token")
+		return openapierrors.New(http.StatusInternalServerError, "could not verify api key")
+	}
+	if token == nil {
+		return openapierrors.New(http.StatusUnauthorized, "api token %s is revoked or expired", name)
+	}
+
+	route := middleware.MatchedRouteFrom(req)
+	if route == nil {
+		return openapierrors.New(http.StatusForbidden, "unknown operation")
+	}
+	operation := req.Method + " " + strings.TrimPrefix(route.PathPattern, route.BasePath)
+
+	roles, ok := operationRoles[operation]
+	if !ok {
+		roles = []tokens.Role{tokens.RoleAdmin}
+	}
+	if !slices.Contains(roles, token.Role) {
+		return openapierrors.New(
+			http.StatusForbidden, "api token %s with role %s cannot be used for %s", name, token.Role, operation,
+		)
+	}
+
+	if operation == authOperation {
+		return authorizeGatewayRequest(req, token)
+	}
+	if !token.ProjectScoped() {
+		return nil
+	}
+	projectOf, ok := projectOperations[operation]
+	if !ok {
+		if !slices.Equal(roles, tokens.AllRoles) {
+			return openapierrors.New(
+				http.StatusForbidden, "api token %s is scoped to projects and cannot be used for %s", name, operation,
+			)
+		}
+		return nil
+	}
+	if project := projectOf(req); !token.AllowsProject(project) {
+		return openapierrors.New(http.StatusForbidden, "api token %s cannot be used for project %q", name, project)
+	}
+	return nil
+}
+
+// authorizeGatewayRequest checks whether a token can be used for the request the API gateway authenticates with the
+// auth endpoint. Requests to other services are only inspected for their method, so only unscoped admin tokens can
+// change anything and scoped tokens cannot be used at all
+func authorizeGatewayRequest(req *http.Request, token *tokens.APIToken) error {
+	method := req.Header.Get(OriginalMethodHeader)
+	uri := req.Header.Get(OriginalURIHeader)
+	if method == "" || strings.HasSuffix(strings.SplitN(uri, "?", 2)[0], "/v1/auth") {
+		return nil
+	}
+	if token.ProjectScoped() {
+		return openapierrors.New(
+			http.StatusForbidden, "api token %s is scoped to projects and cannot be used for %s", token.Name, uri,
+		)
+	}
+	if token.Role == tokens.RoleAdmin {
+		return nil
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return nil
+	}
+	return openapierrors.New(
+		http.StatusForbidden, "api token %s with role %s cannot be used for %s %s", token.Name, token.Role, method, uri,
+	)
+}
+
+func queryProject(req *http.Request) string {
+	return req.URL.Query().Get("project")
+}
+
+// eventProject returns the project in the data of the event sent with the request, the body remains readable
+func eventProject(req *http.Request) string {
+	if req.Body == nil {
+		return ""
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		// the handler of the request gets the same error, e.g. if the event exceeds the max. size
+		req.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errorReader{err: err}))
+		log.WithError(err).Warn("Could not read event to get its project")
+		return ""
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	event := struct {
+		Data struct {
+			Project string `json:"project"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &event); err != nil {
+		return ""
+	}
+	return event.Data.Project
+}
+
+type errorReader struct {
+	err error
+}
+
+func (r errorReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,252 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	openapierrors "github.com/go-openapi/errors"
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/runtime/middleware/untyped"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	middleware_mock "github.com/keptn/keptn/api/middleware/fake"
+	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/tokens"
+)
+
+const authorizerTestSpec = `{
+  "swagger": "2.0",
+  "info": {"title": "test", "version": "1"},
+  "basePath": "/v1",
+  "paths": {
+    "/auth": {"post": {"operationId": "auth", "responses": {"200": {"description": "ok"}}}},
+    "/metadata": {"get": {"operationId": "metadata", "responses": {"200": {"description": "ok"}}}},
+    "/event": {"post": {"operationId": "postEvent", "responses": {"200": {"description": "ok"}}}},
+    "/import": {"post": {"operationId": "import", "responses": {"200": {"description": "ok"}}}},
+    "/export": {"get": {"operationId": "export", "responses": {"200": {"description": "ok"}}}},
+    "/tokens": {"get": {"operationId": "listTokens", "responses": {"200": {"description": "ok"}}}}
+  }
+}`
+
+// newRoutedRequest returns a request that carries the route of the test spec matching it, as the API would
+func newRoutedRequest(t *testing.T, method, target, body string) *http.Request {
+	doc, err := loads.Analyzed(json.RawMessage(authorizerTestSpec), "")
+	require.NoError(t, err)
+
+	api := untyped.NewAPI(doc)
+	for _, op := range []struct{ method, path string }{
+		{http.MethodPost, "/auth"},
+		{http.MethodGet, "/metadata"},
+		{http.MethodPost, "/event"},
+		{http.MethodPost, "/import"},
+		{http.MethodGet, "/export"},
+		{http.MethodGet, "/tokens"},
+	} {
+		api.RegisterOperation(op.method, op.path, runtime.OperationHandlerFunc(func(interface{}) (interface{}, error) {
+			return nil, nil
+		}))
+	}
+
+	var bodyReader io.Reader
+	if body != "" {
+		bodyReader = strings.NewReader(body)
+	}
+	var routed *http.Request
+	router := middleware.NewRouter(middleware.NewContext(doc, api, nil), http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		routed = r
+	}))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, target, bodyReader))
+	require.NotNil(t, routed)
+	return routed
+}
+
+func TestTokenAuthorizer_Authorize(t *testing.T) {
+	projectScoped := &tokens.APIToken{Name: "ci", Role: tokens.RoleTriggerOnly, Projects: []string{"sockshop"}}
+	availableTokens := map[string]*tokens.APIToken{
+		"ci":       projectScoped,
+		"reader":   {Name: "reader", Role: tokens.RoleReadOnly},
+		"trigger":  {Name: "trigger", Role: tokens.RoleTriggerOnly},
+		"admin":    {Name: "admin", Role: tokens.RoleAdmin},
+		"importer": {Name: "importer", Role: tokens.RoleAdmin, Projects: []string{"sockshop"}},
+	}
+
+	tests := []struct {
+		name       string
+		principal  string
+		method     string
+		target     string
+		body       string
+		headers    map[string]string
+		wantStatus int32
+	}{
+		{
+			name:      "secret token can use all operations",
+			principal: "my-secret-token",
+			method:    http.MethodGet,
+			target:    "/v1/tokens",
+		},
+		{
+			name:       "revoked token",
+			principal:  "api-token:unknown",
+			method:     http.MethodGet,
+			target:     "/v1/metadata",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:      "read-only token reads metadata",
+			principal: "api-token:reader",
+			method:    http.MethodGet,
+			target:    "/v1/metadata",
+		},
+		{
+			name:       "read-only token cannot send events",
+			principal:  "api-token:reader",
+			method:     http.MethodPost,
+			target:     "/v1/event",
+			body:       `{"data": {"project": "sockshop"}}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:      "read-only token exports",
+			principal: "api-token:reader",
+			method:    http.MethodGet,
+			target:    "/v1/export?project=sockshop",
+		},
+		{
+			name:       "trigger-only token cannot import",
+			principal:  "api-token:trigger",
+			method:     http.MethodPost,
+			target:     "/v1/import?project=sockshop",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "trigger-only token cannot manage tokens",
+			principal:  "api-token:trigger",
+			method:     http.MethodGet,
+			target:     "/v1/tokens",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:      "admin token manages tokens",
+			principal: "api-token:admin",
+			method:    http.MethodGet,
+			target:    "/v1/tokens",
+		},
+		{
+			name:      "scoped token sends event of its project",
+			principal: "api-token:ci",
+			method:    http.MethodPost,
+			target:    "/v1/event",
+			body:      `{"type": "sh.keptn.event.dev.delivery.triggered", "data": {"project": "sockshop"}}`,
+		},
+		{
+			name:       "scoped token cannot send event of other project",
+			principal:  "api-token:ci",
+			method:     http.MethodPost,
+			target:     "/v1/event",
+			body:       `{"type": "sh.keptn.event.dev.delivery.triggered", "data": {"project": "podtato-head"}}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "scoped token cannot send event without project",
+			principal:  "api-token:ci",
+			method:     http.MethodPost,
+			target:     "/v1/event",
+			body:       `{"type": "sh.keptn.event.dev.delivery.triggered", "data": {}}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:      "scoped admin token imports into its project",
+			principal: "api-token:importer",
+			method:    http.MethodPost,
+			target:    "/v1/import?project=sockshop",
+		},
+		{
+			name:       "scoped admin token cannot import into other project",
+			principal:  "api-token:importer",
+			method:     http.MethodPost,
+			target:     "/v1/import?project=podtato-head",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "scoped admin token cannot manage tokens",
+			principal:  "api-token:importer",
+			method:     http.MethodGet,
+			target:     "/v1/tokens",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:      "read-only token authenticates read request of gateway",
+			principal: "api-token:reader",
+			method:    http.MethodPost,
+			target:    "/v1/auth",
+			headers: map[string]string{
+				OriginalMethodHeader: http.MethodGet,
+				OriginalURIHeader:    "/api/controlPlane/v1/project",
+			},
+		},
+		{
+			name:      "read-only token cannot authenticate write request of gateway",
+			principal: "api-token:reader",
+			method:    http.MethodPost,
+			target:    "/v1/auth",
+			headers: map[string]string{
+				OriginalMethodHeader: http.MethodDelete,
+				OriginalURIHeader:    "/api/controlPlane/v1/project/sockshop",
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:      "scoped token cannot authenticate request of gateway",
+			principal: "api-token:ci",
+			method:    http.MethodPost,
+			target:    "/v1/auth",
+			headers: map[string]string{
+				OriginalMethodHeader: http.MethodGet,
+				OriginalURIHeader:    "/api/controlPlane/v1/project",
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:      "scoped token checks its validity",
+			principal: "api-token:ci",
+			method:    http.MethodPost,
+			target:    "/v1/auth",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookup := &middleware_mock.TokenLookupMock{
+				GetFunc: func(name string) (*tokens.APIToken, error) {
+					return availableTokens[name], nil
+				},
+			}
+			req := newRoutedRequest(t, tt.method, tt.target, tt.body)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			principal := models.Principal(tt.principal)
+
+			err := NewTokenAuthorizer(lookup).Authorize(req, &principal)
+			if tt.wantStatus == 0 {
+				require.NoError(t, err)
+				if tt.body != "" {
+					body, err := io.ReadAll(req.Body)
+					require.NoError(t, err)
+					assert.Equal(t, tt.body, string(body))
+				}
+				return
+			}
+			var apiErr openapierrors.Error
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tt.wantStatus, apiErr.Code())
+		})
+	}
+}
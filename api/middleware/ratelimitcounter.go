@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/nats-io/nats.go"
+)
+
+// RateLimitCounter counts the requests with the same key within the window starting at windowStart
+type RateLimitCounter interface {
+	// Increment counts a request and returns the number of requests counted within the window, including this one
+	Increment(key string, windowStart time.Time, window time.Duration) (int64, error)
+}
+
+type windowCount struct {
+	windowStart time.Time
+	expiry      time.Time
+	count       int64
+}
+
+// MemoryRateLimitCounter counts the requests received by one replica of the API service
+type MemoryRateLimitCounter struct {
+	theClock clock.Clock
+	counts   map[string]*windowCount
+	mutex    sync.Mutex
+}
+
+func NewMemoryRateLimitCounter(theClock clock.Clock) *MemoryRateLimitCounter {
+	c := &MemoryRateLimitCounter{
+		theClock: theClock,
+		counts:   map[string]*windowCount{},
+	}
+
+	ticker := c.theClock.Ticker(1 * time.Minute)
+	go func() {
+		for {
+			<-ticker.C
+			c.cleanExpiredCounts()
+		}
+	}()
+
+	return c
+}
+
+func (c *MemoryRateLimitCounter) Increment(key string, windowStart time.Time, window time.Duration) (int64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	current, ok := c.counts[key]
+	if !ok || !current.windowStart.Equal(windowStart) {
+		current = &windowCount{windowStart: windowStart, expiry: windowStart.Add(window)}
+		c.counts[key] = current
+	}
+	current.count++
+	return current.count, nil
+}
+
+func (c *MemoryRateLimitCounter) cleanExpiredCounts() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := c.theClock.Now()
+	for key, count := range c.counts {
+		if !now.Before(count.expiry) {
+			delete(c.counts, key)
+		}
+	}
+}
+
+// maxIncrementAttempts limits the attempts to update a counter that is concurrently updated by other replicas
+const maxIncrementAttempts = 10
+
+// NatsRateLimitCounter counts the requests in a NATS JetStream key-value bucket, which is shared by all replicas of
+// the API service. Counters are removed by NATS once the TTL of the bucket has passed
+type NatsRateLimitCounter struct {
+	kv nats.KeyValue
+}
+
+// NewNatsRateLimitCounter returns a counter using the given bucket, which is created with the given TTL if it does not
+// exist. The TTL has to be at least as long as the longest window of the rate limits
+func NewNatsRateLimitCounter(js nats.JetStreamContext, bucket string, ttl time.Duration) (*NatsRateLimitCounter, error) {
+	kv, err := js.KeyValue(bucket)
+	if errors.Is(err, nats.ErrBucketNotFound) {
+		kv, err = js.CreateKeyValue(&nats.KeyValueConfig{
+			Bucket:      bucket,
+			Description: "request counters of the rate limits of the Keptn API",
+			TTL:         ttl,
+			History:     1,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not open rate limit bucket %s: %w", bucket, err)
+	}
+	return &NatsRateLimitCounter{kv: kv}, nil
+}
+
+func (c *NatsRateLimitCounter) Increment(key string, windowStart time.Time, window time.Duration) (int64, error) {
+	// keys of NATS key-value buckets are restricted to few characters, principals and IPs are therefore hashed
+	hash := sha256.Sum256([]byte(key))
+	kvKey := hex.EncodeToString(hash[:16]) + "." + strconv.FormatInt(windowStart.Unix(), 10)
+
+	for attempt := 0; attempt < maxIncrementAttempts; attempt++ {
+		entry, err := c.kv.Get(kvKey)
+		if errors.Is(err, nats.ErrKeyNotFound) {
+			if _, err := c.kv.Create(kvKey, []byte("1")); err == nil {
+				return 1, nil
+			}
+			// another replica created the counter in the meantime
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("could not read rate limit counter: %w", err)
+		}
+
+		count, err := strconv.ParseInt(string(entry.Value()), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid rate limit counter %q: %w", entry.Value(), err)
+		}
+		count++
+		if _, err := c.kv.Update(kvKey, []byte(strconv.FormatInt(count, 10)), entry.Revision()); err == nil {
+			return count, nil
+		}
+		// another replica updated the counter in the meantime
+	}
+	return 0, fmt.Errorf("could not update rate limit counter after %d attempts", maxIncrementAttempts)
+}
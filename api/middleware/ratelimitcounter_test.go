@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/nats-io/nats-server/v2/server"
+	natstest "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRateLimitCounter(t *testing.T) {
+	mockClock := clock.NewMock()
+	counter := NewMemoryRateLimitCounter(mockClock)
+	window := time.Minute
+	windowStart := mockClock.Now().Truncate(window)
+
+	for i := int64(1); i <= 3; i++ {
+		count, err := counter.Increment("key", windowStart, window)
+		require.NoError(t, err)
+		require.Equal(t, i, count)
+	}
+	count, err := counter.Increment("other-key", windowStart, window)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	// counting starts again in the next window
+	count, err = counter.Increment("key", windowStart.Add(window), window)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	// proceed the internal clock of the counter and check if the expired counts are being cleaned up
+	mockClock.Add(2 * time.Minute)
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+	require.Empty(t, counter.counts)
+}
+
+func TestNatsRateLimitCounter(t *testing.T) {
+	opts := natstest.DefaultTestOptions
+	opts.Port = server.RANDOM_PORT
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	svr := natstest.RunServer(&opts)
+	defer svr.Shutdown()
+
+	conn, err := nats.Connect(svr.ClientURL())
+	require.NoError(t, err)
+	defer conn.Close()
+	js, err := conn.JetStream()
+	require.NoError(t, err)
+
+	// two replicas share the counters of the bucket
+	replicas := make([]*NatsRateLimitCounter, 2)
+	for i := range replicas {
+		replicas[i], err = NewNatsRateLimitCounter(js, "rate-limits", time.Minute)
+		require.NoError(t, err)
+	}
+
+	windowStart := time.Now().Truncate(time.Minute)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(counter *NatsRateLimitCounter) {
+			defer wg.Done()
+			_, err := counter.Increment("POST /event/1m0s/principal=api-token:ci", windowStart, time.Minute)
+			require.NoError(t, err)
+		}(replicas[i%2])
+	}
+	wg.Wait()
+
+	count, err := replicas[0].Increment("POST /event/1m0s/principal=api-token:ci", windowStart, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, int64(11), count)
+
+	count, err = replicas[1].Increment("POST /event/1m0s/principal=api-token:ci", windowStart.Add(time.Minute), time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
@@ -0,0 +1,193 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/keptn/keptn/api/models"
+)
+
+// RateLimitKey determines which requests to a route share their rate limit
+type RateLimitKey string
+
+const (
+	// RateLimitKeyPrincipal limits the requests per API token. Requests with an invalid token are limited per client IP
+	RateLimitKeyPrincipal RateLimitKey = "principal"
+	// RateLimitKeyIP limits the requests per client IP
+	RateLimitKeyIP RateLimitKey = "ip"
+	// RateLimitKeyProject limits the requests per project, e.g. data.project of the events sent to POST /event.
+	// Requests are only counted for the project if their API token is valid and can be used for the project,
+	// all other requests are limited per client IP
+	RateLimitKeyProject RateLimitKey = "project"
+)
+
+// Headers set on the responses of rate limited routes
+const (
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+	RetryAfterHeader         = "Retry-After"
+)
+
+// RateLimit allows a number of requests to a route of the API within a fixed window
+type RateLimit struct {
+	Method   string
+	Path     string
+	Key      RateLimitKey
+	Requests int64
+	Window   time.Duration
+}
+
+func (l RateLimit) String() string {
+	return fmt.Sprintf("%s %s: %d requests per %s per %s", l.Method, l.Path, l.Requests, l.Window, l.Key)
+}
+
+// ParseRateLimits parses the JSON list of rate limits given by RATE_LIMITS, e.g.
+// [{"method": "POST", "path": "/event", "key": "project", "requests": 100, "window": "1m"}]
+func ParseRateLimits(config string) ([]RateLimit, error) {
+	if strings.TrimSpace(config) == "" {
+		return nil, nil
+	}
+	var rawLimits []struct {
+		Method   string       `json:"method"`
+		Path     string       `json:"path"`
+		Key      RateLimitKey `json:"key"`
+		Requests int64        `json:"requests"`
+		Window   string       `json:"window"`
+	}
+	if err := json.Unmarshal([]byte(config), &rawLimits); err != nil {
+		return nil, fmt.Errorf("could not parse rate limits: %w", err)
+	}
+
+	limits := make([]RateLimit, 0, len(rawLimits))
+	for i, raw := range rawLimits {
+		if raw.Method == "" || raw.Path == "" {
+			return nil, fmt.Errorf("rate limit %d: method and path are required", i)
+		}
+		switch raw.Key {
+		case RateLimitKeyPrincipal, RateLimitKeyIP, RateLimitKeyProject:
+		default:
+			return nil, fmt.Errorf("rate limit %d: unknown key %q", i, raw.Key)
+		}
+		if raw.Requests <= 0 {
+			return nil, fmt.Errorf("rate limit %d: requests must be positive", i)
+		}
+		window, err := time.ParseDuration(raw.Window)
+		if err != nil || window < time.Second {
+			return nil, fmt.Errorf("rate limit %d: window must be a duration of at least 1s", i)
+		}
+		limits = append(limits, RateLimit{
+			Method:   strings.ToUpper(raw.Method),
+			Path:     raw.Path,
+			Key:      raw.Key,
+			Requests: raw.Requests,
+			Window:   window,
+		})
+	}
+	return limits, nil
+}
+
+// RouteRateLimiter rejects the requests to a route exceeding a RateLimit with status 429. The requests are counted
+// by a RateLimitCounter, which can be shared between the replicas of the API service
+type RouteRateLimiter struct {
+	limit          RateLimit
+	counter        RateLimitCounter
+	tokenValidator TokenValidator
+	tokens         tokenLookup
+	theClock       clock.Clock
+}
+
+func NewRouteRateLimiter(limit RateLimit, counter RateLimitCounter, tokenValidator TokenValidator, tokens tokenLookup, theClock clock.Clock) *RouteRateLimiter {
+	return &RouteRateLimiter{
+		limit:          limit,
+		counter:        counter,
+		tokenValidator: tokenValidator,
+		tokens:         tokens,
+		theClock:       theClock,
+	}
+}
+
+func (r *RouteRateLimiter) Handle(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.Apply(w, req, handler)
+	})
+}
+
+func (r *RouteRateLimiter) Apply(w http.ResponseWriter, req *http.Request, handler http.Handler) {
+	now := r.theClock.Now()
+	windowStart := now.Truncate(r.limit.Window)
+	count, err := r.counter.Increment(r.counterKey(req), windowStart, r.limit.Window)
+	if err != nil {
+		// requests are not rejected only because they cannot be counted
+		log.WithError(err).Errorf("Could not count request for rate limit %s", r.limit)
+		handler.ServeHTTP(w, req)
+		return
+	}
+
+	reset := int64(math.Ceil(windowStart.Add(r.limit.Window).Sub(now).Seconds()))
+	remaining := r.limit.Requests - count
+	if remaining < 0 {
+		remaining = 0
+	}
+	w.Header().Set(RateLimitLimitHeader, strconv.FormatInt(r.limit.Requests, 10))
+	w.Header().Set(RateLimitRemainingHeader, strconv.FormatInt(remaining, 10))
+	w.Header().Set(RateLimitResetHeader, strconv.FormatInt(reset, 10))
+
+	if count > r.limit.Requests {
+		w.Header().Set(RetryAfterHeader, strconv.FormatInt(reset, 10))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		message := fmt.Sprintf("rate limit of %d requests per %s exceeded", r.limit.Requests, r.limit.Window)
+		_ = json.NewEncoder(w).Encode(models.Error{Code: http.StatusTooManyRequests, Message: &message})
+		return
+	}
+	handler.ServeHTTP(w, req)
+}
+
+// counterKey returns the key under which a request is counted
+func (r *RouteRateLimiter) counterKey(req *http.Request) string {
+	operation := r.limit.Method + " " + r.limit.Path
+	prefix := fmt.Sprintf("%s/%s/", operation, r.limit.Window)
+	switch r.limit.Key {
+	case RateLimitKeyPrincipal:
+		if principal, err := r.tokenValidator.ValidateToken(req.Header.Get("x-token")); err == nil {
+			return prefix + "principal=" + PrincipalName(principal)
+		}
+	case RateLimitKeyProject:
+		// the limiter runs before the authorization of the request, so a request is only counted for its project
+		// if its token can be used for the project. Otherwise, anyone could use up the limit of any project
+		principal, err := r.tokenValidator.ValidateToken(req.Header.Get("x-token"))
+		if err != nil {
+			break
+		}
+		projectOf, ok := projectOperations[operation]
+		if !ok {
+			projectOf = queryProject
+		}
+		if project := projectOf(req); project != "" && r.allowsProject(principal, project) {
+			return prefix + "project=" + project
+		}
+	}
+	return prefix + "ip=" + getRemoteIP(req)
+}
+
+// allowsProject returns whether the principal can be used for the project. The SECRET_TOKEN can be used for all projects
+func (r *RouteRateLimiter) allowsProject(principal *models.Principal, project string) bool {
+	name, ok := managedTokenName(principal)
+	if !ok {
+		return true
+	}
+	token, err := r.tokens.Get(name)
+	if err != nil || token == nil {
+		return false
+	}
+	return token.AllowsProject(project)
+}
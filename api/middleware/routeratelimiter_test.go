@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	middleware_mock "github.com/keptn/keptn/api/middleware/fake"
+	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/tokens"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	limits, err := ParseRateLimits(`[
+		{"method": "post", "path": "/event", "key": "project", "requests": 100, "window": "1m"},
+		{"method": "GET", "path": "/export", "key": "principal", "requests": 5, "window": "1h"}
+	]`)
+	require.NoError(t, err)
+	require.Equal(t, []RateLimit{
+		{Method: http.MethodPost, Path: "/event", Key: RateLimitKeyProject, Requests: 100, Window: time.Minute},
+		{Method: http.MethodGet, Path: "/export", Key: RateLimitKeyPrincipal, Requests: 5, Window: time.Hour},
+	}, limits)
+
+	limits, err = ParseRateLimits("")
+	require.NoError(t, err)
+	require.Empty(t, limits)
+
+	invalid := []string{
+		`{"method": "POST"}`,
+		`[{"path": "/event", "key": "ip", "requests": 1, "window": "1m"}]`,
+		`[{"method": "POST", "path": "/event", "key": "stage", "requests": 1, "window": "1m"}]`,
+		`[{"method": "POST", "path": "/event", "key": "ip", "requests": 0, "window": "1m"}]`,
+		`[{"method": "POST", "path": "/event", "key": "ip", "requests": 1, "window": "10ms"}]`,
+	}
+	for _, config := range invalid {
+		_, err := ParseRateLimits(config)
+		assert.Error(t, err, config)
+	}
+}
+
+func TestRouteRateLimiter(t *testing.T) {
+	mockClock := clock.NewMock()
+	mockClock.Set(time.Date(2023, 1, 1, 12, 0, 15, 0, time.UTC))
+	tokenValidator := &middleware_mock.TokenValidatorMock{
+		ValidateTokenFunc: func(token string) (*models.Principal, error) {
+			if token == "" {
+				return nil, errors.New("incorrect api key auth")
+			}
+			principal := models.Principal("api-token:" + token)
+			return &principal, nil
+		},
+	}
+	tokenLookup := &middleware_mock.TokenLookupMock{
+		GetFunc: func(name string) (*tokens.APIToken, error) {
+			switch name {
+			case "revoked":
+				return nil, nil
+			case "podtato-ci":
+				return &tokens.APIToken{Name: name, Role: tokens.RoleTriggerOnly, Projects: []string{"podtato-head"}}, nil
+			}
+			return &tokens.APIToken{Name: name, Role: tokens.RoleAdmin}, nil
+		},
+	}
+
+	send := func(rl *RouteRateLimiter, token, ip, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/event", strings.NewReader(body))
+		req.Header.Set("x-token", token)
+		req.RemoteAddr = ip + ":1234"
+		recorder := httptest.NewRecorder()
+		rl.Apply(recorder, req, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the event can still be read by the handler
+			assert.Equal(t, body, readAll(t, r))
+			w.WriteHeader(http.StatusOK)
+		}))
+		return recorder
+	}
+
+	t.Run("limit per principal", func(t *testing.T) {
+		limit := RateLimit{Method: http.MethodPost, Path: "/event", Key: RateLimitKeyPrincipal, Requests: 2, Window: time.Minute}
+		rl := NewRouteRateLimiter(limit, NewMemoryRateLimitCounter(mockClock), tokenValidator, tokenLookup, mockClock)
+
+		resp := send(rl, "ci", "10.0.0.1", "{}")
+		require.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "2", resp.Header().Get(RateLimitLimitHeader))
+		assert.Equal(t, "1", resp.Header().Get(RateLimitRemainingHeader))
+		assert.Equal(t, "45", resp.Header().Get(RateLimitResetHeader))
+
+		// the same token from another IP shares the limit
+		require.Equal(t, http.StatusOK, send(rl, "ci", "10.0.0.2", "{}").Code)
+		resp = send(rl, "ci", "10.0.0.1", "{}")
+		require.Equal(t, http.StatusTooManyRequests, resp.Code)
+		assert.Equal(t, "0", resp.Header().Get(RateLimitRemainingHeader))
+		assert.Equal(t, "45", resp.Header().Get(RetryAfterHeader))
+		assert.Contains(t, resp.Body.String(), `"code":429`)
+
+		// other tokens are not limited
+		require.Equal(t, http.StatusOK, send(rl, "other", "10.0.0.1", "{}").Code)
+		// requests without a valid token are limited per IP
+		require.Equal(t, http.StatusOK, send(rl, "", "10.0.0.1", "{}").Code)
+	})
+
+	t.Run("limit per project", func(t *testing.T) {
+		limit := RateLimit{Method: http.MethodPost, Path: "/event", Key: RateLimitKeyProject, Requests: 1, Window: time.Minute}
+		rl := NewRouteRateLimiter(limit, NewMemoryRateLimitCounter(mockClock), tokenValidator, tokenLookup, mockClock)
+
+		sockshopEvent := `{"type": "sh.keptn.event.dev.delivery.triggered", "data": {"project": "sockshop"}}`
+		require.Equal(t, http.StatusOK, send(rl, "ci", "10.0.0.1", sockshopEvent).Code)
+		require.Equal(t, http.StatusTooManyRequests, send(rl, "other", "10.0.0.2", sockshopEvent).Code)
+
+		podtatoEvent := `{"type": "sh.keptn.event.dev.delivery.triggered", "data": {"project": "podtato-head"}}`
+		// requests whose token is invalid, revoked or cannot be used for the project are limited per IP and do not
+		// use up the limit of the project
+		require.Equal(t, http.StatusOK, send(rl, "", "10.0.0.3", podtatoEvent).Code)
+		require.Equal(t, http.StatusOK, send(rl, "revoked", "10.0.0.4", podtatoEvent).Code)
+		require.Equal(t, http.StatusOK, send(rl, "podtato-ci", "10.0.0.5", sockshopEvent).Code)
+		require.Equal(t, http.StatusTooManyRequests, send(rl, "", "10.0.0.3", podtatoEvent).Code)
+
+		require.Equal(t, http.StatusOK, send(rl, "podtato-ci", "10.0.0.1", podtatoEvent).Code)
+		require.Equal(t, http.StatusTooManyRequests, send(rl, "ci", "10.0.0.6", podtatoEvent).Code)
+	})
+
+	t.Run("limit per ip", func(t *testing.T) {
+		limit := RateLimit{Method: http.MethodPost, Path: "/event", Key: RateLimitKeyIP, Requests: 1, Window: time.Minute}
+		rl := NewRouteRateLimiter(limit, NewMemoryRateLimitCounter(mockClock), tokenValidator, tokenLookup, mockClock)
+
+		require.Equal(t, http.StatusOK, send(rl, "ci", "10.0.0.1", "{}").Code)
+		require.Equal(t, http.StatusTooManyRequests, send(rl, "other", "10.0.0.1", "{}").Code)
+		require.Equal(t, http.StatusOK, send(rl, "ci", "10.0.0.2", "{}").Code)
+
+		// requests are allowed again in the next window
+		mockClock.Add(time.Minute)
+		require.Equal(t, http.StatusOK, send(rl, "ci", "10.0.0.1", "{}").Code)
+	})
+
+	t.Run("requests are allowed if they cannot be counted", func(t *testing.T) {
+		limit := RateLimit{Method: http.MethodPost, Path: "/event", Key: RateLimitKeyIP, Requests: 1, Window: time.Minute}
+		rl := NewRouteRateLimiter(limit, failingCounter{}, tokenValidator, tokenLookup, mockClock)
+
+		require.Equal(t, http.StatusOK, send(rl, "ci", "10.0.0.1", "{}").Code)
+		require.Equal(t, http.StatusOK, send(rl, "ci", "10.0.0.1", "{}").Code)
+	})
+}
+
+type failingCounter struct{}
+
+func (failingCounter) Increment(string, time.Time, time.Duration) (int64, error) {
+	return 0, errors.New("bucket not available")
+}
+
+func readAll(t *testing.T, r *http.Request) string {
+	buf := new(strings.Builder)
+	_, err := io.Copy(buf, r.Body)
+	require.NoError(t, err)
+	return buf.String()
+}
@@ -1,8 +1,13 @@
 package middleware
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
 	openapierrors "github.com/go-openapi/errors"
 	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/tokens"
 	log "github.com/sirupsen/logrus"
 	"net/http"
 	"os"
@@ -23,3 +28,68 @@ func (b *BasicTokenValidator) ValidateToken(token string) (*models.Principal, er
 	log.Warn("Access attempt with incorrect API token")
 	return nil, openapierrors.New(http.StatusUnauthorized, "incorrect api key auth")
 }
+
+//go:generate moq -pkg middleware_mock --skip-ensure -out ./fake/tokenlookup_mock.go . tokenLookup:TokenLookupMock
+type tokenLookup interface {
+	Lookup(value string) (*tokens.APIToken, error)
+	Get(name string) (*tokens.APIToken, error)
+}
+
+// managedTokenPrincipalPrefix marks the principals of named API tokens, the principal of the SECRET_TOKEN is the
+// token itself
+const managedTokenPrincipalPrefix = "api-token:"
+
+// ManagedTokenValidator accepts the SECRET_TOKEN as well as the named API tokens that are neither revoked nor expired
+type ManagedTokenValidator struct {
+	tokens tokenLookup
+}
+
+func NewManagedTokenValidator(tokens tokenLookup) *ManagedTokenValidator {
+	return &ManagedTokenValidator{tokens: tokens}
+}
+
+func (m *ManagedTokenValidator) ValidateToken(token string) (*models.Principal, error) {
+	if token != "" && token == os.Getenv("SECRET_TOKEN") {
+		prin := models.Principal(token)
+		return &prin, nil
+	}
+	apiToken, err := m.tokens.Lookup(token)
+	if err != nil {
+		log.WithError(err).Error("Could not look up API token")
+		return nil, openapierrors.New(http.StatusInternalServerError, "could not verify api key")
+	}
+	if apiToken == nil {
+		log.Warn("Access attempt with incorrect API token")
+		return nil, openapierrors.New(http.StatusUnauthorized, "incorrect api key auth")
+	}
+	prin := models.Principal(managedTokenPrincipalPrefix + apiToken.Name)
+	return &prin, nil
+}
+
+// managedTokenName returns the name of the named API token of the given principal, or false if the principal
+// authenticated with the SECRET_TOKEN
+func managedTokenName(principal *models.Principal) (string, bool) {
+	if principal == nil {
+		return "", false
+	}
+	if !strings.HasPrefix(string(*principal), managedTokenPrincipalPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(string(*principal), managedTokenPrincipalPrefix), true
+}
+
+// PrincipalHeader is set on the response of the auth endpoint. The API gateway forwards it to the services it
+// proxies, which use it to identify the actor of a request, e.g. in audit records
+const PrincipalHeader = "X-Keptn-Principal"
+
+// PrincipalName returns an identifier for the given principal that does not reveal the API token
+func PrincipalName(principal *models.Principal) string {
+	if principal == nil {
+		return ""
+	}
+	if _, ok := managedTokenName(principal); ok {
+		return string(*principal)
+	}
+	hash := sha256.Sum256([]byte(*principal))
+	return "api-token-" + hex.EncodeToString(hash[:4])
+}
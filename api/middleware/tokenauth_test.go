@@ -1,10 +1,18 @@
 package middleware
 
 import (
+	"errors"
+	"net/http"
 	"reflect"
 	"testing"
 
+	openapierrors "github.com/go-openapi/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	middleware_mock "github.com/keptn/keptn/api/middleware/fake"
 	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/tokens"
 )
 
 func TestValidateToken(t *testing.T) {
@@ -54,3 +62,52 @@ func TestValidateToken(t *testing.T) {
 		})
 	}
 }
+
+func TestPrincipalName(t *testing.T) {
+	principal := models.Principal("my-token")
+	name := PrincipalName(&principal)
+	if name != "api-token-fece50d2" {
+		t.Errorf("PrincipalName() = %v, want api-token-fece50d2", name)
+	}
+	otherPrincipal := models.Principal("my-other-token")
+	if PrincipalName(&otherPrincipal) == name {
+		t.Errorf("PrincipalName() must differ for different tokens")
+	}
+	if PrincipalName(nil) != "" {
+		t.Errorf("PrincipalName() must be empty without principal")
+	}
+}
+
+func TestManagedTokenValidator_ValidateToken(t *testing.T) {
+	t.Setenv("SECRET_TOKEN", "my-secret-token")
+	lookup := &middleware_mock.TokenLookupMock{
+		LookupFunc: func(value string) (*tokens.APIToken, error) {
+			switch value {
+			case "my-ci-token":
+				return &tokens.APIToken{Name: "ci", Role: tokens.RoleTriggerOnly}, nil
+			case "broken":
+				return nil, errors.New("store not reachable")
+			}
+			return nil, nil
+		},
+	}
+	validator := NewManagedTokenValidator(lookup)
+
+	principal, err := validator.ValidateToken("my-secret-token")
+	require.NoError(t, err)
+	assert.Equal(t, models.Principal("my-secret-token"), *principal)
+
+	principal, err = validator.ValidateToken("my-ci-token")
+	require.NoError(t, err)
+	assert.Equal(t, models.Principal("api-token:ci"), *principal)
+	assert.Equal(t, "api-token:ci", PrincipalName(principal))
+
+	var apiErr openapierrors.Error
+	_, err = validator.ValidateToken("unknown")
+	require.ErrorAs(t, err, &apiErr)
+	assert.EqualValues(t, http.StatusUnauthorized, apiErr.Code())
+
+	_, err = validator.ValidateToken("broken")
+	require.ErrorAs(t, err, &apiErr)
+	assert.EqualValues(t, http.StatusInternalServerError, apiErr.Code())
+}
@@ -21,6 +21,9 @@ import (
 // swagger:model importSummary
 type ImportSummary struct {
 
+	// dry run
+	DryRun bool `json:"dryRun,omitempty"`
+
 	// message
 	Message string `json:"message,omitempty"`
 
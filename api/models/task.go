@@ -7,9 +7,12 @@ package models
 
 import (
 	"context"
+	"encoding/json"
 
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // Task task
@@ -17,6 +20,13 @@ import (
 // swagger:model task
 type Task struct {
 
+	// message
+	Message string `json:"message,omitempty"`
+
+	// outcome
+	// Enum: [success failure rolledBack rollbackFailed]
+	Outcome string `json:"outcome,omitempty"`
+
 	// response
 	Response interface{} `json:"response,omitempty"`
 
@@ -26,6 +36,63 @@ type Task struct {
 
 // Validate validates this task
 func (m *Task) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateOutcome(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var taskTypeOutcomePropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["success","failure","rolledBack","rollbackFailed"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		taskTypeOutcomePropEnum = append(taskTypeOutcomePropEnum, v)
+	}
+}
+
+const (
+
+	// TaskOutcomeSuccess captures enum value "success"
+	TaskOutcomeSuccess string = "success"
+
+	// TaskOutcomeFailure captures enum value "failure"
+	TaskOutcomeFailure string = "failure"
+
+	// TaskOutcomeRolledBack captures enum value "rolledBack"
+	TaskOutcomeRolledBack string = "rolledBack"
+
+	// TaskOutcomeRollbackFailed captures enum value "rollbackFailed"
+	TaskOutcomeRollbackFailed string = "rollbackFailed"
+)
+
+// prop value enum
+func (m *Task) validateOutcomeEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, taskTypeOutcomePropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *Task) validateOutcome(formats strfmt.Registry) error {
+	if swag.IsZero(m.Outcome) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateOutcomeEnum("outcome", "body", m.Outcome); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -9,16 +9,23 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/benbjohnson/clock"
 	"github.com/kelseyhightower/envconfig"
+	"github.com/nats-io/nats.go"
 	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 
+	"github.com/keptn/keptn/api/eventschema"
+	"github.com/keptn/keptn/api/exporter"
 	"github.com/keptn/keptn/api/handlers"
+	"github.com/keptn/keptn/api/idempotency"
 	"github.com/keptn/keptn/api/importer"
 	"github.com/keptn/keptn/api/importer/execute"
 	"github.com/keptn/keptn/api/importer/model"
@@ -27,8 +34,11 @@ import (
 	"github.com/keptn/keptn/api/restapi/operations"
 	"github.com/keptn/keptn/api/restapi/operations/auth"
 	"github.com/keptn/keptn/api/restapi/operations/event"
+	"github.com/keptn/keptn/api/restapi/operations/export"
 	"github.com/keptn/keptn/api/restapi/operations/import_operations"
 	"github.com/keptn/keptn/api/restapi/operations/metadata"
+	"github.com/keptn/keptn/api/restapi/operations/token"
+	"github.com/keptn/keptn/api/tokens"
 )
 
 //go:generate swagger generate server --target ../../api --name Keptn --spec ../swagger.yaml --principal models.Principal
@@ -36,16 +46,28 @@ import (
 const envVarLogLevel = "LOG_LEVEL"
 
 type EnvConfig struct {
-	HideDeprecated            bool    `envconfig:"HIDE_DEPRECATED" default:"false"`
-	ImportBasePath            string  `envconfig:"IMPORT_BASE_PATH"`
-	EventValidationEnabled    bool    `envconfig:"EVENT_VALIDATION_ENABLED" default:"true"`
-	MaxAuthEnabled            bool    `envconfig:"MAX_AUTH_ENABLED" default:"true"`
-	MaxAuthRequestsPerSecond  float64 `envconfig:"MAX_AUTH_REQUESTS_PER_SECOND" default:"1"`
-	MaxAuthRequestBurst       int     `envconfig:"MAX_AUTH_REQUESTS_BURST" default:"2"`
-	MaxImportUncompressedSize uint64  `envconfig:"MAX_IMPORT_UNCOMPRESSED_SIZE" default:"52428800"` // 50MB default value
-	MaxEventSizeKB            int64   `envconfig:"MAX_EVENT_SIZE_KB" default:"64"`
-	OAuthEnabled              bool    `envconfig:"OAUTH_ENABLED" default:"false"`
-	OAuthPrefix               string  `envconfig:"OAUTH_PREFIX" default:"keptn:"`
+	HideDeprecated               bool          `envconfig:"HIDE_DEPRECATED" default:"false"`
+	ImportBasePath               string        `envconfig:"IMPORT_BASE_PATH"`
+	EventValidationEnabled       bool          `envconfig:"EVENT_VALIDATION_ENABLED" default:"true"`
+	MaxAuthEnabled               bool          `envconfig:"MAX_AUTH_ENABLED" default:"true"`
+	MaxAuthRequestsPerSecond     float64       `envconfig:"MAX_AUTH_REQUESTS_PER_SECOND" default:"1"`
+	MaxAuthRequestBurst          int           `envconfig:"MAX_AUTH_REQUESTS_BURST" default:"2"`
+	MaxImportUncompressedSize    uint64        `envconfig:"MAX_IMPORT_UNCOMPRESSED_SIZE" default:"52428800"` // 50MB default value
+	MaxEventSizeKB               int64         `envconfig:"MAX_EVENT_SIZE_KB" default:"64"`
+	OAuthEnabled                 bool          `envconfig:"OAUTH_ENABLED" default:"false"`
+	OAuthPrefix                  string        `envconfig:"OAUTH_PREFIX" default:"keptn:"`
+	APITokensEnabled             bool          `envconfig:"API_TOKENS_ENABLED" default:"true"`
+	APITokensRefreshInterval     time.Duration `envconfig:"API_TOKENS_REFRESH_INTERVAL" default:"10s"`
+	RateLimits                   string        `envconfig:"RATE_LIMITS"`
+	RateLimitsDistributed        bool          `envconfig:"RATE_LIMITS_DISTRIBUTED" default:"false"`
+	RateLimitsBucket             string        `envconfig:"RATE_LIMITS_BUCKET" default:"keptn-api-rate-limits"`
+	NatsURL                      string        `envconfig:"NATS_URL" default:"nats://keptn-nats"`
+	EventSchemaValidationEnabled bool          `envconfig:"EVENT_SCHEMA_VALIDATION_ENABLED" default:"false"`
+	EventSchemaDir               string        `envconfig:"EVENT_SCHEMA_DIR"`
+	EventSchemaCacheTTL          time.Duration `envconfig:"EVENT_SCHEMA_CACHE_TTL" default:"1m"`
+	IdempotencyEnabled           bool          `envconfig:"IDEMPOTENCY_ENABLED" default:"false"`
+	IdempotencyWindow            time.Duration `envconfig:"IDEMPOTENCY_WINDOW" default:"10m"`
+	IdempotencyBucket            string        `envconfig:"IDEMPOTENCY_BUCKET" default:"keptn-api-idempotency-keys"`
 }
 
 // MaxEventSizeBytes returns MaxEventSizeKB in bytes
@@ -85,22 +107,41 @@ func configureAPI(api *operations.KeptnAPI) http.Handler {
 
 	api.JSONProducer = runtime.JSONProducer()
 
+	api.BinProducer = runtime.ByteStreamProducer()
+
 	// Applies when the "x-token" header is set
-	tokenValidator := &custommiddleware.BasicTokenValidator{}
+	tokenManager := tokens.NewManager(newTokenStore(env), clock.New(), env.APITokensRefreshInterval)
+	tokenValidator := custommiddleware.NewManagedTokenValidator(tokenManager)
 	api.KeyAuth = tokenValidator.ValidateToken
 
-	// Set your custom authorizer if needed. Default one is security.Authorized()
-	// Expected interface runtime.Authorizer
-	//
-	// Example:
-	// api.APIAuthorizer = security.Authorized()
+	// Restricts the operations named API tokens can be used for to their role and projects
+	api.APIAuthorizer = custommiddleware.NewTokenAuthorizer(tokenManager)
 	api.AuthAuthHandler = auth.AuthHandlerFunc(
 		func(params auth.AuthParams, principal *models.Principal) middleware.Responder {
-			return auth.NewAuthOK()
+			return middleware.ResponderFunc(func(rw http.ResponseWriter, producer runtime.Producer) {
+				rw.Header().Set(custommiddleware.PrincipalHeader, custommiddleware.PrincipalName(principal))
+				auth.NewAuthOK().WriteResponse(rw, producer)
+			})
 		},
 	)
 
-	api.EventPostEventHandler = event.PostEventHandlerFunc(handlers.PostEventHandlerFunc(env.EventValidationEnabled))
+	keptnEndpointProvider := execute.NewKeptnEndpointProviderFromEnv()
+
+	// Event endpoint, validating the data of events against the schemas of the event schema registry and not
+	// forwarding duplicates of events again
+	var idempotencyStore idempotency.Store
+	if env.IdempotencyEnabled {
+		idempotencyStore = newIdempotencyStore(env)
+	}
+	postEventHandler := handlers.PostEventHandlerFunc(env.EventValidationEnabled, nil, idempotencyStore)
+	if env.EventSchemaValidationEnabled {
+		schemaRegistry := eventschema.NewRegistry(
+			eventschema.NewResourceServiceSchemaRetriever(keptnEndpointProvider), env.EventSchemaDir,
+			env.EventSchemaCacheTTL, clock.New(),
+		)
+		postEventHandler = handlers.PostEventHandlerFunc(env.EventValidationEnabled, schemaRegistry, idempotencyStore)
+	}
+	api.EventPostEventHandler = event.PostEventHandlerFunc(postEventHandler)
 	// api.EventGetEventHandler = event.GetEventHandlerFunc(handlers.GetEventHandlerFunc)
 
 	// Metadata endpoint
@@ -109,8 +150,6 @@ func configureAPI(api *operations.KeptnAPI) http.Handler {
 	// api.EvaluationTriggerEvaluationHandler = evaluation.TriggerEvaluationHandlerFunc(handlers.TriggerEvaluationHandlerFunc)
 
 	// Import endpoint
-	keptnEndpointProvider := execute.NewKeptnEndpointProviderFromEnv()
-
 	projectChecker := handlers.NewControlPlaneProjectRetriever(keptnEndpointProvider)
 
 	importProcessor := importer.NewImportPackageProcessor(
@@ -127,6 +166,20 @@ func configureAPI(api *operations.KeptnAPI) http.Handler {
 		),
 	)
 
+	// Export endpoint
+	api.ExportExportHandler = export.ExportHandlerFunc(
+		handlers.GetExportHandlerFunc(
+			projectChecker,
+			exporter.NewProjectExporter(exporter.NewKeptnProjectDataRetriever(keptnEndpointProvider)),
+		),
+	)
+
+	// Token endpoints
+	tokensHandler := handlers.NewTokensHandler(tokenManager)
+	api.TokenCreateTokenHandler = token.CreateTokenHandlerFunc(tokensHandler.HandleCreateToken)
+	api.TokenListTokensHandler = token.ListTokensHandlerFunc(tokensHandler.HandleListTokens)
+	api.TokenRevokeTokenHandler = token.RevokeTokenHandlerFunc(tokensHandler.HandleRevokeToken)
+
 	if env.MaxAuthEnabled {
 		rateLimiter := custommiddleware.NewRateLimiter(
 			env.MaxAuthRequestsPerSecond, env.MaxAuthRequestBurst, tokenValidator, clock.New(),
@@ -134,6 +187,23 @@ func configureAPI(api *operations.KeptnAPI) http.Handler {
 		api.AddMiddlewareFor(http.MethodPost, "/auth", rateLimiter.Handle)
 	}
 
+	rateLimits, err := custommiddleware.ParseRateLimits(env.RateLimits)
+	if err != nil {
+		log.WithError(err).Error("Failed to process env var RATE_LIMITS")
+		os.Exit(1)
+	}
+	if len(rateLimits) > 0 {
+		counter := newRateLimitCounter(env, rateLimits)
+		for _, limit := range rateLimits {
+			if _, ok := api.HandlerFor(limit.Method, limit.Path); !ok {
+				log.Warnf("Ignoring rate limit %s of unknown route", limit)
+				continue
+			}
+			rateLimiter := custommiddleware.NewRouteRateLimiter(limit, counter, tokenValidator, tokenManager, clock.New())
+			api.AddMiddlewareFor(limit.Method, limit.Path, rateLimiter.Handle)
+		}
+	}
+
 	if env.EventValidationEnabled && env.MaxEventSizeKB > 0 {
 		api.AddMiddlewareFor(http.MethodPost, "/event", custommiddleware.EnforceMaxEventSize(env.MaxEventSizeBytes()))
 	}
@@ -143,6 +213,81 @@ func configureAPI(api *operations.KeptnAPI) http.Handler {
 	return setupGlobalMiddleware(api.Serve(setupMiddlewares), env)
 }
 
+// newTokenStore returns the store of the named API tokens, or nil if they are disabled or the API service does not
+// run in a cluster. Then only the SECRET_TOKEN is accepted
+func newTokenStore(env *EnvConfig) tokens.Store {
+	if !env.APITokensEnabled {
+		return nil
+	}
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		log.WithError(err).Warn("Could not get InClusterConfig, named API tokens are not available")
+		return nil
+	}
+	clientSet, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		log.WithError(err).Warn("Could not create kubernetes client, named API tokens are not available")
+		return nil
+	}
+	return tokens.NewKubernetesStore(clientSet, os.Getenv("POD_NAMESPACE"))
+}
+
+// newRateLimitCounter returns the counter shared by all replicas of the API service in distributed mode, otherwise
+// or if NATS is not available the counter of this replica
+func newRateLimitCounter(env *EnvConfig, limits []custommiddleware.RateLimit) custommiddleware.RateLimitCounter {
+	if !env.RateLimitsDistributed {
+		return custommiddleware.NewMemoryRateLimitCounter(clock.New())
+	}
+
+	var ttl time.Duration
+	for _, limit := range limits {
+		if limit.Window > ttl {
+			ttl = limit.Window
+		}
+	}
+	counter, err := func() (custommiddleware.RateLimitCounter, error) {
+		js, err := newJetStreamContext(env)
+		if err != nil {
+			return nil, err
+		}
+		return custommiddleware.NewNatsRateLimitCounter(js, env.RateLimitsBucket, ttl)
+	}()
+	if err != nil {
+		log.WithError(err).Warn("Rate limits are counted per replica of the API service")
+		return custommiddleware.NewMemoryRateLimitCounter(clock.New())
+	}
+	return counter
+}
+
+// newIdempotencyStore returns the store shared by all replicas of the API service, or the store of this replica if
+// NATS is not available
+func newIdempotencyStore(env *EnvConfig) idempotency.Store {
+	store, err := func() (idempotency.Store, error) {
+		js, err := newJetStreamContext(env)
+		if err != nil {
+			return nil, err
+		}
+		return idempotency.NewNatsStore(js, env.IdempotencyBucket, env.IdempotencyWindow)
+	}()
+	if err != nil {
+		log.WithError(err).Warn("Duplicates of events are only detected per replica of the API service")
+		return idempotency.NewMemoryStore(env.IdempotencyWindow, clock.New())
+	}
+	return store
+}
+
+func newJetStreamContext(env *EnvConfig) (nats.JetStreamContext, error) {
+	conn, err := nats.Connect(env.NatsURL, nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to NATS: %w", err)
+	}
+	js, err := conn.JetStream()
+	if err != nil {
+		return nil, fmt.Errorf("could not create JetStream context: %w", err)
+	}
+	return js, nil
+}
+
 // The TLS configuration before HTTPS server starts.
 func configureTLS(tlsConfig *tls.Config) {
 	// Make all necessary changes to the TLS configuration here.
@@ -60,6 +60,13 @@ func init() {
             "schema": {
               "$ref": "#/definitions/keptnContextExtendedCE"
             }
+          },
+          {
+            "maxLength": 255,
+            "type": "string",
+            "description": "Key identifying retries of the same event. Within the idempotency window, events with a known key are not forwarded again, but the keptn context of the original event is returned. Defaults to the source and id of the event",
+            "name": "Idempotency-Key",
+            "in": "header"
           }
         ],
         "responses": {
@@ -84,6 +91,54 @@ func init() {
         }
       }
     },
+    "/export": {
+      "get": {
+        "description": "Export the services, secrets, webhook subscriptions and resources of a project as a zip package that can be imported into another project  \n\u003cspan class=\"oauth-scopes\"\u003eRequired OAuth scopes: ${prefix}:integrations:read,${prefix}:projects:read,${prefix}:services:read,${prefix}:resources:read,${prefix}:secrets:read\u003c/span\u003e",
+        "produces": [
+          "application/octet-stream"
+        ],
+        "tags": [
+          "Export"
+        ],
+        "summary": "Export a project as zip package",
+        "operationId": "export",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The project which should be exported",
+            "name": "project",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "file"
+            },
+            "headers": {
+              "Content-Disposition": {
+                "type": "string",
+                "description": "Attachment containing the file name of the export package"
+              }
+            }
+          },
+          "404": {
+            "description": "Project not found",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "424": {
+            "description": "Failed Dependency",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/import": {
       "post": {
         "description": "Import a zip package to create services, secrets and webhook subscriptions, as well as add resources to Keptn",
@@ -106,6 +161,12 @@ func init() {
             "in": "formData",
             "required": true
           },
+          {
+            "type": "boolean",
+            "description": "Validate the package and check that all its tasks can be executed without applying any changes",
+            "name": "dryRun",
+            "in": "query"
+          },
           {
             "type": "string",
             "description": "The project on which the configuration should be applied",
@@ -139,6 +200,12 @@ func init() {
               "$ref": "#/definitions/error"
             }
           },
+          "422": {
+            "description": "Import failed, the changes of the executed tasks have been rolled back",
+            "schema": {
+              "$ref": "#/definitions/importSummary"
+            }
+          },
           "424": {
             "description": "Failed Dependency",
             "schema": {
@@ -165,9 +232,194 @@ func init() {
           }
         }
       }
+    },
+  "/tokens": {
+    "get": {
+      "description": "List the named API tokens. The values of the tokens are not returned  \nRequires a token with role admin that is not scoped to projects",
+      "tags": [
+        "Token"
+      ],
+      "summary": "List the API tokens",
+      "operationId": "listTokens",
+      "responses": {
+        "200": {
+          "description": "OK",
+          "schema": {
+            "$ref": "#/definitions/apiTokens"
+          }
+        },
+        "424": {
+          "description": "Failed Dependency",
+          "schema": {
+            "$ref": "#/definitions/error"
+          }
+        }
+      }
+    },
+    "post": {
+      "description": "Create a named API token with a role and optionally a list of projects it can be used for. The value of the token is only returned in this response  \nRequires a token with role admin that is not scoped to projects",
+      "tags": [
+        "Token"
+      ],
+      "summary": "Create an API token",
+      "operationId": "createToken",
+      "parameters": [
+        {
+          "name": "body",
+          "in": "body",
+          "required": true,
+          "schema": {
+            "$ref": "#/definitions/createTokenRequest"
+          }
+        }
+      ],
+      "responses": {
+        "201": {
+          "description": "Created",
+          "schema": {
+            "$ref": "#/definitions/apiToken"
+          }
+        },
+        "400": {
+          "description": "Invalid token",
+          "schema": {
+            "$ref": "#/definitions/error"
+          }
+        },
+        "409": {
+          "description": "Token already exists",
+          "schema": {
+            "$ref": "#/definitions/error"
+          }
+        },
+        "424": {
+          "description": "Failed Dependency",
+          "schema": {
+            "$ref": "#/definitions/error"
+          }
+        }
+      }
+    }
+  },
+  "/tokens/{tokenName}": {
+    "delete": {
+      "description": "Revoke a named API token  \nRequires a token with role admin that is not scoped to projects",
+      "tags": [
+        "Token"
+      ],
+      "summary": "Revoke an API token",
+      "operationId": "revokeToken",
+      "parameters": [
+        {
+          "type": "string",
+          "description": "The name of the token",
+          "name": "tokenName",
+          "in": "path",
+          "required": true
+        }
+      ],
+      "responses": {
+        "204": {
+          "description": "Revoked"
+        },
+        "404": {
+          "description": "Token not found",
+          "schema": {
+            "$ref": "#/definitions/error"
+          }
+        },
+        "424": {
+          "description": "Failed Dependency",
+          "schema": {
+            "$ref": "#/definitions/error"
+          }
+        }
+      }
     }
+  }
   },
   "definitions": {
+  "apiToken": {
+    "type": "object",
+    "required": [
+      "name",
+      "role"
+    ],
+    "properties": {
+      "createdAt": {
+        "type": "string",
+        "format": "date-time"
+      },
+      "expiresAt": {
+        "type": "string",
+        "format": "date-time"
+      },
+      "name": {
+        "type": "string"
+      },
+      "projects": {
+        "type": "array",
+        "items": {
+          "type": "string"
+        }
+      },
+      "role": {
+        "type": "string",
+        "enum": [
+          "read-only",
+          "trigger-only",
+          "admin"
+        ]
+      },
+      "token": {
+        "description": "The value of the token, only returned when the token is created",
+        "type": "string"
+      }
+    }
+  },
+  "apiTokens": {
+    "type": "object",
+    "properties": {
+      "tokens": {
+        "type": "array",
+        "items": {
+          "$ref": "#/definitions/apiToken"
+        }
+      }
+    }
+  },
+  "createTokenRequest": {
+    "type": "object",
+    "required": [
+      "name",
+      "role"
+    ],
+    "properties": {
+      "expiresAt": {
+        "type": "string",
+        "format": "date-time"
+      },
+      "name": {
+        "type": "string",
+        "pattern": "^[a-z0-9]([-a-z0-9]{0,38}[a-z0-9])?$"
+      },
+      "projects": {
+        "description": "The projects the token can be used for, all projects if empty",
+        "type": "array",
+        "items": {
+          "type": "string"
+        }
+      },
+      "role": {
+        "type": "string",
+        "enum": [
+          "read-only",
+          "trigger-only",
+          "admin"
+        ]
+      }
+    }
+  },
     "error": {
       "type": "object",
       "required": [
@@ -200,6 +452,9 @@ func init() {
     "importSummary": {
       "type": "object",
       "properties": {
+        "dryRun": {
+          "type": "boolean"
+        },
         "message": {
           "type": "string"
         },
@@ -298,6 +553,18 @@ func init() {
     "task": {
       "type": "object",
       "properties": {
+        "message": {
+          "type": "string"
+        },
+        "outcome": {
+          "type": "string",
+          "enum": [
+            "success",
+            "failure",
+            "rolledBack",
+            "rollbackFailed"
+          ]
+        },
         "response": {
           "type": "object"
         },
@@ -363,6 +630,13 @@ func init() {
             "schema": {
               "$ref": "#/definitions/keptnContextExtendedCE"
             }
+          },
+          {
+            "maxLength": 255,
+            "type": "string",
+            "description": "Key identifying retries of the same event. Within the idempotency window, events with a known key are not forwarded again, but the keptn context of the original event is returned. Defaults to the source and id of the event",
+            "name": "Idempotency-Key",
+            "in": "header"
           }
         ],
         "responses": {
@@ -387,6 +661,54 @@ func init() {
         }
       }
     },
+    "/export": {
+      "get": {
+        "description": "Export the services, secrets, webhook subscriptions and resources of a project as a zip package that can be imported into another project  \n\u003cspan class=\"oauth-scopes\"\u003eRequired OAuth scopes: ${prefix}:integrations:read,${prefix}:projects:read,${prefix}:services:read,${prefix}:resources:read,${prefix}:secrets:read\u003c/span\u003e",
+        "produces": [
+          "application/octet-stream"
+        ],
+        "tags": [
+          "Export"
+        ],
+        "summary": "Export a project as zip package",
+        "operationId": "export",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The project which should be exported",
+            "name": "project",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "file"
+            },
+            "headers": {
+              "Content-Disposition": {
+                "type": "string",
+                "description": "Attachment containing the file name of the export package"
+              }
+            }
+          },
+          "404": {
+            "description": "Project not found",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "424": {
+            "description": "Failed Dependency",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/import": {
       "post": {
         "description": "Import a zip package to create services, secrets and webhook subscriptions, as well as add resources to Keptn",
@@ -409,6 +731,12 @@ func init() {
             "in": "formData",
             "required": true
           },
+          {
+            "type": "boolean",
+            "description": "Validate the package and check that all its tasks can be executed without applying any changes",
+            "name": "dryRun",
+            "in": "query"
+          },
           {
             "type": "string",
             "description": "The project on which the configuration should be applied",
@@ -442,6 +770,12 @@ func init() {
               "$ref": "#/definitions/error"
             }
           },
+          "422": {
+            "description": "Import failed, the changes of the executed tasks have been rolled back",
+            "schema": {
+              "$ref": "#/definitions/importSummary"
+            }
+          },
           "424": {
             "description": "Failed Dependency",
             "schema": {
@@ -468,9 +802,194 @@ func init() {
           }
         }
       }
+    },
+  "/tokens": {
+    "get": {
+      "description": "List the named API tokens. The values of the tokens are not returned  \nRequires a token with role admin that is not scoped to projects",
+      "tags": [
+        "Token"
+      ],
+      "summary": "List the API tokens",
+      "operationId": "listTokens",
+      "responses": {
+        "200": {
+          "description": "OK",
+          "schema": {
+            "$ref": "#/definitions/apiTokens"
+          }
+        },
+        "424": {
+          "description": "Failed Dependency",
+          "schema": {
+            "$ref": "#/definitions/error"
+          }
+        }
+      }
+    },
+    "post": {
+      "description": "Create a named API token with a role and optionally a list of projects it can be used for. The value of the token is only returned in this response  \nRequires a token with role admin that is not scoped to projects",
+      "tags": [
+        "Token"
+      ],
+      "summary": "Create an API token",
+      "operationId": "createToken",
+      "parameters": [
+        {
+          "name": "body",
+          "in": "body",
+          "required": true,
+          "schema": {
+            "$ref": "#/definitions/createTokenRequest"
+          }
+        }
+      ],
+      "responses": {
+        "201": {
+          "description": "Created",
+          "schema": {
+            "$ref": "#/definitions/apiToken"
+          }
+        },
+        "400": {
+          "description": "Invalid token",
+          "schema": {
+            "$ref": "#/definitions/error"
+          }
+        },
+        "409": {
+          "description": "Token already exists",
+          "schema": {
+            "$ref": "#/definitions/error"
+          }
+        },
+        "424": {
+          "description": "Failed Dependency",
+          "schema": {
+            "$ref": "#/definitions/error"
+          }
+        }
+      }
+    }
+  },
+  "/tokens/{tokenName}": {
+    "delete": {
+      "description": "Revoke a named API token  \nRequires a token with role admin that is not scoped to projects",
+      "tags": [
+        "Token"
+      ],
+      "summary": "Revoke an API token",
+      "operationId": "revokeToken",
+      "parameters": [
+        {
+          "type": "string",
+          "description": "The name of the token",
+          "name": "tokenName",
+          "in": "path",
+          "required": true
+        }
+      ],
+      "responses": {
+        "204": {
+          "description": "Revoked"
+        },
+        "404": {
+          "description": "Token not found",
+          "schema": {
+            "$ref": "#/definitions/error"
+          }
+        },
+        "424": {
+          "description": "Failed Dependency",
+          "schema": {
+            "$ref": "#/definitions/error"
+          }
+        }
+      }
     }
+  }
   },
   "definitions": {
+  "apiToken": {
+    "type": "object",
+    "required": [
+      "name",
+      "role"
+    ],
+    "properties": {
+      "createdAt": {
+        "type": "string",
+        "format": "date-time"
+      },
+      "expiresAt": {
+        "type": "string",
+        "format": "date-time"
+      },
+      "name": {
+        "type": "string"
+      },
+      "projects": {
+        "type": "array",
+        "items": {
+          "type": "string"
+        }
+      },
+      "role": {
+        "type": "string",
+        "enum": [
+          "read-only",
+          "trigger-only",
+          "admin"
+        ]
+      },
+      "token": {
+        "description": "The value of the token, only returned when the token is created",
+        "type": "string"
+      }
+    }
+  },
+  "apiTokens": {
+    "type": "object",
+    "properties": {
+      "tokens": {
+        "type": "array",
+        "items": {
+          "$ref": "#/definitions/apiToken"
+        }
+      }
+    }
+  },
+  "createTokenRequest": {
+    "type": "object",
+    "required": [
+      "name",
+      "role"
+    ],
+    "properties": {
+      "expiresAt": {
+        "type": "string",
+        "format": "date-time"
+      },
+      "name": {
+        "type": "string",
+        "pattern": "^[a-z0-9]([-a-z0-9]{0,38}[a-z0-9])?$"
+      },
+      "projects": {
+        "description": "The projects the token can be used for, all projects if empty",
+        "type": "array",
+        "items": {
+          "type": "string"
+        }
+      },
+      "role": {
+        "type": "string",
+        "enum": [
+          "read-only",
+          "trigger-only",
+          "admin"
+        ]
+      }
+    }
+  },
     "error": {
       "type": "object",
       "required": [
@@ -503,6 +1022,9 @@ func init() {
     "importSummary": {
       "type": "object",
       "properties": {
+        "dryRun": {
+          "type": "boolean"
+        },
         "message": {
           "type": "string"
         },
@@ -601,6 +1123,18 @@ func init() {
     "task": {
       "type": "object",
       "properties": {
+        "message": {
+          "type": "string"
+        },
+        "outcome": {
+          "type": "string",
+          "enum": [
+            "success",
+            "failure",
+            "rolledBack",
+            "rollbackFailed"
+          ]
+        },
         "response": {
           "type": "object"
         },
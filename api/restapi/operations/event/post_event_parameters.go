@@ -12,6 +12,7 @@ import (
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/validate"
 
 	"github.com/keptn/keptn/api/models"
@@ -38,6 +39,11 @@ type PostEventParams struct {
 	  In: body
 	*/
 	Body *models.KeptnContextExtendedCE
+	/*Key identifying retries of the same event. Within the idempotency window, events with a known key are not forwarded again, but the keptn context of the original event is returned. Defaults to the source and id of the event
+	  In: header
+	  Max Length: 255
+	*/
+	IdempotencyKey *string
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -70,8 +76,43 @@ func (o *PostEventParams) BindRequest(r *http.Request, route *middleware.Matched
 			}
 		}
 	}
+
+	if err := o.bindIdempotencyKey(r.Header[http.CanonicalHeaderKey("Idempotency-Key")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
+
+// bindIdempotencyKey binds and validates parameter IdempotencyKey from header.
+func (o *PostEventParams) bindIdempotencyKey(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.IdempotencyKey = &raw
+
+	if err := o.validateIdempotencyKey(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateIdempotencyKey carries on validations for parameter IdempotencyKey
+func (o *PostEventParams) validateIdempotencyKey(formats strfmt.Registry) error {
+
+	if err := validate.MaxLength("Idempotency-Key", "header", *o.IdempotencyKey, 255); err != nil {
+		return err
+	}
+
+	return nil
+}
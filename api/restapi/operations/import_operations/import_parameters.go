@@ -14,6 +14,7 @@ import (
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/go-openapi/validate"
 )
 
@@ -46,6 +47,10 @@ type ImportParams struct {
 	  In: formData
 	*/
 	ConfigPackage io.ReadCloser
+	/*Validate the package and check that all its tasks can be executed without applying any changes
+	  In: query
+	*/
+	DryRun *bool
 	/*The project on which the configuration should be applied
 	  Required: true
 	  In: query
@@ -82,6 +87,11 @@ func (o *ImportParams) BindRequest(r *http.Request, route *middleware.MatchedRou
 		o.ConfigPackage = &runtime.File{Data: configPackage, Header: configPackageHeader}
 	}
 
+	qDryRun, qhkDryRun, _ := qs.GetOK("dryRun")
+	if err := o.bindDryRun(qDryRun, qhkDryRun, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qProject, qhkProject, _ := qs.GetOK("project")
 	if err := o.bindProject(qProject, qhkProject, route.Formats); err != nil {
 		res = append(res, err)
@@ -99,6 +109,29 @@ func (o *ImportParams) bindConfigPackage(file multipart.File, header *multipart.
 	return nil
 }
 
+// bindDryRun binds and validates parameter DryRun from query.
+func (o *ImportParams) bindDryRun(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("dryRun", "query", "bool", raw)
+	}
+	o.DryRun = &value
+
+	return nil
+}
+
 // bindProject binds and validates parameter Project from query.
 func (o *ImportParams) bindProject(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	if !hasKey {
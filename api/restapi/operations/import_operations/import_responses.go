@@ -189,6 +189,50 @@ func (o *ImportUnsupportedMediaType) WriteResponse(rw http.ResponseWriter, produ
 	}
 }
 
+// ImportUnprocessableEntityCode is the HTTP code returned for type ImportUnprocessableEntity
+const ImportUnprocessableEntityCode int = 422
+
+/*ImportUnprocessableEntity Import failed, the changes of the executed tasks have been rolled back
+
+swagger:response importUnprocessableEntity
+*/
+type ImportUnprocessableEntity struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ImportSummary `json:"body,omitempty"`
+}
+
+// NewImportUnprocessableEntity creates ImportUnprocessableEntity with default headers values
+func NewImportUnprocessableEntity() *ImportUnprocessableEntity {
+
+	return &ImportUnprocessableEntity{}
+}
+
+// WithPayload adds the payload to the import unprocessable entity response
+func (o *ImportUnprocessableEntity) WithPayload(payload *models.ImportSummary) *ImportUnprocessableEntity {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the import unprocessable entity response
+func (o *ImportUnprocessableEntity) SetPayload(payload *models.ImportSummary) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ImportUnprocessableEntity) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(422)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // ImportFailedDependencyCode is the HTTP code returned for type ImportFailedDependency
 const ImportFailedDependencyCode int = 424
 
@@ -9,10 +9,13 @@ import (
 	"errors"
 	"net/url"
 	golangswaggerpaths "path"
+
+	"github.com/go-openapi/swag"
 )
 
 // ImportURL generates an URL for the import operation
 type ImportURL struct {
+	DryRun  *bool
 	Project string
 
 	_basePath string
@@ -49,6 +52,14 @@ func (o *ImportURL) Build() (*url.URL, error) {
 
 	qs := make(url.Values)
 
+	var dryRunQ string
+	if o.DryRun != nil {
+		dryRunQ = swag.FormatBool(*o.DryRun)
+	}
+	if dryRunQ != "" {
+		qs.Set("dryRun", dryRunQ)
+	}
+
 	projectQ := o.Project
 	if projectQ != "" {
 		qs.Set("project", projectQ)
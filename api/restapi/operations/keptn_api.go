@@ -22,8 +22,10 @@ import (
 	"github.com/keptn/keptn/api/models"
 	"github.com/keptn/keptn/api/restapi/operations/auth"
 	"github.com/keptn/keptn/api/restapi/operations/event"
+	"github.com/keptn/keptn/api/restapi/operations/export"
 	"github.com/keptn/keptn/api/restapi/operations/import_operations"
 	"github.com/keptn/keptn/api/restapi/operations/metadata"
+	"github.com/keptn/keptn/api/restapi/operations/token"
 )
 
 // NewKeptnAPI creates a new Keptn instance
@@ -47,6 +49,7 @@ func NewKeptnAPI(spec *loads.Document) *KeptnAPI {
 		JSONConsumer:          runtime.JSONConsumer(),
 		MultipartformConsumer: runtime.DiscardConsumer,
 
+		BinProducer:  runtime.ByteStreamProducer(),
 		JSONProducer: runtime.JSONProducer(),
 
 		EventPostEventHandler: event.PostEventHandlerFunc(func(params event.PostEventParams, principal *models.Principal) middleware.Responder {
@@ -58,9 +61,21 @@ func NewKeptnAPI(spec *loads.Document) *KeptnAPI {
 		ImportOperationsImportHandler: import_operations.ImportHandlerFunc(func(params import_operations.ImportParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation import_operations.Import has not yet been implemented")
 		}),
+		ExportExportHandler: export.ExportHandlerFunc(func(params export.ExportParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation export.Export has not yet been implemented")
+		}),
 		MetadataMetadataHandler: metadata.MetadataHandlerFunc(func(params metadata.MetadataParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation metadata.Metadata has not yet been implemented")
 		}),
+		TokenCreateTokenHandler: token.CreateTokenHandlerFunc(func(params token.CreateTokenParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation token.CreateToken has not yet been implemented")
+		}),
+		TokenListTokensHandler: token.ListTokensHandlerFunc(func(params token.ListTokensParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation token.ListTokens has not yet been implemented")
+		}),
+		TokenRevokeTokenHandler: token.RevokeTokenHandlerFunc(func(params token.RevokeTokenParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation token.RevokeToken has not yet been implemented")
+		}),
 
 		// Applies when the "x-token" header is set
 		KeyAuth: func(token string) (*models.Principal, error) {
@@ -104,6 +119,9 @@ type KeptnAPI struct {
 	//   - multipart/form-data
 	MultipartformConsumer runtime.Consumer
 
+	// BinProducer registers a producer for the following mime types:
+	//   - application/octet-stream
+	BinProducer runtime.Producer
 	// JSONProducer registers a producer for the following mime types:
 	//   - application/json
 	JSONProducer runtime.Producer
@@ -121,8 +139,16 @@ type KeptnAPI struct {
 	AuthAuthHandler auth.AuthHandler
 	// ImportOperationsImportHandler sets the operation handler for the import operation
 	ImportOperationsImportHandler import_operations.ImportHandler
+	// ExportExportHandler sets the operation handler for the export operation
+	ExportExportHandler export.ExportHandler
 	// MetadataMetadataHandler sets the operation handler for the metadata operation
 	MetadataMetadataHandler metadata.MetadataHandler
+	// TokenCreateTokenHandler sets the operation handler for the create token operation
+	TokenCreateTokenHandler token.CreateTokenHandler
+	// TokenListTokensHandler sets the operation handler for the list tokens operation
+	TokenListTokensHandler token.ListTokensHandler
+	// TokenRevokeTokenHandler sets the operation handler for the revoke token operation
+	TokenRevokeTokenHandler token.RevokeTokenHandler
 
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
@@ -199,6 +225,9 @@ func (o *KeptnAPI) Validate() error {
 		unregistered = append(unregistered, "MultipartformConsumer")
 	}
 
+	if o.BinProducer == nil {
+		unregistered = append(unregistered, "BinProducer")
+	}
 	if o.JSONProducer == nil {
 		unregistered = append(unregistered, "JSONProducer")
 	}
@@ -216,9 +245,21 @@ func (o *KeptnAPI) Validate() error {
 	if o.ImportOperationsImportHandler == nil {
 		unregistered = append(unregistered, "import_operations.ImportHandler")
 	}
+	if o.ExportExportHandler == nil {
+		unregistered = append(unregistered, "export.ExportHandler")
+	}
 	if o.MetadataMetadataHandler == nil {
 		unregistered = append(unregistered, "metadata.MetadataHandler")
 	}
+	if o.TokenCreateTokenHandler == nil {
+		unregistered = append(unregistered, "token.CreateTokenHandler")
+	}
+	if o.TokenListTokensHandler == nil {
+		unregistered = append(unregistered, "token.ListTokensHandler")
+	}
+	if o.TokenRevokeTokenHandler == nil {
+		unregistered = append(unregistered, "token.RevokeTokenHandler")
+	}
 
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
@@ -280,6 +321,8 @@ func (o *KeptnAPI) ProducersFor(mediaTypes []string) map[string]runtime.Producer
 	result := make(map[string]runtime.Producer, len(mediaTypes))
 	for _, mt := range mediaTypes {
 		switch mt {
+		case "application/octet-stream":
+			result["application/octet-stream"] = o.BinProducer
 		case "application/json":
 			result["application/json"] = o.JSONProducer
 		}
@@ -337,7 +380,23 @@ func (o *KeptnAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/export"] = export.NewExport(o.context, o.ExportExportHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/metadata"] = metadata.NewMetadata(o.context, o.MetadataMetadataHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/tokens"] = token.NewCreateToken(o.context, o.TokenCreateTokenHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/tokens"] = token.NewListTokens(o.context, o.TokenListTokensHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/tokens/{tokenName}"] = token.NewRevokeToken(o.context, o.TokenRevokeTokenHandler)
 }
 
 // Serve creates a http handler to serve the API over HTTP
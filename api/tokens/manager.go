@@ -0,0 +1,157 @@
+package tokens
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"golang.org/x/exp/slices"
+)
+
+var /*const*/ ErrInvalidToken = errors.New("invalid token")
+var /*const*/ ErrStoreUnavailable = errors.New("token store not available")
+
+const tokenValueBytes = 32
+
+var tokenNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,38}[a-z0-9])?$`)
+
+// Manager creates, revokes and looks up the named API tokens. Tokens are cached and reloaded from the store after
+// the refresh interval, so that tokens created or revoked by other replicas of the API service are picked up
+type Manager struct {
+	store           Store
+	theClock        clock.Clock
+	refreshInterval time.Duration
+	tokens          []APIToken
+	lastRefresh     time.Time
+	mutex           sync.Mutex
+}
+
+// NewManager creates a Manager for the tokens of the given store. Without a store, only lookups are possible and no
+// token is ever found
+func NewManager(store Store, theClock clock.Clock, refreshInterval time.Duration) *Manager {
+	return &Manager{
+		store:           store,
+		theClock:        theClock,
+		refreshInterval: refreshInterval,
+	}
+}
+
+// Create creates a token and returns it together with its value. The value is not stored and cannot be retrieved later
+func (m *Manager) Create(name string, role Role, projects []string, expiresAt *time.Time) (APIToken, string, error) {
+	if m.store == nil {
+		return APIToken{}, "", ErrStoreUnavailable
+	}
+	now := m.theClock.Now().UTC()
+	if !tokenNamePattern.MatchString(name) {
+		return APIToken{}, "", fmt.Errorf(
+			"%w: name must consist of at most 40 lower case alphanumeric characters or '-'", ErrInvalidToken,
+		)
+	}
+	if !slices.Contains(AllRoles, role) {
+		return APIToken{}, "", fmt.Errorf("%w: unknown role %s", ErrInvalidToken, role)
+	}
+	if expiresAt != nil && !expiresAt.After(now) {
+		return APIToken{}, "", fmt.Errorf("%w: expiry must be in the future", ErrInvalidToken)
+	}
+
+	value, err := newTokenValue()
+	if err != nil {
+		return APIToken{}, "", fmt.Errorf("could not generate token: %w", err)
+	}
+	token := APIToken{
+		Name:      name,
+		Role:      role,
+		Projects:  projects,
+		Hash:      HashToken(value),
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	}
+	if err := m.store.Create(token); err != nil {
+		return APIToken{}, "", err
+	}
+
+	m.invalidate()
+	return token, value, nil
+}
+
+// Revoke deletes the token with the given name
+func (m *Manager) Revoke(name string) error {
+	if m.store == nil {
+		return ErrStoreUnavailable
+	}
+	if err := m.store.Delete(name); err != nil {
+		return err
+	}
+	m.invalidate()
+	return nil
+}
+
+// List returns all tokens, including expired ones
+func (m *Manager) List() ([]APIToken, error) {
+	if m.store == nil {
+		return nil, ErrStoreUnavailable
+	}
+	return m.store.List()
+}
+
+// Lookup returns the token with the given value, or nil if there is no such token or it is expired
+func (m *Manager) Lookup(value string) (*APIToken, error) {
+	hash := HashToken(value)
+	return m.find(func(token APIToken) bool {
+		return token.Hash == hash
+	})
+}
+
+// Get returns the token with the given name, or nil if there is no such token or it is expired
+func (m *Manager) Get(name string) (*APIToken, error) {
+	return m.find(func(token APIToken) bool {
+		return token.Name == name
+	})
+}
+
+func (m *Manager) find(matches func(token APIToken) bool) (*APIToken, error) {
+	if m.store == nil {
+		return nil, nil
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := m.theClock.Now()
+	if m.tokens == nil || now.Sub(m.lastRefresh) >= m.refreshInterval {
+		tokens, err := m.store.List()
+		if err != nil {
+			return nil, err
+		}
+		m.tokens = append([]APIToken{}, tokens...)
+		m.lastRefresh = now
+	}
+
+	for _, token := range m.tokens {
+		if matches(token) {
+			if token.Expired(now) {
+				return nil, nil
+			}
+			return &token, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *Manager) invalidate() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.tokens = nil
+}
+
+func newTokenValue() (string, error) {
+	value := make([]byte, tokenValueBytes)
+	if _, err := rand.Read(value); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(value), nil
+}
@@ -0,0 +1,150 @@
+package tokens
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore keeps tokens in memory and counts the calls of List
+type memoryStore struct {
+	tokens    []APIToken
+	listCalls int
+	listErr   error
+}
+
+func (s *memoryStore) Create(token APIToken) error {
+	for _, t := range s.tokens {
+		if t.Name == token.Name {
+			return ErrTokenAlreadyExists
+		}
+	}
+	s.tokens = append(s.tokens, token)
+	return nil
+}
+
+func (s *memoryStore) Delete(name string) error {
+	for i, t := range s.tokens {
+		if t.Name == name {
+			s.tokens = append(s.tokens[:i], s.tokens[i+1:]...)
+			return nil
+		}
+	}
+	return ErrTokenNotFound
+}
+
+func (s *memoryStore) List() ([]APIToken, error) {
+	s.listCalls++
+	return s.tokens, s.listErr
+}
+
+func TestManager_Create(t *testing.T) {
+	theClock := clock.NewMock()
+	theClock.Set(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	past := theClock.Now().Add(-time.Hour)
+	future := theClock.Now().Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		tokenName string
+		role      Role
+		expiresAt *time.Time
+		wantErr   error
+	}{
+		{name: "valid token", tokenName: "ci-pipeline", role: RoleTriggerOnly, expiresAt: &future},
+		{name: "invalid name", tokenName: "CI Pipeline", role: RoleTriggerOnly, wantErr: ErrInvalidToken},
+		{name: "unknown role", tokenName: "ci-pipeline", role: "owner", wantErr: ErrInvalidToken},
+		{name: "expiry in the past", tokenName: "ci-pipeline", role: RoleAdmin, expiresAt: &past, wantErr: ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &memoryStore{}
+			m := NewManager(store, theClock, time.Minute)
+
+			token, value, err := m.Create(tt.tokenName, tt.role, []string{"sockshop"}, tt.expiresAt)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, store.tokens)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, value)
+			assert.Equal(t, HashToken(value), token.Hash)
+			assert.Equal(t, theClock.Now(), token.CreatedAt)
+			assert.Equal(t, []APIToken{token}, store.tokens)
+		})
+	}
+}
+
+func TestManager_Lookup(t *testing.T) {
+	theClock := clock.NewMock()
+	store := &memoryStore{}
+	m := NewManager(store, theClock, time.Minute)
+
+	expiry := theClock.Now().Add(time.Hour)
+	_, value, err := m.Create("ci-pipeline", RoleTriggerOnly, nil, &expiry)
+	require.NoError(t, err)
+
+	token, err := m.Lookup(value)
+	require.NoError(t, err)
+	require.NotNil(t, token)
+	assert.Equal(t, "ci-pipeline", token.Name)
+
+	token, err = m.Lookup("unknown")
+	require.NoError(t, err)
+	assert.Nil(t, token)
+
+	// tokens are cached until the refresh interval has passed
+	token, err = m.Get("ci-pipeline")
+	require.NoError(t, err)
+	assert.NotNil(t, token)
+	assert.Equal(t, 1, store.listCalls)
+
+	theClock.Add(time.Minute)
+	_, err = m.Get("ci-pipeline")
+	require.NoError(t, err)
+	assert.Equal(t, 2, store.listCalls)
+
+	// expired tokens are not found
+	theClock.Add(time.Hour)
+	token, err = m.Lookup(value)
+	require.NoError(t, err)
+	assert.Nil(t, token)
+}
+
+func TestManager_Revoke(t *testing.T) {
+	m := NewManager(&memoryStore{}, clock.NewMock(), time.Hour)
+
+	_, value, err := m.Create("ci-pipeline", RoleAdmin, nil, nil)
+	require.NoError(t, err)
+	token, err := m.Lookup(value)
+	require.NoError(t, err)
+	require.NotNil(t, token)
+
+	require.NoError(t, m.Revoke("ci-pipeline"))
+	token, err = m.Lookup(value)
+	require.NoError(t, err)
+	assert.Nil(t, token)
+
+	assert.ErrorIs(t, m.Revoke("ci-pipeline"), ErrTokenNotFound)
+}
+
+func TestManager_StoreErrors(t *testing.T) {
+	m := NewManager(nil, clock.NewMock(), time.Minute)
+	_, _, err := m.Create("ci-pipeline", RoleAdmin, nil, nil)
+	assert.ErrorIs(t, err, ErrStoreUnavailable)
+	_, err = m.List()
+	assert.ErrorIs(t, err, ErrStoreUnavailable)
+	token, err := m.Lookup("value")
+	assert.NoError(t, err)
+	assert.Nil(t, token)
+
+	listErr := errors.New("unreachable")
+	m = NewManager(&memoryStore{listErr: listErr}, clock.NewMock(), time.Minute)
+	_, err = m.Lookup("value")
+	assert.ErrorIs(t, err, listErr)
+}
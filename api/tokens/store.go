@@ -0,0 +1,170 @@
+package tokens
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+var /*const*/ ErrTokenNotFound = errors.New("token not found")
+var /*const*/ ErrTokenAlreadyExists = errors.New("token already exists")
+
+// Store persists the named API tokens
+type Store interface {
+	Create(token APIToken) error
+	Delete(name string) error
+	List() ([]APIToken, error)
+}
+
+// TokenSecretName is the name of the secret containing the named API tokens. The API service is only allowed to read
+// and update this secret
+const TokenSecretName = "keptn-named-api-tokens"
+
+const (
+	managedByLabel      = "app.kubernetes.io/managed-by"
+	managedByLabelValue = "api-service"
+)
+
+// storedToken is the representation of a token in the token secret
+type storedToken struct {
+	Role      Role       `json:"role"`
+	Projects  []string   `json:"projects,omitempty"`
+	Hash      string     `json:"hash"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// KubernetesStore stores the API tokens in a single secret in the namespace of the API service, each token under its name
+type KubernetesStore struct {
+	client    kubernetes.Interface
+	namespace string
+}
+
+func NewKubernetesStore(client kubernetes.Interface, namespace string) *KubernetesStore {
+	return &KubernetesStore{client: client, namespace: namespace}
+}
+
+func (s *KubernetesStore) Create(token APIToken) error {
+	value, err := json.Marshal(storedToken{
+		Role:      token.Role,
+		Projects:  token.Projects,
+		Hash:      token.Hash,
+		CreatedAt: token.CreatedAt.UTC(),
+		ExpiresAt: toUTC(token.ExpiresAt),
+	})
+	if err != nil {
+		return fmt.Errorf("could not create token %s: %w", token.Name, err)
+	}
+
+	err = s.updateSecret(func(data map[string][]byte) error {
+		if _, ok := data[token.Name]; ok {
+			return ErrTokenAlreadyExists
+		}
+		data[token.Name] = value
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not create token %s: %w", token.Name, err)
+	}
+	return nil
+}
+
+func (s *KubernetesStore) Delete(name string) error {
+	err := s.updateSecret(func(data map[string][]byte) error {
+		if _, ok := data[name]; !ok {
+			return ErrTokenNotFound
+		}
+		delete(data, name)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not delete token %s: %w", name, err)
+	}
+	return nil
+}
+
+func (s *KubernetesStore) List() ([]APIToken, error) {
+	secret, err := s.client.CoreV1().Secrets(s.namespace).Get(context.TODO(), TokenSecretName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return []APIToken{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not list tokens: %w", err)
+	}
+
+	tokens := make([]APIToken, 0, len(secret.Data))
+	for name, value := range secret.Data {
+		stored := storedToken{}
+		if err := json.Unmarshal(value, &stored); err != nil {
+			return nil, fmt.Errorf("could not read token %s: %w", name, err)
+		}
+		tokens = append(tokens, APIToken{
+			Name:      name,
+			Role:      stored.Role,
+			Projects:  stored.Projects,
+			Hash:      stored.Hash,
+			CreatedAt: stored.CreatedAt,
+			ExpiresAt: stored.ExpiresAt,
+		})
+	}
+	return tokens, nil
+}
+
+// updateSecret applies the given update to the tokens in the token secret. Concurrent updates of other replicas
+// are detected by the resource version of the secret, in which case the update is applied again.
+// The secret is created if it does not exist yet, which is only possible if the API service may create secrets
+func (s *KubernetesStore) updateSecret(update func(data map[string][]byte) error) error {
+	secrets := s.client.CoreV1().Secrets(s.namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := secrets.Get(context.TODO(), TokenSecretName, metav1.GetOptions{})
+		exists := !k8serrors.IsNotFound(err)
+		if !exists {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      TokenSecretName,
+					Namespace: s.namespace,
+					Labels: map[string]string{
+						managedByLabel: managedByLabelValue,
+					},
+				},
+				Type: corev1.SecretTypeOpaque,
+			}
+		} else if err != nil {
+			return err
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		if err := update(secret.Data); err != nil {
+			return err
+		}
+
+		if !exists {
+			_, err = secrets.Create(context.TODO(), secret, metav1.CreateOptions{})
+			if k8serrors.IsAlreadyExists(err) {
+				// the secret has been created by another replica in the meantime
+				return k8serrors.NewConflict(corev1.Resource("secrets"), TokenSecretName, err)
+			}
+			return err
+		}
+		_, err = secrets.Update(context.TODO(), secret, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func toUTC(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
@@ -0,0 +1,69 @@
+package tokens
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestKubernetesStore(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	store := NewKubernetesStore(client, "keptn")
+
+	expiry := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	token := APIToken{
+		Name:      "ci-pipeline",
+		Role:      RoleTriggerOnly,
+		Projects:  []string{"sockshop", "podtato-head"},
+		Hash:      HashToken("value"),
+		CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		ExpiresAt: &expiry,
+	}
+	require.NoError(t, store.Create(token))
+	require.NoError(t, store.Create(APIToken{Name: "admin", Role: RoleAdmin, Hash: HashToken("other"), CreatedAt: token.CreatedAt}))
+	assert.ErrorIs(t, store.Create(token), ErrTokenAlreadyExists)
+
+	secret, err := client.CoreV1().Secrets("keptn").Get(context.TODO(), "keptn-named-api-tokens", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, secret.Data, 2)
+	assert.NotContains(t, string(secret.Data["ci-pipeline"]), `"value"`)
+
+	listed, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Contains(t, listed, token)
+
+	require.NoError(t, store.Delete("ci-pipeline"))
+	assert.ErrorIs(t, store.Delete("ci-pipeline"), ErrTokenNotFound)
+
+	listed, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "admin", listed[0].Name)
+	assert.Nil(t, listed[0].Projects)
+	assert.Nil(t, listed[0].ExpiresAt)
+}
+
+func TestKubernetesStoreWithExistingSecret(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "keptn-named-api-tokens", Namespace: "keptn"},
+	})
+	store := NewKubernetesStore(client, "keptn")
+
+	listed, err := store.List()
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+
+	require.NoError(t, store.Create(APIToken{Name: "admin", Role: RoleAdmin, Hash: HashToken("value"), CreatedAt: time.Now()}))
+
+	listed, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "admin", listed[0].Name)
+}
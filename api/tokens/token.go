@@ -0,0 +1,56 @@
+package tokens
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+// Role determines the API endpoints a token can be used for
+type Role string
+
+const (
+	// RoleReadOnly allows reading metadata and exporting projects
+	RoleReadOnly Role = "read-only"
+	// RoleTriggerOnly allows sending events
+	RoleTriggerOnly Role = "trigger-only"
+	// RoleAdmin allows using all endpoints
+	RoleAdmin Role = "admin"
+)
+
+// AllRoles contains the roles that can be assigned to a token
+var AllRoles = []Role{RoleReadOnly, RoleTriggerOnly, RoleAdmin}
+
+// APIToken is a named API token. Only the hash of the token value is stored
+type APIToken struct {
+	Name      string
+	Role      Role
+	Projects  []string
+	Hash      string
+	CreatedAt time.Time
+	ExpiresAt *time.Time
+}
+
+// Expired returns whether the token is expired at the given time
+func (t APIToken) Expired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+// ProjectScoped returns whether the token can only be used for some projects
+func (t APIToken) ProjectScoped() bool {
+	return len(t.Projects) > 0
+}
+
+// AllowsProject returns whether the token can be used for the given project. Tokens without projects can be used for
+// all projects
+func (t APIToken) AllowsProject(project string) bool {
+	return !t.ProjectScoped() || slices.Contains(t.Projects, project)
+}
+
+// HashToken returns the hash under which the given token value is stored
+func HashToken(value string) string {
+	hash := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/keptn/keptn/cli/internal/cespec"
+	"github.com/spf13/cobra"
+)
+
+type generateEventSchemasCmdParams struct {
+	Directory *string
+	Tasks     *[]string
+}
+
+var generateEventSchemasParams *generateEventSchemasCmdParams
+
+// generateEventSchemasCmd implements the generate event-schemas command
+var generateEventSchemasCmd = &cobra.Command{
+	Use:   "event-schemas",
+	Args:  cobra.NoArgs,
+	Short: "Generates the JSON schemas of the data of Keptn CloudEvents",
+	Long: `Generates the JSON schemas of the data of the Keptn CloudEvents, one file per event type.
+
+The Keptn API validates the data of events sent to it against the schema of their type. Schemas are looked up in the
+resources of the project of an event, in the directory event-schemas, and in the global schema directory of the API.
+The generated schemas can be used as a starting point for the schemas of custom task events.
+
+With --task, the schemas of the triggered, started and finished events of custom tasks are generated, containing the
+common event data and an object property named after the task.
+`,
+	Example: `keptn generate event-schemas
+
+keptn generate event-schemas --dir=/some/directory --task=securityscan
+keptn add-resource --project=sockshop --resource=/some/directory/sh.keptn.event.securityscan.finished.json --resourceUri=event-schemas/sh.keptn.event.securityscan.finished.json`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		outputDir := "./event-schemas"
+		if *generateEventSchemasParams.Directory != "" {
+			outputDir = *generateEventSchemasParams.Directory
+		}
+
+		if _, err := os.Stat(outputDir); os.IsNotExist(err) {
+			return fmt.Errorf("error trying to access directory %s. Please make sure the directory exists", outputDir)
+		}
+
+		files, err := cespec.GenerateSchemas(outputDir, *generateEventSchemasParams.Tasks)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d schemas have been written to: %s\n", len(files), outputDir)
+
+		return nil
+	},
+}
+
+func init() {
+	generateCmd.AddCommand(generateEventSchemasCmd)
+
+	generateEventSchemasParams = &generateEventSchemasCmdParams{}
+	generateEventSchemasParams.Directory = generateEventSchemasCmd.Flags().StringP("dir", "", "./event-schemas", "directory where the schemas should be written to")
+	generateEventSchemasParams.Tasks = generateEventSchemasCmd.Flags().StringSliceP("task", "", []string{}, "custom tasks whose event schemas should be generated")
+}
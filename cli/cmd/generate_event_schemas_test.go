@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+)
+
+func TestGenerateEventSchemas(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+	dname := t.TempDir()
+
+	cmd := fmt.Sprintf("generate event-schemas --dir=%s --task=securityscan --mock", dname)
+	_, err := executeActionCommandC(cmd)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dname, "sh.keptn.event.deployment.finished.json"))
+	require.NoError(t, err)
+	schema := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(content, &schema))
+	assert.Equal(t, "http://json-schema.org/draft-04/schema#", schema["$schema"])
+	assert.Contains(t, schema["properties"], "deployment")
+	assert.NotContains(t, schema, "$ref")
+
+	content, err = os.ReadFile(filepath.Join(dname, "sh.keptn.event.securityscan.finished.json"))
+	require.NoError(t, err)
+	schema = map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(content, &schema))
+	assert.Contains(t, schema["properties"], "securityscan")
+	assert.Contains(t, schema["properties"], "project")
+	assert.Equal(t, []interface{}{"project", "stage", "service"}, schema["required"])
+	assert.FileExists(t, filepath.Join(dname, "sh.keptn.event.securityscan.triggered.json"))
+	assert.FileExists(t, filepath.Join(dname, "sh.keptn.event.securityscan.started.json"))
+}
+
+func TestGenerateEventSchemasInvalidTask(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	cmd := fmt.Sprintf("generate event-schemas --dir=%s --task=Security_Scan --mock", t.TempDir())
+	_, err := executeActionCommandC(cmd)
+	assert.EqualError(t, err, "invalid task name Security_Scan: only lower case alphanumeric characters or '-' are allowed")
+}
+
+func TestGenerateEventSchemasDirectoryDoesNotExist(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	_, err := executeActionCommandC("generate event-schemas --dir=does/not/exist --mock")
+	assert.EqualError(t, err, "error trying to access directory does/not/exist. Please make sure the directory exists")
+}
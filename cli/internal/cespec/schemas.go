@@ -0,0 +1,156 @@
+package cespec
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+
+	"github.com/invopop/jsonschema"
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// schemaVersion is the JSON schema draft supported by the event schema registry of the API service
+const schemaVersion = "http://json-schema.org/draft-04/schema#"
+
+var taskNamePattern = regexp.MustCompile(`^[a-z0-9][-a-z0-9]*$`)
+
+type schemaEvent struct {
+	eventType string
+	data      interface{}
+}
+
+// schemaEvents are the Keptn CloudEvents whose data schemas are generated
+var schemaEvents = []schemaEvent{
+	{eventType: keptnv2.GetTriggeredEventType(keptnv2.ProjectCreateTaskName), data: projectCreateData},
+	{eventType: keptnv2.GetStartedEventType(keptnv2.ProjectCreateTaskName), data: projectCreateStartedEventData},
+	{eventType: keptnv2.GetFinishedEventType(keptnv2.ProjectCreateTaskName), data: projectCreateFinishedEventData},
+	{eventType: keptnv2.GetStartedEventType(keptnv2.ServiceCreateTaskName), data: serviceCreateStartedEventData},
+	{eventType: keptnv2.GetStatusChangedEventType(keptnv2.ServiceCreateTaskName), data: serviceCreateStatusChangesData},
+	{eventType: keptnv2.GetFinishedEventType(keptnv2.ServiceCreateTaskName), data: serviceCreateFinishedEventData},
+	{eventType: keptnv2.GetTriggeredEventType(keptnv2.ApprovalTaskName), data: approvalTriggeredEventData},
+	{eventType: keptnv2.GetStartedEventType(keptnv2.ApprovalTaskName), data: approvalStartedEventData},
+	{eventType: keptnv2.GetStatusChangedEventType(keptnv2.ApprovalTaskName), data: approvalStatusChangedEventData},
+	{eventType: keptnv2.GetFinishedEventType(keptnv2.ApprovalTaskName), data: approvalFinishedEventData},
+	{eventType: keptnv2.GetTriggeredEventType(keptnv2.DeploymentTaskName), data: deploymentTriggeredEventData},
+	{eventType: keptnv2.GetStartedEventType(keptnv2.DeploymentTaskName), data: deploymentStartedEventData},
+	{eventType: keptnv2.GetStatusChangedEventType(keptnv2.DeploymentTaskName), data: deploymentStatusChangedEventData},
+	{eventType: keptnv2.GetFinishedEventType(keptnv2.DeploymentTaskName), data: deploymentFinishedEventData},
+	{eventType: keptnv2.GetTriggeredEventType(keptnv2.RollbackTaskName), data: rollbackTriggeredEventData},
+	{eventType: keptnv2.GetStartedEventType(keptnv2.RollbackTaskName), data: rollbackStartedEventData},
+	{eventType: keptnv2.GetFinishedEventType(keptnv2.RollbackTaskName), data: rollbackFinishedEventData},
+	{eventType: keptnv2.GetTriggeredEventType(keptnv2.TestTaskName), data: testTriggeredEventData},
+	{eventType: keptnv2.GetStartedEventType(keptnv2.TestTaskName), data: testStartedEventData},
+	{eventType: keptnv2.GetStatusChangedEventType(keptnv2.TestTaskName), data: testStatusChangedEventData},
+	{eventType: keptnv2.GetFinishedEventType(keptnv2.TestTaskName), data: testTestFinishedEventData},
+	{eventType: keptnv2.GetTriggeredEventType(keptnv2.EvaluationTaskName), data: evaluationTriggeredEventData},
+	{eventType: keptnv2.GetStartedEventType(keptnv2.EvaluationTaskName), data: evaluationStartedEventData},
+	{eventType: keptnv2.GetStatusChangedEventType(keptnv2.EvaluationTaskName), data: evaluationStatusChangedEventData},
+	{eventType: keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), data: evaluationFinishedEventData},
+	{eventType: keptnv2.GetInvalidatedEventType(keptnv2.EvaluationTaskName), data: evaluationInvalidatedEventData},
+	{eventType: keptnv2.GetTriggeredEventType(keptnv2.ReleaseTaskName), data: releaseTriggeredEventData},
+	{eventType: keptnv2.GetStartedEventType(keptnv2.ReleaseTaskName), data: releaseStartedEventData},
+	{eventType: keptnv2.GetStatusChangedEventType(keptnv2.ReleaseTaskName), data: releaseStatusChangedEventData},
+	{eventType: keptnv2.GetFinishedEventType(keptnv2.ReleaseTaskName), data: releaseFinishedEventData},
+	{eventType: keptnv2.GetTriggeredEventType(keptnv2.GetActionTaskName), data: getActionTriggeredEventData},
+	{eventType: keptnv2.GetStartedEventType(keptnv2.GetActionTaskName), data: getActionStartedEventData},
+	{eventType: keptnv2.GetFinishedEventType(keptnv2.GetActionTaskName), data: getActionFinishedEventData},
+	{eventType: keptnv2.GetTriggeredEventType(keptnv2.ActionTaskName), data: actionTriggeredEventData},
+	{eventType: keptnv2.GetStartedEventType(keptnv2.ActionTaskName), data: actionStartedEventData},
+	{eventType: keptnv2.GetFinishedEventType(keptnv2.ActionTaskName), data: actionFinishedEventData},
+	{eventType: keptnv2.GetTriggeredEventType(keptnv2.GetSLITaskName), data: getSLITriggeredEventData},
+	{eventType: keptnv2.GetStartedEventType(keptnv2.GetSLITaskName), data: getSLIStartedEventData},
+	{eventType: keptnv2.GetFinishedEventType(keptnv2.GetSLITaskName), data: getSLIFinishedEventData},
+	{eventType: keptn.ConfigureMonitoringEventType, data: configureMonitoringEventData},
+	{eventType: keptn.ProblemEventType, data: problemOpenEventData},
+	{eventType: keptnv2.ErrorLogEventName, data: errorLogEventData},
+}
+
+// GenerateSchemas writes the JSON schema of the data of every Keptn CloudEvent to a file named after the event type in
+// the provided outputDir path, e.g. sh.keptn.event.deployment.finished.json. For each custom task, the schemas of its
+// triggered, started and finished events are written, which contain the common event data and an object property
+// named after the task. The paths of the written files are returned
+func GenerateSchemas(outputDir string, customTasks []string) ([]string, error) {
+	schemas := map[string]*jsonschema.Schema{}
+	eventTypes := []string{}
+	for _, event := range schemaEvents {
+		if _, ok := schemas[event.eventType]; !ok {
+			eventTypes = append(eventTypes, event.eventType)
+		}
+		schemas[event.eventType] = reflectDataSchema(event.data)
+	}
+	for _, task := range customTasks {
+		if !taskNamePattern.MatchString(task) {
+			return nil, fmt.Errorf("invalid task name %s: only lower case alphanumeric characters or '-' are allowed", task)
+		}
+		for _, eventType := range []string{
+			keptnv2.GetTriggeredEventType(task), keptnv2.GetStartedEventType(task), keptnv2.GetFinishedEventType(task),
+		} {
+			eventTypes = append(eventTypes, eventType)
+			schemas[eventType] = customTaskDataSchema(task)
+		}
+	}
+
+	files := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		content, err := json.MarshalIndent(schemas[eventType], "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("could not marshal schema of %s: %w", eventType, err)
+		}
+		file := filepath.Join(outputDir, eventType+".json")
+		if err := os.WriteFile(file, append(content, '\n'), 0644); err != nil {
+			return nil, fmt.Errorf("could not write schema of %s: %w", eventType, err)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// reflectDataSchema returns a self-contained schema of the data, which allows additional properties, e.g. the data of
+// other tasks carried along in a sequence
+func reflectDataSchema(data interface{}) *jsonschema.Schema {
+	reflector := &jsonschema.Reflector{
+		Anonymous:                 true,
+		AllowAdditionalProperties: true,
+		DoNotReference:            true,
+	}
+	schema := reflector.Reflect(data)
+	describeAnyValues(schema)
+	schema.Version = schemaVersion
+	return schema
+}
+
+// describeAnyValues describes the empty schemas of interface values and raw JSON, which would otherwise be marshalled
+// as boolean schema that is not part of draft 4
+func describeAnyValues(schema *jsonschema.Schema) {
+	if schema == nil {
+		return
+	}
+	if reflect.DeepEqual(schema, &jsonschema.Schema{}) {
+		schema.Description = "any JSON value"
+		return
+	}
+	if schema.Properties != nil {
+		for _, name := range schema.Properties.Keys() {
+			property, _ := schema.Properties.Get(name)
+			describeAnyValues(property.(*jsonschema.Schema))
+		}
+	}
+	describeAnyValues(schema.Items)
+	for _, subSchema := range schema.OneOf {
+		describeAnyValues(subSchema)
+	}
+}
+
+func customTaskDataSchema(task string) *jsonschema.Schema {
+	schema := reflectDataSchema(keptnv2.EventData{})
+	schema.Properties.Set(task, &jsonschema.Schema{
+		Type:        "object",
+		Description: fmt.Sprintf("The data of the %s task", task),
+	})
+	schema.Required = []string{"project", "stage", "service"}
+	return schema
+}
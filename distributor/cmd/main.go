@@ -21,14 +21,20 @@ import (
 	"github.com/keptn/keptn/distributor/pkg/api"
 	"github.com/keptn/keptn/distributor/pkg/clientget"
 	"github.com/keptn/keptn/distributor/pkg/config"
+	"github.com/keptn/keptn/distributor/pkg/delivery"
 	"github.com/keptn/keptn/distributor/pkg/forwarder"
+	"github.com/keptn/keptn/distributor/pkg/ledger"
 	"github.com/keptn/keptn/distributor/pkg/poller"
 	"github.com/keptn/keptn/distributor/pkg/receiver"
+	"github.com/keptn/keptn/distributor/pkg/recipient"
+	"github.com/keptn/keptn/distributor/pkg/transform"
 	"github.com/keptn/keptn/distributor/pkg/uniform/controlplane"
 	"github.com/keptn/keptn/distributor/pkg/uniform/log"
 	"github.com/keptn/keptn/distributor/pkg/uniform/watch"
 	"github.com/keptn/keptn/distributor/pkg/utils"
 	logger "github.com/sirupsen/logrus"
+	"io"
+	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
@@ -50,10 +56,6 @@ func main() {
 	bark(env)
 
 	executionContext := createExecutionContext()
-	eventSender, err := poller.CreateEventSender(env)
-	if err != nil {
-		logger.WithError(err).Fatal("Could not initialize event sender.")
-	}
 
 	httpClient, err := clientget.CreateClientGetter(env).Get()
 	if err != nil {
@@ -67,37 +69,108 @@ func main() {
 
 	controlPlane := controlplane.New(apiset.UniformV1(), env.PubSubConnectionType(), env)
 	uniformWatch := watch.New(controlPlane, env)
-	forwarder := forwarder.New(apiset.APIV1(), httpClient, env, forwarder.WithMaxBytes(env.GetAPIProxyMaxBytes()))
+	forwarderOpts := []func(f *forwarder.Forwarder){forwarder.WithMaxBytes(env.GetAPIProxyMaxBytes())}
+	if env.PubSubConnectionType() == config.ConnectionTypeLocal {
+		output, err := createLocalEventOutput(env)
+		if err != nil {
+			logger.WithError(err).Fatal("Could not open output for local events.")
+		}
+		defer output.Close()
+		forwarderOpts = append(forwarderOpts, forwarder.WithEventWriter(output))
+	}
+	forwarder := forwarder.New(apiset.APIV1(), httpClient, env, forwarderOpts...)
+
+	eventSender, err := createEventSender(env, forwarder)
+	if err != nil {
+		logger.WithError(err).Fatal("Could not initialize event sender.")
+	}
 
 	// Start event forwarder
 	logger.Info("Starting Event Forwarder")
 	forwarder.Start(executionContext)
 
+	deliveryMetrics := delivery.NewMetrics()
+	if env.MetricsPort > 0 {
+		startMetricsServer(env.MetricsPort, deliveryMetrics)
+	}
+	var deadLetterHandler delivery.DeadLetterHandler
+	if env.DeliveryDeadLetterEnabled {
+		deadLetterHandler = delivery.NewTaskFinishedDeadLetterHandler(forwarder, eventSource(env))
+	}
+	retryingEventSender := delivery.NewRetryingEventSender(eventSender, delivery.NewRetryPolicyFromEnv(env), deadLetterHandler, deliveryMetrics)
+
+	var transformer *transform.Transformer
+	if env.TransformationsFile != "" {
+		transformer, err = transform.NewFromFile(env.TransformationsFile)
+		if err != nil {
+			logger.WithError(err).Fatal("Could not load event transformations.")
+		}
+	}
+
+	var deliveries *ledger.Ledger
+	if env.DeliveryLedgerFile != "" {
+		deliveries, err = ledger.Open(env.DeliveryLedgerFile, env.DeliveryLedgerRetention)
+		if err != nil {
+			logger.WithError(err).Fatal("Could not open delivery ledger.")
+		}
+		defer deliveries.Close()
+	}
+
 	// Eventually start registration process
-	if env.ValidateRegistrationConstraints() {
+	integrationID := ""
+	if env.PubSubConnectionType() != config.ConnectionTypeLocal && env.ValidateRegistrationConstraints() {
 		id, err := uniformWatch.Start(executionContext)
 		if err != nil {
 			logger.Fatal(err)
 		}
+		integrationID = id
 		uniformLogger := log.New(id, apiset.LogsV1())
 		uniformLogger.Start(executionContext, forwarder.EventChannel)
 	}
 
+	var receiverOpts []func(n *receiver.NATSEventReceiver)
+	if transformer != nil {
+		receiverOpts = append(receiverOpts, receiver.WithTransformer(transformer))
+	}
+	if deliveries != nil {
+		receiverOpts = append(receiverOpts, receiver.WithLedger(deliveries))
+	}
+
 	logger.Infof("Connection type: %s", env.PubSubConnectionType())
-	if env.PubSubConnectionType() == config.ConnectionTypeHTTP {
+	switch env.PubSubConnectionType() {
+	case config.ConnectionTypeLocal:
+		logger.Infof("Starting file event receiver for %s", env.LocalEventSource)
+		fileEventReceiver := receiver.NewFileEventReceiver(env, retryingEventSender, receiverOpts...)
+		if err := fileEventReceiver.Start(executionContext); err != nil {
+			logger.Fatalf("Could not start file event receiver: %v", err)
+		}
+	case config.ConnectionTypeHTTP:
 		err := env.ValidateKeptnAPIEndpointURL()
 		if err != nil {
 			logger.Fatalf("No valid URL configured for keptn api endpoint: %s", err)
 		}
 		logger.Info("Starting HTTP event poller")
-		httpEventPoller := poller.New(env, apiset.ShipyardControlV1(), eventSender)
+		var pollerOpts []func(p *poller.Poller)
+		if transformer != nil {
+			pollerOpts = append(pollerOpts, poller.WithTransformer(transformer))
+		}
+		if deliveries != nil {
+			pollerOpts = append(pollerOpts, poller.WithLedger(deliveries))
+		}
+		if integrationID != "" {
+			pollerOpts = append(pollerOpts, poller.WithIntegrationID(integrationID))
+		}
+		if env.LongPollingTimeout() > 0 {
+			pollerOpts = append(pollerOpts, poller.WithLongPolling(poller.NewHTTPLongPollingClient(httpClient, env)))
+		}
+		httpEventPoller := poller.New(env, apiset.ShipyardControlV1(), retryingEventSender, pollerOpts...)
 		uniformWatch.RegisterListener(httpEventPoller)
 		if err := httpEventPoller.Start(executionContext); err != nil {
 			logger.Fatalf("Could not start HTTP event poller: %v", err)
 		}
-	} else {
+	default:
 		logger.Info("Starting NATS event receiver")
-		natsEventReceiver := receiver.New(env, eventSender, env.ValidateRegistrationConstraints())
+		natsEventReceiver := receiver.New(env, retryingEventSender, env.ValidateRegistrationConstraints(), receiverOpts...)
 		uniformWatch.RegisterListener(natsEventReceiver)
 		if err := natsEventReceiver.Start(executionContext); err != nil {
 			logger.Fatalf("Could not start NATS event receiver: %v", err)
@@ -106,6 +179,59 @@ func main() {
 	executionContext.Wg.Wait()
 }
 
+// createLocalEventOutput opens the output for the events sent by the integration when reading events from LOCAL_EVENT_SOURCE
+func createLocalEventOutput(env config.EnvConfig) (io.WriteCloser, error) {
+	if env.LocalEventOutput == "" || env.LocalEventOutput == "-" {
+		return os.Stdout, nil
+	}
+	return os.OpenFile(env.LocalEventOutput, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+}
+
+// createEventSender creates the sender delivering events to the integration using the configured recipient protocol.
+// For protocols without CloudEvents support, the replies of the integration are forwarded to Keptn by the given forwarder
+func createEventSender(env config.EnvConfig, fw *forwarder.Forwarder) (poller.EventSender, error) {
+	if env.PubSubRecipientProtocol == "" || env.PubSubRecipientProtocol == recipient.ProtocolCloudEvents {
+		return poller.CreateEventSender(env)
+	}
+
+	envelope, err := recipient.ParseEnvelope(env.PubSubRecipientEnvelope)
+	if err != nil {
+		return nil, err
+	}
+	tasks := recipient.NewTasks(eventSource(env))
+	fw.Handle(env.ReplyForwardingPath, recipient.NewReplyHandler(tasks, fw))
+
+	switch env.PubSubRecipientProtocol {
+	case recipient.ProtocolWebhook:
+		logger.Infof("Delivering events as JSON webhooks to %s", env.PubSubRecipientURL())
+		return recipient.NewWebhookEventSender(&http.Client{}, env.PubSubRecipientURL(), envelope, tasks), nil
+	case recipient.ProtocolGRPC:
+		logger.Infof("Delivering events via gRPC stream to %s", env.PubSubRecipientAddress())
+		return recipient.NewGRPCEventSender(env.PubSubRecipientAddress(), envelope, tasks, fw), nil
+	default:
+		return nil, fmt.Errorf("unsupported recipient protocol: %s", env.PubSubRecipientProtocol)
+	}
+}
+
+// eventSource returns the source of the events sent on behalf of the integration, which is the name of the Keptn service
+func eventSource(env config.EnvConfig) string {
+	if env.K8sDeploymentName != "" {
+		return env.K8sDeploymentName
+	}
+	return "distributor"
+}
+
+func startMetricsServer(port int, metrics *delivery.Metrics) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics)
+	go func() {
+		logger.Infof("Serving metrics on port %d", port)
+		if err := http.ListenAndServe(fmt.Sprintf(":%d", port), mux); err != nil {
+			logger.Errorf("Could not serve metrics: %v", err)
+		}
+	}()
+}
+
 func bark(env config.EnvConfig) {
 	padR := func(str string) string {
 		width := 40
@@ -137,6 +263,7 @@ func bark(env config.EnvConfig) {
 	fmt.Printf(printFmtStr, padR("Api proxy port"), strOrUnknown(strconv.Itoa(env.APIProxyPort)))
 	fmt.Printf(printFmtStr, padR("PubSub URL"), strOrUnknown(env.PubSubURL))
 	fmt.Printf(printFmtStr, padR("PubSub group"), strOrUnknown(env.PubSubGroup))
+	fmt.Printf(printFmtStr, padR("PubSub recipient protocol"), strOrUnknown(env.PubSubRecipientProtocol))
 	fmt.Printf(printFmtStr, padR("K8S node name"), strOrUnknown(env.K8sNodeName))
 	fmt.Printf(printFmtStr, padR("K8S namespace"), strOrUnknown(env.K8sNamespace))
 	fmt.Printf(printFmtStr, padR("K8S deployment name"), strOrUnknown(env.K8sDeploymentName))
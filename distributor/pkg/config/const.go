@@ -2,7 +2,7 @@ package config
 
 type ConnectionType string
 
-var ConnectionTypeToLocation = map[ConnectionType]string{ConnectionTypeNATS: "control-plane", ConnectionTypeHTTP: "remote-execution-plane"}
+var ConnectionTypeToLocation = map[ConnectionType]string{ConnectionTypeNATS: "control-plane", ConnectionTypeHTTP: "remote-execution-plane", ConnectionTypeLocal: "local"}
 
 const mongoDbUrl = "/mongodb-datastore"
 const configServiceUrl = "/resource-service"
@@ -23,11 +23,14 @@ var ExternalAPIProxyMappings = map[string]string{
 const (
 	ConnectionTypeNATS ConnectionType = "nats"
 	ConnectionTypeHTTP ConnectionType = "http"
+	// ConnectionTypeLocal reads events from files or stdin instead of connecting to Keptn
+	ConnectionTypeLocal ConnectionType = "local"
 )
 
 const (
 	DefaultShipyardControllerBaseURL = "http://shipyard-controller:8080"
 	DefaultEventsEndpoint            = DefaultShipyardControllerBaseURL + "/v1/event/triggered"
 	DefaultPollingInterval           = 10
+	MaxLongPollingTimeoutSeconds     = 30
 	DefaultAPIProxyHTTPTimeout       = 30
 )
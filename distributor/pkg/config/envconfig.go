@@ -21,13 +21,18 @@ type EnvConfig struct {
 	APIProxyMaxPayloadBytesKB int           `envconfig:"API_PROXY_MAX_PAYLOAD_BYTES_KB" default:"64"`
 	APIProxyHTTPTimeout       string        `envconfig:"API_PROXY_HTTP_TIMEOUT" default:"30"`
 	HTTPPollingInterval       string        `envconfig:"HTTP_POLLING_INTERVAL" default:"10"`
+	HTTPLongPollingTimeout    time.Duration `envconfig:"HTTP_LONG_POLLING_TIMEOUT" default:"20s"`
 	EventForwardingPath       string        `envconfig:"EVENT_FORWARDING_PATH" default:"/event"`
+	ReplyForwardingPath       string        `envconfig:"REPLY_FORWARDING_PATH" default:"/reply"`
 	VerifySSL                 bool          `envconfig:"HTTP_SSL_VERIFY" default:"true"`
 	PubSubURL                 string        `envconfig:"PUBSUB_URL" default:"nats://keptn-nats"`
 	PubSubTopic               string        `envconfig:"PUBSUB_TOPIC" default:""`
 	PubSubRecipient           string        `envconfig:"PUBSUB_RECIPIENT" default:"http://127.0.0.1"`
 	PubSubRecipientPort       string        `envconfig:"PUBSUB_RECIPIENT_PORT" default:"8080"`
 	PubSubRecipientPath       string        `envconfig:"PUBSUB_RECIPIENT_PATH" default:""`
+	PubSubRecipientProtocol   string        `envconfig:"PUBSUB_RECIPIENT_PROTOCOL" default:"cloudevents"`
+	PubSubRecipientEnvelope   string        `envconfig:"PUBSUB_RECIPIENT_ENVELOPE" default:""`
+	TransformationsFile       string        `envconfig:"TRANSFORMATIONS_FILE" default:""`
 	PubSubGroup               string        `envconfig:"PUBSUB_GROUP" default:""`
 	ProjectFilter             string        `envconfig:"PROJECT_FILTER" default:""`
 	StageFilter               string        `envconfig:"STAGE_FILTER" default:""`
@@ -49,9 +54,24 @@ type EnvConfig struct {
 	OAuthScopes               []string      `envconfig:"OAUTH_SCOPES" default:""`
 	OAuthDiscovery            string        `envconfig:"OAUTH_DISCOVERY" default:""`
 	OauthTokenURL             string        `envconfig:"OAUTH_TOKEN_URL" default:""`
+	DeliveryMaxAttempts       int           `envconfig:"DELIVERY_MAX_ATTEMPTS" default:"5"`
+	DeliveryInitialBackoff    time.Duration `envconfig:"DELIVERY_INITIAL_BACKOFF" default:"1s"`
+	DeliveryMaxBackoff        time.Duration `envconfig:"DELIVERY_MAX_BACKOFF" default:"1m"`
+	DeliveryTimeout           time.Duration `envconfig:"DELIVERY_TIMEOUT" default:"5s"`
+	DeliveryRetryQueueSize    int           `envconfig:"DELIVERY_RETRY_QUEUE_SIZE" default:"100"`
+	DeliveryDeadLetterEnabled bool          `envconfig:"DELIVERY_DEAD_LETTER_ENABLED" default:"true"`
+	DeliveryLedgerFile        string        `envconfig:"DELIVERY_LEDGER_FILE" default:""`
+	DeliveryLedgerRetention   time.Duration `envconfig:"DELIVERY_LEDGER_RETENTION" default:"24h"`
+	LocalEventSource          string        `envconfig:"LOCAL_EVENT_SOURCE" default:""`
+	LocalEventOutput          string        `envconfig:"LOCAL_EVENT_OUTPUT" default:"-"`
+	MetricsPort               int           `envconfig:"METRICS_PORT" default:"0"`
 }
 
 func (env *EnvConfig) PubSubConnectionType() ConnectionType {
+	if env.LocalEventSource != "" {
+		// events are read from files or stdin, and the events sent by the integration are written to LOCAL_EVENT_OUTPUT
+		return ConnectionTypeLocal
+	}
 	if env.KeptnAPIEndpoint == "" {
 		// if no Keptn API URL has been defined, this means that run inside the Keptn cluster -> we can subscribe to events directly via NATS
 		return ConnectionTypeNATS
@@ -152,6 +172,18 @@ func (env *EnvConfig) HTTPPollingEndpoint() string {
 	return parsedURL.String()
 }
 
+// LongPollingTimeout returns how long the Keptn API should hold a polling request until new .triggered events are available.
+// Timeouts below one second disable long polling, and the timeout is limited to the maximum supported by the Keptn API
+func (env *EnvConfig) LongPollingTimeout() time.Duration {
+	if env.HTTPLongPollingTimeout < time.Second {
+		return 0
+	}
+	if env.HTTPLongPollingTimeout > MaxLongPollingTimeoutSeconds*time.Second {
+		return MaxLongPollingTimeoutSeconds * time.Second
+	}
+	return env.HTTPLongPollingTimeout
+}
+
 func (env *EnvConfig) PubSubRecipientURL() string {
 	recipientService := env.PubSubRecipient
 
@@ -166,6 +198,12 @@ func (env *EnvConfig) PubSubRecipientURL() string {
 	return recipientService + ":" + env.PubSubRecipientPort + path
 }
 
+// PubSubRecipientAddress returns the host and port of the recipient, without scheme and path
+func (env *EnvConfig) PubSubRecipientAddress() string {
+	host := strings.TrimPrefix(strings.TrimPrefix(env.PubSubRecipient, "https://"), "http://")
+	return host + ":" + env.PubSubRecipientPort
+}
+
 func (env *EnvConfig) PubSubTopics() []string {
 	if env.PubSubTopic == "" {
 		return []string{}
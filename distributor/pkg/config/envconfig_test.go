@@ -353,6 +353,43 @@ func TestEnvConfig_GetAPIProxyHTTPTimeout(t *testing.T) {
 	}
 }
 
+func TestEnvConfig_LongPollingTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		want    time.Duration
+	}{
+		{
+			name:    "long polling disabled",
+			timeout: 0,
+			want:    0,
+		},
+		{
+			name:    "sub-second timeout disables long polling",
+			timeout: 500 * time.Millisecond,
+			want:    0,
+		},
+		{
+			name:    "configured timeout",
+			timeout: 20 * time.Second,
+			want:    20 * time.Second,
+		},
+		{
+			name:    "timeout is limited",
+			timeout: 5 * time.Minute,
+			want:    30 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := &EnvConfig{
+				HTTPLongPollingTimeout: tt.timeout,
+			}
+			assert.Equal(t, tt.want, env.LongPollingTimeout())
+		})
+	}
+}
+
 func TestEnvConfig_GetAPIProxyMaxBytes(t *testing.T) {
 	type fields struct {
 		APIProxyMaxBytesKB int
@@ -379,3 +416,46 @@ func TestEnvConfig_GetAPIProxyMaxBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestEnvConfig_PubSubRecipientAddress(t *testing.T) {
+	tests := []struct {
+		name      string
+		recipient string
+		port      string
+		want      string
+	}{
+		{
+			name:      "service name",
+			recipient: "my-service",
+			port:      "9000",
+			want:      "my-service:9000",
+		},
+		{
+			name:      "HTTP recipient",
+			recipient: "http://my-service",
+			port:      "9000",
+			want:      "my-service:9000",
+		},
+		{
+			name:      "HTTPS recipient",
+			recipient: "https://my-service",
+			port:      "443",
+			want:      "my-service:443",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := &EnvConfig{
+				PubSubRecipient:     tt.recipient,
+				PubSubRecipientPort: tt.port,
+			}
+			assert.Equal(t, tt.want, env.PubSubRecipientAddress())
+		})
+	}
+}
+
+func TestEnvConfig_PubSubConnectionType(t *testing.T) {
+	assert.Equal(t, ConnectionTypeNATS, (&EnvConfig{}).PubSubConnectionType())
+	assert.Equal(t, ConnectionTypeHTTP, (&EnvConfig{KeptnAPIEndpoint: "http://keptn"}).PubSubConnectionType())
+	assert.Equal(t, ConnectionTypeLocal, (&EnvConfig{KeptnAPIEndpoint: "http://keptn", LocalEventSource: "-"}).PubSubConnectionType())
+}
@@ -0,0 +1,66 @@
+package delivery
+
+import (
+	"context"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	logger "github.com/sirupsen/logrus"
+)
+
+// TaskFinishedDeadLetterHandler completes task .triggered events that could not be delivered by sending a .started
+// and an errored .finished event on behalf of the Keptn service, so that the task sequence does not wait for a
+// response that will never arrive. Other events are only logged
+type TaskFinishedDeadLetterHandler struct {
+	// eventSender sends the .started and .finished events to Keptn, e.g. via the Forwarder
+	eventSender EventSender
+	source      string
+}
+
+func NewTaskFinishedDeadLetterHandler(eventSender EventSender, source string) *TaskFinishedDeadLetterHandler {
+	return &TaskFinishedDeadLetterHandler{
+		eventSender: eventSender,
+		source:      source,
+	}
+}
+
+func (h *TaskFinishedDeadLetterHandler) HandleDeadLetter(event cloudevents.Event, err error) {
+	if !keptnv2.IsTaskEventType(event.Type()) || !keptnv2.IsTriggeredEventType(event.Type()) {
+		logger.Errorf("Dropping event %s of type %s: %v", event.ID(), event.Type(), err)
+		return
+	}
+
+	triggeredEvent, convErr := keptnv2.ToKeptnEvent(event)
+	if convErr != nil {
+		logger.Errorf("Could not decode undeliverable event %s: %v", event.ID(), convErr)
+		return
+	}
+
+	startedEvent, createErr := keptnv2.CreateStartedEvent(h.source, triggeredEvent, nil)
+	if createErr != nil {
+		logger.Errorf("Could not create .started event for undeliverable event %s: %v", event.ID(), createErr)
+		return
+	}
+	finishedEvent, createErr := keptnv2.CreateFinishedEventWithError(h.source, triggeredEvent, nil, &keptnv2.Error{
+		StatusType: keptnv2.StatusErrored,
+		ResultType: keptnv2.ResultFailed,
+		Message:    err.Error(),
+	})
+	if createErr != nil {
+		logger.Errorf("Could not create .finished event for undeliverable event %s: %v", event.ID(), createErr)
+		return
+	}
+
+	logger.Errorf("Sending errored .finished event for undeliverable event %s: %v", event.ID(), err)
+	for _, e := range []*models.KeptnContextExtendedCE{startedEvent, finishedEvent} {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		sendErr := h.eventSender.Send(ctx, keptnv2.ToCloudEvent(*e))
+		cancel()
+		if sendErr != nil {
+			logger.Errorf("Could not send %s event for undeliverable event %s: %v", *e.Type, event.ID(), sendErr)
+			return
+		}
+	}
+}
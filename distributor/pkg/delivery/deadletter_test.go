@@ -0,0 +1,66 @@
+package delivery
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/go-utils/pkg/common/strutils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskFinishedDeadLetterHandler_TaskTriggeredEvent(t *testing.T) {
+	eventSender := &testEventSender{}
+	handler := NewTaskFinishedDeadLetterHandler(eventSender, "my-service")
+
+	triggeredEvent := keptnv2.ToCloudEvent(models.KeptnContextExtendedCE{
+		ID:             "my-triggered-id",
+		Shkeptncontext: "my-context",
+		Type:           strutils.Stringp("sh.keptn.event.deployment.triggered"),
+		Source:         strutils.Stringp("shipyard-controller"),
+		Data:           keptnv2.EventData{Project: "my-project", Stage: "my-stage", Service: "my-service"},
+	})
+
+	handler.HandleDeadLetter(triggeredEvent, errors.New("could not deliver event"))
+
+	sentEvents := eventSender.getSentEvents()
+	require.Len(t, sentEvents, 2)
+	assert.Equal(t, "sh.keptn.event.deployment.started", sentEvents[0].Type())
+	assert.Equal(t, "sh.keptn.event.deployment.finished", sentEvents[1].Type())
+
+	for _, event := range sentEvents {
+		assert.Equal(t, "my-service", event.Source())
+		assert.Equal(t, "my-triggered-id", event.Extensions()["triggeredid"])
+		assert.Equal(t, "my-context", event.Extensions()["shkeptncontext"])
+	}
+
+	finishedData := keptnv2.EventData{}
+	require.Nil(t, sentEvents[1].DataAs(&finishedData))
+	assert.Equal(t, keptnv2.EventData{
+		Project: "my-project",
+		Stage:   "my-stage",
+		Service: "my-service",
+		Status:  keptnv2.StatusErrored,
+		Result:  keptnv2.ResultFailed,
+		Message: "could not deliver event",
+	}, finishedData)
+}
+
+func TestTaskFinishedDeadLetterHandler_OtherEvents(t *testing.T) {
+	eventSender := &testEventSender{}
+	handler := NewTaskFinishedDeadLetterHandler(eventSender, "my-service")
+
+	for _, eventType := range []string{"sh.keptn.event.deployment.finished", "sh.keptn.event.dev.delivery.triggered"} {
+		handler.HandleDeadLetter(keptnv2.ToCloudEvent(models.KeptnContextExtendedCE{
+			ID:             "my-id",
+			Shkeptncontext: "my-context",
+			Type:           strutils.Stringp(eventType),
+			Source:         strutils.Stringp("shipyard-controller"),
+			Data:           keptnv2.EventData{Project: "my-project"},
+		}), errors.New("could not deliver event"))
+	}
+
+	assert.Empty(t, eventSender.getSentEvents())
+}
@@ -0,0 +1,65 @@
+package delivery
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+)
+
+// Metrics counts the delivery attempts of events to the Keptn service
+type Metrics struct {
+	attempts       int64
+	failedAttempts int64
+	retried        int64
+	deliveredCount int64
+	deadLetters    int64
+	queueSize      int64
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
+
+func (m *Metrics) attempt() {
+	atomic.AddInt64(&m.attempts, 1)
+}
+
+func (m *Metrics) failedAttempt() {
+	atomic.AddInt64(&m.failedAttempts, 1)
+}
+
+func (m *Metrics) delivered(attempts int) {
+	atomic.AddInt64(&m.deliveredCount, 1)
+	if attempts > 1 {
+		atomic.AddInt64(&m.retried, 1)
+	}
+}
+
+func (m *Metrics) deadLettered() {
+	atomic.AddInt64(&m.deadLetters, 1)
+}
+
+func (m *Metrics) setQueueSize(size int) {
+	atomic.StoreInt64(&m.queueSize, int64(size))
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	metrics := []struct {
+		name       string
+		metricType string
+		help       string
+		value      int64
+	}{
+		{"keptn_distributor_delivery_attempts_total", "counter", "Number of attempts to deliver an event to the Keptn service", atomic.LoadInt64(&m.attempts)},
+		{"keptn_distributor_delivery_failed_attempts_total", "counter", "Number of failed attempts to deliver an event to the Keptn service", atomic.LoadInt64(&m.failedAttempts)},
+		{"keptn_distributor_events_delivered_total", "counter", "Number of events delivered to the Keptn service", atomic.LoadInt64(&m.deliveredCount)},
+		{"keptn_distributor_events_delivered_after_retry_total", "counter", "Number of events delivered to the Keptn service after at least one retry", atomic.LoadInt64(&m.retried)},
+		{"keptn_distributor_events_dead_lettered_total", "counter", "Number of events that could not be delivered to the Keptn service", atomic.LoadInt64(&m.deadLetters)},
+		{"keptn_distributor_retry_queue_size", "gauge", "Number of events waiting for a retry", atomic.LoadInt64(&m.queueSize)},
+	}
+	for _, metric := range metrics {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %d\n", metric.name, metric.help, metric.name, metric.metricType, metric.name, metric.value)
+	}
+}
@@ -0,0 +1,164 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn/keptn/distributor/pkg/config"
+	logger "github.com/sirupsen/logrus"
+)
+
+// ErrRetryQueueFull is returned if an event could not be delivered and there is no space left to retry it later
+var ErrRetryQueueFull = errors.New("retry queue is full")
+
+// EventSender sends events to the Keptn service
+type EventSender interface {
+	Send(ctx context.Context, event cloudevents.Event) error
+}
+
+// DeadLetterHandler handles events that could not be delivered to the Keptn service
+type DeadLetterHandler interface {
+	HandleDeadLetter(event cloudevents.Event, err error)
+}
+
+// RetryPolicy determines how often and when the delivery of an event is retried
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of delivery attempts, including the first one
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry. It is doubled for every further retry
+	InitialBackoff time.Duration
+	// MaxBackoff is the upper limit of the delay between two attempts
+	MaxBackoff time.Duration
+	// Timeout is the timeout of a single delivery attempt
+	Timeout time.Duration
+	// QueueSize is the maximum number of events waiting for a retry
+	QueueSize int
+}
+
+func NewRetryPolicyFromEnv(env config.EnvConfig) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    env.DeliveryMaxAttempts,
+		InitialBackoff: env.DeliveryInitialBackoff,
+		MaxBackoff:     env.DeliveryMaxBackoff,
+		Timeout:        env.DeliveryTimeout,
+		QueueSize:      env.DeliveryRetryQueueSize,
+	}
+}
+
+// Backoff returns the delay after the given number of failed attempts
+func (p RetryPolicy) Backoff(failedAttempts int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < failedAttempts; i++ {
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return backoff
+}
+
+type retryItem struct {
+	event    cloudevents.Event
+	attempts int
+}
+
+// RetryingEventSender sends events to the Keptn service and retries failed deliveries according to its RetryPolicy.
+// Events waiting for a retry are kept in a bounded in-memory queue, i.e. they are lost if the distributor is restarted.
+// Events that could not be delivered after the last attempt are passed to the DeadLetterHandler
+type RetryingEventSender struct {
+	eventSender       EventSender
+	policy            RetryPolicy
+	deadLetterHandler DeadLetterHandler
+	metrics           *Metrics
+	mutex             *sync.Mutex
+	queued            int
+}
+
+func NewRetryingEventSender(eventSender EventSender, policy RetryPolicy, deadLetterHandler DeadLetterHandler, metrics *Metrics) *RetryingEventSender {
+	return &RetryingEventSender{
+		eventSender:       eventSender,
+		policy:            policy,
+		deadLetterHandler: deadLetterHandler,
+		metrics:           metrics,
+		mutex:             &sync.Mutex{},
+	}
+}
+
+// Send tries to deliver the event once. If the delivery fails, the event is queued for a retry and no error is returned.
+// An error is only returned if the event has been passed to the DeadLetterHandler right away,
+// i.e. if no retries are configured or the retry queue is full
+func (s *RetryingEventSender) Send(ctx context.Context, event cloudevents.Event) error {
+	return s.deliver(ctx, &retryItem{event: event})
+}
+
+func (s *RetryingEventSender) deliver(ctx context.Context, item *retryItem) error {
+	item.attempts++
+	err := s.send(ctx, item.event)
+	if err == nil {
+		s.metrics.delivered(item.attempts)
+		return nil
+	}
+	s.metrics.failedAttempt()
+
+	if item.attempts >= s.policy.MaxAttempts {
+		err = fmt.Errorf("could not deliver event %s after %d attempts: %w", item.event.ID(), item.attempts, err)
+		s.deadLetter(item.event, err)
+		return err
+	}
+	// the item must not be accessed after it has been enqueued, since the retry may already be running
+	eventID, attempts := item.event.ID(), item.attempts
+	if !s.enqueue(item) {
+		err = fmt.Errorf("could not deliver event %s: %v: %w", eventID, err, ErrRetryQueueFull)
+		s.deadLetter(item.event, err)
+		return err
+	}
+	logger.Warnf("Could not deliver event %s (attempt %d of %d), retrying in %s: %v", eventID, attempts, s.policy.MaxAttempts, s.policy.Backoff(attempts), err)
+	return nil
+}
+
+func (s *RetryingEventSender) send(ctx context.Context, event cloudevents.Event) error {
+	s.metrics.attempt()
+	if s.policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.policy.Timeout)
+		defer cancel()
+	}
+	return s.eventSender.Send(ctx, event)
+}
+
+func (s *RetryingEventSender) enqueue(item *retryItem) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.queued >= s.policy.QueueSize {
+		return false
+	}
+	s.queued++
+	s.metrics.setQueueSize(s.queued)
+
+	time.AfterFunc(s.policy.Backoff(item.attempts), func() {
+		s.mutex.Lock()
+		s.queued--
+		s.metrics.setQueueSize(s.queued)
+		s.mutex.Unlock()
+
+		// the context of the original Send call may already be gone at this point
+		if err := s.deliver(context.Background(), item); err != nil {
+			logger.Error(err.Error())
+		}
+	})
+	return true
+}
+
+func (s *RetryingEventSender) deadLetter(event cloudevents.Event, err error) {
+	s.metrics.deadLettered()
+	if s.deadLetterHandler != nil {
+		s.deadLetterHandler.HandleDeadLetter(event, err)
+	}
+}
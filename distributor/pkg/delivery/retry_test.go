@@ -0,0 +1,187 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEventSender struct {
+	mutex      sync.Mutex
+	failures   int
+	attempts   int
+	sentEvents []cloudevents.Event
+}
+
+func (s *testEventSender) Send(_ context.Context, event cloudevents.Event) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.attempts++
+	if s.attempts <= s.failures {
+		return errors.New("connection refused")
+	}
+	s.sentEvents = append(s.sentEvents, event)
+	return nil
+}
+
+func (s *testEventSender) getSentEvents() []cloudevents.Event {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.sentEvents
+}
+
+type testDeadLetterHandler struct {
+	mutex  sync.Mutex
+	events []cloudevents.Event
+	errs   []error
+}
+
+func (h *testDeadLetterHandler) HandleDeadLetter(event cloudevents.Event, err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.events = append(h.events, event)
+	h.errs = append(h.errs, err)
+}
+
+func (h *testDeadLetterHandler) getEvents() []cloudevents.Event {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.events
+}
+
+func newTestEvent(id string) cloudevents.Event {
+	event := cloudevents.NewEvent()
+	event.SetID(id)
+	event.SetType("sh.keptn.event.task.triggered")
+	event.SetSource("shipyard-controller")
+	return event
+}
+
+func newTestRetryPolicy(maxAttempts int, queueSize int) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		Timeout:        time.Second,
+		QueueSize:      queueSize,
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	assert.Equal(t, time.Second, policy.Backoff(1))
+	assert.Equal(t, 2*time.Second, policy.Backoff(2))
+	assert.Equal(t, 4*time.Second, policy.Backoff(3))
+	assert.Equal(t, 5*time.Second, policy.Backoff(4))
+	assert.Equal(t, 5*time.Second, policy.Backoff(100))
+
+	policy = RetryPolicy{InitialBackoff: 10 * time.Second, MaxBackoff: 5 * time.Second}
+	assert.Equal(t, 5*time.Second, policy.Backoff(1))
+}
+
+func TestRetryingEventSender_DeliversRightAway(t *testing.T) {
+	eventSender := &testEventSender{}
+	deadLetterHandler := &testDeadLetterHandler{}
+	metrics := NewMetrics()
+	sender := NewRetryingEventSender(eventSender, newTestRetryPolicy(3, 10), deadLetterHandler, metrics)
+
+	err := sender.Send(context.Background(), newTestEvent("id-1"))
+
+	require.Nil(t, err)
+	require.Len(t, eventSender.getSentEvents(), 1)
+	assert.Empty(t, deadLetterHandler.getEvents())
+	assert.Equal(t, int64(1), atomic.LoadInt64(&metrics.attempts))
+	assert.Equal(t, int64(1), atomic.LoadInt64(&metrics.deliveredCount))
+	assert.Equal(t, int64(0), atomic.LoadInt64(&metrics.retried))
+}
+
+func TestRetryingEventSender_RetriesFailedDelivery(t *testing.T) {
+	eventSender := &testEventSender{failures: 2}
+	deadLetterHandler := &testDeadLetterHandler{}
+	metrics := NewMetrics()
+	sender := NewRetryingEventSender(eventSender, newTestRetryPolicy(3, 10), deadLetterHandler, metrics)
+
+	err := sender.Send(context.Background(), newTestEvent("id-1"))
+	require.Nil(t, err)
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt64(&metrics.deliveredCount) == 1
+	}, time.Second, time.Millisecond)
+	assert.Len(t, eventSender.getSentEvents(), 1)
+	assert.Empty(t, deadLetterHandler.getEvents())
+	assert.Equal(t, int64(3), atomic.LoadInt64(&metrics.attempts))
+	assert.Equal(t, int64(2), atomic.LoadInt64(&metrics.failedAttempts))
+	assert.Equal(t, int64(1), atomic.LoadInt64(&metrics.retried))
+}
+
+func TestRetryingEventSender_DeadLettersAfterLastAttempt(t *testing.T) {
+	eventSender := &testEventSender{failures: 3}
+	deadLetterHandler := &testDeadLetterHandler{}
+	metrics := NewMetrics()
+	sender := NewRetryingEventSender(eventSender, newTestRetryPolicy(3, 10), deadLetterHandler, metrics)
+
+	err := sender.Send(context.Background(), newTestEvent("id-1"))
+	require.Nil(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(deadLetterHandler.getEvents()) == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, "id-1", deadLetterHandler.getEvents()[0].ID())
+	assert.Empty(t, eventSender.getSentEvents())
+	assert.Equal(t, int64(3), atomic.LoadInt64(&metrics.failedAttempts))
+	assert.Equal(t, int64(1), atomic.LoadInt64(&metrics.deadLetters))
+}
+
+func TestRetryingEventSender_WithoutRetries(t *testing.T) {
+	eventSender := &testEventSender{failures: 1}
+	deadLetterHandler := &testDeadLetterHandler{}
+	sender := NewRetryingEventSender(eventSender, newTestRetryPolicy(1, 10), deadLetterHandler, NewMetrics())
+
+	err := sender.Send(context.Background(), newTestEvent("id-1"))
+
+	require.NotNil(t, err)
+	assert.Len(t, deadLetterHandler.getEvents(), 1)
+}
+
+func TestRetryingEventSender_RetryQueueFull(t *testing.T) {
+	eventSender := &testEventSender{failures: 2}
+	deadLetterHandler := &testDeadLetterHandler{}
+	policy := newTestRetryPolicy(3, 1)
+	policy.InitialBackoff = time.Hour
+	sender := NewRetryingEventSender(eventSender, policy, deadLetterHandler, NewMetrics())
+
+	require.Nil(t, sender.Send(context.Background(), newTestEvent("id-1")))
+
+	err := sender.Send(context.Background(), newTestEvent("id-2"))
+	require.ErrorIs(t, err, ErrRetryQueueFull)
+	require.Len(t, deadLetterHandler.getEvents(), 1)
+	assert.Equal(t, "id-2", deadLetterHandler.getEvents()[0].ID())
+}
+
+func TestMetrics_ServeHTTP(t *testing.T) {
+	metrics := NewMetrics()
+	metrics.attempt()
+	metrics.attempt()
+	metrics.failedAttempt()
+	metrics.delivered(2)
+	metrics.setQueueSize(3)
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := recorder.Body.String()
+	assert.Contains(t, body, "# TYPE keptn_distributor_delivery_attempts_total counter\nkeptn_distributor_delivery_attempts_total 2\n")
+	assert.Contains(t, body, "keptn_distributor_delivery_failed_attempts_total 1\n")
+	assert.Contains(t, body, "keptn_distributor_events_delivered_total 1\n")
+	assert.Contains(t, body, "keptn_distributor_events_delivered_after_retry_total 1\n")
+	assert.Contains(t, body, "keptn_distributor_events_dead_lettered_total 0\n")
+	assert.Contains(t, body, "# TYPE keptn_distributor_retry_queue_size gauge\nkeptn_distributor_retry_queue_size 3\n")
+}
@@ -2,6 +2,7 @@ package forwarder
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	cenats "github.com/cloudevents/sdk-go/protocol/nats/v2"
@@ -12,10 +13,12 @@ import (
 	"github.com/keptn/keptn/distributor/pkg/utils"
 	"github.com/nats-io/nats.go"
 	logger "github.com/sirupsen/logrus"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -33,6 +36,16 @@ type Forwarder struct {
 	pubSubConnections map[string]*cenats.Sender
 	env               config.EnvConfig
 	maxBytes          int64
+	handlers          map[string]http.HandlerFunc
+	eventWriter       io.Writer
+	eventWriterMutex  sync.Mutex
+}
+
+// WithEventWriter lets the Forwarder write the events as JSON lines to the given writer instead of sending them to Keptn
+func WithEventWriter(w io.Writer) func(f *Forwarder) {
+	return func(f *Forwarder) {
+		f.eventWriter = w
+	}
 }
 
 func New(keptnEventAPI api.APIV1Interface, client *http.Client, env config.EnvConfig, opts ...func(f *Forwarder)) *Forwarder {
@@ -42,6 +55,7 @@ func New(keptnEventAPI api.APIV1Interface, client *http.Client, env config.EnvCo
 		httpClient:        client,
 		pubSubConnections: map[string]*cenats.Sender{},
 		env:               env,
+		handlers:          map[string]http.HandlerFunc{},
 	}
 
 	for _, o := range opts {
@@ -51,11 +65,19 @@ func New(keptnEventAPI api.APIV1Interface, client *http.Client, env config.EnvCo
 	return fw
 }
 
+// Handle registers an additional handler at the given path of the API proxy. It has to be called before Start
+func (f *Forwarder) Handle(path string, handler http.HandlerFunc) {
+	f.handlers[path] = handler
+}
+
 func (f *Forwarder) Start(executionContext *utils.ExecutionContext) {
 	mux := http.NewServeMux()
 	mux.Handle("/health", f.httpMiddleWare(api.HealthEndpointHandler))
 	mux.Handle(f.env.EventForwardingPath, f.httpMiddleWare(f.handleEvent))
 	mux.Handle(f.env.APIProxyPath, f.httpMiddleWare(f.apiProxyHandler))
+	for path, handler := range f.handlers {
+		mux.Handle(path, f.httpMiddleWare(handler))
+	}
 	serverURL := fmt.Sprintf("localhost:%d", f.env.APIProxyPort)
 
 	svr := &http.Server{
@@ -108,6 +130,12 @@ func (f *Forwarder) handleEvent(rw http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// Send forwards the given event to Keptn, which allows the Forwarder to be used as the sender for events
+// created by the distributor itself
+func (f *Forwarder) Send(_ context.Context, event cloudevents.Event) error {
+	return f.forwardEvent(event)
+}
+
 func (f *Forwarder) forwardEvent(event cloudevents.Event) error {
 	logger.Infof("Received CloudEvent with ID %s - Forwarding to Keptn", event.ID())
 	select {
@@ -117,6 +145,9 @@ func (f *Forwarder) forwardEvent(event cloudevents.Event) error {
 		// no-op
 	}
 
+	if f.eventWriter != nil {
+		return f.writeEvent(event)
+	}
 	if event.Context.GetType() == v0_2_0.ErrorLogEventName {
 		return nil
 	}
@@ -127,6 +158,17 @@ func (f *Forwarder) forwardEvent(event cloudevents.Event) error {
 	return f.forwardEventToAPI(event)
 }
 
+func (f *Forwarder) writeEvent(event cloudevents.Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	f.eventWriterMutex.Lock()
+	defer f.eventWriterMutex.Unlock()
+	_, err = f.eventWriter.Write(append(line, '\n'))
+	return err
+}
+
 func (f *Forwarder) forwardEventToNATSServer(event cloudevents.Event) error {
 	pubSubConnection, err := f.createPubSubConnection(event.Context.GetType())
 	if err != nil {
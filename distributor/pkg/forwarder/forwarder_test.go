@@ -318,3 +318,21 @@ func runNATSServer() (*server.Server, func()) {
 	svr := natsserver.RunRandClientPortServer()
 	return svr, func() { svr.Shutdown() }
 }
+
+func Test_WriteEvents(t *testing.T) {
+	output := &bytes.Buffer{}
+	f := New(nil, &http.Client{}, config.EnvConfig{}, WithEventWriter(output))
+
+	for _, e := range []string{taskStartedEvent, taskFinishedEvent} {
+		event, err := utils.DecodeNATSMessage([]byte(e))
+		assert.Nil(t, err)
+		assert.Nil(t, f.Send(context.Background(), *event))
+	}
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	assert.Len(t, lines, 2)
+	written, err := utils.DecodeNATSMessage([]byte(lines[1]))
+	assert.Nil(t, err)
+	assert.Equal(t, "5de83495-4f83-481c-8dbe-fcceb2e0243b", written.ID())
+	assert.Equal(t, "sh.keptn.events.task.finished", written.Type())
+}
@@ -0,0 +1,278 @@
+package ledger
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	logger "github.com/sirupsen/logrus"
+)
+
+// minCompactionRecords is the number of records the ledger file may contain before it is compacted
+const minCompactionRecords = 1000
+
+// Ledger records the events that have been delivered for each subscription, so that an event is delivered at most once per subscription.
+// If the ledger is backed by a file, the recorded deliveries survive restarts of the distributor
+type Ledger struct {
+	mutex     sync.Mutex
+	entries   map[key]entry
+	retention time.Duration
+	path      string
+	file      *os.File
+	records   int
+	// lastEviction is the last time the expired entries have been removed
+	lastEviction time.Time
+	now          func() time.Time
+}
+
+type key struct {
+	subscriptionID string
+	eventID        string
+}
+
+type entry struct {
+	// lastSeen is the last time the event has been recorded for the subscription
+	lastSeen time.Time
+	// persisted is the last time the entry has been written to the ledger file
+	persisted time.Time
+}
+
+// record is a single line of the ledger file
+type record struct {
+	SubscriptionID string    `json:"subscriptionID"`
+	EventID        string    `json:"eventID"`
+	Time           time.Time `json:"time"`
+	Removed        bool      `json:"removed,omitempty"`
+}
+
+// New creates a new in-memory Ledger. Entries expire after the given retention, a retention <= 0 keeps them forever
+func New(retention time.Duration) *Ledger {
+	return &Ledger{
+		entries:   map[key]entry{},
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// Open creates a new Ledger backed by the file at the given path. Existing entries of the file are loaded
+func Open(path string, retention time.Duration) (*Ledger, error) {
+	l := New(retention)
+	l.path = path
+
+	if err := l.load(); err != nil {
+		return nil, fmt.Errorf("could not load delivery ledger %s: %w", path, err)
+	}
+	if err := l.compact(); err != nil {
+		return nil, fmt.Errorf("could not write delivery ledger %s: %w", path, err)
+	}
+	return l, nil
+}
+
+// Record records the delivery of the given event for the given subscription.
+// It returns false if the event has already been recorded for the subscription. In that case, the retention of the entry is extended
+func (l *Ledger) Record(subscriptionID, eventID string) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	k := key{subscriptionID: subscriptionID, eventID: eventID}
+	now := l.now()
+	l.evictExpired(now)
+	existing, found := l.entries[k]
+	if found && l.expired(existing, now) {
+		found = false
+	}
+
+	e := entry{lastSeen: now, persisted: existing.persisted}
+	if !found || (l.retention > 0 && now.Sub(existing.persisted) > l.retention/2) {
+		l.write(record{SubscriptionID: subscriptionID, EventID: eventID, Time: now})
+		e.persisted = now
+	}
+	l.entries[k] = e
+	return !found
+}
+
+// Contains checks whether the given event has been recorded for the given subscription
+func (l *Ledger) Contains(subscriptionID, eventID string) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	e, ok := l.entries[key{subscriptionID: subscriptionID, eventID: eventID}]
+	return ok && !l.expired(e, l.now())
+}
+
+// Remove removes the given event of the given subscription, e.g. if it could not be delivered
+func (l *Ledger) Remove(subscriptionID, eventID string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	k := key{subscriptionID: subscriptionID, eventID: eventID}
+	if _, ok := l.entries[k]; !ok {
+		return
+	}
+	delete(l.entries, k)
+	l.write(record{SubscriptionID: subscriptionID, EventID: eventID, Time: l.now(), Removed: true})
+}
+
+// Keep removes all events of the given subscription except the given ones
+func (l *Ledger) Keep(subscriptionID string, eventIDs []string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	keep := map[string]bool{}
+	for _, id := range eventIDs {
+		keep[id] = true
+	}
+	for k := range l.entries {
+		if k.subscriptionID == subscriptionID && !keep[k.eventID] {
+			delete(l.entries, k)
+			l.write(record{SubscriptionID: k.subscriptionID, EventID: k.eventID, Time: l.now(), Removed: true})
+		}
+	}
+}
+
+// Close closes the ledger file
+func (l *Ledger) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+func (l *Ledger) expired(e entry, now time.Time) bool {
+	return l.retention > 0 && now.Sub(e.lastSeen) > l.retention
+}
+
+// evictExpired removes the expired entries at most every half retention, so that the ledger does not grow if events are
+// only recorded. The ledger file is compacted with the next write once it contains too many expired entries. The caller must hold the mutex
+func (l *Ledger) evictExpired(now time.Time) {
+	if l.retention <= 0 || now.Sub(l.lastEviction) < l.retention/2 {
+		return
+	}
+	l.lastEviction = now
+	for k, e := range l.entries {
+		if l.expired(e, now) {
+			delete(l.entries, k)
+		}
+	}
+}
+
+func (l *Ledger) load() error {
+	file, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		r := record{}
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			// the last line might be incomplete if the distributor has been terminated while writing it
+			logger.Warnf("Skipping invalid entry of delivery ledger %s: %v", l.path, err)
+			continue
+		}
+		k := key{subscriptionID: r.SubscriptionID, eventID: r.EventID}
+		if r.Removed {
+			delete(l.entries, k)
+		} else {
+			l.entries[k] = entry{lastSeen: r.Time, persisted: r.Time}
+		}
+	}
+	return scanner.Err()
+}
+
+// write appends the given record to the ledger file. The caller must hold the mutex
+func (l *Ledger) write(r record) {
+	if l.path == "" {
+		return
+	}
+	if l.records > minCompactionRecords && l.records > 2*len(l.entries) {
+		if err := l.compact(); err != nil {
+			logger.Errorf("Could not compact delivery ledger %s: %v", l.path, err)
+		}
+	}
+	if l.file == nil {
+		return
+	}
+
+	line, err := json.Marshal(r)
+	if err != nil {
+		logger.Errorf("Could not write delivery ledger %s: %v", l.path, err)
+		return
+	}
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		logger.Errorf("Could not write delivery ledger %s: %v", l.path, err)
+		return
+	}
+	l.records++
+}
+
+// compact replaces the ledger file with a file containing only the entries that have not expired. The caller must hold the mutex
+func (l *Ledger) compact() error {
+	now := l.now()
+	tmpPath := l.path + ".tmp"
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(tmp)
+	records := 0
+	for k, e := range l.entries {
+		if l.expired(e, now) {
+			delete(l.entries, k)
+			continue
+		}
+		line, err := json.Marshal(record{SubscriptionID: k.subscriptionID, EventID: k.eventID, Time: e.lastSeen})
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+		if _, err := writer.Write(append(line, '\n')); err != nil {
+			tmp.Close()
+			return err
+		}
+		l.entries[k] = entry{lastSeen: e.lastSeen, persisted: e.lastSeen}
+		records++
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if l.file != nil {
+		l.file.Close()
+		l.file = nil
+	}
+	if err := os.Rename(tmpPath, l.path); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	l.file = file
+	l.records = records
+	return nil
+}
@@ -0,0 +1,169 @@
+package ledger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedger_Record(t *testing.T) {
+	l := New(0)
+
+	assert.False(t, l.Contains("sub1", "event1"))
+	assert.True(t, l.Record("sub1", "event1"))
+	assert.False(t, l.Record("sub1", "event1"))
+	assert.True(t, l.Contains("sub1", "event1"))
+
+	// events are recorded per subscription
+	assert.False(t, l.Contains("sub2", "event1"))
+	assert.True(t, l.Record("sub2", "event1"))
+
+	l.Remove("sub1", "event1")
+	assert.False(t, l.Contains("sub1", "event1"))
+	assert.True(t, l.Contains("sub2", "event1"))
+	assert.True(t, l.Record("sub1", "event1"))
+}
+
+func TestLedger_Keep(t *testing.T) {
+	l := New(0)
+	l.Record("sub1", "event1")
+	l.Record("sub1", "event2")
+	l.Record("sub2", "event3")
+
+	l.Keep("sub1", []string{"event2"})
+
+	assert.False(t, l.Contains("sub1", "event1"))
+	assert.True(t, l.Contains("sub1", "event2"))
+	assert.True(t, l.Contains("sub2", "event3"))
+}
+
+func TestLedger_Retention(t *testing.T) {
+	now := time.Now()
+	l := New(time.Hour)
+	l.now = func() time.Time { return now }
+
+	l.Record("sub1", "event1")
+	l.Record("sub1", "event2")
+
+	now = now.Add(50 * time.Minute)
+	// recording the event again extends its retention
+	assert.False(t, l.Record("sub1", "event2"))
+
+	now = now.Add(20 * time.Minute)
+	assert.False(t, l.Contains("sub1", "event1"))
+	assert.True(t, l.Contains("sub1", "event2"))
+	assert.True(t, l.Record("sub1", "event1"))
+}
+
+func TestLedger_EvictExpired(t *testing.T) {
+	now := time.Now()
+	l := New(time.Hour)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 10; i++ {
+		l.Record("sub1", fmt.Sprintf("event%d", i))
+	}
+	require.Len(t, l.entries, 10)
+
+	now = now.Add(2 * time.Hour)
+	l.Record("sub1", "event")
+	assert.Len(t, l.entries, 1)
+}
+
+func TestOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger", "deliveries.jsonl")
+
+	l, err := Open(path, time.Hour)
+	require.Nil(t, err)
+	l.Record("sub1", "event1")
+	l.Record("sub1", "event2")
+	l.Record("sub2", "event1")
+	l.Remove("sub1", "event2")
+	require.Nil(t, l.Close())
+
+	// the deliveries survive a restart
+	l, err = Open(path, time.Hour)
+	require.Nil(t, err)
+	assert.True(t, l.Contains("sub1", "event1"))
+	assert.False(t, l.Contains("sub1", "event2"))
+	assert.True(t, l.Contains("sub2", "event1"))
+	assert.False(t, l.Record("sub1", "event1"))
+	require.Nil(t, l.Close())
+
+	// expired entries are not loaded
+	l, err = Open(path, time.Hour)
+	require.Nil(t, err)
+	l.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	assert.False(t, l.Contains("sub1", "event1"))
+	require.Nil(t, l.Close())
+}
+
+func TestOpen_InvalidEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deliveries.jsonl")
+	content := fmt.Sprintf(`{"subscriptionID":"sub1","eventID":"event1","time":"%s"}
+{"subscriptionID":"sub1","eventID":"ev`, time.Now().Format(time.RFC3339))
+	require.Nil(t, os.WriteFile(path, []byte(content), 0644))
+
+	l, err := Open(path, time.Hour)
+	require.Nil(t, err)
+	defer l.Close()
+	assert.True(t, l.Contains("sub1", "event1"))
+	assert.True(t, l.Record("sub1", "event2"))
+
+	written, err := os.ReadFile(path)
+	require.Nil(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(string(written)), "\n"), 2)
+}
+
+func TestLedger_Compaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deliveries.jsonl")
+	l, err := Open(path, time.Hour)
+	require.Nil(t, err)
+	defer l.Close()
+
+	for i := 0; i < 3*minCompactionRecords; i++ {
+		l.Record("sub1", fmt.Sprintf("event%d", i))
+		l.Remove("sub1", fmt.Sprintf("event%d", i))
+	}
+	l.Record("sub1", "event")
+
+	written, err := os.ReadFile(path)
+	require.Nil(t, err)
+	assert.Less(t, len(strings.Split(string(written), "\n")), 2*minCompactionRecords+10)
+
+	reopened, err := Open(path, time.Hour)
+	require.Nil(t, err)
+	defer reopened.Close()
+	assert.True(t, reopened.Contains("sub1", "event"))
+	assert.False(t, reopened.Contains("sub1", "event0"))
+}
+
+func TestLedger_CompactionOfExpiredEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deliveries.jsonl")
+	l, err := Open(path, time.Hour)
+	require.Nil(t, err)
+	defer l.Close()
+	now := time.Now()
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 3*minCompactionRecords; i++ {
+		l.Record("sub1", fmt.Sprintf("event%d", i))
+	}
+
+	// only new events are recorded after the others expired
+	now = now.Add(2 * time.Hour)
+	for i := 0; i < 10; i++ {
+		l.Record("sub1", fmt.Sprintf("new-event%d", i))
+	}
+
+	written, err := os.ReadFile(path)
+	require.Nil(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(string(written)), "\n"), 10)
+	assert.Len(t, l.entries, 10)
+}
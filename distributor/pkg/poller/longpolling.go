@@ -0,0 +1,156 @@
+package poller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/distributor/pkg/config"
+)
+
+// ErrLongPollingNotSupported indicates that the Keptn API does not support long polling for open .triggered events
+var ErrLongPollingNotSupported = errors.New("long polling is not supported by the Keptn API")
+
+// additional time granted to the Keptn API to answer a long polling request after the wait timeout has been reached
+const longPollingResponseGracePeriod = 10 * time.Second
+
+// LongPollingClient retrieves open .triggered events from the Keptn API.
+// If the caller already knows the current set of open events, the Keptn API holds the request until this set changes
+type LongPollingClient interface {
+	// GetOpenTriggeredEvents returns the open .triggered events matching the filter, together with the ETag identifying them.
+	// If etag is set, the request blocks until the open events differ from the ones identified by the etag, or until the wait timeout is reached.
+	// In the latter case, changed is false and no events are returned
+	GetOpenTriggeredEvents(ctx context.Context, filter EventFilter, etag string) (events []*apimodels.KeptnContextExtendedCE, newETag string, changed bool, err error)
+}
+
+// HTTPLongPollingClient implements LongPollingClient for the /controlPlane/v1/event/triggered endpoint of the Keptn API
+type HTTPLongPollingClient struct {
+	httpClient  *http.Client
+	endpoint    string
+	apiToken    string
+	waitTimeout time.Duration
+}
+
+// NewHTTPLongPollingClient creates a new HTTPLongPollingClient
+func NewHTTPLongPollingClient(httpClient *http.Client, env config.EnvConfig) *HTTPLongPollingClient {
+	// the timeout of the shared client would abort requests held by the Keptn API, therefore it is replaced by a per-request deadline
+	longPollingHTTPClient := *httpClient
+	longPollingHTTPClient.Timeout = 0
+	return &HTTPLongPollingClient{
+		httpClient:  &longPollingHTTPClient,
+		endpoint:    env.HTTPPollingEndpoint(),
+		apiToken:    env.KeptnAPIToken,
+		waitTimeout: env.LongPollingTimeout(),
+	}
+}
+
+func (c *HTTPLongPollingClient) GetOpenTriggeredEvents(ctx context.Context, filter EventFilter, etag string) ([]*apimodels.KeptnContextExtendedCE, string, bool, error) {
+	events := []*apimodels.KeptnContextExtendedCE{}
+	newETag := ""
+	nextPageKey := ""
+
+	for {
+		// only the first page is requested with long polling, the subsequent pages are returned immediately
+		waitForChanges := nextPageKey == "" && etag != ""
+		result, responseETag, err := c.getEventsPage(ctx, filter, nextPageKey, etag, waitForChanges)
+		if err != nil {
+			return nil, "", false, err
+		}
+		if result == nil {
+			return nil, etag, false, nil
+		}
+		if newETag == "" {
+			newETag = responseETag
+		}
+
+		events = append(events, result.Events...)
+		if result.NextPageKey == "" || result.NextPageKey == "0" {
+			break
+		}
+		nextPageKey = result.NextPageKey
+	}
+	return events, newETag, true, nil
+}
+
+func (c *HTTPLongPollingClient) getEventsPage(ctx context.Context, filter EventFilter, nextPageKey string, etag string, waitForChanges bool) (*apimodels.Events, string, error) {
+	requestURL, err := url.Parse(c.endpoint + "/" + filter.EventType)
+	if err != nil {
+		return nil, "", err
+	}
+
+	query := requestURL.Query()
+	if filter.Project != "" {
+		query.Set("project", filter.Project)
+	}
+	if filter.Stage != "" {
+		query.Set("stage", filter.Stage)
+	}
+	if filter.Service != "" {
+		query.Set("service", filter.Service)
+	}
+	if filter.SubscriptionID != "" {
+		query.Set("subscriptionID", filter.SubscriptionID)
+		query.Set("integrationID", filter.IntegrationID)
+	}
+	if nextPageKey != "" {
+		query.Set("nextPageKey", nextPageKey)
+	}
+
+	timeout := longPollingResponseGracePeriod
+	if waitForChanges {
+		query.Set("waitTimeout", strconv.Itoa(int(c.waitTimeout.Seconds())))
+		timeout += c.waitTimeout
+	}
+	requestURL.RawQuery = query.Encode()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL.String(), nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.apiToken != "" {
+		req.Header.Set("x-token", c.apiToken)
+	}
+	if waitForChanges {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, etag, nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("could not retrieve events of type %s: unexpected status code %d: %s", filter.EventType, resp.StatusCode, string(body))
+	}
+
+	responseETag := resp.Header.Get("ETag")
+	if responseETag == "" {
+		return nil, "", ErrLongPollingNotSupported
+	}
+
+	result := &apimodels.Events{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, "", err
+	}
+	return result, responseETag, nil
+}
@@ -0,0 +1,117 @@
+package poller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnapi "github.com/keptn/go-utils/pkg/api/utils"
+	"github.com/keptn/keptn/distributor/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLongPollingClient(serverURL string) *HTTPLongPollingClient {
+	return NewHTTPLongPollingClient(&http.Client{Timeout: time.Millisecond}, config.EnvConfig{
+		KeptnAPIEndpoint:       serverURL,
+		KeptnAPIToken:          "my-token",
+		HTTPLongPollingTimeout: 5 * time.Second,
+	})
+}
+
+func TestHTTPLongPollingClient_GetOpenTriggeredEvents(t *testing.T) {
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		requests = append(requests, request)
+		events := apimodels.Events{}
+		if request.URL.Query().Get("nextPageKey") == "" {
+			events.Events = []*apimodels.KeptnContextExtendedCE{{ID: "id-1"}}
+			events.NextPageKey = "1"
+		} else {
+			events.Events = []*apimodels.KeptnContextExtendedCE{{ID: "id-2"}}
+			events.NextPageKey = "0"
+		}
+		w.Header().Set("ETag", `"new-etag"`)
+		marshal, _ := json.Marshal(events)
+		w.Write(marshal)
+	}))
+	defer server.Close()
+
+	client := newTestLongPollingClient(server.URL)
+	events, etag, changed, err := client.GetOpenTriggeredEvents(context.Background(), EventFilter{
+		EventFilter: keptnapi.EventFilter{
+			EventType: "sh.keptn.event.task.triggered",
+			Project:   "a",
+		},
+		SubscriptionID: "my-subscription",
+		IntegrationID:  "my-integration",
+	}, `"old-etag"`)
+
+	require.Nil(t, err)
+	require.True(t, changed)
+	assert.Equal(t, `"new-etag"`, etag)
+	require.Len(t, events, 2)
+	assert.Equal(t, "id-1", events[0].ID)
+	assert.Equal(t, "id-2", events[1].ID)
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, "/controlPlane/v1/event/triggered/sh.keptn.event.task.triggered", requests[0].URL.Path)
+	assert.Equal(t, "my-token", requests[0].Header.Get("x-token"))
+	assert.Equal(t, "a", requests[0].URL.Query().Get("project"))
+	assert.Equal(t, "my-subscription", requests[0].URL.Query().Get("subscriptionID"))
+	assert.Equal(t, "my-integration", requests[0].URL.Query().Get("integrationID"))
+	// only the first page is requested with long polling
+	assert.Equal(t, `"old-etag"`, requests[0].Header.Get("If-None-Match"))
+	assert.Equal(t, "5", requests[0].URL.Query().Get("waitTimeout"))
+	assert.Empty(t, requests[1].Header.Get("If-None-Match"))
+	assert.Empty(t, requests[1].URL.Query().Get("waitTimeout"))
+	assert.Equal(t, "1", requests[1].URL.Query().Get("nextPageKey"))
+}
+
+func TestHTTPLongPollingClient_GetOpenTriggeredEventsNotModified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		// held longer than the timeout of the shared http client
+		time.Sleep(10 * time.Millisecond)
+		w.Header().Set("ETag", request.Header.Get("If-None-Match"))
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+
+	client := newTestLongPollingClient(server.URL)
+	events, etag, changed, err := client.GetOpenTriggeredEvents(context.Background(), EventFilter{EventFilter: keptnapi.EventFilter{EventType: "sh.keptn.event.task.triggered"}}, `"my-etag"`)
+
+	require.Nil(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, `"my-etag"`, etag)
+	assert.Empty(t, events)
+}
+
+func TestHTTPLongPollingClient_GetOpenTriggeredEventsNotSupported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		marshal, _ := json.Marshal(apimodels.Events{})
+		w.Write(marshal)
+	}))
+	defer server.Close()
+
+	client := newTestLongPollingClient(server.URL)
+	_, _, _, err := client.GetOpenTriggeredEvents(context.Background(), EventFilter{EventFilter: keptnapi.EventFilter{EventType: "sh.keptn.event.task.triggered"}}, "")
+
+	assert.ErrorIs(t, err, ErrLongPollingNotSupported)
+}
+
+func TestHTTPLongPollingClient_GetOpenTriggeredEventsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := newTestLongPollingClient(server.URL)
+	_, _, _, err := client.GetOpenTriggeredEvents(context.Background(), EventFilter{EventFilter: keptnapi.EventFilter{EventType: "sh.keptn.event.task.triggered"}}, "")
+
+	require.NotNil(t, err)
+	assert.NotErrorIs(t, err, ErrLongPollingNotSupported)
+}
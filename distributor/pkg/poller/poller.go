@@ -9,11 +9,15 @@ import (
 	api "github.com/keptn/go-utils/pkg/api/utils"
 	"github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/keptn/distributor/pkg/config"
+	"github.com/keptn/keptn/distributor/pkg/ledger"
 	"github.com/keptn/keptn/distributor/pkg/model"
+	"github.com/keptn/keptn/distributor/pkg/transform"
 	"github.com/keptn/keptn/distributor/pkg/utils"
 	logger "github.com/sirupsen/logrus"
 
+	"reflect"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -33,20 +37,68 @@ func CreateEventSender(env config.EnvConfig) (EventSender, error) {
 type Poller struct {
 	shipyardControlAPI   api.ShipyardControlV1Interface
 	eventSender          EventSender
-	ceCache              *utils.Cache
+	deliveries           *ledger.Ledger
 	env                  config.EnvConfig
 	eventMatcher         *utils.EventMatcher
 	currentSubscriptions []apimodels.EventSubscription
+	integrationID        string
+	longPollingClient    LongPollingClient
+	transformer          *transform.Transformer
+	// mutex protects the current subscriptions and the state of the long polling loops
+	mutex                  sync.Mutex
+	longPollingCtx         context.Context
+	longPolls              map[string]*longPoll
+	longPollingUnsupported bool
 }
 
-func New(envConfig config.EnvConfig, shipyardControlAPI api.ShipyardControlV1Interface, eventSender EventSender) *Poller {
-	return &Poller{
+// longPoll represents the long polling loop for a single subscription
+type longPoll struct {
+	subscription apimodels.EventSubscription
+	cancel       context.CancelFunc
+}
+
+// WithLongPolling lets the poller wait for new events of each subscription using long polling requests.
+// If the Keptn API does not support long polling, the poller falls back to polling in the configured interval
+func WithLongPolling(client LongPollingClient) func(p *Poller) {
+	return func(p *Poller) {
+		p.longPollingClient = client
+	}
+}
+
+// WithTransformer lets the poller apply the pipelines of the given transformer to the events before sending them
+func WithTransformer(transformer *transform.Transformer) func(p *Poller) {
+	return func(p *Poller) {
+		p.transformer = transformer
+	}
+}
+
+// WithIntegrationID lets the poller reference its subscriptions by the ID of the registered integration when polling for events
+func WithIntegrationID(integrationID string) func(p *Poller) {
+	return func(p *Poller) {
+		p.integrationID = integrationID
+	}
+}
+
+// WithLedger lets the poller record the delivered events in the given ledger
+func WithLedger(deliveries *ledger.Ledger) func(p *Poller) {
+	return func(p *Poller) {
+		p.deliveries = deliveries
+	}
+}
+
+func New(envConfig config.EnvConfig, shipyardControlAPI api.ShipyardControlV1Interface, eventSender EventSender, opts ...func(p *Poller)) *Poller {
+	p := &Poller{
 		shipyardControlAPI: shipyardControlAPI,
 		eventSender:        eventSender,
-		ceCache:            utils.NewCache(),
+		deliveries:         ledger.New(envConfig.DeliveryLedgerRetention),
 		env:                envConfig,
 		eventMatcher:       utils.NewEventMatcherFromEnv(envConfig),
+		longPolls:          map[string]*longPoll{},
 	}
+	for _, o := range opts {
+		o(p)
+	}
+	return p
 }
 
 func (p *Poller) Start(ctx *utils.ExecutionContext) error {
@@ -54,18 +106,17 @@ func (p *Poller) Start(ctx *utils.ExecutionContext) error {
 		return errors.New("could not start NatsEventReceiver: no pubsub recipient defined")
 	}
 
-	pollingInterval, err := strconv.ParseInt(p.env.HTTPPollingInterval, 10, 64)
-	if err != nil {
-		pollingInterval = config.DefaultPollingInterval
-	}
+	pollingInterval := p.pollingInterval()
 
 	logger.Infof("Polling events from: %s", p.env.HTTPPollingEndpoint())
+	p.startLongPolling(ctx)
 	for {
 		select {
-		case <-time.After(time.Duration(pollingInterval) * time.Second):
+		case <-time.After(pollingInterval):
 			p.doPollEvents()
 		case <-ctx.Done():
 			logger.Info("Terminating HTTP event poller")
+			p.stopLongPolling()
 			ctx.Wg.Done()
 			return nil
 		}
@@ -73,29 +124,156 @@ func (p *Poller) Start(ctx *utils.ExecutionContext) error {
 }
 
 func (p *Poller) UpdateSubscriptions(subscriptions []apimodels.EventSubscription) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
 	p.currentSubscriptions = subscriptions
+	p.syncLongPolls()
+}
+
+func (p *Poller) pollingInterval() time.Duration {
+	pollingInterval, err := strconv.ParseInt(p.env.HTTPPollingInterval, 10, 64)
+	if err != nil {
+		pollingInterval = config.DefaultPollingInterval
+	}
+	return time.Duration(pollingInterval) * time.Second
 }
 
 func (p *Poller) doPollEvents() {
+	p.mutex.Lock()
+	subscriptions := []apimodels.EventSubscription{}
 	for _, sub := range p.currentSubscriptions {
+		// subscriptions with an active long polling loop do not need to be polled
+		if _, ok := p.longPolls[sub.ID]; !ok {
+			subscriptions = append(subscriptions, sub)
+		}
+	}
+	p.mutex.Unlock()
+
+	for _, sub := range subscriptions {
 		p.pollEventsForSubscription(sub)
 	}
 }
 
+func (p *Poller) startLongPolling(ctx context.Context) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.longPollingClient == nil {
+		return
+	}
+	logger.Info("Using long polling to wait for new events")
+	p.longPollingCtx = ctx
+	p.syncLongPolls()
+}
+
+func (p *Poller) stopLongPolling() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.longPollingCtx = nil
+	p.syncLongPolls()
+}
+
+// disableLongPolling stops all long polling loops, leaving the subscriptions to the periodic polling
+func (p *Poller) disableLongPolling() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.longPollingUnsupported = true
+	p.syncLongPolls()
+}
+
+// syncLongPolls starts a long polling loop for every current subscription and stops the loops of removed or changed subscriptions.
+// The caller must hold the mutex of the poller
+func (p *Poller) syncLongPolls() {
+	active := p.longPollingCtx != nil && p.longPollingClient != nil && !p.longPollingUnsupported
+
+	subscriptions := map[string]apimodels.EventSubscription{}
+	if active {
+		for _, sub := range p.currentSubscriptions {
+			subscriptions[sub.ID] = sub
+		}
+	}
+
+	for id, lp := range p.longPolls {
+		if sub, ok := subscriptions[id]; !ok || !reflect.DeepEqual(sub, lp.subscription) {
+			lp.cancel()
+			delete(p.longPolls, id)
+		}
+	}
+	for id, sub := range subscriptions {
+		if _, ok := p.longPolls[id]; ok {
+			continue
+		}
+		ctx, cancel := context.WithCancel(p.longPollingCtx)
+		p.longPolls[id] = &longPoll{subscription: sub, cancel: cancel}
+		go p.longPollEventsForSubscription(ctx, sub)
+	}
+}
+
+func (p *Poller) longPollEventsForSubscription(ctx context.Context, subscription apimodels.EventSubscription) {
+	eventFilter := getEventFilterForSubscription(subscription, p.integrationID)
+	etag := ""
+	var knownEvents []*apimodels.KeptnContextExtendedCE
+	for {
+		if etag != "" && !p.allEventsSent(subscription, knownEvents) {
+			// sending an event failed - request the open events immediately to send it again
+			etag = ""
+		}
+		events, newETag, changed, err := p.longPollingClient.GetOpenTriggeredEvents(ctx, eventFilter, etag)
+		if ctx.Err() != nil {
+			return
+		}
+		if errors.Is(err, ErrLongPollingNotSupported) {
+			logger.Warnf("Long polling is not supported by the Keptn API, polling for new events every %s instead", p.pollingInterval())
+			p.disableLongPolling()
+			return
+		}
+		if err != nil {
+			logger.Warnf("Could not retrieve events of type %s: %s", subscription.Event, err)
+			etag = ""
+			select {
+			case <-time.After(p.pollingInterval()):
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+		if !changed {
+			continue
+		}
+		etag = newETag
+		knownEvents = events
+		p.processEvents(subscription, events)
+	}
+}
+
+func (p *Poller) allEventsSent(subscription apimodels.EventSubscription, events []*apimodels.KeptnContextExtendedCE) bool {
+	for _, event := range events {
+		if !p.deliveries.Contains(subscription.ID, event.ID) {
+			return false
+		}
+	}
+	return true
+}
+
 func (p *Poller) pollEventsForSubscription(subscription apimodels.EventSubscription) {
 
-	eventFilter := getEventFilterForSubscription(subscription)
-	events, err := p.shipyardControlAPI.GetOpenTriggeredEvents(eventFilter)
+	// the Keptn API client does not support referencing the subscription, only its single-valued filters are passed
+	eventFilter := getEventFilterForSubscription(subscription, p.integrationID)
+	events, err := p.shipyardControlAPI.GetOpenTriggeredEvents(eventFilter.EventFilter)
 	if err != nil {
 		logger.Warnf("Could not retrieve events of type %s: %s", subscription.Event, err)
 		return
 	}
 
+	p.processEvents(subscription, events)
+}
+
+func (p *Poller) processEvents(subscription apimodels.EventSubscription, events []*apimodels.KeptnContextExtendedCE) {
 	logger.Debugf("Received %d new .triggered events", len(events))
 	// iterate over all events, discard the event if it has already been sent
 	for index := range events {
 		event := *events[index]
-		if p.ceCache.Contains(subscription.ID, event.ID) {
+		// record the delivery before sending the event
+		if !p.deliveries.Record(subscription.ID, event.ID) {
 			// Skip this event as it has already been sent
 			logger.Infof("CloudEvent with ID %s has already been sent for subscription %s", event.ID, subscription.ID)
 			continue
@@ -106,31 +284,39 @@ func (p *Poller) pollEventsForSubscription(subscription apimodels.EventSubscript
 		if err := event.AddTemporaryData("distributor", model.AdditionalSubscriptionData{SubscriptionID: subscription.ID}, apimodels.AddTemporaryDataOptions{OverwriteIfExisting: true}); err != nil {
 			logger.Errorf("Could not add temporary information about subscriptions to event: %v", err)
 		}
-
-		// add to CloudEvents cache
-		p.ceCache.Add(subscription.ID, event.ID)
 		go func() {
 			logger.Infof("Sending CloudEvent with ID %s to %s", event.ID, p.env.PubSubRecipient)
 			if err := p.sendEvent(event, subscription); err != nil {
 				logger.Errorf("Sending CloudEvent with ID %s to %s failed: %s", event.ID, p.env.PubSubRecipient, err.Error())
-				// Sending failed, remove from CloudEvents cache
-				p.ceCache.Remove(subscription.ID, event.ID)
+				// Sending failed, remove from the ledger to send it again with the next poll
+				p.deliveries.Remove(subscription.ID, event.ID)
 			}
 		}()
 	}
 
 	logger.Debugf("Cleaning up list of sent events for topic %s", subscription.Event)
-	p.ceCache.Keep(subscription.ID, utils.ToIds(events))
+	p.deliveries.Keep(subscription.ID, utils.ToIds(events))
+}
+
+// EventFilter is the filter for the open .triggered events of a subscription.
+// Besides the filter supported by the Keptn API client, it references the subscription and its integration
+type EventFilter struct {
+	api.EventFilter
+	SubscriptionID string
+	IntegrationID  string
 }
 
 // getEventFilterForSubscription returns the event filter for the subscription
 // Per default, it only sets the event type of the subscription.
 // If exactly one project, stage or service is specified respectively, they are included in the filter.
-// However, this is only a (very) short term solution for the RBAC use case.
-// In the long term, we should just pass the subscription ID in the request, since the backend knows the required filters associated with the subscription.
-func getEventFilterForSubscription(subscription apimodels.EventSubscription) api.EventFilter {
-	eventFilter := api.EventFilter{
-		EventType: subscription.Event,
+// If the ID of the integration is known, the subscription is referenced as well, which lets the shipyard-controller
+// apply the complete filter of the subscription. Control planes not supporting this ignore the reference and return
+// a superset of the matching events, which are filtered by the event matcher of the poller
+func getEventFilterForSubscription(subscription apimodels.EventSubscription, integrationID string) EventFilter {
+	eventFilter := EventFilter{
+		EventFilter: api.EventFilter{
+			EventType: subscription.Event,
+		},
 	}
 
 	if len(subscription.Filter.Projects) == 1 {
@@ -142,6 +328,10 @@ func getEventFilterForSubscription(subscription apimodels.EventSubscription) api
 	if len(subscription.Filter.Services) == 1 {
 		eventFilter.Service = subscription.Filter.Services[0]
 	}
+	if integrationID != "" && subscription.ID != "" {
+		eventFilter.SubscriptionID = subscription.ID
+		eventFilter.IntegrationID = integrationID
+	}
 
 	return eventFilter
 }
@@ -153,10 +343,18 @@ func (p *Poller) sendEvent(e apimodels.KeptnContextExtendedCE, subscription apim
 		return nil
 	}
 
-	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
-	defer cancel()
+	if p.transformer != nil {
+		transformed, err := p.transformer.Transform(e, &subscription)
+		if err != nil {
+			return err
+		}
+		if transformed == nil {
+			return nil
+		}
+		event = v0_2_0.ToCloudEvent(*transformed)
+	}
 
-	if err := p.eventSender.Send(ctx, event); err != nil {
+	if err := p.eventSender.Send(context.Background(), event); err != nil {
 		return err
 	}
 
@@ -4,18 +4,24 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
 	keptnapi "github.com/keptn/go-utils/pkg/api/utils"
 	"github.com/keptn/go-utils/pkg/common/strutils"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	keptnfake "github.com/keptn/go-utils/pkg/lib/v0_2_0/fake"
 	"github.com/keptn/keptn/distributor/pkg/config"
+	"github.com/keptn/keptn/distributor/pkg/ledger"
+	"github.com/keptn/keptn/distributor/pkg/transform"
 	"github.com/keptn/keptn/distributor/pkg/utils"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -206,12 +212,13 @@ func Test_PollAndForwardEvents2(t *testing.T) {
 
 func Test_getEventFilterForSubscription(t *testing.T) {
 	type args struct {
-		subscription apimodels.EventSubscription
+		subscription  apimodels.EventSubscription
+		integrationID string
 	}
 	tests := []struct {
 		name string
 		args args
-		want keptnapi.EventFilter
+		want EventFilter
 	}{
 		{
 			name: "get default filter",
@@ -220,22 +227,62 @@ func Test_getEventFilterForSubscription(t *testing.T) {
 					Event: "my-event",
 				},
 			},
-			want: keptnapi.EventFilter{
-				EventType: "my-event",
+			want: EventFilter{
+				EventFilter: keptnapi.EventFilter{
+					EventType: "my-event",
+				},
+			},
+		},
+		{
+			name: "multiple projects",
+			args: args{
+				subscription: apimodels.EventSubscription{
+					Event: "my-event",
+					Filter: apimodels.EventSubscriptionFilter{
+						Projects: []string{"a", "b"},
+					},
+				},
+			},
+			want: EventFilter{
+				EventFilter: keptnapi.EventFilter{
+					EventType: "my-event",
+				},
 			},
 		},
 		{
-			name: "multiple projects - get default filter",
+			name: "multiple projects, stages and services with integration ID",
 			args: args{
 				subscription: apimodels.EventSubscription{
+					ID:    "my-subscription",
 					Event: "my-event",
 					Filter: apimodels.EventSubscriptionFilter{
 						Projects: []string{"a", "b"},
+						Stages:   []string{"dev", "staging"},
+						Services: []string{"service-a", "service-b"},
 					},
 				},
+				integrationID: "my-integration",
+			},
+			want: EventFilter{
+				EventFilter: keptnapi.EventFilter{
+					EventType: "my-event",
+				},
+				SubscriptionID: "my-subscription",
+				IntegrationID:  "my-integration",
+			},
+		},
+		{
+			name: "subscription without integration ID",
+			args: args{
+				subscription: apimodels.EventSubscription{
+					ID:    "my-subscription",
+					Event: "my-event",
+				},
 			},
-			want: keptnapi.EventFilter{
-				EventType: "my-event",
+			want: EventFilter{
+				EventFilter: keptnapi.EventFilter{
+					EventType: "my-event",
+				},
 			},
 		},
 		{
@@ -248,9 +295,11 @@ func Test_getEventFilterForSubscription(t *testing.T) {
 					},
 				},
 			},
-			want: keptnapi.EventFilter{
-				EventType: "my-event",
-				Project:   "a",
+			want: EventFilter{
+				EventFilter: keptnapi.EventFilter{
+					EventType: "my-event",
+					Project:   "a",
+				},
 			},
 		},
 		{
@@ -264,16 +313,19 @@ func Test_getEventFilterForSubscription(t *testing.T) {
 					},
 				},
 			},
-			want: keptnapi.EventFilter{
-				EventType: "my-event",
-				Project:   "a",
-				Stage:     "stage-a",
+			want: EventFilter{
+				EventFilter: keptnapi.EventFilter{
+					EventType: "my-event",
+					Project:   "a",
+					Stage:     "stage-a",
+				},
 			},
 		},
 		{
-			name: "one project, one stage, one service",
+			name: "one project, one stage, one service with integration ID",
 			args: args{
 				subscription: apimodels.EventSubscription{
+					ID:    "my-subscription",
 					Event: "my-event",
 					Filter: apimodels.EventSubscriptionFilter{
 						Projects: []string{"a"},
@@ -281,20 +333,216 @@ func Test_getEventFilterForSubscription(t *testing.T) {
 						Services: []string{"service-a"},
 					},
 				},
+				integrationID: "my-integration",
 			},
-			want: keptnapi.EventFilter{
-				EventType: "my-event",
-				Project:   "a",
-				Stage:     "stage-a",
-				Service:   "service-a",
+			want: EventFilter{
+				EventFilter: keptnapi.EventFilter{
+					EventType: "my-event",
+					Project:   "a",
+					Stage:     "stage-a",
+					Service:   "service-a",
+				},
+				SubscriptionID: "my-subscription",
+				IntegrationID:  "my-integration",
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := getEventFilterForSubscription(tt.args.subscription); !reflect.DeepEqual(got, tt.want) {
+			if got := getEventFilterForSubscription(tt.args.subscription, tt.args.integrationID); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("getEventFilterForSubscription() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func Test_LongPollAndForwardEvents(t *testing.T) {
+	var mutex sync.Mutex
+	openEvents := []*apimodels.KeptnContextExtendedCE{newTestTriggeredEvent("id-1")}
+	changed := make(chan struct{})
+
+	getETag := func() string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		ids := utils.ToIds(openEvents)
+		return `"` + strings.Join(ids, ",") + `"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		if request.URL.Query().Get("waitTimeout") != "" && request.Header.Get("If-None-Match") == getETag() {
+			select {
+			case <-changed:
+			case <-time.After(time.Second):
+				w.Header().Set("ETag", getETag())
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		w.Header().Set("ETag", getETag())
+		mutex.Lock()
+		marshal, _ := json.Marshal(apimodels.Events{Events: openEvents})
+		mutex.Unlock()
+		w.Write(marshal)
+	}))
+	defer server.Close()
+
+	envConfig := config.EnvConfig{
+		KeptnAPIEndpoint:       server.URL,
+		PubSubRecipient:        "http://127.0.0.1",
+		HTTPPollingInterval:    "3600",
+		HTTPLongPollingTimeout: time.Second,
+	}
+	var sentEvents int32
+	eventSender := keptnfake.EventSender{}
+	eventSender.AddReactor("*", func(event cloudevents.Event) error {
+		atomic.AddInt32(&sentEvents, 1)
+		return nil
+	})
+	apiset, _ := keptnapi.New(server.URL)
+	poller := New(envConfig, apiset.ShipyardControlV1(), &eventSender, WithLongPolling(NewHTTPLongPollingClient(&http.Client{}, envConfig)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	executionContext := utils.NewExecutionContext(ctx, 1)
+	poller.UpdateSubscriptions([]apimodels.EventSubscription{
+		{
+			ID:    "id1",
+			Event: "sh.keptn.event.task.triggered",
+		},
+	})
+	go poller.Start(executionContext)
+
+	// the polling interval is never reached, so the events can only be received via long polling
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&sentEvents) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mutex.Lock()
+	openEvents = append(openEvents, newTestTriggeredEvent("id-2"))
+	mutex.Unlock()
+	close(changed)
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&sentEvents) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	executionContext.Wg.Wait()
+}
+
+func Test_LongPollingFallsBackToPolling(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		// no ETag - the API does not support long polling
+		marshal, _ := json.Marshal(apimodels.Events{Events: []*apimodels.KeptnContextExtendedCE{newTestTriggeredEvent("id-1")}})
+		w.Write(marshal)
+	}))
+	defer server.Close()
+
+	envConfig := config.EnvConfig{
+		KeptnAPIEndpoint:       server.URL,
+		PubSubRecipient:        "http://127.0.0.1",
+		HTTPPollingInterval:    "1",
+		HTTPLongPollingTimeout: time.Second,
+	}
+	var sentEvents int32
+	eventSender := keptnfake.EventSender{}
+	eventSender.AddReactor("*", func(event cloudevents.Event) error {
+		atomic.AddInt32(&sentEvents, 1)
+		return nil
+	})
+	apiset, _ := keptnapi.New(server.URL)
+	poller := New(envConfig, apiset.ShipyardControlV1(), &eventSender, WithLongPolling(NewHTTPLongPollingClient(&http.Client{}, envConfig)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	executionContext := utils.NewExecutionContext(ctx, 1)
+	poller.UpdateSubscriptions([]apimodels.EventSubscription{
+		{
+			ID:    "id1",
+			Event: "sh.keptn.event.task.triggered",
+		},
+	})
+	go poller.Start(executionContext)
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&sentEvents) == 1
+	}, 5*time.Second, 100*time.Millisecond)
+
+	poller.mutex.Lock()
+	assert.True(t, poller.longPollingUnsupported)
+	assert.Empty(t, poller.longPolls)
+	poller.mutex.Unlock()
+
+	cancel()
+	executionContext.Wg.Wait()
+}
+
+func Test_sendEventAppliesTransformations(t *testing.T) {
+	transformer, err := transform.New(transform.Config{
+		Pipelines: []*transform.Pipeline{
+			{
+				SubscriptionID: "id1",
+				Transformations: []*transform.Transformation{
+					{Action: transform.ActionSet, Path: "data.image", Template: "{{ .data.some }}"},
+					{Action: transform.ActionDrop, Expression: `{{ eq .id "dropped" }}`},
+				},
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	eventSender := keptnfake.EventSender{}
+	poller := New(config.EnvConfig{}, nil, &eventSender, WithTransformer(transformer))
+
+	subscription := apimodels.EventSubscription{ID: "id1", Event: "sh.keptn.event.task.triggered"}
+	assert.Nil(t, poller.sendEvent(*newTestTriggeredEvent("sent"), subscription))
+	assert.Nil(t, poller.sendEvent(*newTestTriggeredEvent("dropped"), subscription))
+
+	assert.Len(t, eventSender.SentEvents, 1)
+	data := map[string]interface{}{}
+	assert.Nil(t, eventSender.SentEvents[0].DataAs(&data))
+	assert.Equal(t, map[string]interface{}{"some": "property", "image": "property"}, data)
+}
+
+func Test_processEventsDoesNotResendEventsAfterRestart(t *testing.T) {
+	ledgerFile := filepath.Join(t.TempDir(), "deliveries.jsonl")
+	subscription := apimodels.EventSubscription{ID: "id1", Event: "sh.keptn.event.task.triggered"}
+	events := []*apimodels.KeptnContextExtendedCE{newTestTriggeredEvent("1")}
+
+	var sentEvents int32
+	eventSender := keptnfake.EventSender{}
+	eventSender.AddReactor("*", func(event cloudevents.Event) error {
+		atomic.AddInt32(&sentEvents, 1)
+		return nil
+	})
+
+	deliveries, err := ledger.Open(ledgerFile, time.Hour)
+	assert.Nil(t, err)
+	poller := New(config.EnvConfig{}, nil, &eventSender, WithLedger(deliveries))
+	poller.processEvents(subscription, events)
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&sentEvents) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Nil(t, deliveries.Close())
+
+	// simulate a restart of the distributor
+	deliveries, err = ledger.Open(ledgerFile, time.Hour)
+	assert.Nil(t, err)
+	defer deliveries.Close()
+	poller = New(config.EnvConfig{}, nil, &eventSender, WithLedger(deliveries))
+	poller.processEvents(subscription, append(events, newTestTriggeredEvent("2")))
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&sentEvents) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&sentEvents))
+}
+
+func newTestTriggeredEvent(id string) *apimodels.KeptnContextExtendedCE {
+	return &apimodels.KeptnContextExtendedCE{
+		ID:          id,
+		Type:        strutils.Stringp("sh.keptn.event.task.triggered"),
+		Source:      strutils.Stringp("source"),
+		Specversion: "1.0",
+		Data:        map[string]interface{}{"some": "property"},
+	}
+}
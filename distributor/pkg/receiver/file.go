@@ -0,0 +1,177 @@
+package receiver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/distributor/pkg/config"
+	"github.com/keptn/keptn/distributor/pkg/poller"
+	"github.com/keptn/keptn/distributor/pkg/utils"
+	logger "github.com/sirupsen/logrus"
+)
+
+// StdinEventSource is the LOCAL_EVENT_SOURCE reading the events from stdin
+const StdinEventSource = "-"
+
+// eventFileExtensions are the extensions of the files read from a LOCAL_EVENT_SOURCE directory
+var eventFileExtensions = []string{".json", ".jsonl", ".ndjson"}
+
+// FileEventReceiver reads events from files or stdin and sends them to the keptn service
+// like the NATSEventReceiver, which allows running integrations without a control plane
+type FileEventReceiver struct {
+	env      config.EnvConfig
+	source   string
+	stdin    io.Reader
+	receiver *NATSEventReceiver
+}
+
+// NewFileEventReceiver creates a new FileEventReceiver reading the events from env.LocalEventSource.
+// The events are filtered and transformed using the given options of the NATSEventReceiver
+func NewFileEventReceiver(env config.EnvConfig, eventSender poller.EventSender, opts ...func(n *NATSEventReceiver)) *FileEventReceiver {
+	return &FileEventReceiver{
+		env:      env,
+		source:   env.LocalEventSource,
+		stdin:    os.Stdin,
+		receiver: New(env, eventSender, false, opts...),
+	}
+}
+
+// Start sends all events of the source to the keptn service and waits until the execution context is cancelled
+func (f *FileEventReceiver) Start(ctx *utils.ExecutionContext) error {
+	defer func() {
+		ctx.Wg.Done()
+		logger.Info("Terminating file event receiver")
+	}()
+
+	if err := f.readEvents(); err != nil {
+		return err
+	}
+	logger.Infof("All events of %s have been read", f.source)
+
+	<-ctx.Done()
+	return nil
+}
+
+func (f *FileEventReceiver) readEvents() error {
+	if f.source == StdinEventSource {
+		return f.readEventsFrom(f.stdin, "stdin")
+	}
+
+	info, err := os.Stat(f.source)
+	if err != nil {
+		return fmt.Errorf("could not read events from %s: %w", f.source, err)
+	}
+	files := []string{f.source}
+	if info.IsDir() {
+		if files, err = eventFiles(f.source); err != nil {
+			return fmt.Errorf("could not read events from %s: %w", f.source, err)
+		}
+	}
+
+	for _, file := range files {
+		if err := f.readEventsFromFile(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *FileEventReceiver) readEventsFromFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not read events from %s: %w", path, err)
+	}
+	defer file.Close()
+	return f.readEventsFrom(file, path)
+}
+
+// readEventsFrom sends the events of the given reader, which contains either a single event or a sequence of events, e.g. as JSON lines
+func (f *FileEventReceiver) readEventsFrom(reader io.Reader, name string) error {
+	decoder := json.NewDecoder(reader)
+	for {
+		raw := json.RawMessage{}
+		if err := decoder.Decode(&raw); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("could not read events from %s: %w", name, err)
+		}
+		f.handleEvent(raw, name)
+	}
+}
+
+func (f *FileEventReceiver) handleEvent(data []byte, name string) {
+	cloudEvent, err := utils.DecodeNATSMessage(data)
+	if err != nil {
+		logger.Errorf("Skipping invalid event of %s: %v", name, err)
+		return
+	}
+	if !matchesTopics(f.env.PubSubTopics(), cloudEvent.Type()) {
+		logger.Debugf("Skipping event %s of type %s, since it does not match PUBSUB_TOPIC", cloudEvent.ID(), cloudEvent.Type())
+		return
+	}
+
+	keptnEvent, err := v0_2_0.ToKeptnEvent(*cloudEvent)
+	if err != nil {
+		logger.Errorf("Skipping invalid event of %s: %v", name, err)
+		return
+	}
+	if err := f.receiver.sendEvent(keptnEvent, nil); err != nil {
+		logger.Errorf("Could not send cloud event: %v", err)
+	}
+}
+
+func eventFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		for _, extension := range eventFileExtensions {
+			if strings.EqualFold(filepath.Ext(entry.Name()), extension) {
+				files = append(files, filepath.Join(dir, entry.Name()))
+				break
+			}
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// matchesTopics checks whether the given event type matches one of the topics, using the wildcards of NATS subjects.
+// If no topics are given, all events match
+func matchesTopics(topics []string, eventType string) bool {
+	if len(topics) == 0 {
+		return true
+	}
+	for _, topic := range topics {
+		if matchesTopic(topic, eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesTopic(topic string, eventType string) bool {
+	topicTokens := strings.Split(topic, ".")
+	typeTokens := strings.Split(eventType, ".")
+	for i, token := range topicTokens {
+		if token == ">" {
+			return len(typeTokens) > i
+		}
+		if i >= len(typeTokens) || (token != "*" && token != typeTokens[i]) {
+			return false
+		}
+	}
+	return len(topicTokens) == len(typeTokens)
+}
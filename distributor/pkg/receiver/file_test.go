@@ -0,0 +1,143 @@
+package receiver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	keptnfake "github.com/keptn/go-utils/pkg/lib/v0_2_0/fake"
+	"github.com/keptn/keptn/distributor/pkg/config"
+	"github.com/keptn/keptn/distributor/pkg/transform"
+	"github.com/keptn/keptn/distributor/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingEventSender returns a fake event sender and a function returning the sent events
+func recordingEventSender() (*keptnfake.EventSender, func() []cloudevents.Event) {
+	var mutex sync.Mutex
+	var sentEvents []cloudevents.Event
+	eventSender := &keptnfake.EventSender{}
+	eventSender.AddReactor("*", func(event cloudevents.Event) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		sentEvents = append(sentEvents, event)
+		return nil
+	})
+	return eventSender, func() []cloudevents.Event {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]cloudevents.Event{}, sentEvents...)
+	}
+}
+
+func Test_FileEventReceiverReadsDirectory(t *testing.T) {
+	dir := t.TempDir()
+	// a single, pretty printed event
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "01-task.json"), []byte(`{
+  "data": {"project": "my-project", "stage": "stage1", "service": "service"},
+  "id": "event-1",
+  "source": "shipyard-controller",
+  "specversion": "1.0",
+  "type": "sh.keptn.event.task.triggered",
+  "shkeptncontext": "3c9ffbbb-6e1d-4789-9fee-6e63b4bcc1fb"
+}`), 0644))
+	// JSON lines, including an event that does not match PUBSUB_TOPIC
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "02-tasks.jsonl"), []byte(task2TriggerEvent+"\n"+strings.Replace(task1TriggerEvent, "6de83495-4f83-481c-8dbe-fcceb2e0243b", "event-2", 1)+"\n"), 0644))
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not an event"), 0644))
+
+	eventSender, sentEvents := recordingEventSender()
+	envConfig := config.EnvConfig{
+		PubSubRecipient:  "http://127.0.0.1",
+		PubSubTopic:      "sh.keptn.event.task.>",
+		LocalEventSource: dir,
+	}
+	transformer, err := transform.New(transform.Config{Pipelines: []*transform.Pipeline{{
+		Transformations: []*transform.Transformation{{Action: transform.ActionSet, Path: "data.source", Value: "file"}},
+	}}})
+	require.Nil(t, err)
+	receiver := NewFileEventReceiver(envConfig, eventSender, WithTransformer(transformer))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	executionContext := utils.NewExecutionContext(ctx, 1)
+	go receiver.Start(executionContext)
+
+	assert.Eventually(t, func() bool {
+		return len(sentEvents()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	events := sentEvents()
+	assert.Equal(t, "event-1", events[0].ID())
+	assert.Equal(t, "event-2", events[1].ID())
+	data := map[string]interface{}{}
+	require.Nil(t, events[0].DataAs(&data))
+	assert.Equal(t, "file", data["source"])
+
+	cancel()
+	executionContext.Wg.Wait()
+}
+
+func Test_FileEventReceiverReadsStdin(t *testing.T) {
+	eventSender, sentEvents := recordingEventSender()
+	envConfig := config.EnvConfig{
+		PubSubRecipient:  "http://127.0.0.1",
+		LocalEventSource: StdinEventSource,
+		StageFilter:      "dev",
+	}
+	receiver := NewFileEventReceiver(envConfig, eventSender)
+	receiver.stdin = strings.NewReader(task1TriggerEvent + "\ninvalid\n" + task2TriggerEvent)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	executionContext := utils.NewExecutionContext(ctx, 1)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- receiver.Start(executionContext)
+	}()
+
+	// the input ends with invalid JSON
+	assert.NotNil(t, <-errs)
+	executionContext.Wg.Wait()
+	cancel()
+
+	// the first event does not match the STAGE_FILTER, and reading stops at the invalid line
+	require.Len(t, sentEvents(), 0)
+
+	receiver.stdin = strings.NewReader(task1TriggerEvent + "\n" + task2TriggerEvent + "\n")
+	ctx, cancel = context.WithCancel(context.Background())
+	executionContext = utils.NewExecutionContext(ctx, 1)
+	go receiver.Start(executionContext)
+
+	assert.Eventually(t, func() bool {
+		return len(sentEvents()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "sh.keptn.event.task2.triggered", sentEvents()[0].Type())
+
+	cancel()
+	executionContext.Wg.Wait()
+}
+
+func Test_matchesTopic(t *testing.T) {
+	tests := []struct {
+		topic     string
+		eventType string
+		want      bool
+	}{
+		{topic: "sh.keptn.event.task.triggered", eventType: "sh.keptn.event.task.triggered", want: true},
+		{topic: "sh.keptn.event.task.triggered", eventType: "sh.keptn.event.task.finished", want: false},
+		{topic: "sh.keptn.event.*.triggered", eventType: "sh.keptn.event.task.triggered", want: true},
+		{topic: "sh.keptn.event.*.triggered", eventType: "sh.keptn.event.stage.task.triggered", want: false},
+		{topic: "sh.keptn.>", eventType: "sh.keptn.event.task.triggered", want: true},
+		{topic: "sh.keptn.event.task.>", eventType: "sh.keptn.event.task", want: false},
+		{topic: "sh.keptn.event", eventType: "sh.keptn.event.task.triggered", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.topic+" "+tt.eventType, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchesTopic(tt.topic, tt.eventType))
+		})
+	}
+}
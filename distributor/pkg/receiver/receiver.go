@@ -6,11 +6,12 @@ import (
 	"fmt"
 
 	"sync"
-	"time"
 
+	"github.com/keptn/keptn/distributor/pkg/ledger"
 	"github.com/keptn/keptn/distributor/pkg/model"
 	nats2 "github.com/keptn/keptn/distributor/pkg/natsconnection"
 	"github.com/keptn/keptn/distributor/pkg/poller"
+	"github.com/keptn/keptn/distributor/pkg/transform"
 	"github.com/keptn/keptn/distributor/pkg/utils"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
@@ -33,25 +34,44 @@ type NATSEventReceiver struct {
 	eventSender           poller.EventSender
 	eventMatcher          *utils.EventMatcher
 	natsConnectionHandler *nats2.NatsConnectionHandler
-	ceCache               *utils.Cache
+	deliveries            *ledger.Ledger
 	mutex                 *sync.Mutex
 	currentSubscriptions  []models.EventSubscription
 	pullSubscriptions     bool
+	transformer           *transform.Transformer
 }
 
-func New(env config.EnvConfig, eventSender poller.EventSender, pullSubscriptions bool) *NATSEventReceiver {
+// WithTransformer lets the receiver apply the pipelines of the given transformer to the events before sending them
+func WithTransformer(transformer *transform.Transformer) func(n *NATSEventReceiver) {
+	return func(n *NATSEventReceiver) {
+		n.transformer = transformer
+	}
+}
+
+// WithLedger lets the receiver record the delivered events in the given ledger
+func WithLedger(deliveries *ledger.Ledger) func(n *NATSEventReceiver) {
+	return func(n *NATSEventReceiver) {
+		n.deliveries = deliveries
+	}
+}
+
+func New(env config.EnvConfig, eventSender poller.EventSender, pullSubscriptions bool, opts ...func(n *NATSEventReceiver)) *NATSEventReceiver {
 	eventMatcher := utils.NewEventMatcherFromEnv(env)
 	nch := nats2.NewNatsConnectionHandler(env.PubSubURL)
 
-	return &NATSEventReceiver{
+	n := &NATSEventReceiver{
 		env:                   env,
 		eventSender:           eventSender,
 		eventMatcher:          eventMatcher,
-		ceCache:               utils.NewCache(),
+		deliveries:            ledger.New(env.DeliveryLedgerRetention),
 		mutex:                 &sync.Mutex{},
 		natsConnectionHandler: nch,
 		pullSubscriptions:     pullSubscriptions,
 	}
+	for _, o := range opts {
+		o(n)
+	}
+	return n
 }
 
 func (n *NATSEventReceiver) Start(ctx *utils.ExecutionContext) error {
@@ -128,22 +148,12 @@ func (n *NATSEventReceiver) handleMessage(m *nats.Msg) {
 
 func (n *NATSEventReceiver) sendEventForSubscriptions(subscriptions []models.EventSubscription, keptnEvent models.KeptnContextExtendedCE) error {
 	for i, subscription := range subscriptions {
-		// check if the event with the given ID has already been sent for the subscription
-		if n.ceCache.Contains(subscription.ID, keptnEvent.ID) {
+		// record the delivery, unless the event with the given ID has already been sent for the subscription
+		if !n.deliveries.Record(subscription.ID, keptnEvent.ID) {
 			// Skip this event as it has already been sent
 			logger.Debugf("CloudEvent with ID %s has already been sent", keptnEvent.ID)
 			continue
 		}
-		// add to CloudEvents cache
-		n.ceCache.Add(subscription.ID, keptnEvent.ID)
-
-		defer func(subscription models.EventSubscription) {
-			// after some time, remove the cache entry
-			go func() {
-				<-time.After(10 * time.Second)
-				n.ceCache.Remove(subscription.ID, keptnEvent.ID)
-			}()
-		}(subscription)
 
 		// add subscription ID as additional information to the keptn event
 		if err := keptnEvent.AddTemporaryData("distributor", model.AdditionalSubscriptionData{SubscriptionID: subscription.ID}, models.AddTemporaryDataOptions{OverwriteIfExisting: true}); err != nil {
@@ -181,11 +191,19 @@ func (n *NATSEventReceiver) sendEvent(e models.KeptnContextExtendedCE, subscript
 		return nil
 	}
 
-	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
-	defer cancel()
+	if n.transformer != nil {
+		transformed, err := n.transformer.Transform(e, subscription)
+		if err != nil {
+			return err
+		}
+		if transformed == nil {
+			return nil
+		}
+		event = v0_2_0.ToCloudEvent(*transformed)
+	}
 
 	logger.Infof("Sending CloudEvent with ID %s to %s", event.ID(), n.env.PubSubRecipient)
-	if err := n.eventSender.Send(ctx, event); err != nil {
+	if err := n.eventSender.Send(context.Background(), event); err != nil {
 		return err
 	}
 	return nil
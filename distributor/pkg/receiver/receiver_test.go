@@ -3,16 +3,19 @@ package receiver
 import (
 	"context"
 	"fmt"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/keptn/go-utils/pkg/api/models"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	keptnfake "github.com/keptn/go-utils/pkg/lib/v0_2_0/fake"
 	"github.com/keptn/keptn/distributor/pkg/config"
+	"github.com/keptn/keptn/distributor/pkg/transform"
 	"github.com/keptn/keptn/distributor/pkg/utils"
 	"github.com/nats-io/nats-server/v2/server"
 	natsserver "github.com/nats-io/nats-server/v2/test"
 	"github.com/nats-io/nats.go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"sync"
 	"testing"
 	"time"
 )
@@ -351,6 +354,76 @@ func Test_ReceiveFromNATSAndForwardEventNoSubscriptionPulling(t *testing.T) {
 	executionContext.Wg.Wait()
 }
 
+func Test_ReceiveFromNATSAndForwardTransformedEvent(t *testing.T) {
+	svr, shutdownNats := runNATSServer()
+	defer shutdownNats()
+	natsURL := svr.Addr().String()
+	natsPublisher, _ := nats.Connect(natsURL)
+
+	transformer, err := transform.New(transform.Config{
+		Pipelines: []*transform.Pipeline{
+			{
+				Event: "sh.keptn.event.task.triggered",
+				Transformations: []*transform.Transformation{
+					{Action: transform.ActionSet, Path: "data.namespace", Template: "{{ .data.project }}-{{ .data.stage }}"},
+				},
+			},
+			{
+				Event: "sh.keptn.event.task2.triggered",
+				Transformations: []*transform.Transformation{
+					{Action: transform.ActionDrop, Expression: `{{ eq .data.project "sockshop" }}`},
+				},
+			},
+		},
+	})
+	require.Nil(t, err)
+
+	var mutex sync.Mutex
+	var sentEvents []cloudevents.Event
+	eventSender := &keptnfake.EventSender{}
+	eventSender.AddReactor("*", func(event cloudevents.Event) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		sentEvents = append(sentEvents, event)
+		return nil
+	})
+	envConfig := config.EnvConfig{
+		PubSubRecipient: "http://127.0.0.1",
+		PubSubTopic:     "sh.keptn.event.task.triggered,sh.keptn.event.task2.triggered",
+		PubSubURL:       natsURL,
+	}
+	receiver := New(envConfig, eventSender, false, WithTransformer(transformer))
+	ctx, cancelReceiver := context.WithCancel(context.Background())
+	executionContext := utils.NewExecutionContext(ctx, 1)
+	go receiver.Start(executionContext)
+
+	// make sure the message handler of the receiver is set before continuing with the test
+	require.Eventually(t, func() bool {
+		return receiver.natsConnectionHandler.MessageHandler != nil
+	}, 5*time.Second, time.Second)
+
+	natsPublisher.Publish("sh.keptn.event.task2.triggered", []byte(task2TriggerEvent))
+	natsPublisher.Publish("sh.keptn.event.task.triggered", []byte(task1TriggerEvent))
+
+	assert.Eventually(t, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(sentEvents) == 1
+	}, time.Second*time.Duration(5), time.Second)
+
+	time.Sleep(time.Second)
+	mutex.Lock()
+	defer mutex.Unlock()
+	require.Len(t, sentEvents, 1)
+	data := map[string]interface{}{}
+	require.Nil(t, sentEvents[0].DataAs(&data))
+	assert.Equal(t, "sh.keptn.event.task.triggered", sentEvents[0].Type())
+	assert.Equal(t, "my-project-stage1", data["namespace"])
+
+	cancelReceiver()
+	executionContext.Wg.Wait()
+}
+
 func runNATSServer() (*server.Server, func()) {
 	svr := natsserver.RunRandClientPortServer()
 	return svr, func() { svr.Shutdown() }
@@ -0,0 +1,90 @@
+package recipient
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// DefaultEnvelope is used if no envelope has been configured
+const DefaultEnvelope = "id=id,type=type,source=source,time=time,shkeptncontext=shkeptncontext,triggeredid=triggeredid,data=data"
+
+// envelopeAttributes contains the functions to retrieve the event attributes that can be used in an envelope
+var envelopeAttributes = map[string]func(event cloudevents.Event) (interface{}, error){
+	"id":     func(event cloudevents.Event) (interface{}, error) { return event.ID(), nil },
+	"type":   func(event cloudevents.Event) (interface{}, error) { return event.Type(), nil },
+	"source": func(event cloudevents.Event) (interface{}, error) { return event.Source(), nil },
+	"time":   func(event cloudevents.Event) (interface{}, error) { return event.Time(), nil },
+	"shkeptncontext": func(event cloudevents.Event) (interface{}, error) {
+		return event.Extensions()["shkeptncontext"], nil
+	},
+	"triggeredid": func(event cloudevents.Event) (interface{}, error) {
+		return event.Extensions()["triggeredid"], nil
+	},
+	"gitcommitid": func(event cloudevents.Event) (interface{}, error) {
+		return event.Extensions()["gitcommitid"], nil
+	},
+	"data": func(event cloudevents.Event) (interface{}, error) {
+		var data interface{}
+		if len(event.Data()) == 0 {
+			return nil, nil
+		}
+		if err := json.Unmarshal(event.Data(), &data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	},
+	"event": func(event cloudevents.Event) (interface{}, error) {
+		return keptnv2.ToKeptnEvent(event)
+	},
+}
+
+// Envelope maps the properties of the JSON documents delivered to webhook and gRPC recipients to attributes of the event
+type Envelope map[string]string
+
+// ParseEnvelope parses a comma separated list of property=attribute pairs, e.g. "event_id=id,payload=data".
+// Supported attributes are id, type, source, time, shkeptncontext, triggeredid, gitcommitid, data, and event (the complete Keptn event)
+func ParseEnvelope(envelope string) (Envelope, error) {
+	if strings.TrimSpace(envelope) == "" {
+		envelope = DefaultEnvelope
+	}
+
+	result := Envelope{}
+	for _, pair := range strings.Split(envelope, ",") {
+		property, attribute, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found || property == "" {
+			return nil, fmt.Errorf("invalid envelope entry '%s': expected <property>=<attribute>", pair)
+		}
+		if _, ok := envelopeAttributes[attribute]; !ok {
+			return nil, fmt.Errorf("invalid envelope entry '%s': unknown attribute '%s'", pair, attribute)
+		}
+		result[property] = attribute
+	}
+	return result, nil
+}
+
+// Wrap creates the JSON document for the given event
+func (e Envelope) Wrap(event cloudevents.Event) (map[string]interface{}, error) {
+	document := map[string]interface{}{}
+	for property, attribute := range e {
+		value, err := envelopeAttributes[attribute](event)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve attribute %s of event %s: %w", attribute, event.ID(), err)
+		}
+		document[property] = value
+	}
+
+	// normalize the document to plain JSON types
+	marshalled, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(marshalled, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
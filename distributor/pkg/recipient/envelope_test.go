@@ -0,0 +1,75 @@
+package recipient
+
+import (
+	"testing"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/go-utils/pkg/common/strutils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTriggeredEvent() models.KeptnContextExtendedCE {
+	return models.KeptnContextExtendedCE{
+		ID:             "my-triggered-id",
+		Type:           strutils.Stringp("sh.keptn.event.test.triggered"),
+		Source:         strutils.Stringp("shipyard-controller"),
+		Shkeptncontext: "my-context",
+		Specversion:    "1.0",
+		Data: keptnv2.EventData{
+			Project: "my-project",
+			Stage:   "my-stage",
+			Service: "my-service",
+		},
+	}
+}
+
+func TestParseEnvelope(t *testing.T) {
+	envelope, err := ParseEnvelope("")
+	require.Nil(t, err)
+	assert.Len(t, envelope, 7)
+	assert.Equal(t, "data", envelope["data"])
+
+	envelope, err = ParseEnvelope("event_id=id, payload=data")
+	require.Nil(t, err)
+	assert.Equal(t, Envelope{"event_id": "id", "payload": "data"}, envelope)
+
+	_, err = ParseEnvelope("event_id")
+	assert.NotNil(t, err)
+
+	_, err = ParseEnvelope("event_id=unknown")
+	assert.NotNil(t, err)
+}
+
+func TestEnvelope_Wrap(t *testing.T) {
+	envelope, err := ParseEnvelope("event_id=id,event_type=type,context=shkeptncontext,payload=data")
+	require.Nil(t, err)
+
+	document, err := envelope.Wrap(keptnv2.ToCloudEvent(newTestTriggeredEvent()))
+	require.Nil(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"event_id":   "my-triggered-id",
+		"event_type": "sh.keptn.event.test.triggered",
+		"context":    "my-context",
+		"payload": map[string]interface{}{
+			"project": "my-project",
+			"stage":   "my-stage",
+			"service": "my-service",
+		},
+	}, document)
+}
+
+func TestEnvelope_WrapEvent(t *testing.T) {
+	envelope, err := ParseEnvelope("event=event")
+	require.Nil(t, err)
+
+	document, err := envelope.Wrap(keptnv2.ToCloudEvent(newTestTriggeredEvent()))
+	require.Nil(t, err)
+
+	event, ok := document["event"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "my-triggered-id", event["id"])
+	assert.Equal(t, "my-context", event["shkeptncontext"])
+}
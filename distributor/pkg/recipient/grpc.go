@@ -0,0 +1,154 @@
+package recipient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	logger "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// EventStreamConnectMethod is the bidirectional streaming method gRPC recipients have to implement (see eventstream.proto).
+// The distributor sends the events wrapped in the configured envelope, and the recipient replies with Reply messages
+const EventStreamConnectMethod = "/keptn.distributor.v1.EventStream/Connect"
+
+// EventStreamDesc describes the stream of the EventStream service
+var EventStreamDesc = grpc.StreamDesc{
+	StreamName:    "Connect",
+	ServerStreams: true,
+	ClientStreams: true,
+}
+
+// GRPCEventSender delivers events to the recipient via a bidirectional gRPC stream and forwards the replies received on the stream
+type GRPCEventSender struct {
+	target      string
+	envelope    Envelope
+	tasks       *Tasks
+	replySender EventSender
+	dialOptions []grpc.DialOption
+
+	mutex  sync.Mutex
+	conn   *grpc.ClientConn
+	stream grpc.ClientStream
+	cancel context.CancelFunc
+}
+
+// NewGRPCEventSender creates a new GRPCEventSender. The connection to the given target is established with the first event
+func NewGRPCEventSender(target string, envelope Envelope, tasks *Tasks, replySender EventSender, dialOptions ...grpc.DialOption) *GRPCEventSender {
+	if len(dialOptions) == 0 {
+		dialOptions = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	return &GRPCEventSender{
+		target:      target,
+		envelope:    envelope,
+		tasks:       tasks,
+		replySender: replySender,
+		dialOptions: dialOptions,
+	}
+}
+
+func (s *GRPCEventSender) Send(ctx context.Context, event cloudevents.Event) error {
+	document, err := s.envelope.Wrap(event)
+	if err != nil {
+		return err
+	}
+	message, err := structpb.NewStruct(document)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stream, err := s.getStream()
+	if err != nil {
+		return err
+	}
+
+	s.tasks.Add(event)
+	if err := stream.SendMsg(message); err != nil {
+		s.closeStream()
+		return fmt.Errorf("could not deliver event %s to %s: %w", event.ID(), s.target, err)
+	}
+	return nil
+}
+
+// Close closes the stream and the connection to the recipient
+func (s *GRPCEventSender) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.closeStream()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// getStream returns the current stream, or opens a new one. The caller must hold the mutex
+func (s *GRPCEventSender) getStream() (grpc.ClientStream, error) {
+	if s.stream != nil {
+		return s.stream, nil
+	}
+	if s.conn == nil {
+		conn, err := grpc.Dial(s.target, s.dialOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("could not connect to %s: %w", s.target, err)
+		}
+		s.conn = conn
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := s.conn.NewStream(ctx, &EventStreamDesc, EventStreamConnectMethod)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("could not open event stream to %s: %w", s.target, err)
+	}
+	s.stream = stream
+	s.cancel = cancel
+	go s.receiveReplies(stream)
+	return stream, nil
+}
+
+// closeStream cancels the current stream. The caller must hold the mutex
+func (s *GRPCEventSender) closeStream() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.stream = nil
+	s.cancel = nil
+}
+
+func (s *GRPCEventSender) receiveReplies(stream grpc.ClientStream) {
+	for {
+		message := &structpb.Struct{}
+		if err := stream.RecvMsg(message); err != nil {
+			logger.Infof("Event stream to %s has been closed: %v", s.target, err)
+			s.mutex.Lock()
+			if s.stream == stream {
+				s.closeStream()
+			}
+			s.mutex.Unlock()
+			return
+		}
+
+		reply := Reply{}
+		marshalled, err := json.Marshal(message.AsMap())
+		if err == nil {
+			err = json.Unmarshal(marshalled, &reply)
+		}
+		if err != nil {
+			logger.Errorf("Received invalid reply from %s: %v", s.target, err)
+			continue
+		}
+		if err := s.tasks.Reply(context.Background(), reply, s.replySender); err != nil {
+			logger.Errorf("Failed to forward reply to triggered event %s: %v", reply.TriggeredID, err)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package recipient
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	keptnfake "github.com/keptn/go-utils/pkg/lib/v0_2_0/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// startEventStreamServer starts a recipient that replies with a .finished reply to every received event
+func startEventStreamServer(t *testing.T, received chan<- map[string]interface{}) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+
+	server := grpc.NewServer()
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "keptn.distributor.v1.EventStream",
+		HandlerType: (*interface{})(nil),
+		Streams: []grpc.StreamDesc{
+			{
+				StreamName:    "Connect",
+				ServerStreams: true,
+				ClientStreams: true,
+				Handler: func(srv interface{}, stream grpc.ServerStream) error {
+					for {
+						message := &structpb.Struct{}
+						if err := stream.RecvMsg(message); err != nil {
+							return nil
+						}
+						document := message.AsMap()
+						received <- document
+						reply, _ := structpb.NewStruct(map[string]interface{}{
+							"triggeredid": document["id"],
+							"type":        "finished",
+							"data":        map[string]interface{}{"result": "pass"},
+						})
+						if err := stream.SendMsg(reply); err != nil {
+							return err
+						}
+					}
+				},
+			},
+		},
+	}, struct{}{})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func TestGRPCEventSender_Send(t *testing.T) {
+	received := make(chan map[string]interface{}, 1)
+	target := startEventStreamServer(t, received)
+
+	var mutex sync.Mutex
+	var replies []cloudevents.Event
+	replySender := &keptnfake.EventSender{}
+	replySender.AddReactor("*", func(event cloudevents.Event) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		replies = append(replies, event)
+		return nil
+	})
+
+	envelope, _ := ParseEnvelope("")
+	sender := NewGRPCEventSender(target, envelope, NewTasks("my-integration"), replySender)
+	defer sender.Close()
+
+	err := sender.Send(context.Background(), keptnv2.ToCloudEvent(newTestTriggeredEvent()))
+	require.Nil(t, err)
+
+	document := <-received
+	assert.Equal(t, "my-triggered-id", document["id"])
+	assert.Equal(t, "sh.keptn.event.test.triggered", document["type"])
+
+	assert.Eventually(t, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(replies) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, "sh.keptn.event.test.finished", replies[0].Type())
+	assert.Equal(t, "my-triggered-id", replies[0].Extensions()["triggeredid"])
+}
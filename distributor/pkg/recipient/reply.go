@@ -0,0 +1,152 @@
+package recipient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+const (
+	// ProtocolCloudEvents delivers events to the recipient using the CloudEvents HTTP binding
+	ProtocolCloudEvents = "cloudevents"
+	// ProtocolWebhook delivers events to the recipient as plain JSON documents via HTTP POST requests
+	ProtocolWebhook = "webhook"
+	// ProtocolGRPC delivers events to the recipient via a bidirectional gRPC stream
+	ProtocolGRPC = "grpc"
+)
+
+// maxTaskAge is the time after which delivered .triggered events without a .finished reply are forgotten
+const maxTaskAge = 24 * time.Hour
+
+// ErrUnknownTask indicates that a reply refers to a .triggered event that has not been delivered by the distributor
+var ErrUnknownTask = errors.New("unknown triggered event")
+
+// ErrInvalidReplyType indicates that the type of a reply is neither 'started' nor 'finished'
+var ErrInvalidReplyType = errors.New("reply type must be 'started' or 'finished'")
+
+// EventSender sends events created from the replies of a recipient to Keptn
+type EventSender interface {
+	Send(ctx context.Context, event cloudevents.Event) error
+}
+
+// Reply is sent by integrations that do not implement CloudEvents to report the progress of a task
+type Reply struct {
+	// TriggeredID is the ID of the .triggered event the reply refers to
+	TriggeredID string `json:"triggeredid"`
+	// Type is either 'started', 'finished', or the complete type of the .started/.finished event
+	Type string `json:"type"`
+	// Data is merged into the project, stage, service, and labels of the .triggered event
+	Data map[string]interface{} `json:"data,omitempty"`
+}
+
+type task struct {
+	event       models.KeptnContextExtendedCE
+	deliveredAt time.Time
+}
+
+// Tasks keeps track of the .triggered events delivered to a recipient, so that its replies can be turned into Keptn events
+type Tasks struct {
+	mutex  sync.Mutex
+	tasks  map[string]task
+	source string
+}
+
+// NewTasks creates a new Tasks instance. The given source is used for the events created from replies
+func NewTasks(source string) *Tasks {
+	return &Tasks{
+		tasks:  map[string]task{},
+		source: source,
+	}
+}
+
+// Add registers the given event if it is a .triggered event of a task
+func (t *Tasks) Add(event cloudevents.Event) {
+	if !keptnv2.IsTaskEventType(event.Type()) || !keptnv2.IsTriggeredEventType(event.Type()) {
+		return
+	}
+	keptnEvent, err := keptnv2.ToKeptnEvent(event)
+	if err != nil {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	now := time.Now()
+	for id, existing := range t.tasks {
+		if now.Sub(existing.deliveredAt) > maxTaskAge {
+			delete(t.tasks, id)
+		}
+	}
+	t.tasks[event.ID()] = task{event: keptnEvent, deliveredAt: now}
+}
+
+// ToEvent creates the .started or .finished event for the given reply
+func (t *Tasks) ToEvent(reply Reply) (*cloudevents.Event, error) {
+	kind := reply.Type
+	if keptnv2.IsStartedEventType(kind) {
+		kind = "started"
+	} else if keptnv2.IsFinishedEventType(kind) {
+		kind = "finished"
+	}
+	kind = strings.ToLower(kind)
+	if kind != "started" && kind != "finished" {
+		return nil, ErrInvalidReplyType
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	triggered, ok := t.tasks[reply.TriggeredID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTask, reply.TriggeredID)
+	}
+
+	eventData := map[string]interface{}{}
+	commonEventData := keptnv2.EventData{}
+	if err := triggered.event.DataAs(&commonEventData); err != nil {
+		return nil, err
+	}
+	if err := keptnv2.Decode(commonEventData, &eventData); err != nil {
+		return nil, err
+	}
+	for key, value := range reply.Data {
+		eventData[key] = value
+	}
+
+	var keptnEvent *models.KeptnContextExtendedCE
+	var err error
+	if kind == "started" {
+		keptnEvent, err = keptnv2.CreateStartedEvent(t.source, triggered.event, eventData)
+	} else {
+		keptnEvent, err = keptnv2.CreateFinishedEvent(t.source, triggered.event, eventData)
+	}
+	if err != nil {
+		return nil, err
+	}
+	event := keptnv2.ToCloudEvent(*keptnEvent)
+	return &event, nil
+}
+
+// Reply creates the event for the given reply and sends it with the given EventSender
+func (t *Tasks) Reply(ctx context.Context, reply Reply, sender EventSender) error {
+	event, err := t.ToEvent(reply)
+	if err != nil {
+		return err
+	}
+	if err := sender.Send(ctx, *event); err != nil {
+		return err
+	}
+	if keptnv2.IsFinishedEventType(event.Type()) {
+		// the task is completed, further replies are rejected
+		t.mutex.Lock()
+		delete(t.tasks, reply.TriggeredID)
+		t.mutex.Unlock()
+	}
+	return nil
+}
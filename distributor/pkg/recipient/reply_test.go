@@ -0,0 +1,96 @@
+package recipient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	keptnfake "github.com/keptn/go-utils/pkg/lib/v0_2_0/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTasks_Reply(t *testing.T) {
+	tasks := NewTasks("my-integration")
+	tasks.Add(keptnv2.ToCloudEvent(newTestTriggeredEvent()))
+
+	sender := &keptnfake.EventSender{}
+	err := tasks.Reply(context.Background(), Reply{TriggeredID: "my-triggered-id", Type: "started"}, sender)
+	require.Nil(t, err)
+
+	err = tasks.Reply(context.Background(), Reply{
+		TriggeredID: "my-triggered-id",
+		Type:        "sh.keptn.event.test.finished",
+		Data:        map[string]interface{}{"result": "fail", "test": map[string]interface{}{"duration": 5}},
+	}, sender)
+	require.Nil(t, err)
+
+	require.Len(t, sender.SentEvents, 2)
+	started := sender.SentEvents[0]
+	assert.Equal(t, "sh.keptn.event.test.started", started.Type())
+	assert.Equal(t, "my-integration", started.Source())
+	assert.Equal(t, "my-triggered-id", started.Extensions()["triggeredid"])
+	assert.Equal(t, "my-context", started.Extensions()["shkeptncontext"])
+
+	finished := sender.SentEvents[1]
+	assert.Equal(t, "sh.keptn.event.test.finished", finished.Type())
+	data := map[string]interface{}{}
+	require.Nil(t, finished.DataAs(&data))
+	assert.Equal(t, "my-project", data["project"])
+	assert.Equal(t, "my-stage", data["stage"])
+	assert.Equal(t, "my-service", data["service"])
+	assert.Equal(t, "fail", data["result"])
+	assert.Equal(t, "succeeded", data["status"])
+	assert.Equal(t, map[string]interface{}{"duration": float64(5)}, data["test"])
+
+	// the task is completed
+	err = tasks.Reply(context.Background(), Reply{TriggeredID: "my-triggered-id", Type: "finished"}, sender)
+	assert.ErrorIs(t, err, ErrUnknownTask)
+}
+
+func TestTasks_ReplyInvalid(t *testing.T) {
+	tasks := NewTasks("my-integration")
+	tasks.Add(keptnv2.ToCloudEvent(newTestTriggeredEvent()))
+	sender := &keptnfake.EventSender{}
+
+	err := tasks.Reply(context.Background(), Reply{TriggeredID: "my-triggered-id", Type: "triggered"}, sender)
+	assert.ErrorIs(t, err, ErrInvalidReplyType)
+
+	err = tasks.Reply(context.Background(), Reply{TriggeredID: "unknown", Type: "started"}, sender)
+	assert.ErrorIs(t, err, ErrUnknownTask)
+
+	assert.Empty(t, sender.SentEvents)
+}
+
+func TestTasks_ReplyFinishedCanBeRetried(t *testing.T) {
+	tasks := NewTasks("my-integration")
+	tasks.Add(keptnv2.ToCloudEvent(newTestTriggeredEvent()))
+
+	sender := &keptnfake.EventSender{}
+	sender.AddReactor("*", func(event cloudevents.Event) error {
+		return errors.New("oops")
+	})
+	err := tasks.Reply(context.Background(), Reply{TriggeredID: "my-triggered-id", Type: "finished"}, sender)
+	assert.NotNil(t, err)
+
+	sender = &keptnfake.EventSender{}
+	err = tasks.Reply(context.Background(), Reply{TriggeredID: "my-triggered-id", Type: "finished"}, sender)
+	require.Nil(t, err)
+	assert.Len(t, sender.SentEvents, 1)
+}
+
+func TestTasks_AddIgnoresNonTaskEvents(t *testing.T) {
+	tasks := NewTasks("my-integration")
+	event := newTestTriggeredEvent()
+	event.Type = stringp("sh.keptn.event.test.finished")
+	tasks.Add(keptnv2.ToCloudEvent(event))
+
+	_, err := tasks.ToEvent(Reply{TriggeredID: "my-triggered-id", Type: "started"})
+	assert.ErrorIs(t, err, ErrUnknownTask)
+}
+
+func stringp(s string) *string {
+	return &s
+}
@@ -0,0 +1,108 @@
+package recipient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	logger "github.com/sirupsen/logrus"
+)
+
+// WebhookEventSender delivers events to the recipient as plain JSON documents, wrapped in the configured envelope
+type WebhookEventSender struct {
+	httpClient *http.Client
+	url        string
+	envelope   Envelope
+	tasks      *Tasks
+}
+
+// NewWebhookEventSender creates a new WebhookEventSender
+func NewWebhookEventSender(httpClient *http.Client, url string, envelope Envelope, tasks *Tasks) *WebhookEventSender {
+	return &WebhookEventSender{
+		httpClient: httpClient,
+		url:        url,
+		envelope:   envelope,
+		tasks:      tasks,
+	}
+}
+
+func (s *WebhookEventSender) Send(ctx context.Context, event cloudevents.Event) error {
+	document, err := s.envelope.Wrap(event)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+
+	// register the task before it is delivered, since the recipient might reply before the request has been completed
+	s.tasks.Add(event)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("could not deliver event %s to %s: unexpected status code %d: %s", event.ID(), s.url, resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// NewReplyHandler returns a handler accepting replies of recipients that do not implement CloudEvents.
+// The body of a request is either a single Reply, or a list of replies
+func NewReplyHandler(tasks *Tasks, sender EventSender) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			rw.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			logger.Errorf("Failed to read body from request: %v", err)
+			rw.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		replies := []Reply{}
+		if err := json.Unmarshal(body, &replies); err != nil {
+			reply := Reply{}
+			if err := json.Unmarshal(body, &reply); err != nil {
+				http.Error(rw, fmt.Sprintf("invalid reply: %v", err), http.StatusBadRequest)
+				return
+			}
+			replies = append(replies, reply)
+		}
+
+		for _, reply := range replies {
+			if err := tasks.Reply(req.Context(), reply, sender); err != nil {
+				logger.Errorf("Failed to forward reply to triggered event %s: %v", reply.TriggeredID, err)
+				switch {
+				case errors.Is(err, ErrInvalidReplyType):
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+				case errors.Is(err, ErrUnknownTask):
+					http.Error(rw, err.Error(), http.StatusNotFound)
+				default:
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+				}
+				return
+			}
+		}
+		rw.WriteHeader(http.StatusAccepted)
+	}
+}
@@ -0,0 +1,118 @@
+package recipient
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	keptnfake "github.com/keptn/go-utils/pkg/lib/v0_2_0/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookEventSender_Send(t *testing.T) {
+	received := make(chan map[string]interface{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		body, _ := ioutil.ReadAll(req.Body)
+		document := map[string]interface{}{}
+		_ = json.Unmarshal(body, &document)
+		received <- document
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	envelope, _ := ParseEnvelope("event_id=id,payload=data")
+	tasks := NewTasks("my-integration")
+	sender := NewWebhookEventSender(server.Client(), server.URL, envelope, tasks)
+
+	err := sender.Send(context.Background(), keptnv2.ToCloudEvent(newTestTriggeredEvent()))
+	require.Nil(t, err)
+
+	document := <-received
+	assert.Equal(t, "my-triggered-id", document["event_id"])
+	assert.NotNil(t, document["payload"])
+
+	_, err = tasks.ToEvent(Reply{TriggeredID: "my-triggered-id", Type: "started"})
+	assert.Nil(t, err)
+}
+
+func TestWebhookEventSender_SendFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	envelope, _ := ParseEnvelope("")
+	sender := NewWebhookEventSender(server.Client(), server.URL, envelope, NewTasks("my-integration"))
+
+	err := sender.Send(context.Background(), keptnv2.ToCloudEvent(newTestTriggeredEvent()))
+	assert.NotNil(t, err)
+}
+
+func TestNewReplyHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		wantStatus int
+		wantEvents int
+	}{
+		{
+			name:       "single reply",
+			method:     http.MethodPost,
+			body:       `{"triggeredid": "my-triggered-id", "type": "started"}`,
+			wantStatus: http.StatusAccepted,
+			wantEvents: 1,
+		},
+		{
+			name:       "list of replies",
+			method:     http.MethodPost,
+			body:       `[{"triggeredid": "my-triggered-id", "type": "started"}, {"triggeredid": "my-triggered-id", "type": "finished", "data": {"result": "pass"}}]`,
+			wantStatus: http.StatusAccepted,
+			wantEvents: 2,
+		},
+		{
+			name:       "invalid body",
+			method:     http.MethodPost,
+			body:       `invalid`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid type",
+			method:     http.MethodPost,
+			body:       `{"triggeredid": "my-triggered-id", "type": "triggered"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown task",
+			method:     http.MethodPost,
+			body:       `{"triggeredid": "unknown", "type": "started"}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "wrong method",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := NewTasks("my-integration")
+			tasks.Add(keptnv2.ToCloudEvent(newTestTriggeredEvent()))
+			sender := &keptnfake.EventSender{}
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, "/reply", strings.NewReader(tt.body))
+			NewReplyHandler(tasks, sender).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Len(t, sender.SentEvents, tt.wantEvents)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package transform
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	logger "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// Pipeline is an ordered list of transformations applied to the events of the matching subscriptions
+type Pipeline struct {
+	// SubscriptionID restricts the pipeline to the subscription with the given ID
+	SubscriptionID string `json:"subscriptionID,omitempty" yaml:"subscriptionID,omitempty"`
+	// Event restricts the pipeline to subscriptions for the given event type, e.g. sh.keptn.event.deployment.triggered.
+	// Events received without subscription are matched by their type
+	Event           string            `json:"event,omitempty" yaml:"event,omitempty"`
+	Transformations []*Transformation `json:"transformations" yaml:"transformations"`
+}
+
+func (p *Pipeline) matches(event models.KeptnContextExtendedCE, subscription *models.EventSubscription) bool {
+	if subscription == nil {
+		if p.SubscriptionID != "" {
+			return false
+		}
+		return p.Event == "" || (event.Type != nil && p.Event == *event.Type)
+	}
+	if p.SubscriptionID != "" && p.SubscriptionID != subscription.ID {
+		return false
+	}
+	return p.Event == "" || p.Event == subscription.Event
+}
+
+// Config contains the transformation pipelines of the distributor
+type Config struct {
+	Pipelines []*Pipeline `json:"pipelines" yaml:"pipelines"`
+}
+
+// Transformer applies the configured pipelines to events before they are forwarded to the integration
+type Transformer struct {
+	pipelines []*Pipeline
+}
+
+// New creates a new Transformer and validates the given pipelines
+func New(config Config) (*Transformer, error) {
+	for i, pipeline := range config.Pipelines {
+		for j, transformation := range pipeline.Transformations {
+			if err := transformation.compile(); err != nil {
+				return nil, fmt.Errorf("invalid transformation %d of pipeline %d: %w", j, i, err)
+			}
+		}
+	}
+	return &Transformer{pipelines: config.Pipelines}, nil
+}
+
+// NewFromFile creates a new Transformer from the given YAML or JSON file
+func NewFromFile(path string) (*Transformer, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read transformations file: %w", err)
+	}
+	config := Config{}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("could not parse transformations file: %w", err)
+	}
+	return New(config)
+}
+
+// Transform applies all pipelines matching the given event and subscription. The subscription is nil for events
+// that are not forwarded for a specific subscription. Transform returns nil if the event has been dropped.
+// Transformations that cannot be applied to the event are skipped
+func (t *Transformer) Transform(event models.KeptnContextExtendedCE, subscription *models.EventSubscription) (*models.KeptnContextExtendedCE, error) {
+	var document map[string]interface{}
+	for _, pipeline := range t.pipelines {
+		if !pipeline.matches(event, subscription) {
+			continue
+		}
+		if document == nil {
+			if err := convert(event, &document); err != nil {
+				return nil, err
+			}
+		}
+		for _, transformation := range pipeline.Transformations {
+			drop, err := transformation.apply(document)
+			if err != nil {
+				logger.Errorf("Could not apply %s transformation to event %s: %v", transformation.Action, event.ID, err)
+				continue
+			}
+			if drop {
+				logger.Infof("Dropping event %s", event.ID)
+				return nil, nil
+			}
+		}
+	}
+	if document == nil {
+		return &event, nil
+	}
+
+	result := models.KeptnContextExtendedCE{}
+	if err := convert(document, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func convert(in interface{}, out interface{}) error {
+	marshalled, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(marshalled, out)
+}
@@ -0,0 +1,210 @@
+package transform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/go-utils/pkg/common/strutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEvent() models.KeptnContextExtendedCE {
+	return models.KeptnContextExtendedCE{
+		ID:          "my-id",
+		Type:        strutils.Stringp("sh.keptn.event.deployment.triggered"),
+		Source:      strutils.Stringp("shipyard-controller"),
+		Specversion: "1.0",
+		Data: map[string]interface{}{
+			"project": "my-project",
+			"stage":   "dev",
+			"service": "my-service",
+			"labels": map[string]interface{}{
+				"image":  "my-image:1.0",
+				"secret": "password",
+			},
+		},
+	}
+}
+
+func TestTransformer_Transform(t *testing.T) {
+	tests := []struct {
+		name         string
+		pipelines    []*Pipeline
+		subscription *models.EventSubscription
+		wantData     map[string]interface{}
+		wantDropped  bool
+	}{
+		{
+			name: "set, template, and delete",
+			pipelines: []*Pipeline{
+				{
+					Transformations: []*Transformation{
+						{Action: ActionSet, Path: "data.team", Value: "platform"},
+						{Action: ActionSet, Path: "data.deployment.image", Template: "{{ .data.labels.image }}"},
+						{Action: ActionSet, Path: "data.namespace", Template: `{{ .data.project }}-{{ get . "data.stage" | upper }}`},
+						{Action: ActionDelete, Path: "data.labels.secret"},
+					},
+				},
+			},
+			wantData: map[string]interface{}{
+				"project":    "my-project",
+				"stage":      "dev",
+				"service":    "my-service",
+				"team":       "platform",
+				"namespace":  "my-project-DEV",
+				"deployment": map[string]interface{}{"image": "my-image:1.0"},
+				"labels":     map[string]interface{}{"image": "my-image:1.0"},
+			},
+		},
+		{
+			name: "missing values",
+			pipelines: []*Pipeline{
+				{
+					Transformations: []*Transformation{
+						{Action: ActionDelete, Path: "data.labels"},
+						{Action: ActionSet, Path: "data.version", Template: `{{ .data.version }}`},
+						// fails, since data.labels does not exist
+						{Action: ActionSet, Path: "data.image", Template: `{{ .data.labels.image }}`},
+						{Action: ActionSet, Path: "data.tag", Template: `{{ get . "data.labels.tag" | default "latest" }}`},
+					},
+				},
+			},
+			wantData: map[string]interface{}{
+				"project": "my-project",
+				"stage":   "dev",
+				"service": "my-service",
+				"version": "",
+				"tag":     "latest",
+			},
+		},
+		{
+			name: "drop by expression",
+			pipelines: []*Pipeline{
+				{
+					Transformations: []*Transformation{
+						{Action: ActionDrop, Expression: `{{ eq .data.stage "dev" }}`},
+					},
+				},
+			},
+			wantDropped: true,
+		},
+		{
+			name: "expression not matching",
+			pipelines: []*Pipeline{
+				{
+					Transformations: []*Transformation{
+						{Action: ActionDrop, Expression: `{{ eq .data.stage "production" }}`},
+					},
+				},
+			},
+			wantData: newTestEvent().Data.(map[string]interface{}),
+		},
+		{
+			name: "pipelines for other subscriptions are ignored",
+			pipelines: []*Pipeline{
+				{
+					SubscriptionID: "other-subscription",
+					Transformations: []*Transformation{
+						{Action: ActionDrop, Expression: "true"},
+					},
+				},
+				{
+					Event: "sh.keptn.event.test.triggered",
+					Transformations: []*Transformation{
+						{Action: ActionDrop, Expression: "true"},
+					},
+				},
+				{
+					SubscriptionID: "my-subscription",
+					Event:          "sh.keptn.event.deployment.triggered",
+					Transformations: []*Transformation{
+						{Action: ActionDelete, Path: "data.labels"},
+					},
+				},
+			},
+			subscription: &models.EventSubscription{ID: "my-subscription", Event: "sh.keptn.event.deployment.triggered"},
+			wantData: map[string]interface{}{
+				"project": "my-project",
+				"stage":   "dev",
+				"service": "my-service",
+			},
+		},
+		{
+			name: "failing transformations are skipped",
+			pipelines: []*Pipeline{
+				{
+					Transformations: []*Transformation{
+						{Action: ActionSet, Path: "data.project.name", Value: "invalid"},
+						{Action: ActionDelete, Path: "data.labels"},
+					},
+				},
+			},
+			wantData: map[string]interface{}{
+				"project": "my-project",
+				"stage":   "dev",
+				"service": "my-service",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transformer, err := New(Config{Pipelines: tt.pipelines})
+			require.Nil(t, err)
+
+			got, err := transformer.Transform(newTestEvent(), tt.subscription)
+			require.Nil(t, err)
+			if tt.wantDropped {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, "my-id", got.ID)
+			assert.Equal(t, "sh.keptn.event.deployment.triggered", *got.Type)
+			assert.Equal(t, tt.wantData, got.Data)
+		})
+	}
+}
+
+func TestNew_InvalidTransformations(t *testing.T) {
+	invalid := []*Transformation{
+		{Action: "unknown"},
+		{Action: ActionSet},
+		{Action: ActionSet, Path: "data.image", Value: "image", Template: "{{ .data.image }}"},
+		{Action: ActionDelete},
+		{Action: ActionDrop},
+		{Action: ActionDrop, Expression: "{{ .data.stage"},
+	}
+	for _, transformation := range invalid {
+		_, err := New(Config{Pipelines: []*Pipeline{{Transformations: []*Transformation{transformation}}}})
+		assert.NotNil(t, err)
+	}
+}
+
+func TestNewFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transformations.yaml")
+	err := os.WriteFile(path, []byte(`pipelines:
+  - event: sh.keptn.event.deployment.triggered
+    transformations:
+      - action: set
+        path: data.config
+        value:
+          replicas: 2
+      - action: drop
+        expression: '{{ eq .data.service "other-service" }}'
+`), 0644)
+	require.Nil(t, err)
+
+	transformer, err := NewFromFile(path)
+	require.Nil(t, err)
+
+	got, err := transformer.Transform(newTestEvent(), nil)
+	require.Nil(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, map[string]interface{}{"replicas": float64(2)}, got.Data.(map[string]interface{})["config"])
+
+	_, err = NewFromFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.NotNil(t, err)
+}
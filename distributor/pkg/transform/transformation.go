@@ -0,0 +1,178 @@
+package transform
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+const (
+	// ActionSet sets the value at the given path to a static value or to the result of a template
+	ActionSet = "set"
+	// ActionDelete removes the value at the given path
+	ActionDelete = "delete"
+	// ActionDrop drops the event if the given expression evaluates to true
+	ActionDrop = "drop"
+)
+
+// noValue is rendered by text/template for missing map entries
+const noValue = "<no value>"
+
+// Transformation is a single step of a Pipeline
+type Transformation struct {
+	// Action is one of set, delete, or drop
+	Action string `json:"action" yaml:"action"`
+	// Path is the dot separated path of the modified property, e.g. data.labels.image
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// Value is the static value set by the set action
+	Value interface{} `json:"value,omitempty" yaml:"value,omitempty"`
+	// Template is a Go template computing the value of the set action
+	Template string `json:"template,omitempty" yaml:"template,omitempty"`
+	// Expression is a Go template which drops the event if it evaluates to true
+	Expression string `json:"expression,omitempty" yaml:"expression,omitempty"`
+
+	template *template.Template
+}
+
+// compile validates the transformation and parses its template
+func (t *Transformation) compile() error {
+	var text string
+	switch t.Action {
+	case ActionSet:
+		if t.Path == "" {
+			return errors.New("set requires a path")
+		}
+		if t.Value != nil && t.Template != "" {
+			return errors.New("set requires either a value or a template")
+		}
+		text = t.Template
+	case ActionDelete:
+		if t.Path == "" {
+			return errors.New("delete requires a path")
+		}
+	case ActionDrop:
+		if t.Expression == "" {
+			return errors.New("drop requires an expression")
+		}
+		text = t.Expression
+	default:
+		return fmt.Errorf("unknown action '%s'", t.Action)
+	}
+
+	if text == "" {
+		return nil
+	}
+	tmpl, err := template.New(t.Action).Option("missingkey=zero").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	t.template = tmpl
+	return nil
+}
+
+// apply applies the transformation to the given event document and reports whether the event should be dropped
+func (t *Transformation) apply(document map[string]interface{}) (bool, error) {
+	switch t.Action {
+	case ActionSet:
+		value := t.Value
+		if t.template != nil {
+			rendered, err := t.render(document)
+			if err != nil {
+				return false, err
+			}
+			value = rendered
+		}
+		return false, setPath(document, t.Path, value)
+	case ActionDelete:
+		deletePath(document, t.Path)
+		return false, nil
+	case ActionDrop:
+		rendered, err := t.render(document)
+		if err != nil {
+			return false, err
+		}
+		return strings.TrimSpace(rendered) == "true", nil
+	}
+	return false, nil
+}
+
+func (t *Transformation) render(document map[string]interface{}) (string, error) {
+	buf := &bytes.Buffer{}
+	if err := t.template.Execute(buf, document); err != nil {
+		return "", err
+	}
+	return strings.ReplaceAll(buf.String(), noValue, ""), nil
+}
+
+var templateFuncs = template.FuncMap{
+	// get returns the value at the given path of the event, or an empty string if it does not exist
+	"get": func(document map[string]interface{}, path string) interface{} {
+		value, ok := getPath(document, path)
+		if !ok || value == nil {
+			return ""
+		}
+		return value
+	},
+	// default returns the given fallback if the value is empty
+	"default": func(fallback interface{}, value interface{}) interface{} {
+		if value == nil || value == "" {
+			return fallback
+		}
+		return value
+	},
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"json": func(value interface{}) (string, error) {
+		marshalled, err := json.Marshal(value)
+		return string(marshalled), err
+	},
+}
+
+func getPath(document map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = document
+	for _, key := range strings.Split(path, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func setPath(document map[string]interface{}, path string, value interface{}) error {
+	keys := strings.Split(path, ".")
+	current := document
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key]
+		if !ok || next == nil {
+			created := map[string]interface{}{}
+			current[key] = created
+			current = created
+			continue
+		}
+		m, ok := next.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("could not set %s: %s is not an object", path, key)
+		}
+		current = m
+	}
+	current[keys[len(keys)-1]] = value
+	return nil
+}
+
+func deletePath(document map[string]interface{}, path string) {
+	keys := strings.Split(path, ".")
+	parent, ok := getPath(document, strings.Join(keys[:len(keys)-1], "."))
+	if len(keys) == 1 {
+		parent, ok = document, true
+	}
+	if m, isMap := parent.(map[string]interface{}); ok && isMap {
+		delete(m, keys[len(keys)-1])
+	}
+}
@@ -624,6 +624,17 @@ Usage:
       drop: ["ALL"]
 {{- end -}}
 
+{{/*
+Returns "true" if named API tokens are enabled. They are enabled unless apiService.apiTokens.enabled is set to false
+*/}}
+{{- define "keptn.apiTokens.enabled" -}}
+  {{- if hasKey (.Values.apiService.apiTokens | default dict) "enabled" }}
+    {{- .Values.apiService.apiTokens.enabled }}
+  {{- else }}
+    {{- true }}
+  {{- end }}
+{{- end -}}
+
 {{/*
 Return the proper Docker Image Registry Secret Names
 */}}
@@ -0,0 +1,23 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDuration parses durations like time.ParseDuration, additionally accepting days and weeks, e.g. 7d or 2w
+func ParseDuration(raw string) (time.Duration, error) {
+	units := map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour}
+	for suffix, unit := range units {
+		if strings.HasSuffix(raw, suffix) {
+			count, err := strconv.Atoi(strings.TrimSuffix(raw, suffix))
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", raw)
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+	return time.ParseDuration(raw)
+}
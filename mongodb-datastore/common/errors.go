@@ -5,3 +5,5 @@ import (
 )
 
 var ErrInvalidEventFilter = errors.New("invalid event filter")
+
+var ErrInvalidMetricQuery = errors.New("invalid metric query")
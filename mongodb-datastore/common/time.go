@@ -0,0 +1,27 @@
+package common
+
+import (
+	"strings"
+	"time"
+)
+
+// ParseTime parses RFC 3339 timestamps, dates, now and times relative to now, e.g. now-7d or now-12h
+func ParseTime(raw string, now time.Time) (time.Time, error) {
+	if raw == "now" {
+		return now, nil
+	}
+	if strings.HasPrefix(raw, "now-") || strings.HasPrefix(raw, "now+") {
+		offset, err := ParseDuration(raw[4:])
+		if err != nil {
+			return time.Time{}, err
+		}
+		if raw[3] == '-' {
+			offset = -offset
+		}
+		return now.Add(offset), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, raw); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", raw)
+}
@@ -0,0 +1,424 @@
+// Package eventfilter parses the queries used to filter the events stored in the mongodb-datastore into MongoDB
+// filters. A query consists of terms joined by AND and OR, which can be negated by NOT and grouped by parentheses,
+// e.g.
+//
+//	data.project:sockshop AND data.service:carts AND (data.result:fail OR NOT data.status:succeeded)
+//
+// Terms match the value of a property of the events:
+//
+//	key:value             equality, quoted values may contain spaces, e.g. data.stage:"hardening stage"
+//	key:v1,v2             one of the values
+//	key:*                 the property exists
+//	key:[from TO to]      inclusive range, * for an open bound, {from TO to} for an exclusive range
+//	key:>v, >=v, <v, <=v  comparison
+//	key:/regex/i          regular expression, with optional flags i, m, s and x
+//
+// Bounds of ranges and comparisons on time can be timestamps, dates, now or relative to now, e.g. time:>now-7d
+package eventfilter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/keptn/keptn/mongodb-datastore/common"
+)
+
+const (
+	andKeyword = "AND"
+	orKeyword  = "OR"
+	notKeyword = "NOT"
+
+	timePropertyPath = "time"
+)
+
+// property paths must not contain operators of MongoDB, i.e. start with $
+var propertyPathPattern = regexp.MustCompile(`^[a-zA-Z0-9_][-a-zA-Z0-9_.]*$`)
+
+var regexFlagsPattern = regexp.MustCompile(`^[imsx]*$`)
+
+// Parse parses the query into a MongoDB filter. Terms joined by AND are merged into one document as long as their
+// properties differ, so that equality matches, e.g. on data.project, are found on the top level of the filter.
+// Relative times are resolved against now
+func Parse(query string, now time.Time) (bson.M, error) {
+	p := &parser{query: []rune(query), now: now}
+	p.skipSpaces()
+	if p.atEnd() {
+		return bson.M{}, nil
+	}
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.atEnd() {
+		return nil, p.errorf("unexpected %q", string(p.query[p.pos]))
+	}
+	return filter, nil
+}
+
+// And combines filters, merging them into one document as long as their properties differ. Only the terms whose
+// properties are already present are nested into $and, so that the remaining properties stay on the top level
+func And(filters ...bson.M) bson.M {
+	merged := bson.M{}
+	var conflicting []bson.M
+	for _, filter := range filters {
+		merged, conflicting = mergeFilter(merged, conflicting, filter)
+	}
+	if len(conflicting) > 0 {
+		merged["$and"] = conflicting
+	}
+	return merged
+}
+
+// mergeFilter adds the properties of the filter to the merged document, or to the conflicting terms if the property is
+// already present. The operands of a nested $and are merged the same way after the other properties of the filter
+func mergeFilter(merged bson.M, conflicting []bson.M, filter bson.M) (bson.M, []bson.M) {
+	keys := make([]string, 0, len(filter))
+	for key := range filter {
+		keys = append(keys, key)
+	}
+	// the keys are sorted, so that the same filters are always combined into the same document
+	sort.Strings(keys)
+
+	var nested []bson.M
+	for _, key := range keys {
+		if operands, ok := filter[key].([]bson.M); ok && key == "$and" {
+			nested = operands
+			continue
+		}
+		if _, ok := merged[key]; ok {
+			conflicting = append(conflicting, bson.M{key: filter[key]})
+			continue
+		}
+		merged[key] = filter[key]
+	}
+	for _, operand := range nested {
+		merged, conflicting = mergeFilter(merged, conflicting, operand)
+	}
+	return merged, conflicting
+}
+
+// onlyOperand returns the operands of the filter if it consists of the given operator only
+func onlyOperand(filter bson.M, operator string) ([]bson.M, bool) {
+	if len(filter) != 1 {
+		return nil, false
+	}
+	operands, ok := filter[operator].([]bson.M)
+	return operands, ok
+}
+
+type parser struct {
+	query []rune
+	pos   int
+	now   time.Time
+}
+
+func (p *parser) parseOr() (bson.M, error) {
+	var operands []bson.M
+	for {
+		operand, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if nested, ok := onlyOperand(operand, "$or"); ok {
+			operands = append(operands, nested...)
+		} else {
+			operands = append(operands, operand)
+		}
+		if !p.consumeKeyword(orKeyword) {
+			break
+		}
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return bson.M{"$or": operands}, nil
+}
+
+func (p *parser) parseAnd() (bson.M, error) {
+	var operands []bson.M
+	for {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+		if !p.consumeKeyword(andKeyword) {
+			break
+		}
+	}
+	if !p.atEnd() && p.query[p.pos] != ')' && !p.atKeyword(orKeyword) {
+		return nil, p.errorf("expected AND or OR")
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return And(operands...), nil
+}
+
+func (p *parser) parseNot() (bson.M, error) {
+	if p.consumeKeyword(notKeyword) {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return bson.M{"$nor": []bson.M{operand}}, nil
+	}
+	if !p.atEnd() && p.query[p.pos] == '(' {
+		p.pos++
+		p.skipSpaces()
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.atEnd() || p.query[p.pos] != ')' {
+			return nil, p.errorf("expected )")
+		}
+		p.pos++
+		p.skipSpaces()
+		return filter, nil
+	}
+	return p.parseTerm()
+}
+
+func (p *parser) parseTerm() (bson.M, error) {
+	start := p.pos
+	for !p.atEnd() && p.query[p.pos] != ':' && !p.atBoundary() {
+		p.pos++
+	}
+	if p.atEnd() || p.query[p.pos] != ':' {
+		p.pos = start
+		return nil, p.errorf("expected <property>:<value>")
+	}
+	property := string(p.query[start:p.pos])
+	if !propertyPathPattern.MatchString(property) {
+		p.pos = start
+		return nil, p.errorf("invalid property %q", property)
+	}
+	p.pos++
+
+	condition, err := p.parseCondition(property)
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	return bson.M{property: condition}, nil
+}
+
+func (p *parser) parseCondition(property string) (interface{}, error) {
+	if p.atEnd() {
+		return nil, p.errorf("expected value of %s", property)
+	}
+	switch p.query[p.pos] {
+	case '"':
+		return p.parseQuoted()
+	case '/':
+		return p.parseRegex()
+	case '[', '{':
+		return p.parseRange(property)
+	case '>', '<':
+		return p.parseComparison(property)
+	}
+
+	value := p.readValue()
+	switch {
+	case value == "":
+		return nil, p.errorf("expected value of %s", property)
+	case value == "*":
+		return bson.M{"$exists": true}, nil
+	case strings.Contains(value, ","):
+		values := strings.Split(value, ",")
+		for _, v := range values {
+			if v == "" {
+				return nil, p.errorf("empty value in list of %s", property)
+			}
+		}
+		return bson.M{"$in": values}, nil
+	}
+	return value, nil
+}
+
+func (p *parser) parseQuoted() (string, error) {
+	start := p.pos
+	p.pos++
+	var value strings.Builder
+	for !p.atEnd() {
+		r := p.query[p.pos]
+		p.pos++
+		switch {
+		case r == '\\' && !p.atEnd():
+			value.WriteRune(p.query[p.pos])
+			p.pos++
+		case r == '"':
+			return value.String(), nil
+		default:
+			value.WriteRune(r)
+		}
+	}
+	p.pos = start
+	return "", p.errorf("unterminated quoted value")
+}
+
+func (p *parser) parseRegex() (bson.M, error) {
+	start := p.pos
+	p.pos++
+	var pattern strings.Builder
+	for {
+		if p.atEnd() {
+			p.pos = start
+			return nil, p.errorf("unterminated regular expression")
+		}
+		r := p.query[p.pos]
+		p.pos++
+		if r == '/' {
+			break
+		}
+		if r == '\\' && !p.atEnd() && p.query[p.pos] == '/' {
+			r = '/'
+			p.pos++
+		} else if r == '\\' && !p.atEnd() {
+			pattern.WriteRune(r)
+			r = p.query[p.pos]
+			p.pos++
+		}
+		pattern.WriteRune(r)
+	}
+	flags := p.readValue()
+	if !regexFlagsPattern.MatchString(flags) {
+		return nil, p.errorf("invalid flags %q of regular expression", flags)
+	}
+	if _, err := regexp.Compile(pattern.String()); err != nil {
+		return nil, fmt.Errorf("%w: invalid regular expression: %v", common.ErrInvalidEventFilter, err)
+	}
+	condition := bson.M{"$regex": pattern.String()}
+	if flags != "" {
+		condition["$options"] = flags
+	}
+	return condition, nil
+}
+
+func (p *parser) parseRange(property string) (bson.M, error) {
+	start := p.pos
+	lowerOperator, upperOperator, closing := "$gte", "$lte", ']'
+	if p.query[p.pos] == '{' {
+		lowerOperator, upperOperator, closing = "$gt", "$lt", '}'
+	}
+	end := p.pos + 1
+	for end < len(p.query) && p.query[end] != closing {
+		end++
+	}
+	if end == len(p.query) {
+		return nil, p.errorf("unterminated range")
+	}
+	bounds := strings.Split(string(p.query[p.pos+1:end]), " TO ")
+	p.pos = end + 1
+	if len(bounds) != 2 {
+		p.pos = start
+		return nil, p.errorf("expected range [<from> TO <to>]")
+	}
+
+	condition := bson.M{}
+	for i, operator := range []string{lowerOperator, upperOperator} {
+		bound := strings.TrimSpace(bounds[i])
+		if bound == "*" {
+			continue
+		}
+		value, err := p.comparisonValue(property, bound)
+		if err != nil {
+			return nil, err
+		}
+		condition[operator] = value
+	}
+	if len(condition) == 0 {
+		return bson.M{"$exists": true}, nil
+	}
+	return condition, nil
+}
+
+func (p *parser) parseComparison(property string) (bson.M, error) {
+	operator := "$gt"
+	if p.query[p.pos] == '<' {
+		operator = "$lt"
+	}
+	p.pos++
+	if !p.atEnd() && p.query[p.pos] == '=' {
+		operator += "e"
+		p.pos++
+	}
+	raw := p.readValue()
+	if raw == "" {
+		return nil, p.errorf("expected value of %s", property)
+	}
+	value, err := p.comparisonValue(property, raw)
+	if err != nil {
+		return nil, err
+	}
+	return bson.M{operator: value}, nil
+}
+
+// comparisonValue returns the value a property is compared with. Times are stored as RFC 3339 strings, other values
+// are compared as numbers if possible
+func (p *parser) comparisonValue(property string, raw string) (interface{}, error) {
+	if property == timePropertyPath {
+		t, err := common.ParseTime(raw, p.now)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid time %q: %v", common.ErrInvalidEventFilter, raw, err)
+		}
+		return t.UTC().Format(time.RFC3339Nano), nil
+	}
+	if number, err := strconv.ParseFloat(raw, 64); err == nil {
+		return number, nil
+	}
+	return raw, nil
+}
+
+// readValue reads an unquoted value, which ends at a space or a closing parenthesis
+func (p *parser) readValue() string {
+	start := p.pos
+	for !p.atEnd() && !p.atBoundary() {
+		p.pos++
+	}
+	return string(p.query[start:p.pos])
+}
+
+func (p *parser) consumeKeyword(keyword string) bool {
+	if !p.atKeyword(keyword) {
+		return false
+	}
+	p.pos += len(keyword)
+	p.skipSpaces()
+	return true
+}
+
+// atKeyword checks if the keyword, followed by a space or parenthesis, is at the current position
+func (p *parser) atKeyword(keyword string) bool {
+	end := p.pos + len(keyword)
+	if end > len(p.query) || string(p.query[p.pos:end]) != keyword {
+		return false
+	}
+	return end == len(p.query) || unicode.IsSpace(p.query[end]) || p.query[end] == '('
+}
+
+func (p *parser) atBoundary() bool {
+	return unicode.IsSpace(p.query[p.pos]) || p.query[p.pos] == ')'
+}
+
+func (p *parser) atEnd() bool {
+	return p.pos >= len(p.query)
+}
+
+func (p *parser) skipSpaces() {
+	for !p.atEnd() && unicode.IsSpace(p.query[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s at position %d", common.ErrInvalidEventFilter, fmt.Sprintf(format, args...), p.pos+1)
+}
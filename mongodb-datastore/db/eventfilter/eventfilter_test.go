@@ -0,0 +1,193 @@
+package eventfilter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/keptn/keptn/mongodb-datastore/common"
+)
+
+func TestParse(t *testing.T) {
+	now := time.Date(2022, 5, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		query string
+		want  bson.M
+	}{
+		{
+			name:  "empty query",
+			query: " ",
+			want:  bson.M{},
+		},
+		{
+			name:  "key values joined by AND",
+			query: "data.project:sockshop AND shkeptncontext:test-context",
+			want: bson.M{
+				"data.project":   "sockshop",
+				"shkeptncontext": "test-context",
+			},
+		},
+		{
+			name:  "list of values",
+			query: "data.project:sockshop AND data.result:pass,warn",
+			want: bson.M{
+				"data.project": "sockshop",
+				"data.result":  bson.M{"$in": []string{"pass", "warn"}},
+			},
+		},
+		{
+			name:  "quoted value",
+			query: `data.stage:"hardening \"stage\""`,
+			want:  bson.M{"data.stage": `hardening "stage"`},
+		},
+		{
+			name:  "OR",
+			query: "data.project:sockshop AND (data.result:fail OR data.status:errored OR data.status:aborted)",
+			want: bson.M{
+				"data.project": "sockshop",
+				"$or": []bson.M{
+					{"data.result": "fail"},
+					{"data.status": "errored"},
+					{"data.status": "aborted"},
+				},
+			},
+		},
+		{
+			name:  "OR binds weaker than AND",
+			query: "data.service:carts AND data.result:fail OR data.service:orders",
+			want: bson.M{
+				"$or": []bson.M{
+					{"data.service": "carts", "data.result": "fail"},
+					{"data.service": "orders"},
+				},
+			},
+		},
+		{
+			name:  "NOT",
+			query: "data.project:sockshop AND NOT data.stage:dev AND NOT (data.result:pass OR data.result:warn)",
+			want: bson.M{
+				"data.project": "sockshop",
+				"$nor":         []bson.M{{"data.stage": "dev"}},
+				"$and": []bson.M{
+					{"$nor": []bson.M{{"$or": []bson.M{{"data.result": "pass"}, {"data.result": "warn"}}}}},
+				},
+			},
+		},
+		{
+			name:  "existence check and label",
+			query: "data.project:sockshop AND data.labels.team:payments AND data.labels.buildId:*",
+			want: bson.M{
+				"data.project":        "sockshop",
+				"data.labels.team":    "payments",
+				"data.labels.buildId": bson.M{"$exists": true},
+			},
+		},
+		{
+			name:  "regular expression",
+			query: `data.project:sockshop AND data.message:/connection (refused|reset)\/timeout/i`,
+			want: bson.M{
+				"data.project": "sockshop",
+				"data.message": bson.M{"$regex": "connection (refused|reset)/timeout", "$options": "i"},
+			},
+		},
+		{
+			name:  "time range",
+			query: "data.project:sockshop AND time:[2022-05-01 TO 2022-05-03T10:00:00+02:00]",
+			want: bson.M{
+				"data.project": "sockshop",
+				"time": bson.M{
+					"$gte": "2022-05-01T00:00:00Z",
+					"$lte": "2022-05-03T08:00:00Z",
+				},
+			},
+		},
+		{
+			name:  "exclusive open range relative to now",
+			query: "data.project:sockshop AND time:{now-7d TO *}",
+			want: bson.M{
+				"data.project": "sockshop",
+				"time":         bson.M{"$gt": "2022-05-03T12:00:00Z"},
+			},
+		},
+		{
+			name:  "comparisons on the same property",
+			query: "data.project:sockshop AND time:>=now-1w AND time:<now-12h AND data.evaluation.score:<90",
+			want: bson.M{
+				"data.project":          "sockshop",
+				"time":                  bson.M{"$gte": "2022-05-03T12:00:00Z"},
+				"data.evaluation.score": bson.M{"$lt": float64(90)},
+				"$and": []bson.M{
+					{"time": bson.M{"$lt": "2022-05-10T00:00:00Z"}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.query, now)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParse_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "no key value pair", query: "bla"},
+		{name: "missing operator", query: "data.project:sockshop data.stage:dev"},
+		{name: "missing value", query: "data.project:"},
+		{name: "operator as property", query: "$where:sleep(1000)"},
+		{name: "unbalanced parentheses", query: "(data.project:sockshop AND data.stage:dev"},
+		{name: "dangling operator", query: "data.project:sockshop AND"},
+		{name: "unterminated quoted value", query: `data.stage:"dev`},
+		{name: "invalid regular expression", query: "data.message:/(unclosed/"},
+		{name: "invalid flags", query: "data.message:/timeout/g"},
+		{name: "invalid range", query: "time:[now-1d now]"},
+		{name: "invalid time", query: "time:>yesterday"},
+		{name: "empty list value", query: "data.result:pass,"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.query, time.Now())
+			require.ErrorIs(t, err, common.ErrInvalidEventFilter)
+		})
+	}
+}
+
+func TestAnd(t *testing.T) {
+	got := And(
+		bson.M{"data.project": "sockshop", "time": bson.M{"$gt": "2022-05-01T00:00:00Z"}},
+		bson.M{"type": "sh.keptn.event.deployment.finished"},
+		bson.M{"time": bson.M{"$lt": "2022-05-03T00:00:00Z"}},
+	)
+	require.Equal(t, bson.M{
+		"data.project": "sockshop",
+		"type":         "sh.keptn.event.deployment.finished",
+		"time":         bson.M{"$gt": "2022-05-01T00:00:00Z"},
+		"$and": []bson.M{
+			{"time": bson.M{"$lt": "2022-05-03T00:00:00Z"}},
+		},
+	}, got)
+}
+
+func TestAnd_NestedAnd(t *testing.T) {
+	filter, err := Parse("data.project:sockshop AND time:>2022-05-01T00:00:00Z AND time:<2022-05-03T00:00:00Z", time.Now())
+	require.NoError(t, err)
+
+	got := And(filter, bson.M{"type": "sh.keptn.event.deployment.finished"}, bson.M{"time": bson.M{"$gt": "2022-05-02T00:00:00Z"}})
+	require.Equal(t, bson.M{
+		"data.project": "sockshop",
+		"type":         "sh.keptn.event.deployment.finished",
+		"time":         bson.M{"$gt": "2022-05-01T00:00:00Z"},
+		"$and": []bson.M{
+			{"time": bson.M{"$lt": "2022-05-03T00:00:00Z"}},
+			{"time": bson.M{"$gt": "2022-05-02T00:00:00Z"}},
+		},
+	}, got)
+}
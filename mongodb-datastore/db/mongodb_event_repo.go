@@ -7,6 +7,7 @@ import (
 	"github.com/jeremywohl/flatten"
 	keptnapi "github.com/keptn/go-utils/pkg/api/models"
 	"github.com/keptn/keptn/mongodb-datastore/common"
+	"github.com/keptn/keptn/mongodb-datastore/db/eventfilter"
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/event"
 	logger "github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
@@ -34,12 +35,14 @@ const (
 	projectPropertyPath      = "data.project"
 	stagePropertyPath        = "data.stage"
 	servicePropertyPath      = "data.service"
+	// wildcard index covering the labels of events, which have arbitrary keys
+	labelsWildcardPath = "data.labels.$**"
 )
 
 var (
 	projectLocks             = map[string]*sync.Mutex{}
 	rootEventsIndexes        = []string{servicePropertyPath, timePropertyPath}
-	projectEventsIndexes     = []string{servicePropertyPath, keptnContextPropertyPath, typePropertyPath}
+	projectEventsIndexes     = []string{servicePropertyPath, keptnContextPropertyPath, typePropertyPath, timePropertyPath, stagePropertyPath, labelsWildcardPath}
 	invalidatedEventsIndexes = []string{triggeredIDPropertyPath}
 )
 
@@ -173,6 +176,13 @@ func (mr *MongoDBEventRepo) DropProjectCollections(event keptnapi.KeptnContextEx
 
 func (mr *MongoDBEventRepo) GetEvents(params event.GetEventsParams) (*EventsResult, error) {
 	searchOptions := getSearchOptions(params)
+	if params.Filter != nil {
+		filter, err := parseFilter(*params.Filter)
+		if err != nil {
+			return nil, err
+		}
+		searchOptions = eventfilter.And(searchOptions, filter)
+	}
 
 	onlyRootEvents := params.Root != nil
 	collectionName, err := mr.getCollectionNameForQuery(searchOptions)
@@ -196,17 +206,22 @@ func (mr *MongoDBEventRepo) GetEventsByType(params event.GetEventsByTypeParams)
 		return nil, fmt.Errorf("event filter must not be empty: %w", common.ErrInvalidEventFilter)
 	}
 
-	matchFields := parseFilter(params.Filter)
+	matchFields, err := parseFilter(params.Filter)
+	if err != nil {
+		return nil, err
+	}
 	if err := validateFilter(matchFields); err != nil {
 		return nil, err
 	}
 
-	matchFields[typePropertyPath] = params.EventType
+	matchFields = eventfilter.And(matchFields, bson.M{typePropertyPath: params.EventType})
 
 	if params.FromTime != nil {
-		matchFields[timePropertyPath] = bson.M{
-			"$gt": *params.FromTime,
-		}
+		matchFields = eventfilter.And(matchFields, bson.M{
+			timePropertyPath: bson.M{
+				"$gt": *params.FromTime,
+			},
+		})
 	}
 
 	collectionName, err := mr.getCollectionNameForQuery(matchFields)
@@ -401,18 +416,21 @@ func (mr *MongoDBEventRepo) storeEventInCollection(ctx context.Context, event ke
 
 func (mr *MongoDBEventRepo) getCollectionNameForQuery(searchOptions bson.M) (string, error) {
 	collectionName := unmappedEventsCollectionName
-	if searchOptions[projectPropertyPath] != nil && searchOptions[projectPropertyPath] != "" {
+	// conditions other than equality, e.g. a list of projects, do not select a collection
+	project, _ := searchOptions[projectPropertyPath].(string)
+	keptnContext, _ := searchOptions[keptnContextPropertyPath].(string)
+	if project != "" {
 		// if a project has been specified, query the collection for that project
-		collectionName = searchOptions[projectPropertyPath].(string)
-	} else if searchOptions[keptnContextPropertyPath] != nil && searchOptions[keptnContextPropertyPath] != "" {
+		collectionName = project
+	} else if keptnContext != "" {
 		var err error
-		collectionName, err = mr.getProjectForContext(searchOptions[keptnContextPropertyPath].(string))
+		collectionName, err = mr.getProjectForContext(keptnContext)
 		if err != nil {
 			if err == mongo.ErrNoDocuments {
-				logger.Infof("No project found for KeptnContext '%s'", searchOptions[keptnContextPropertyPath])
+				logger.Infof("No project found for KeptnContext '%s'", keptnContext)
 				return unmappedEventsCollectionName, nil
 			}
-			return "", fmt.Errorf("could not load project for KeptnContext '%s': %v", searchOptions[keptnContextPropertyPath], err)
+			return "", fmt.Errorf("could not load project for KeptnContext '%s': %v", keptnContext, err)
 		}
 	}
 
@@ -650,29 +668,18 @@ func getInvalidatedEventQuery(params event.GetEventsByTypeParams, collectionName
 	return aggregationPipeline
 }
 
-func parseFilter(filter string) bson.M {
-	filterObject := bson.M{}
-	keyValues := strings.Split(filter, " AND ")
-
-	for _, keyValuePair := range keyValues {
-		split := strings.Split(keyValuePair, ":")
-		if len(split) == 2 {
-			splitValue := strings.Split(split[1], ",")
-			if len(splitValue) == 1 {
-				filterObject[split[0]] = split[1]
-			} else {
-				filterObject[split[0]] = bson.M{
-					"$in": splitValue,
-				}
-			}
-		}
-	}
-
-	return filterObject
+// parseFilter parses the query language described in the eventfilter package, e.g.
+// data.project:sockshop AND data.result:fail,warn AND time:>now-7d
+func parseFilter(filter string) (bson.M, error) {
+	return eventfilter.Parse(filter, time.Now())
 }
 
+// validateFilter checks that the filter selects the project whose collection is queried, either by its name or the
+// keptn context of a sequence of the project
 func validateFilter(searchOptions bson.M) error {
-	if (searchOptions[projectPropertyPath] == nil || searchOptions[projectPropertyPath] == "") && (searchOptions[keptnContextPropertyPath] == nil || searchOptions[keptnContextPropertyPath] == "") {
+	project, _ := searchOptions[projectPropertyPath].(string)
+	keptnContext, _ := searchOptions[keptnContextPropertyPath].(string)
+	if project == "" && keptnContext == "" {
 		return fmt.Errorf("%w: either 'shkeptncontext' or 'data.project' must be set", common.ErrInvalidEventFilter)
 	}
 
@@ -2,10 +2,12 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	keptnapi "github.com/keptn/go-utils/pkg/api/models"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/keptn/mongodb-datastore/common"
+	"github.com/keptn/keptn/mongodb-datastore/dora"
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/event"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -167,6 +169,221 @@ func TestMongoDBEventRepo_Retrieve_NoProjectOrKeptnContext(t *testing.T) {
 	require.Nil(t, eventsByType)
 }
 
+func TestMongoDBEventRepo_RetrieveWithFilter(t *testing.T) {
+	repo := NewMongoDBEventRepo(GetMongoDBConnectionInstance())
+
+	deploymentFinishedType := keptnv2.GetFinishedEventType(keptnv2.DeploymentTaskName)
+	newEvent := func(id string, service string, result string, labels map[string]interface{}, eventTime time.Time) keptnapi.KeptnContextExtendedCE {
+		return keptnapi.KeptnContextExtendedCE{
+			Contenttype: "application/cloudevents+json",
+			Data: map[string]interface{}{
+				"project": "filter-project",
+				"stage":   "dev",
+				"service": service,
+				"result":  result,
+				"labels":  labels,
+				"message": "deployment of " + service + ": " + result,
+			},
+			ID:                 id,
+			Source:             stringp("test-source"),
+			Specversion:        "1.0",
+			Time:               eventTime,
+			Type:               stringp(deploymentFinishedType),
+			Shkeptncontext:     "context-" + id,
+			Shkeptnspecversion: "0.2.3",
+			Triggeredid:        "triggered-" + id,
+		}
+	}
+	now := time.Now().UTC()
+	testEvents := []keptnapi.KeptnContextExtendedCE{
+		newEvent("failed-payments", "carts", "fail", map[string]interface{}{"team": "payments"}, now.Add(-48*time.Hour)),
+		newEvent("failed-payments-old", "carts", "fail", map[string]interface{}{"team": "payments"}, now.Add(-240*time.Hour)),
+		newEvent("failed-shipping", "carts", "fail", map[string]interface{}{"team": "shipping"}, now.Add(-24*time.Hour)),
+		newEvent("passed-payments", "carts", "pass", map[string]interface{}{"team": "payments"}, now.Add(-24*time.Hour)),
+		newEvent("failed-orders", "orders", "fail", nil, now.Add(-24*time.Hour)),
+	}
+	for _, testEvent := range testEvents {
+		require.Nil(t, repo.InsertEvent(testEvent))
+	}
+
+	tests := []struct {
+		name    string
+		filter  string
+		wantIDs []string
+	}{
+		{
+			name:    "failed deployments of a service with label within the last week",
+			filter:  "data.project:filter-project AND data.service:carts AND data.result:fail AND data.labels.team:payments AND time:>now-7d",
+			wantIDs: []string{"failed-payments"},
+		},
+		{
+			name:    "bounded time range",
+			filter:  "data.project:filter-project AND data.result:fail AND time:>now-7d AND time:<now-36h",
+			wantIDs: []string{"failed-payments"},
+		},
+		{
+			name:    "OR and NOT",
+			filter:  "data.project:filter-project AND (data.service:orders OR data.labels.team:shipping) AND NOT data.result:pass",
+			wantIDs: []string{"failed-shipping", "failed-orders"},
+		},
+		{
+			name:    "label does not exist",
+			filter:  "data.project:filter-project AND NOT data.labels.team:*",
+			wantIDs: []string{"failed-orders"},
+		},
+		{
+			name:    "regular expression on message",
+			filter:  "data.project:filter-project AND data.message:/^DEPLOYMENT OF ORDERS/i",
+			wantIDs: []string{"failed-orders"},
+		},
+	}
+	pageSize := int64(0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventsByType, err := repo.GetEventsByType(
+				event.GetEventsByTypeParams{
+					EventType: deploymentFinishedType,
+					Filter:    tt.filter,
+					Limit:     &pageSize,
+				},
+			)
+			require.Nil(t, err)
+			require.ElementsMatch(t, tt.wantIDs, eventIDs(eventsByType.Events))
+
+			events, err := repo.GetEvents(
+				event.GetEventsParams{
+					Filter:   &tt.filter,
+					PageSize: &pageSize,
+				},
+			)
+			require.Nil(t, err)
+			require.ElementsMatch(t, tt.wantIDs, eventIDs(events.Events))
+		})
+	}
+
+	invalidFilter := "data.project:filter-project AND data.result:"
+	_, err := repo.GetEvents(event.GetEventsParams{Filter: &invalidFilter, PageSize: &pageSize})
+	require.ErrorIs(t, err, common.ErrInvalidEventFilter)
+}
+
+func eventIDs(events []keptnapi.KeptnContextExtendedCE) []string {
+	ids := []string{}
+	for _, e := range events {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+func TestMongoDBEventRepo_DeleteEvents(t *testing.T) {
+	repo := NewMongoDBEventRepo(GetMongoDBConnectionInstance())
+
+	now := time.Now().UTC()
+	newEvent := func(id string, eventType string, eventTime time.Time) keptnapi.KeptnContextExtendedCE {
+		return keptnapi.KeptnContextExtendedCE{
+			Contenttype:        "application/cloudevents+json",
+			Data:               map[string]interface{}{"project": "retention-project", "stage": "dev", "service": "carts"},
+			ID:                 id,
+			Source:             stringp("test-source"),
+			Specversion:        "1.0",
+			Time:               eventTime,
+			Type:               stringp(eventType),
+			Shkeptncontext:     "context-" + id,
+			Shkeptnspecversion: "0.2.3",
+		}
+	}
+	deploymentTriggeredType := keptnv2.GetTriggeredEventType(keptnv2.DeploymentTaskName)
+	for _, testEvent := range []keptnapi.KeptnContextExtendedCE{
+		newEvent("expired", deploymentTriggeredType, now.Add(-240*time.Hour)),
+		newEvent("recent", deploymentTriggeredType, now.Add(-time.Hour)),
+	} {
+		require.Nil(t, repo.InsertEvent(testEvent))
+	}
+
+	projects, err := repo.GetProjects()
+	require.Nil(t, err)
+	require.Contains(t, projects, "retention-project")
+	require.NotContains(t, projects, "retention-project"+rootEventCollectionSuffix)
+	require.NotContains(t, projects, contextToProjectCollection)
+
+	var archived []keptnapi.KeptnContextExtendedCE
+	filter := bson.M{timePropertyPath: bson.M{"$lt": now.Add(-24 * time.Hour).Format(time.RFC3339Nano)}}
+	deleted, err := repo.DeleteEvents("retention-project", filter, func(events []keptnapi.KeptnContextExtendedCE) error {
+		archived = append(archived, events...)
+		return nil
+	})
+	require.Nil(t, err)
+	require.Equal(t, int64(1), deleted)
+	require.Equal(t, []string{"expired"}, eventIDs(archived))
+
+	// events are not deleted if they could not be archived
+	deleted, err = repo.DeleteEvents("retention-project", bson.M{}, func(events []keptnapi.KeptnContextExtendedCE) error {
+		return errors.New("oops")
+	})
+	require.NotNil(t, err)
+	require.Equal(t, int64(0), deleted)
+
+	project := "retention-project"
+	pageSize := int64(0)
+	events, err := repo.GetEvents(event.GetEventsParams{Project: &project, PageSize: &pageSize})
+	require.Nil(t, err)
+	require.Equal(t, []string{"recent"}, eventIDs(events.Events))
+}
+
+func TestMongoDBEventRepo_GetDeploymentsAndOutcomes(t *testing.T) {
+	repo := NewMongoDBEventRepo(GetMongoDBConnectionInstance())
+
+	start := time.Now().UTC().Truncate(time.Second).Add(-24 * time.Hour)
+	newEvent := func(id string, keptnContext string, eventType string, result string, hours int) keptnapi.KeptnContextExtendedCE {
+		return keptnapi.KeptnContextExtendedCE{
+			Contenttype:        "application/cloudevents+json",
+			Data:               map[string]interface{}{"project": "metrics-project", "stage": "dev", "service": "carts", "result": result},
+			ID:                 id,
+			Source:             stringp("test-source"),
+			Specversion:        "1.0",
+			Time:               start.Add(time.Duration(hours) * time.Hour),
+			Type:               stringp(eventType),
+			Shkeptncontext:     keptnContext,
+			Shkeptnspecversion: "0.2.3",
+			Triggeredid:        "triggered-" + id,
+		}
+	}
+	deploymentFinishedType := keptnv2.GetFinishedEventType(keptnv2.DeploymentTaskName)
+	evaluationFinishedType := keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName)
+	testEvents := []keptnapi.KeptnContextExtendedCE{
+		newEvent("failed-sequence-triggered", "failed-context", "sh.keptn.event.dev.delivery.triggered", "", 0),
+		newEvent("failed-deployment", "failed-context", deploymentFinishedType, "pass", 1),
+		newEvent("failed-evaluation", "failed-context", evaluationFinishedType, "fail", 2),
+		newEvent("failed-sequence-finished", "failed-context", "sh.keptn.event.dev.delivery.finished", "fail", 3),
+		newEvent("passed-sequence-triggered", "passed-context", "sh.keptn.event.dev.delivery.triggered", "", 4),
+		newEvent("passed-deployment", "passed-context", deploymentFinishedType, "pass", 5),
+		newEvent("passed-evaluation", "passed-context", evaluationFinishedType, "pass", 6),
+	}
+	for _, testEvent := range testEvents {
+		require.Nil(t, repo.InsertEvent(testEvent))
+	}
+
+	filter := dora.Filter{Project: "metrics-project", Stage: "dev", From: start.Add(-time.Hour), To: start.Add(24 * time.Hour)}
+	deployments, err := repo.GetDeployments(filter)
+	require.Nil(t, err)
+	require.ElementsMatch(t, []dora.Deployment{
+		{Time: start.Add(time.Hour), Stage: "dev", Service: "carts", Result: "pass", SequenceStart: start, Failed: true},
+		{Time: start.Add(5 * time.Hour), Stage: "dev", Service: "carts", Result: "pass", SequenceStart: start.Add(4 * time.Hour)},
+	}, deployments)
+
+	outcomes, err := repo.GetOutcomes(filter)
+	require.Nil(t, err)
+	require.Equal(t, []dora.Outcome{
+		{Time: start.Add(2 * time.Hour), Stage: "dev", Service: "carts", Result: "fail"},
+		{Time: start.Add(3 * time.Hour), Stage: "dev", Service: "carts", Result: "fail"},
+		{Time: start.Add(6 * time.Hour), Stage: "dev", Service: "carts", Result: "pass"},
+	}, outcomes)
+
+	filter.Service = "orders"
+	deployments, err = repo.GetDeployments(filter)
+	require.Nil(t, err)
+	require.Empty(t, deployments)
+}
+
 func TestMongoDBEventRepo_DropCollections(t *testing.T) {
 	repo := NewMongoDBEventRepo(GetMongoDBConnectionInstance())
 
@@ -460,9 +677,10 @@ func Test_parseFilter(t *testing.T) {
 		filter string
 	}
 	tests := []struct {
-		name string
-		args args
-		want bson.M
+		name    string
+		args    args
+		want    bson.M
+		wantErr bool
 	}{
 		{
 			name: "get key values",
@@ -498,12 +716,30 @@ func Test_parseFilter(t *testing.T) {
 			args: args{
 				filter: "bla",
 			},
-			want: bson.M{},
+			wantErr: true,
+		},
+		{
+			name: "OR and NOT",
+			args: args{
+				filter: "data.project:sockshop AND NOT (data.result:pass OR data.result:warn)",
+			},
+			want: bson.M{
+				"data.project": "sockshop",
+				"$nor": []bson.M{
+					{"$or": []bson.M{{"data.result": "pass"}, {"data.result": "warn"}}},
+				},
+			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := parseFilter(tt.args.filter); !reflect.DeepEqual(got, tt.want) {
+			got, err := parseFilter(tt.args.filter)
+			if tt.wantErr {
+				require.ErrorIs(t, err, common.ErrInvalidEventFilter)
+				return
+			}
+			require.NoError(t, err)
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("parseFilter() = %v, want %v", got, tt.want)
 			}
 		})
@@ -562,6 +798,15 @@ func Test_validateFilter(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "list of projects",
+			args: args{
+				searchOptions: bson.M{
+					"data.project": bson.M{"$in": []string{"a", "b"}},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
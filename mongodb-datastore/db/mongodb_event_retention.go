@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	keptnapi "github.com/keptn/go-utils/pkg/api/models"
+	logger "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// retentionBatchSize is the number of expired events that are archived and deleted at once
+const retentionBatchSize = 1000
+
+// GetProjects returns the projects having an events collection, including the collection of unmapped events
+func (mr *MongoDBEventRepo) GetProjects() ([]string, error) {
+	mdbClient, err := mr.DBConnection.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collectionNames, err := mdbClient.Database(getDatabaseName()).ListCollectionNames(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("could not list collections: %w", err)
+	}
+	var projects []string
+	for _, collectionName := range collectionNames {
+		if collectionName == contextToProjectCollection ||
+			strings.HasSuffix(collectionName, rootEventCollectionSuffix) ||
+			strings.HasSuffix(collectionName, invalidatedEventsCollectionSuffix) {
+			continue
+		}
+		projects = append(projects, collectionName)
+	}
+	return projects, nil
+}
+
+// DeleteEvents deletes the events of the project matching the filter in batches. If archive is set, each batch is
+// archived before it is deleted. Root events and invalidated events matching the filter are deleted without being
+// archived, since they are duplicates of events of the project
+func (mr *MongoDBEventRepo) DeleteEvents(project string, filter bson.M, archive func(events []keptnapi.KeptnContextExtendedCE) error) (int64, error) {
+	var deleted int64
+	for {
+		n, err := mr.deleteEventsBatch(project, filter, archive)
+		deleted += n
+		if err != nil {
+			return deleted, err
+		}
+		if n < retentionBatchSize {
+			break
+		}
+	}
+
+	for _, collectionName := range []string{project + rootEventCollectionSuffix, getInvalidatedCollectionName(project)} {
+		collection, ctx, cancel, err := mr.getCollectionAndContext(collectionName)
+		if err != nil {
+			return deleted, err
+		}
+		_, err = collection.DeleteMany(ctx, filter)
+		cancel()
+		if err != nil {
+			return deleted, fmt.Errorf("could not delete expired events from collection %s: %w", collectionName, err)
+		}
+	}
+	return deleted, nil
+}
+
+func (mr *MongoDBEventRepo) deleteEventsBatch(project string, filter bson.M, archive func(events []keptnapi.KeptnContextExtendedCE) error) (int64, error) {
+	collection, ctx, cancel, err := mr.getCollectionAndContext(project)
+	if err != nil {
+		return 0, err
+	}
+	defer cancel()
+
+	findOptions := options.Find().SetSort(bson.D{{Key: timePropertyPath, Value: 1}}).SetLimit(retentionBatchSize)
+	if archive == nil {
+		findOptions.SetProjection(bson.M{"_id": 1})
+	}
+	cur, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return 0, fmt.Errorf("could not find expired events of project %s: %w", project, err)
+	}
+	defer cur.Close(ctx)
+
+	var ids []interface{}
+	var events []keptnapi.KeptnContextExtendedCE
+	for cur.Next(ctx) {
+		var document bson.M
+		if err := cur.Decode(&document); err != nil {
+			return 0, fmt.Errorf("could not decode expired event of project %s: %w", project, err)
+		}
+		ids = append(ids, document["_id"])
+		if archive == nil {
+			continue
+		}
+		keptnEvent, err := decodeEvent(document)
+		if err != nil {
+			// the event is deleted anyway, since it could not be returned by the API either
+			logger.Errorf("Could not archive expired event %v of project %s: %v", document["_id"], project, err)
+			continue
+		}
+		events = append(events, keptnEvent)
+	}
+	if err := cur.Err(); err != nil {
+		return 0, fmt.Errorf("could not read expired events of project %s: %w", project, err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	if archive != nil && len(events) > 0 {
+		if err := archive(events); err != nil {
+			return 0, fmt.Errorf("could not archive expired events of project %s: %w", project, err)
+		}
+	}
+
+	result, err := collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return 0, fmt.Errorf("could not delete expired events of project %s: %w", project, err)
+	}
+	logger.Debugf("Deleted %d expired events of project %s", result.DeletedCount, project)
+	return int64(len(ids)), nil
+}
+
+func decodeEvent(document bson.M) (keptnapi.KeptnContextExtendedCE, error) {
+	var keptnEvent keptnapi.KeptnContextExtendedCE
+	delete(document, "_id")
+	outputEvent, err := flattenRecursively(document)
+	if err != nil {
+		return keptnEvent, err
+	}
+	data, err := json.Marshal(outputEvent)
+	if err != nil {
+		return keptnEvent, err
+	}
+	err = keptnEvent.FromJSON(data)
+	return keptnEvent, err
+}
@@ -0,0 +1,258 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/mongodb-datastore/dora"
+	logger "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	resultPropertyPath      = "data.result"
+	sequenceStartProperty   = "sequenceStart"
+	failuresProperty        = "failures"
+	invalidatedProperty     = "invalidated"
+	keptnContextVariable    = "$$keptnContext"
+	stageVariable           = "$$stage"
+	metricsAggregateTimeout = 30 * time.Second
+)
+
+const (
+	// sequence events have the stage and the sequence in their type, e.g. sh.keptn.event.dev.delivery.triggered,
+	// while task events only have the task, e.g. sh.keptn.event.deployment.triggered
+	sequenceTriggeredTypePattern = `^sh\.keptn\.event\.[^.]+\.[^.]+\.triggered$`
+	sequenceFinishedTypePattern  = `^sh\.keptn\.event\.[^.]+\.[^.]+\.finished$`
+)
+
+type deploymentRecord struct {
+	Time          string      `bson:"time"`
+	Data          outcomeData `bson:"data"`
+	SequenceStart string      `bson:"sequenceStart"`
+	Failed        bool        `bson:"failed"`
+}
+
+type outcomeRecord struct {
+	Time string      `bson:"time"`
+	Data outcomeData `bson:"data"`
+}
+
+type outcomeData struct {
+	Stage   string `bson:"stage"`
+	Service string `bson:"service"`
+	Result  string `bson:"result"`
+}
+
+// GetDeployments returns the finished deployments matching the filter, together with the start of their sequence
+// and whether the evaluation or the sequence in the stage failed
+func (mr *MongoDBEventRepo) GetDeployments(filter dora.Filter) ([]dora.Deployment, error) {
+	var records []deploymentRecord
+	if err := mr.aggregateMetricRecords(filter.Project, getDeploymentsPipeline(filter), &records); err != nil {
+		return nil, err
+	}
+	deployments := make([]dora.Deployment, 0, len(records))
+	for _, record := range records {
+		deploymentTime, err := time.Parse(time.RFC3339Nano, record.Time)
+		if err != nil {
+			logger.Warnf("Could not parse time of deployment: %v", err)
+			continue
+		}
+		deployment := dora.Deployment{
+			Time:    deploymentTime,
+			Stage:   record.Data.Stage,
+			Service: record.Data.Service,
+			Result:  record.Data.Result,
+			Failed:  record.Failed,
+		}
+		if sequenceStart, err := time.Parse(time.RFC3339Nano, record.SequenceStart); err == nil {
+			deployment.SequenceStart = sequenceStart
+		}
+		deployments = append(deployments, deployment)
+	}
+	return deployments, nil
+}
+
+// GetOutcomes returns the results of the finished sequences and evaluations matching the filter, ordered by time.
+// Invalidated evaluations are excluded
+func (mr *MongoDBEventRepo) GetOutcomes(filter dora.Filter) ([]dora.Outcome, error) {
+	var records []outcomeRecord
+	if err := mr.aggregateMetricRecords(filter.Project, getOutcomesPipeline(filter), &records); err != nil {
+		return nil, err
+	}
+	outcomes := make([]dora.Outcome, 0, len(records))
+	for _, record := range records {
+		outcomeTime, err := time.Parse(time.RFC3339Nano, record.Time)
+		if err != nil {
+			logger.Warnf("Could not parse time of outcome: %v", err)
+			continue
+		}
+		outcomes = append(outcomes, dora.Outcome{
+			Time:    outcomeTime,
+			Stage:   record.Data.Stage,
+			Service: record.Data.Service,
+			Result:  record.Data.Result,
+		})
+	}
+	return outcomes, nil
+}
+
+func (mr *MongoDBEventRepo) aggregateMetricRecords(collectionName string, pipeline mongo.Pipeline, records interface{}) error {
+	mdbClient, err := mr.DBConnection.GetClient()
+	if err != nil {
+		return err
+	}
+	collection := mdbClient.Database(getDatabaseName()).Collection(collectionName)
+
+	// aggregations over large time windows take longer than retrieving a page of events
+	ctx, cancel := context.WithTimeout(context.Background(), metricsAggregateTimeout)
+	defer cancel()
+
+	cur, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return fmt.Errorf("could not aggregate events of collection %s: %w", collectionName, err)
+	}
+	// All closes the cursor
+	if err := cur.All(ctx, records); err != nil {
+		return fmt.Errorf("could not decode aggregated events of collection %s: %w", collectionName, err)
+	}
+	return nil
+}
+
+func getDeploymentsPipeline(filter dora.Filter) mongo.Pipeline {
+	matchStage := bson.D{
+		{Key: "$match", Value: getMetricsMatchFields(filter, bson.M{
+			typePropertyPath: keptnv2.GetFinishedEventType(keptnv2.DeploymentTaskName),
+		})},
+	}
+
+	// the first sequence triggered within the keptn context delivered the artifact
+	sequenceStartLookupStage := bson.D{
+		{Key: "$lookup", Value: bson.M{
+			"from": filter.Project,
+			"let":  bson.M{"keptnContext": "$" + keptnContextPropertyPath},
+			"pipeline": mongo.Pipeline{
+				{{Key: "$match", Value: bson.M{
+					"$expr":          bson.M{"$eq": bson.A{"$" + keptnContextPropertyPath, keptnContextVariable}},
+					typePropertyPath: bson.M{"$regex": sequenceTriggeredTypePattern},
+				}}},
+				{{Key: "$sort", Value: bson.M{timePropertyPath: 1}}},
+				{{Key: "$limit", Value: 1}},
+				{{Key: "$project", Value: bson.M{"_id": 0, timePropertyPath: 1}}},
+			},
+			"as": sequenceStartProperty,
+		}},
+	}
+
+	// failed evaluations and sequences of the keptn context in the stage of the deployment
+	failuresLookupStage := bson.D{
+		{Key: "$lookup", Value: bson.M{
+			"from": filter.Project,
+			"let": bson.M{
+				"keptnContext": "$" + keptnContextPropertyPath,
+				"stage":        "$" + stagePropertyPath,
+			},
+			"pipeline": mongo.Pipeline{
+				{{Key: "$match", Value: bson.M{
+					"$expr": bson.M{"$and": bson.A{
+						bson.M{"$eq": bson.A{"$" + keptnContextPropertyPath, keptnContextVariable}},
+						bson.M{"$eq": bson.A{"$" + stagePropertyPath, stageVariable}},
+					}},
+					resultPropertyPath: string(keptnv2.ResultFailed),
+					"$or":              getOutcomeTypes(),
+				}}},
+				getInvalidatedLookupStage(filter.Project),
+				getNotInvalidatedMatchStage(),
+				{{Key: "$limit", Value: 1}},
+				{{Key: "$project", Value: bson.M{"_id": 0, typePropertyPath: 1}}},
+			},
+			"as": failuresProperty,
+		}},
+	}
+
+	projectStage := bson.D{
+		{Key: "$project", Value: bson.M{
+			"_id":               0,
+			timePropertyPath:    1,
+			stagePropertyPath:   1,
+			servicePropertyPath: 1,
+			resultPropertyPath:  1,
+			sequenceStartProperty: bson.M{
+				"$arrayElemAt": bson.A{"$" + sequenceStartProperty + "." + timePropertyPath, 0},
+			},
+			"failed": bson.M{"$gt": bson.A{bson.M{"$size": "$" + failuresProperty}, 0}},
+		}},
+	}
+
+	return mongo.Pipeline{matchStage, sequenceStartLookupStage, failuresLookupStage, projectStage}
+}
+
+func getOutcomesPipeline(filter dora.Filter) mongo.Pipeline {
+	matchStage := bson.D{
+		{Key: "$match", Value: getMetricsMatchFields(filter, bson.M{
+			"$or": getOutcomeTypes(),
+			resultPropertyPath: bson.M{"$in": bson.A{
+				string(keptnv2.ResultPass),
+				string(keptnv2.ResultWarning),
+				string(keptnv2.ResultFailed),
+			}},
+		})},
+	}
+	sortStage := bson.D{
+		{Key: "$sort", Value: bson.M{timePropertyPath: 1}},
+	}
+	projectStage := bson.D{
+		{Key: "$project", Value: bson.M{
+			"_id":               0,
+			timePropertyPath:    1,
+			stagePropertyPath:   1,
+			servicePropertyPath: 1,
+			resultPropertyPath:  1,
+		}},
+	}
+	return mongo.Pipeline{matchStage, getInvalidatedLookupStage(filter.Project), getNotInvalidatedMatchStage(), sortStage, projectStage}
+}
+
+// getOutcomeTypes matches the events which finish evaluations and sequences
+func getOutcomeTypes() bson.A {
+	return bson.A{
+		bson.M{typePropertyPath: keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName)},
+		bson.M{typePropertyPath: bson.M{"$regex": sequenceFinishedTypePattern}},
+	}
+}
+
+func getMetricsMatchFields(filter dora.Filter, matchFields bson.M) bson.M {
+	matchFields[timePropertyPath] = bson.M{
+		"$gte": filter.From.UTC().Format(time.RFC3339Nano),
+		"$lt":  filter.To.UTC().Format(time.RFC3339Nano),
+	}
+	if filter.Stage != "" {
+		matchFields[stagePropertyPath] = filter.Stage
+	}
+	if filter.Service != "" {
+		matchFields[servicePropertyPath] = filter.Service
+	}
+	return matchFields
+}
+
+func getInvalidatedLookupStage(collectionName string) bson.D {
+	return bson.D{
+		{Key: "$lookup", Value: bson.M{
+			"from":         getInvalidatedCollectionName(collectionName),
+			"localField":   triggeredIDPropertyPath,
+			"foreignField": triggeredIDPropertyPath,
+			"as":           invalidatedProperty,
+		}},
+	}
+}
+
+func getNotInvalidatedMatchStage() bson.D {
+	return bson.D{
+		{Key: "$match", Value: bson.M{
+			invalidatedProperty: bson.M{"$size": 0},
+		}},
+	}
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	keptnapi "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/mongodb-datastore/dora"
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/event"
 )
 
@@ -22,3 +23,8 @@ type EventRepo interface {
 	GetEvents(params event.GetEventsParams) (*EventsResult, error)
 	GetEventsByType(params event.GetEventsByTypeParams) (*EventsResult, error)
 }
+
+type MetricsRepo interface {
+	GetDeployments(filter dora.Filter) ([]dora.Deployment, error)
+	GetOutcomes(filter dora.Filter) ([]dora.Outcome, error)
+}
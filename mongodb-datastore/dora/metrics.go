@@ -0,0 +1,212 @@
+package dora
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/keptn/keptn/mongodb-datastore/common"
+)
+
+// Names of the DORA metrics
+const (
+	DeploymentFrequency = "deploymentFrequency"
+	LeadTime            = "leadTime"
+	ChangeFailureRate   = "changeFailureRate"
+	MeanTimeToRestore   = "meanTimeToRestore"
+)
+
+// Units of the values of the metrics
+const (
+	UnitDeployments = "deployments"
+	UnitSeconds     = "seconds"
+	UnitRatio       = "ratio"
+)
+
+// maxBuckets limits the number of time buckets of a series
+const maxBuckets = 1000
+
+const resultFail = "fail"
+
+// Filter selects the events the metrics are computed from
+type Filter struct {
+	Project string
+	// Stage is optional, if empty all stages are included
+	Stage string
+	// Service is optional, if empty all services are included
+	Service string
+	From    time.Time
+	To      time.Time
+}
+
+// Deployment is a finished deployment of a service to a stage
+type Deployment struct {
+	Time    time.Time
+	Stage   string
+	Service string
+	Result  string
+	// SequenceStart is the time the sequence delivering the artifact was triggered, zero if unknown
+	SequenceStart time.Time
+	// Failed is set if the evaluation or the sequence following the deployment in the stage failed
+	Failed bool
+}
+
+// Outcome is the result of a finished sequence or evaluation of a service in a stage
+type Outcome struct {
+	Time    time.Time
+	Stage   string
+	Service string
+	Result  string
+}
+
+// Window is the time window a metric is computed for, split into buckets of the interval
+type Window struct {
+	From     time.Time
+	To       time.Time
+	Interval time.Duration
+}
+
+// NewWindow creates a Window, making sure that it does not consist of too many buckets
+func NewWindow(from time.Time, to time.Time, interval time.Duration) (Window, error) {
+	if !from.Before(to) {
+		return Window{}, fmt.Errorf("%w: from must be before to", common.ErrInvalidMetricQuery)
+	}
+	if interval <= 0 {
+		return Window{}, fmt.Errorf("%w: interval must be positive", common.ErrInvalidMetricQuery)
+	}
+	w := Window{From: from.UTC(), To: to.UTC(), Interval: interval}
+	if w.buckets() > maxBuckets {
+		return Window{}, fmt.Errorf("%w: at most %d buckets are allowed, use a larger interval", common.ErrInvalidMetricQuery, maxBuckets)
+	}
+	return w, nil
+}
+
+func (w Window) buckets() int {
+	return int((w.To.Sub(w.From) + w.Interval - 1) / w.Interval)
+}
+
+// bucket returns the index of the bucket containing t, or false if t is outside the window
+func (w Window) bucket(t time.Time) (int, bool) {
+	if t.Before(w.From) || !t.Before(w.To) {
+		return 0, false
+	}
+	return int(t.Sub(w.From) / w.Interval), true
+}
+
+// Series is the value of a metric over the whole window and per bucket
+type Series struct {
+	Unit    string
+	Value   float64
+	Count   int64
+	Buckets []Bucket
+}
+
+type Bucket struct {
+	From  time.Time
+	To    time.Time
+	Value float64
+	Count int64
+}
+
+// series accumulates samples per bucket, the value of a bucket is either the sum or the mean of its samples
+type series struct {
+	window Window
+	sums   []float64
+	counts []int64
+}
+
+func newSeries(window Window) *series {
+	return &series{window: window, sums: make([]float64, window.buckets()), counts: make([]int64, window.buckets())}
+}
+
+func (s *series) add(t time.Time, value float64) {
+	if i, ok := s.window.bucket(t); ok {
+		s.sums[i] += value
+		s.counts[i]++
+	}
+}
+
+func (s *series) result(unit string, mean bool) Series {
+	result := Series{Unit: unit, Buckets: make([]Bucket, len(s.sums))}
+	var sum float64
+	for i := range s.sums {
+		from := s.window.From.Add(time.Duration(i) * s.window.Interval)
+		to := from.Add(s.window.Interval)
+		if to.After(s.window.To) {
+			to = s.window.To
+		}
+		result.Buckets[i] = Bucket{From: from, To: to, Value: s.sums[i], Count: s.counts[i]}
+		if mean && s.counts[i] > 0 {
+			result.Buckets[i].Value = s.sums[i] / float64(s.counts[i])
+		}
+		sum += s.sums[i]
+		result.Count += s.counts[i]
+	}
+	result.Value = sum
+	if mean && result.Count > 0 {
+		result.Value = sum / float64(result.Count)
+	}
+	return result
+}
+
+// ComputeDeploymentFrequency counts the successful deployments per bucket
+func ComputeDeploymentFrequency(deployments []Deployment, window Window) Series {
+	s := newSeries(window)
+	for _, deployment := range deployments {
+		if deployment.Result != resultFail {
+			s.add(deployment.Time, 1)
+		}
+	}
+	return s.result(UnitDeployments, false)
+}
+
+// ComputeLeadTime computes the mean time from triggering a sequence to the successful deployment of its artifact
+func ComputeLeadTime(deployments []Deployment, window Window) Series {
+	s := newSeries(window)
+	for _, deployment := range deployments {
+		if deployment.Result == resultFail || deployment.SequenceStart.IsZero() || deployment.SequenceStart.After(deployment.Time) {
+			continue
+		}
+		s.add(deployment.Time, deployment.Time.Sub(deployment.SequenceStart).Seconds())
+	}
+	return s.result(UnitSeconds, true)
+}
+
+// ComputeChangeFailureRate computes the ratio of deployments which failed or were followed by a failed evaluation or
+// sequence in the stage
+func ComputeChangeFailureRate(deployments []Deployment, window Window) Series {
+	s := newSeries(window)
+	for _, deployment := range deployments {
+		failed := 0.0
+		if deployment.Failed || deployment.Result == resultFail {
+			failed = 1
+		}
+		s.add(deployment.Time, failed)
+	}
+	return s.result(UnitRatio, true)
+}
+
+// ComputeMeanTimeToRestore computes the mean time from the first failed outcome of a service in a stage to the next
+// successful one. Restored failures are counted in the bucket they were restored in
+func ComputeMeanTimeToRestore(outcomes []Outcome, window Window) Series {
+	sorted := make([]Outcome, len(outcomes))
+	copy(sorted, outcomes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+
+	s := newSeries(window)
+	failingSince := map[string]time.Time{}
+	for _, outcome := range sorted {
+		key := outcome.Stage + "/" + outcome.Service
+		since, failing := failingSince[key]
+		switch {
+		case outcome.Result == resultFail && !failing:
+			failingSince[key] = outcome.Time
+		case outcome.Result != resultFail && failing:
+			s.add(outcome.Time, outcome.Time.Sub(since).Seconds())
+			delete(failingSince, key)
+		}
+	}
+	return s.result(UnitSeconds, true)
+}
@@ -0,0 +1,101 @@
+package dora
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn/keptn/mongodb-datastore/common"
+)
+
+var windowStart = time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+
+func at(hours int) time.Time {
+	return windowStart.Add(time.Duration(hours) * time.Hour)
+}
+
+func testWindow(t *testing.T) Window {
+	window, err := NewWindow(at(0), at(60), 24*time.Hour)
+	require.NoError(t, err)
+	return window
+}
+
+var testDeployments = []Deployment{
+	{Time: at(2), Stage: "dev", Service: "carts", Result: "pass", SequenceStart: at(1)},
+	{Time: at(5), Stage: "dev", Service: "carts", Result: "warning", SequenceStart: at(2), Failed: true},
+	{Time: at(26), Stage: "dev", Service: "carts", Result: "fail", SequenceStart: at(25)},
+	{Time: at(50), Stage: "dev", Service: "carts", Result: "pass"},
+	// outside the window
+	{Time: at(60), Stage: "dev", Service: "carts", Result: "pass", SequenceStart: at(59)},
+}
+
+func TestNewWindow(t *testing.T) {
+	_, err := NewWindow(at(1), at(1), time.Hour)
+	require.ErrorIs(t, err, common.ErrInvalidMetricQuery)
+	_, err = NewWindow(at(0), at(1), 0)
+	require.ErrorIs(t, err, common.ErrInvalidMetricQuery)
+	_, err = NewWindow(at(0), at(24*365), time.Minute)
+	require.ErrorIs(t, err, common.ErrInvalidMetricQuery)
+}
+
+func TestComputeDeploymentFrequency(t *testing.T) {
+	require.Equal(t, Series{
+		Unit:  UnitDeployments,
+		Value: 3,
+		Count: 3,
+		Buckets: []Bucket{
+			{From: at(0), To: at(24), Value: 2, Count: 2},
+			{From: at(24), To: at(48), Value: 0, Count: 0},
+			{From: at(48), To: at(60), Value: 1, Count: 1},
+		},
+	}, ComputeDeploymentFrequency(testDeployments, testWindow(t)))
+}
+
+func TestComputeLeadTime(t *testing.T) {
+	require.Equal(t, Series{
+		Unit:  UnitSeconds,
+		Value: 7200,
+		Count: 2,
+		Buckets: []Bucket{
+			{From: at(0), To: at(24), Value: 7200, Count: 2},
+			{From: at(24), To: at(48), Value: 0, Count: 0},
+			{From: at(48), To: at(60), Value: 0, Count: 0},
+		},
+	}, ComputeLeadTime(testDeployments, testWindow(t)))
+}
+
+func TestComputeChangeFailureRate(t *testing.T) {
+	require.Equal(t, Series{
+		Unit:  UnitRatio,
+		Value: 0.5,
+		Count: 4,
+		Buckets: []Bucket{
+			{From: at(0), To: at(24), Value: 0.5, Count: 2},
+			{From: at(24), To: at(48), Value: 1, Count: 1},
+			{From: at(48), To: at(60), Value: 0, Count: 1},
+		},
+	}, ComputeChangeFailureRate(testDeployments, testWindow(t)))
+}
+
+func TestComputeMeanTimeToRestore(t *testing.T) {
+	outcomes := []Outcome{
+		{Time: at(30), Stage: "dev", Service: "carts", Result: "pass"},
+		{Time: at(10), Stage: "dev", Service: "carts", Result: "fail"},
+		{Time: at(12), Stage: "dev", Service: "carts", Result: "fail"},
+		{Time: at(11), Stage: "dev", Service: "orders", Result: "fail"},
+		{Time: at(13), Stage: "dev", Service: "orders", Result: "warning"},
+		{Time: at(40), Stage: "production", Service: "carts", Result: "fail"},
+		{Time: at(50), Stage: "dev", Service: "carts", Result: "fail"},
+	}
+	require.Equal(t, Series{
+		Unit:  UnitSeconds,
+		Value: 11 * 3600,
+		Count: 2,
+		Buckets: []Bucket{
+			{From: at(0), To: at(24), Value: 2 * 3600, Count: 1},
+			{From: at(24), To: at(48), Value: 20 * 3600, Count: 1},
+			{From: at(48), To: at(60), Value: 0, Count: 0},
+		},
+	}, ComputeMeanTimeToRestore(outcomes, testWindow(t)))
+}
@@ -19,6 +19,13 @@ type envConfig struct {
 	K8SNamespace           string `envconfig:"K8S_NAMESPACE" default:""`
 	K8SNodeName            string `envconfig:"K8S_NODE_NAME" default:""`
 	LogLevel               string `envconfig:"LOG_LEVEL" default:"info"`
+	// RetentionPolicies is a JSON list of policies determining how long events are kept, e.g.
+	// [{"eventType": "sh.keptn.event.evaluation.*", "maxAge": "365d"}]. If empty, events are kept forever
+	RetentionPolicies string `envconfig:"EVENT_RETENTION_POLICIES" default:""`
+	// RetentionInterval in which expired events are deleted
+	RetentionInterval string `envconfig:"EVENT_RETENTION_INTERVAL" default:"1h"`
+	// ArchiveDir to which expired events are written before they are deleted. If empty, events are not archived
+	ArchiveDir string `envconfig:"EVENT_ARCHIVE_DIR" default:""`
 }
 
 func GetMongoDBConnectionString() (string, string, error) {
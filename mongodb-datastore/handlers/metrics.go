@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-openapi/swag"
+	"github.com/keptn/keptn/mongodb-datastore/common"
+	"github.com/keptn/keptn/mongodb-datastore/db"
+	"github.com/keptn/keptn/mongodb-datastore/dora"
+	"github.com/keptn/keptn/mongodb-datastore/models"
+	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/metrics"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultMetricsFrom     = "now-30d"
+	defaultMetricsTo       = "now"
+	defaultMetricsInterval = "1d"
+)
+
+type MetricsRequestHandler struct {
+	metricsRepo db.MetricsRepo
+	now         func() time.Time
+}
+
+func NewMetricsRequestHandler(metricsRepo db.MetricsRepo) *MetricsRequestHandler {
+	return &MetricsRequestHandler{metricsRepo: metricsRepo, now: time.Now}
+}
+
+// GetMetric computes the requested DORA metric over the time window of the params
+func (mrh *MetricsRequestHandler) GetMetric(params metrics.GetMetricParams) (*models.Metric, error) {
+	filter, window, err := mrh.parseMetricParams(params)
+	if err != nil {
+		log.Warnf("Could not get metric %s: %v", params.Metric, err)
+		return nil, err
+	}
+
+	var series dora.Series
+	switch params.Metric {
+	case dora.MeanTimeToRestore:
+		outcomes, err := mrh.metricsRepo.GetOutcomes(filter)
+		if err != nil {
+			log.Errorf("Could not get outcomes of project %s: %v", params.Project, err)
+			return nil, err
+		}
+		series = dora.ComputeMeanTimeToRestore(outcomes, window)
+	case dora.DeploymentFrequency, dora.LeadTime, dora.ChangeFailureRate:
+		deployments, err := mrh.metricsRepo.GetDeployments(filter)
+		if err != nil {
+			log.Errorf("Could not get deployments of project %s: %v", params.Project, err)
+			return nil, err
+		}
+		switch params.Metric {
+		case dora.DeploymentFrequency:
+			series = dora.ComputeDeploymentFrequency(deployments, window)
+		case dora.LeadTime:
+			series = dora.ComputeLeadTime(deployments, window)
+		default:
+			series = dora.ComputeChangeFailureRate(deployments, window)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown metric %s", common.ErrInvalidMetricQuery, params.Metric)
+	}
+
+	return toMetricModel(params, window, series), nil
+}
+
+func (mrh *MetricsRequestHandler) parseMetricParams(params metrics.GetMetricParams) (dora.Filter, dora.Window, error) {
+	now := mrh.now()
+	from, err := common.ParseTime(stringValueOrDefault(params.From, defaultMetricsFrom), now)
+	if err != nil {
+		return dora.Filter{}, dora.Window{}, fmt.Errorf("%w: invalid from: %v", common.ErrInvalidMetricQuery, err)
+	}
+	to, err := common.ParseTime(stringValueOrDefault(params.To, defaultMetricsTo), now)
+	if err != nil {
+		return dora.Filter{}, dora.Window{}, fmt.Errorf("%w: invalid to: %v", common.ErrInvalidMetricQuery, err)
+	}
+	interval, err := common.ParseDuration(stringValueOrDefault(params.Interval, defaultMetricsInterval))
+	if err != nil {
+		return dora.Filter{}, dora.Window{}, fmt.Errorf("%w: invalid interval: %v", common.ErrInvalidMetricQuery, err)
+	}
+	window, err := dora.NewWindow(from, to, interval)
+	if err != nil {
+		return dora.Filter{}, dora.Window{}, err
+	}
+	filter := dora.Filter{
+		Project: params.Project,
+		Stage:   swag.StringValue(params.Stage),
+		Service: swag.StringValue(params.Service),
+		From:    window.From,
+		To:      window.To,
+	}
+	return filter, window, nil
+}
+
+func toMetricModel(params metrics.GetMetricParams, window dora.Window, series dora.Series) *models.Metric {
+	metric := &models.Metric{
+		Metric:   params.Metric,
+		Unit:     series.Unit,
+		Project:  params.Project,
+		Stage:    swag.StringValue(params.Stage),
+		Service:  swag.StringValue(params.Service),
+		From:     window.From.Format(time.RFC3339),
+		To:       window.To.Format(time.RFC3339),
+		Interval: stringValueOrDefault(params.Interval, defaultMetricsInterval),
+		Value:    series.Value,
+		Count:    series.Count,
+		Series:   make([]*models.MetricBucket, 0, len(series.Buckets)),
+	}
+	for _, bucket := range series.Buckets {
+		metric.Series = append(metric.Series, &models.MetricBucket{
+			From:  bucket.From.Format(time.RFC3339),
+			To:    bucket.To.Format(time.RFC3339),
+			Value: bucket.Value,
+			Count: bucket.Count,
+		})
+	}
+	return metric
+}
+
+func stringValueOrDefault(value *string, defaultValue string) string {
+	if value == nil || *value == "" {
+		return defaultValue
+	}
+	return *value
+}
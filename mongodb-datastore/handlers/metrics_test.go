@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/swag"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn/keptn/mongodb-datastore/common"
+	"github.com/keptn/keptn/mongodb-datastore/dora"
+	"github.com/keptn/keptn/mongodb-datastore/models"
+	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/metrics"
+)
+
+type fakeMetricsRepo struct {
+	deployments []dora.Deployment
+	outcomes    []dora.Outcome
+	err         error
+	filter      dora.Filter
+}
+
+func (r *fakeMetricsRepo) GetDeployments(filter dora.Filter) ([]dora.Deployment, error) {
+	r.filter = filter
+	return r.deployments, r.err
+}
+
+func (r *fakeMetricsRepo) GetOutcomes(filter dora.Filter) ([]dora.Outcome, error) {
+	r.filter = filter
+	return r.outcomes, r.err
+}
+
+func newTestMetricsRequestHandler(repo *fakeMetricsRepo) *MetricsRequestHandler {
+	handler := NewMetricsRequestHandler(repo)
+	handler.now = func() time.Time { return time.Date(2022, 5, 3, 12, 0, 0, 0, time.UTC) }
+	return handler
+}
+
+func TestMetricsRequestHandler_GetMetric(t *testing.T) {
+	repo := &fakeMetricsRepo{
+		deployments: []dora.Deployment{
+			{Time: time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC), Stage: "production", Service: "carts", Result: "pass"},
+			{Time: time.Date(2022, 5, 2, 10, 0, 0, 0, time.UTC), Stage: "production", Service: "carts", Result: "pass"},
+			{Time: time.Date(2022, 5, 2, 11, 0, 0, 0, time.UTC), Stage: "production", Service: "carts", Result: "warning"},
+		},
+	}
+	params := metrics.NewGetMetricParams()
+	params.Metric = dora.DeploymentFrequency
+	params.Project = "sockshop"
+	params.Stage = swag.String("production")
+	params.From = swag.String("2022-05-01")
+	params.To = swag.String("now")
+
+	metric, err := newTestMetricsRequestHandler(repo).GetMetric(params)
+	require.NoError(t, err)
+	require.Equal(t, dora.Filter{
+		Project: "sockshop",
+		Stage:   "production",
+		From:    time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2022, 5, 3, 12, 0, 0, 0, time.UTC),
+	}, repo.filter)
+	require.Equal(t, &models.Metric{
+		Metric:   dora.DeploymentFrequency,
+		Unit:     dora.UnitDeployments,
+		Project:  "sockshop",
+		Stage:    "production",
+		From:     "2022-05-01T00:00:00Z",
+		To:       "2022-05-03T12:00:00Z",
+		Interval: "1d",
+		Value:    3,
+		Count:    3,
+		Series: []*models.MetricBucket{
+			{From: "2022-05-01T00:00:00Z", To: "2022-05-02T00:00:00Z", Value: 1, Count: 1},
+			{From: "2022-05-02T00:00:00Z", To: "2022-05-03T00:00:00Z", Value: 2, Count: 2},
+			{From: "2022-05-03T00:00:00Z", To: "2022-05-03T12:00:00Z", Value: 0, Count: 0},
+		},
+	}, metric)
+}
+
+func TestMetricsRequestHandler_GetMetric_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(params *metrics.GetMetricParams)
+	}{
+		{name: "invalid from", modify: func(params *metrics.GetMetricParams) { params.From = swag.String("yesterday") }},
+		{name: "from after to", modify: func(params *metrics.GetMetricParams) { params.From = swag.String("now+1d") }},
+		{name: "invalid interval", modify: func(params *metrics.GetMetricParams) { params.Interval = swag.String("daily") }},
+		{name: "too many buckets", modify: func(params *metrics.GetMetricParams) { params.Interval = swag.String("1m") }},
+		{name: "unknown metric", modify: func(params *metrics.GetMetricParams) { params.Metric = "velocity" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := metrics.NewGetMetricParams()
+			params.Metric = dora.MeanTimeToRestore
+			params.Project = "sockshop"
+			tt.modify(&params)
+
+			_, err := newTestMetricsRequestHandler(&fakeMetricsRepo{}).GetMetric(params)
+			require.ErrorIs(t, err, common.ErrInvalidMetricQuery)
+		})
+	}
+}
+
+func TestMetricsRequestHandler_GetMetric_RepoError(t *testing.T) {
+	params := metrics.NewGetMetricParams()
+	params.Metric = dora.MeanTimeToRestore
+	params.Project = "sockshop"
+
+	_, err := newTestMetricsRequestHandler(&fakeMetricsRepo{err: errors.New("oops")}).GetMetric(params)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, common.ErrInvalidMetricQuery)
+}
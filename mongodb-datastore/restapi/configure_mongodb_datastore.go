@@ -28,6 +28,8 @@ import (
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations"
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/event"
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/health"
+	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/metrics"
+	"github.com/keptn/keptn/mongodb-datastore/retention"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -42,10 +44,16 @@ var mutex = &sync.Mutex{}
 func configureAPI(api *operations.MongodbDatastoreAPI) http.Handler {
 	// configure the api here
 	api.ServeError = apierrors.ServeError
-	eventRequestHandler := handlers.NewEventRequestHandler(db.NewMongoDBEventRepo(db.GetMongoDBConnectionInstance()))
+	eventRepo := db.NewMongoDBEventRepo(db.GetMongoDBConnectionInstance())
+	eventRequestHandler := handlers.NewEventRequestHandler(eventRepo)
+	metricsRequestHandler := handlers.NewMetricsRequestHandler(eventRepo)
 	eventRequestHandler.Env.ConfigLog()
 	api.Logger = log.Infof
 
+	if err := startRetentionJob(context.Background(), eventRepo, eventRequestHandler.Env.RetentionPolicies, eventRequestHandler.Env.RetentionInterval, eventRequestHandler.Env.ArchiveDir); err != nil {
+		log.Fatal(err)
+	}
+
 	// start NATS receiver
 	go func() {
 		err := startControlPlane(context.Background(), api, eventRequestHandler, log.New())
@@ -79,6 +87,17 @@ func configureAPI(api *operations.MongodbDatastoreAPI) http.Handler {
 		return event.NewGetEventsByTypeOK().WithPayload(events)
 	})
 
+	api.MetricsGetMetricHandler = metrics.GetMetricHandlerFunc(func(params metrics.GetMetricParams) middleware.Responder {
+		metric, err := metricsRequestHandler.GetMetric(params)
+		if err != nil {
+			if errors.Is(err, common.ErrInvalidMetricQuery) {
+				return metrics.NewGetMetricBadRequest().WithPayload(&models.Error{Code: http.StatusBadRequest, Message: swag.String(err.Error())})
+			}
+			return metrics.NewGetMetricInternalServerError().WithPayload(&models.Error{Code: http.StatusInternalServerError, Message: swag.String(err.Error())})
+		}
+		return metrics.NewGetMetricOK().WithPayload(metric)
+	})
+
 	api.HealthGetHealthHandler = health.GetHealthHandlerFunc(func(params health.GetHealthParams) middleware.Responder {
 		return health.NewGetHealthOK()
 	})
@@ -108,6 +127,31 @@ func startControlPlane(ctx context.Context, api *operations.MongodbDatastoreAPI,
 	return controlPlane.Register(ctx, eventRequestHandler)
 }
 
+// startRetentionJob periodically deletes the events that are expired according to the retention policies
+func startRetentionJob(ctx context.Context, store retention.EventStore, policiesConfig string, intervalConfig string, archiveDir string) error {
+	policies, err := retention.ParsePolicies(policiesConfig)
+	if err != nil {
+		return err
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+	interval, err := common.ParseDuration(intervalConfig)
+	if err != nil || interval <= 0 {
+		return fmt.Errorf("invalid event retention interval %s", intervalConfig)
+	}
+
+	var archiver retention.Archiver
+	if archiveDir != "" {
+		archiver = retention.NewFileArchiver(archiveDir)
+	}
+	for _, policy := range policies {
+		log.Infof("Event retention: %s", policy)
+	}
+	go retention.NewJob(store, policies, archiver).Start(ctx, interval)
+	return nil
+}
+
 func setPreShutDown(api *operations.MongodbDatastoreAPI, cancel context.CancelFunc) {
 	mutex.Lock()
 	api.PreServerShutdown = func() {
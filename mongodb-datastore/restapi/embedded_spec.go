@@ -94,6 +94,12 @@ func init() {
             "name": "eventID",
             "in": "query"
           },
+          {
+            "type": "string",
+            "description": "Query selecting the events, e.g. data.service:carts AND data.result:fail AND data.labels.team:payments AND time:>now-7d",
+            "name": "filter",
+            "in": "query"
+          },
           {
             "type": "string",
             "description": "Name of the event source",
@@ -223,6 +229,91 @@ func init() {
           }
         }
       }
+    },
+    "/metrics/{metric}": {
+      "get": {
+        "description": "\u003cspan class=\"oauth-scopes\"\u003eRequired OAuth scopes: ${prefix}events:read\u003c/span\u003e\n",
+        "tags": [
+          "metrics"
+        ],
+        "summary": "Computes a DORA metric of a project, stage or service as series of time buckets",
+        "operationId": "getMetric",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Name of the project",
+            "name": "project",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Name of the stage",
+            "name": "stage",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Name of the service",
+            "name": "service",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Start of the time window, e.g. 2022-05-01T00:00:00Z, 2022-05-01 or now-30d. Defaults to now-30d",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "End of the time window, e.g. 2022-05-31T00:00:00Z, 2022-05-31 or now. Defaults to now",
+            "name": "to",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "default": "1d",
+            "description": "Size of the time buckets, e.g. 12h, 1d or 1w",
+            "name": "interval",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "ok",
+            "schema": {
+              "$ref": "#/definitions/Metric"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "enum": [
+            "deploymentFrequency",
+            "leadTime",
+            "changeFailureRate",
+            "meanTimeToRestore"
+          ],
+          "type": "string",
+          "description": "DORA metric to compute",
+          "name": "metric",
+          "in": "path",
+          "required": true
+        }
+      ]
     }
   },
   "definitions": {
@@ -239,6 +330,83 @@ func init() {
         "type": "KeptnContextExtendedCE"
       }
     },
+    "Metric": {
+      "type": "object",
+      "properties": {
+        "count": {
+          "description": "Number of deployments, changes or restored failures the value is computed from",
+          "type": "integer",
+          "format": "int64",
+          "x-omitempty": false
+        },
+        "from": {
+          "description": "Start of the time window",
+          "type": "string"
+        },
+        "interval": {
+          "description": "Size of the time buckets",
+          "type": "string"
+        },
+        "metric": {
+          "description": "Name of the metric",
+          "type": "string"
+        },
+        "project": {
+          "type": "string"
+        },
+        "series": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/MetricBucket"
+          }
+        },
+        "service": {
+          "type": "string"
+        },
+        "stage": {
+          "type": "string"
+        },
+        "to": {
+          "description": "End of the time window",
+          "type": "string"
+        },
+        "unit": {
+          "description": "Unit of the values, deployments, seconds or ratio",
+          "type": "string"
+        },
+        "value": {
+          "description": "Value of the metric over the whole time window",
+          "type": "number",
+          "format": "double",
+          "x-omitempty": false
+        }
+      }
+    },
+    "MetricBucket": {
+      "type": "object",
+      "properties": {
+        "count": {
+          "description": "Number of deployments, changes or restored failures the value is computed from",
+          "type": "integer",
+          "format": "int64",
+          "x-omitempty": false
+        },
+        "from": {
+          "description": "Start of the time bucket",
+          "type": "string"
+        },
+        "to": {
+          "description": "End of the time bucket",
+          "type": "string"
+        },
+        "value": {
+          "description": "Value of the metric within the time bucket",
+          "type": "number",
+          "format": "double",
+          "x-omitempty": false
+        }
+      }
+    },
     "error": {
       "type": "object",
       "required": [
@@ -365,6 +533,12 @@ func init() {
             "name": "eventID",
             "in": "query"
           },
+          {
+            "type": "string",
+            "description": "Query selecting the events, e.g. data.service:carts AND data.result:fail AND data.labels.team:payments AND time:>now-7d",
+            "name": "filter",
+            "in": "query"
+          },
           {
             "type": "string",
             "description": "Name of the event source",
@@ -509,6 +683,91 @@ func init() {
           }
         }
       }
+    },
+    "/metrics/{metric}": {
+      "get": {
+        "description": "\u003cspan class=\"oauth-scopes\"\u003eRequired OAuth scopes: ${prefix}events:read\u003c/span\u003e\n",
+        "tags": [
+          "metrics"
+        ],
+        "summary": "Computes a DORA metric of a project, stage or service as series of time buckets",
+        "operationId": "getMetric",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Name of the project",
+            "name": "project",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Name of the stage",
+            "name": "stage",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Name of the service",
+            "name": "service",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Start of the time window, e.g. 2022-05-01T00:00:00Z, 2022-05-01 or now-30d. Defaults to now-30d",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "End of the time window, e.g. 2022-05-31T00:00:00Z, 2022-05-31 or now. Defaults to now",
+            "name": "to",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "default": "1d",
+            "description": "Size of the time buckets, e.g. 12h, 1d or 1w",
+            "name": "interval",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "ok",
+            "schema": {
+              "$ref": "#/definitions/Metric"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "enum": [
+            "deploymentFrequency",
+            "leadTime",
+            "changeFailureRate",
+            "meanTimeToRestore"
+          ],
+          "type": "string",
+          "description": "DORA metric to compute",
+          "name": "metric",
+          "in": "path",
+          "required": true
+        }
+      ]
     }
   },
   "definitions": {
@@ -525,6 +784,83 @@ func init() {
         "type": "KeptnContextExtendedCE"
       }
     },
+    "Metric": {
+      "type": "object",
+      "properties": {
+        "count": {
+          "description": "Number of deployments, changes or restored failures the value is computed from",
+          "type": "integer",
+          "format": "int64",
+          "x-omitempty": false
+        },
+        "from": {
+          "description": "Start of the time window",
+          "type": "string"
+        },
+        "interval": {
+          "description": "Size of the time buckets",
+          "type": "string"
+        },
+        "metric": {
+          "description": "Name of the metric",
+          "type": "string"
+        },
+        "project": {
+          "type": "string"
+        },
+        "series": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/MetricBucket"
+          }
+        },
+        "service": {
+          "type": "string"
+        },
+        "stage": {
+          "type": "string"
+        },
+        "to": {
+          "description": "End of the time window",
+          "type": "string"
+        },
+        "unit": {
+          "description": "Unit of the values, deployments, seconds or ratio",
+          "type": "string"
+        },
+        "value": {
+          "description": "Value of the metric over the whole time window",
+          "type": "number",
+          "format": "double",
+          "x-omitempty": false
+        }
+      }
+    },
+    "MetricBucket": {
+      "type": "object",
+      "properties": {
+        "count": {
+          "description": "Number of deployments, changes or restored failures the value is computed from",
+          "type": "integer",
+          "format": "int64",
+          "x-omitempty": false
+        },
+        "from": {
+          "description": "Start of the time bucket",
+          "type": "string"
+        },
+        "to": {
+          "description": "End of the time bucket",
+          "type": "string"
+        },
+        "value": {
+          "description": "Value of the metric within the time bucket",
+          "type": "number",
+          "format": "double",
+          "x-omitempty": false
+        }
+      }
+    },
     "error": {
       "type": "object",
       "required": [
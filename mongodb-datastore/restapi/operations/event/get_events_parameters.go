@@ -48,6 +48,10 @@ type GetEventsParams struct {
 	  In: query
 	*/
 	EventID *string
+	/*Query selecting the events, e.g. data.service:carts AND data.result:fail AND data.labels.team:payments AND time:>now-7d
+	  In: query
+	*/
+	Filter *string
 	/*From time to fetch keptn cloud events
 	  In: query
 	*/
@@ -114,6 +118,11 @@ func (o *GetEventsParams) BindRequest(r *http.Request, route *middleware.Matched
 		res = append(res, err)
 	}
 
+	qFilter, qhkFilter, _ := qs.GetOK("filter")
+	if err := o.bindFilter(qFilter, qhkFilter, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qFromTime, qhkFromTime, _ := qs.GetOK("fromTime")
 	if err := o.bindFromTime(qFromTime, qhkFromTime, route.Formats); err != nil {
 		res = append(res, err)
@@ -205,6 +214,24 @@ func (o *GetEventsParams) bindEventID(rawData []string, hasKey bool, formats str
 	return nil
 }
 
+// bindFilter binds and validates parameter Filter from query.
+func (o *GetEventsParams) bindFilter(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.Filter = &raw
+
+	return nil
+}
+
 // bindFromTime binds and validates parameter FromTime from query.
 func (o *GetEventsParams) bindFromTime(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -17,6 +17,7 @@ import (
 type GetEventsURL struct {
 	BeforeTime   *string
 	EventID      *string
+	Filter       *string
 	FromTime     *string
 	KeptnContext *string
 	NextPageKey  *string
@@ -78,6 +79,14 @@ func (o *GetEventsURL) Build() (*url.URL, error) {
 		qs.Set("eventID", eventIDQ)
 	}
 
+	var filterQ string
+	if o.Filter != nil {
+		filterQ = *o.Filter
+	}
+	if filterQ != "" {
+		qs.Set("filter", filterQ)
+	}
+
 	var fromTimeQ string
 	if o.FromTime != nil {
 		fromTimeQ = *o.FromTime
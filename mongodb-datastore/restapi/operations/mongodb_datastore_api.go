@@ -21,6 +21,7 @@ import (
 
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/event"
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/health"
+	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/metrics"
 )
 
 // NewMongodbDatastoreAPI creates a new MongodbDatastore instance
@@ -54,6 +55,9 @@ func NewMongodbDatastoreAPI(spec *loads.Document) *MongodbDatastoreAPI {
 		HealthGetHealthHandler: health.GetHealthHandlerFunc(func(params health.GetHealthParams) middleware.Responder {
 			return middleware.NotImplemented("operation health.GetHealth has not yet been implemented")
 		}),
+		MetricsGetMetricHandler: metrics.GetMetricHandlerFunc(func(params metrics.GetMetricParams) middleware.Responder {
+			return middleware.NotImplemented("operation metrics.GetMetric has not yet been implemented")
+		}),
 	}
 }
 
@@ -97,6 +101,8 @@ type MongodbDatastoreAPI struct {
 	EventGetEventsByTypeHandler event.GetEventsByTypeHandler
 	// HealthGetHealthHandler sets the operation handler for the get health operation
 	HealthGetHealthHandler health.GetHealthHandler
+	// MetricsGetMetricHandler sets the operation handler for the get metric operation
+	MetricsGetMetricHandler metrics.GetMetricHandler
 
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
@@ -183,6 +189,9 @@ func (o *MongodbDatastoreAPI) Validate() error {
 	if o.HealthGetHealthHandler == nil {
 		unregistered = append(unregistered, "health.GetHealthHandler")
 	}
+	if o.MetricsGetMetricHandler == nil {
+		unregistered = append(unregistered, "metrics.GetMetricHandler")
+	}
 
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
@@ -285,6 +294,10 @@ func (o *MongodbDatastoreAPI) initHandlerCache() {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/health"] = health.NewGetHealth(o.context, o.HealthGetHealthHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/metrics/{metric}"] = metrics.NewGetMetric(o.context, o.MetricsGetMetricHandler)
 }
 
 // Serve creates a http handler to serve the API over HTTP
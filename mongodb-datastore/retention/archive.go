@@ -0,0 +1,50 @@
+package retention
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	keptnapi "github.com/keptn/go-utils/pkg/api/models"
+)
+
+// FileArchiver writes expiring events to gzip compressed JSONL files, one file per project and day, e.g.
+// <dir>/sockshop/events-2022-05-03.jsonl.gz. Each batch of events is appended as a separate gzip member, which
+// gzip and zcat read as one stream
+type FileArchiver struct {
+	dir string
+	now func() time.Time
+}
+
+func NewFileArchiver(dir string) *FileArchiver {
+	return &FileArchiver{dir: dir, now: time.Now}
+}
+
+func (a *FileArchiver) Archive(project string, events []keptnapi.KeptnContextExtendedCE) error {
+	projectDir := filepath.Join(a.dir, filepath.Base(project))
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		return fmt.Errorf("could not create archive directory: %w", err)
+	}
+	fileName := filepath.Join(projectDir, "events-"+a.now().UTC().Format("2006-01-02")+".jsonl.gz")
+	file, err := os.OpenFile(fileName, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("could not open archive %s: %w", fileName, err)
+	}
+	defer file.Close()
+
+	writer := gzip.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return fmt.Errorf("could not write event %s to archive %s: %w", event.ID, fileName, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("could not write archive %s: %w", fileName, err)
+	}
+	// events are only deleted once they are persisted in the archive
+	return file.Sync()
+}
@@ -0,0 +1,87 @@
+package retention
+
+import (
+	"context"
+	"time"
+
+	keptnapi "github.com/keptn/go-utils/pkg/api/models"
+	logger "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// EventStore deletes the expired events of the projects
+type EventStore interface {
+	// GetProjects returns the projects having events
+	GetProjects() ([]string, error)
+	// DeleteEvents deletes the events of the project matching the filter. If archive is set, it is called with the
+	// events before they are deleted, and events are only deleted if they could be archived
+	DeleteEvents(project string, filter bson.M, archive func(events []keptnapi.KeptnContextExtendedCE) error) (int64, error)
+}
+
+// Archiver stores expiring events before they are deleted
+type Archiver interface {
+	Archive(project string, events []keptnapi.KeptnContextExtendedCE) error
+}
+
+// Job periodically deletes the events that are expired according to the retention policies
+type Job struct {
+	store    EventStore
+	policies []Policy
+	archiver Archiver
+	now      func() time.Time
+}
+
+// NewJob creates a Job. If archiver is nil, expired events are deleted without being archived
+func NewJob(store EventStore, policies []Policy, archiver Archiver) *Job {
+	return &Job{
+		store:    store,
+		policies: policies,
+		archiver: archiver,
+		now:      time.Now,
+	}
+}
+
+// Start runs the job in the given interval until the context is done
+func (j *Job) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		j.Run()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run deletes the expired events of all projects once. Errors are logged, so that the events of other projects are
+// still deleted
+func (j *Job) Run() {
+	projects, err := j.store.GetProjects()
+	if err != nil {
+		logger.Errorf("Could not get projects to delete expired events: %v", err)
+		return
+	}
+
+	now := j.now()
+	for _, project := range projects {
+		var archive func(events []keptnapi.KeptnContextExtendedCE) error
+		if j.archiver != nil {
+			project := project
+			archive = func(events []keptnapi.KeptnContextExtendedCE) error {
+				return j.archiver.Archive(project, events)
+			}
+		}
+		for _, filter := range Filters(project, j.policies, now) {
+			deleted, err := j.store.DeleteEvents(project, filter, archive)
+			if err != nil {
+				logger.Errorf("Could not delete expired events of project %s: %v", project, err)
+				continue
+			}
+			if deleted > 0 {
+				logger.Infof("Deleted %d expired events of project %s", deleted, project)
+			}
+		}
+	}
+}
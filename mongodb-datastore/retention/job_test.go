@@ -0,0 +1,122 @@
+package retention
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	keptnapi "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// fakeEventStore expires all events of a project on the first filter
+type fakeEventStore struct {
+	events     map[string][]keptnapi.KeptnContextExtendedCE
+	filters    map[string][]bson.M
+	projectErr error
+}
+
+func (s *fakeEventStore) GetProjects() ([]string, error) {
+	var projects []string
+	for project := range s.events {
+		projects = append(projects, project)
+	}
+	return projects, s.projectErr
+}
+
+func (s *fakeEventStore) DeleteEvents(project string, filter bson.M, archive func(events []keptnapi.KeptnContextExtendedCE) error) (int64, error) {
+	s.filters[project] = append(s.filters[project], filter)
+	events := s.events[project]
+	if len(events) == 0 {
+		return 0, nil
+	}
+	if archive != nil {
+		if err := archive(events); err != nil {
+			return 0, err
+		}
+	}
+	s.events[project] = nil
+	return int64(len(events)), nil
+}
+
+func TestJob_Run(t *testing.T) {
+	store := &fakeEventStore{
+		events: map[string][]keptnapi.KeptnContextExtendedCE{
+			"sockshop":     {{ID: "1", Type: stringp("sh.keptn.event.evaluation.finished")}, {ID: "2", Type: stringp("sh.keptn.log.error")}},
+			"podtato-head": {{ID: "3", Type: stringp("sh.keptn.event.deployment.finished")}},
+		},
+		filters: map[string][]bson.M{},
+	}
+	policies := []Policy{
+		{Project: "*", EventType: "*", MaxAge: 90 * 24 * time.Hour},
+		{Project: "sockshop", EventType: "sh.keptn.log.*", MaxAge: 7 * 24 * time.Hour},
+	}
+	archiveDir := t.TempDir()
+	archiver := NewFileArchiver(archiveDir)
+	archiver.now = func() time.Time { return time.Date(2022, 5, 10, 12, 0, 0, 0, time.UTC) }
+	job := NewJob(store, policies, archiver)
+
+	job.Run()
+	require.Empty(t, store.events["sockshop"])
+	require.Empty(t, store.events["podtato-head"])
+	require.Len(t, store.filters["sockshop"], 2)
+	require.Len(t, store.filters["podtato-head"], 1)
+	require.Equal(t, []string{"1", "2"}, readArchive(t, filepath.Join(archiveDir, "sockshop", "events-2022-05-10.jsonl.gz")))
+
+	// archives of the same day are appended
+	store.events["sockshop"] = []keptnapi.KeptnContextExtendedCE{{ID: "4", Type: stringp("sh.keptn.log.error")}}
+	job.Run()
+	require.Equal(t, []string{"1", "2", "4"}, readArchive(t, filepath.Join(archiveDir, "sockshop", "events-2022-05-10.jsonl.gz")))
+}
+
+func TestJob_Run_ArchiveFails(t *testing.T) {
+	store := &fakeEventStore{
+		events: map[string][]keptnapi.KeptnContextExtendedCE{
+			"sockshop": {{ID: "1", Type: stringp("sh.keptn.event.evaluation.finished")}},
+		},
+		filters: map[string][]bson.M{},
+	}
+	archiveDir := filepath.Join(t.TempDir(), "archive")
+	// the archive directory cannot be created, since a file with the same name exists
+	require.NoError(t, os.WriteFile(archiveDir, nil, 0644))
+	job := NewJob(store, []Policy{{Project: "*", EventType: "*", MaxAge: time.Hour}}, NewFileArchiver(archiveDir))
+
+	job.Run()
+	require.Len(t, store.events["sockshop"], 1)
+}
+
+func TestJob_Run_NoProjects(t *testing.T) {
+	store := &fakeEventStore{filters: map[string][]bson.M{}, projectErr: errors.New("oops")}
+	job := NewJob(store, []Policy{{Project: "*", EventType: "*", MaxAge: time.Hour}}, nil)
+
+	job.Run()
+	require.Empty(t, store.filters)
+}
+
+func readArchive(t *testing.T, fileName string) []string {
+	file, err := os.Open(fileName)
+	require.NoError(t, err)
+	defer file.Close()
+	reader, err := gzip.NewReader(file)
+	require.NoError(t, err)
+
+	var ids []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		var event keptnapi.KeptnContextExtendedCE
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		ids = append(ids, event.ID)
+	}
+	require.NoError(t, scanner.Err())
+	return ids
+}
+
+func stringp(s string) *string {
+	return &s
+}
@@ -0,0 +1,139 @@
+package retention
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/keptn/keptn/mongodb-datastore/common"
+)
+
+// wildcard matches all projects or event types
+const wildcard = "*"
+
+const (
+	timePropertyPath = "time"
+	typePropertyPath = "type"
+)
+
+// Policy determines how long the events of a project and event type are kept
+type Policy struct {
+	// Project the policy applies to, or * for all projects
+	Project string
+	// EventType the policy applies to, a prefix ending with *, e.g. sh.keptn.event.evaluation.*, or * for all types
+	EventType string
+	// MaxAge after which events are deleted
+	MaxAge time.Duration
+}
+
+func (p Policy) String() string {
+	return fmt.Sprintf("events of type %s of project %s are kept for %s", p.EventType, p.Project, p.MaxAge)
+}
+
+// ParsePolicies parses the JSON list of policies given by EVENT_RETENTION_POLICIES, e.g.
+// [{"eventType": "sh.keptn.event.evaluation.*", "maxAge": "365d"}, {"project": "sockshop", "eventType": "*", "maxAge": "30d"}]
+func ParsePolicies(config string) ([]Policy, error) {
+	if strings.TrimSpace(config) == "" {
+		return nil, nil
+	}
+	var rawPolicies []struct {
+		Project   string `json:"project"`
+		EventType string `json:"eventType"`
+		MaxAge    string `json:"maxAge"`
+	}
+	if err := json.Unmarshal([]byte(config), &rawPolicies); err != nil {
+		return nil, fmt.Errorf("could not parse retention policies: %w", err)
+	}
+
+	policies := make([]Policy, 0, len(rawPolicies))
+	for i, raw := range rawPolicies {
+		policy := Policy{Project: raw.Project, EventType: raw.EventType}
+		if policy.Project == "" {
+			policy.Project = wildcard
+		}
+		if policy.EventType == "" {
+			policy.EventType = wildcard
+		}
+		if strings.Contains(strings.TrimSuffix(policy.EventType, wildcard), wildcard) {
+			return nil, fmt.Errorf("retention policy %d: event type may only end with *", i)
+		}
+		maxAge, err := common.ParseDuration(raw.MaxAge)
+		if err != nil || maxAge <= 0 {
+			return nil, fmt.Errorf("retention policy %d: maxAge must be a positive duration, e.g. 7d", i)
+		}
+		policy.MaxAge = maxAge
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+// Filters returns the filters selecting the expired events of the project. Each event is expired according to the
+// most specific policy matching it: policies of the project take precedence over policies of all projects, exact
+// event types over prefixes and longer prefixes over shorter ones
+func Filters(project string, policies []Policy, now time.Time) []bson.M {
+	// policies of the project replace policies of all projects for the same event types
+	byEventType := map[string]Policy{}
+	for _, policy := range policies {
+		if policy.Project != project && policy.Project != wildcard {
+			continue
+		}
+		if existing, ok := byEventType[policy.EventType]; ok && existing.Project != wildcard {
+			continue
+		}
+		byEventType[policy.EventType] = policy
+	}
+
+	applicable := make([]Policy, 0, len(byEventType))
+	for _, policy := range byEventType {
+		applicable = append(applicable, policy)
+	}
+	sort.Slice(applicable, func(i, j int) bool {
+		return moreSpecific(applicable[i].EventType, applicable[j].EventType)
+	})
+
+	filters := make([]bson.M, 0, len(applicable))
+	for i, policy := range applicable {
+		filter := bson.M{
+			timePropertyPath: bson.M{"$lt": now.Add(-policy.MaxAge).UTC().Format(time.RFC3339Nano)},
+		}
+		if condition := typeCondition(policy.EventType); condition != nil {
+			filter[typePropertyPath] = condition
+		}
+		// events matching more specific policies are expired by them
+		var moreSpecificTypes []bson.M
+		for _, other := range applicable[:i] {
+			moreSpecificTypes = append(moreSpecificTypes, bson.M{typePropertyPath: typeCondition(other.EventType)})
+		}
+		if len(moreSpecificTypes) > 0 {
+			filter["$nor"] = moreSpecificTypes
+		}
+		filters = append(filters, filter)
+	}
+	return filters
+}
+
+func moreSpecific(eventType string, other string) bool {
+	isPrefix, otherIsPrefix := strings.HasSuffix(eventType, wildcard), strings.HasSuffix(other, wildcard)
+	if isPrefix != otherIsPrefix {
+		return !isPrefix
+	}
+	if len(eventType) != len(other) {
+		return len(eventType) > len(other)
+	}
+	return eventType < other
+}
+
+func typeCondition(eventType string) interface{} {
+	if eventType == wildcard {
+		return nil
+	}
+	if prefix := strings.TrimSuffix(eventType, wildcard); prefix != eventType {
+		return bson.M{"$regex": "^" + regexp.QuoteMeta(prefix)}
+	}
+	return eventType
+}
@@ -0,0 +1,92 @@
+package retention
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestParsePolicies(t *testing.T) {
+	policies, err := ParsePolicies(`[
+		{"eventType": "sh.keptn.event.evaluation.*", "maxAge": "365d"},
+		{"project": "sockshop", "maxAge": "168h"}
+	]`)
+	require.NoError(t, err)
+	require.Equal(t, []Policy{
+		{Project: "*", EventType: "sh.keptn.event.evaluation.*", MaxAge: 365 * 24 * time.Hour},
+		{Project: "sockshop", EventType: "*", MaxAge: 7 * 24 * time.Hour},
+	}, policies)
+
+	policies, err = ParsePolicies("")
+	require.NoError(t, err)
+	require.Empty(t, policies)
+
+	invalidConfigs := []string{
+		`{"maxAge": "7d"}`,
+		`[{"eventType": "sh.keptn.*.finished", "maxAge": "7d"}]`,
+		`[{"eventType": "*"}]`,
+		`[{"eventType": "*", "maxAge": "-7d"}]`,
+	}
+	for _, config := range invalidConfigs {
+		_, err := ParsePolicies(config)
+		require.Error(t, err, config)
+	}
+}
+
+func TestFilters(t *testing.T) {
+	now := time.Date(2022, 5, 10, 12, 0, 0, 0, time.UTC)
+	policies := []Policy{
+		{Project: "*", EventType: "*", MaxAge: 90 * 24 * time.Hour},
+		{Project: "*", EventType: "sh.keptn.event.evaluation.*", MaxAge: 365 * 24 * time.Hour},
+		{Project: "*", EventType: "sh.keptn.event.evaluation.finished", MaxAge: 2 * 365 * 24 * time.Hour},
+		{Project: "sockshop", EventType: "sh.keptn.event.evaluation.*", MaxAge: 30 * 24 * time.Hour},
+		{Project: "podtato-head", EventType: "*", MaxAge: 7 * 24 * time.Hour},
+	}
+
+	require.Equal(t, []bson.M{
+		{
+			"time": bson.M{"$lt": "2020-05-10T12:00:00Z"},
+			"type": "sh.keptn.event.evaluation.finished",
+		},
+		{
+			"time": bson.M{"$lt": "2022-04-10T12:00:00Z"},
+			"type": bson.M{"$regex": `^sh\.keptn\.event\.evaluation\.`},
+			"$nor": []bson.M{
+				{"type": "sh.keptn.event.evaluation.finished"},
+			},
+		},
+		{
+			"time": bson.M{"$lt": "2022-02-09T12:00:00Z"},
+			"$nor": []bson.M{
+				{"type": "sh.keptn.event.evaluation.finished"},
+				{"type": bson.M{"$regex": `^sh\.keptn\.event\.evaluation\.`}},
+			},
+		},
+	}, Filters("sockshop", policies, now))
+
+	// the policy of the project for all event types only replaces the policy of all projects for all event types
+	require.Equal(t, []bson.M{
+		{
+			"time": bson.M{"$lt": "2020-05-10T12:00:00Z"},
+			"type": "sh.keptn.event.evaluation.finished",
+		},
+		{
+			"time": bson.M{"$lt": "2021-05-10T12:00:00Z"},
+			"type": bson.M{"$regex": `^sh\.keptn\.event\.evaluation\.`},
+			"$nor": []bson.M{
+				{"type": "sh.keptn.event.evaluation.finished"},
+			},
+		},
+		{
+			"time": bson.M{"$lt": "2022-05-03T12:00:00Z"},
+			"$nor": []bson.M{
+				{"type": "sh.keptn.event.evaluation.finished"},
+				{"type": bson.M{"$regex": `^sh\.keptn\.event\.evaluation\.`}},
+			},
+		},
+	}, Filters("podtato-head", policies, now))
+
+	require.Empty(t, Filters("sockshop", nil, now))
+}
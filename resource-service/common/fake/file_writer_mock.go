@@ -4,6 +4,7 @@
 package common_mock
 
 import (
+	"io"
 	"path/filepath"
 	"sync"
 )
@@ -14,15 +15,24 @@ import (
 //
 // 		// make and configure a mocked common.IFileSystem
 // 		mockedIFileSystem := &IFileSystemMock{
+// 			CopyFileFunc: func(source string, target string) error {
+// 				panic("mock out the CopyFile method")
+// 			},
 // 			DeleteFileFunc: func(path string) error {
 // 				panic("mock out the DeleteFile method")
 // 			},
 // 			FileExistsFunc: func(path string) bool {
 // 				panic("mock out the FileExists method")
 // 			},
+// 			ListDirectoriesFunc: func(path string) ([]string, error) {
+// 				panic("mock out the ListDirectories method")
+// 			},
 // 			MakeDirFunc: func(path string) error {
 // 				panic("mock out the MakeDir method")
 // 			},
+// 			OpenFileFunc: func(filename string) (io.ReadCloser, int64, error) {
+// 				panic("mock out the OpenFile method")
+// 			},
 // 			ReadFileFunc: func(filename string) ([]byte, error) {
 // 				panic("mock out the ReadFile method")
 // 			},
@@ -38,6 +48,9 @@ import (
 // 			WriteHelmChartFunc: func(path string) error {
 // 				panic("mock out the WriteHelmChart method")
 // 			},
+// 			WriteTempFileFunc: func(content io.Reader) (string, int64, error) {
+// 				panic("mock out the WriteTempFile method")
+// 			},
 // 		}
 //
 // 		// use mockedIFileSystem in code that requires common.IFileSystem
@@ -45,15 +58,24 @@ import (
 //
 // 	}
 type IFileSystemMock struct {
+	// CopyFileFunc mocks the CopyFile method.
+	CopyFileFunc func(source string, target string) error
+
 	// DeleteFileFunc mocks the DeleteFile method.
 	DeleteFileFunc func(path string) error
 
 	// FileExistsFunc mocks the FileExists method.
 	FileExistsFunc func(path string) bool
 
+	// ListDirectoriesFunc mocks the ListDirectories method.
+	ListDirectoriesFunc func(path string) ([]string, error)
+
 	// MakeDirFunc mocks the MakeDir method.
 	MakeDirFunc func(path string) error
 
+	// OpenFileFunc mocks the OpenFile method.
+	OpenFileFunc func(filename string) (io.ReadCloser, int64, error)
+
 	// ReadFileFunc mocks the ReadFile method.
 	ReadFileFunc func(filename string) ([]byte, error)
 
@@ -69,8 +91,18 @@ type IFileSystemMock struct {
 	// WriteHelmChartFunc mocks the WriteHelmChart method.
 	WriteHelmChartFunc func(path string) error
 
+	// WriteTempFileFunc mocks the WriteTempFile method.
+	WriteTempFileFunc func(content io.Reader) (string, int64, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// CopyFile holds details about calls to the CopyFile method.
+		CopyFile []struct {
+			// Source is the source argument value.
+			Source string
+			// Target is the target argument value.
+			Target string
+		}
 		// DeleteFile holds details about calls to the DeleteFile method.
 		DeleteFile []struct {
 			// Path is the path argument value.
@@ -81,11 +113,21 @@ type IFileSystemMock struct {
 			// Path is the path argument value.
 			Path string
 		}
+		// ListDirectories holds details about calls to the ListDirectories method.
+		ListDirectories []struct {
+			// Path is the path argument value.
+			Path string
+		}
 		// MakeDir holds details about calls to the MakeDir method.
 		MakeDir []struct {
 			// Path is the path argument value.
 			Path string
 		}
+		// OpenFile holds details about calls to the OpenFile method.
+		OpenFile []struct {
+			// Filename is the filename argument value.
+			Filename string
+		}
 		// ReadFile holds details about calls to the ReadFile method.
 		ReadFile []struct {
 			// Filename is the filename argument value.
@@ -117,15 +159,59 @@ type IFileSystemMock struct {
 			// Path is the path argument value.
 			Path string
 		}
+		// WriteTempFile holds details about calls to the WriteTempFile method.
+		WriteTempFile []struct {
+			// Content is the content argument value.
+			Content io.Reader
+		}
 	}
+	lockCopyFile               sync.RWMutex
 	lockDeleteFile             sync.RWMutex
 	lockFileExists             sync.RWMutex
+	lockListDirectories        sync.RWMutex
 	lockMakeDir                sync.RWMutex
+	lockOpenFile               sync.RWMutex
 	lockReadFile               sync.RWMutex
 	lockWalkPath               sync.RWMutex
 	lockWriteBase64EncodedFile sync.RWMutex
 	lockWriteFile              sync.RWMutex
 	lockWriteHelmChart         sync.RWMutex
+	lockWriteTempFile          sync.RWMutex
+}
+
+// CopyFile calls CopyFileFunc.
+func (mock *IFileSystemMock) CopyFile(source string, target string) error {
+	if mock.CopyFileFunc == nil {
+		panic("IFileSystemMock.CopyFileFunc: method is nil but IFileSystem.CopyFile was just called")
+	}
+	callInfo := struct {
+		Source string
+		Target string
+	}{
+		Source: source,
+		Target: target,
+	}
+	mock.lockCopyFile.Lock()
+	mock.calls.CopyFile = append(mock.calls.CopyFile, callInfo)
+	mock.lockCopyFile.Unlock()
+	return mock.CopyFileFunc(source, target)
+}
+
+// CopyFileCalls gets all the calls that were made to CopyFile.
+// Check the length with:
+//     len(mockedIFileSystem.CopyFileCalls())
+func (mock *IFileSystemMock) CopyFileCalls() []struct {
+	Source string
+	Target string
+} {
+	var calls []struct {
+		Source string
+		Target string
+	}
+	mock.lockCopyFile.RLock()
+	calls = mock.calls.CopyFile
+	mock.lockCopyFile.RUnlock()
+	return calls
 }
 
 // DeleteFile calls DeleteFileFunc.
@@ -190,6 +276,37 @@ func (mock *IFileSystemMock) FileExistsCalls() []struct {
 	return calls
 }
 
+// ListDirectories calls ListDirectoriesFunc.
+func (mock *IFileSystemMock) ListDirectories(path string) ([]string, error) {
+	if mock.ListDirectoriesFunc == nil {
+		panic("IFileSystemMock.ListDirectoriesFunc: method is nil but IFileSystem.ListDirectories was just called")
+	}
+	callInfo := struct {
+		Path string
+	}{
+		Path: path,
+	}
+	mock.lockListDirectories.Lock()
+	mock.calls.ListDirectories = append(mock.calls.ListDirectories, callInfo)
+	mock.lockListDirectories.Unlock()
+	return mock.ListDirectoriesFunc(path)
+}
+
+// ListDirectoriesCalls gets all the calls that were made to ListDirectories.
+// Check the length with:
+//     len(mockedIFileSystem.ListDirectoriesCalls())
+func (mock *IFileSystemMock) ListDirectoriesCalls() []struct {
+	Path string
+} {
+	var calls []struct {
+		Path string
+	}
+	mock.lockListDirectories.RLock()
+	calls = mock.calls.ListDirectories
+	mock.lockListDirectories.RUnlock()
+	return calls
+}
+
 // MakeDir calls MakeDirFunc.
 func (mock *IFileSystemMock) MakeDir(path string) error {
 	if mock.MakeDirFunc == nil {
@@ -221,6 +338,37 @@ func (mock *IFileSystemMock) MakeDirCalls() []struct {
 	return calls
 }
 
+// OpenFile calls OpenFileFunc.
+func (mock *IFileSystemMock) OpenFile(filename string) (io.ReadCloser, int64, error) {
+	if mock.OpenFileFunc == nil {
+		panic("IFileSystemMock.OpenFileFunc: method is nil but IFileSystem.OpenFile was just called")
+	}
+	callInfo := struct {
+		Filename string
+	}{
+		Filename: filename,
+	}
+	mock.lockOpenFile.Lock()
+	mock.calls.OpenFile = append(mock.calls.OpenFile, callInfo)
+	mock.lockOpenFile.Unlock()
+	return mock.OpenFileFunc(filename)
+}
+
+// OpenFileCalls gets all the calls that were made to OpenFile.
+// Check the length with:
+//     len(mockedIFileSystem.OpenFileCalls())
+func (mock *IFileSystemMock) OpenFileCalls() []struct {
+	Filename string
+} {
+	var calls []struct {
+		Filename string
+	}
+	mock.lockOpenFile.RLock()
+	calls = mock.calls.OpenFile
+	mock.lockOpenFile.RUnlock()
+	return calls
+}
+
 // ReadFile calls ReadFileFunc.
 func (mock *IFileSystemMock) ReadFile(filename string) ([]byte, error) {
 	if mock.ReadFileFunc == nil {
@@ -387,3 +535,34 @@ func (mock *IFileSystemMock) WriteHelmChartCalls() []struct {
 	mock.lockWriteHelmChart.RUnlock()
 	return calls
 }
+
+// WriteTempFile calls WriteTempFileFunc.
+func (mock *IFileSystemMock) WriteTempFile(content io.Reader) (string, int64, error) {
+	if mock.WriteTempFileFunc == nil {
+		panic("IFileSystemMock.WriteTempFileFunc: method is nil but IFileSystem.WriteTempFile was just called")
+	}
+	callInfo := struct {
+		Content io.Reader
+	}{
+		Content: content,
+	}
+	mock.lockWriteTempFile.Lock()
+	mock.calls.WriteTempFile = append(mock.calls.WriteTempFile, callInfo)
+	mock.lockWriteTempFile.Unlock()
+	return mock.WriteTempFileFunc(content)
+}
+
+// WriteTempFileCalls gets all the calls that were made to WriteTempFile.
+// Check the length with:
+//     len(mockedIFileSystem.WriteTempFileCalls())
+func (mock *IFileSystemMock) WriteTempFileCalls() []struct {
+	Content io.Reader
+} {
+	var calls []struct {
+		Content io.Reader
+	}
+	mock.lockWriteTempFile.RLock()
+	calls = mock.calls.WriteTempFile
+	mock.lockWriteTempFile.RUnlock()
+	return calls
+}
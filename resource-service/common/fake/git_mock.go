@@ -26,6 +26,18 @@ import (
 // 			CreateBranchFunc: func(gitContext common_models.GitContext, branch string, sourceBranch string) error {
 // 				panic("mock out the CreateBranch method")
 // 			},
+// 			CreateTagFunc: func(gitContext common_models.GitContext, tag string, branch string, message string) (string, error) {
+// 				panic("mock out the CreateTag method")
+// 			},
+// 			DeleteTagFunc: func(gitContext common_models.GitContext, tag string) error {
+// 				panic("mock out the DeleteTag method")
+// 			},
+// 			GetBranchesFunc: func(gitContext common_models.GitContext) ([]string, error) {
+// 				panic("mock out the GetBranches method")
+// 			},
+// 			GetCurrentBranchFunc: func(gitContext common_models.GitContext) (string, error) {
+// 				panic("mock out the GetCurrentBranch method")
+// 			},
 // 			GetCurrentRevisionFunc: func(gitContext common_models.GitContext) (string, error) {
 // 				panic("mock out the GetCurrentRevision method")
 // 			},
@@ -35,6 +47,9 @@ import (
 // 			GetFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
 // 				panic("mock out the GetFileRevision method")
 // 			},
+// 			GetTagsFunc: func(gitContext common_models.GitContext) ([]common_models.GitTag, error) {
+// 				panic("mock out the GetTags method")
+// 			},
 // 			MigrateProjectFunc: func(gitContext common_models.GitContext, newMetadatacontent []byte) error {
 // 				panic("mock out the MigrateProject method")
 // 			},
@@ -59,6 +74,9 @@ import (
 // 			StageAndCommitAllFunc: func(gitContext common_models.GitContext, message string) (string, error) {
 // 				panic("mock out the StageAndCommitAll method")
 // 			},
+// 			SyncUpstreamFunc: func(gitContext common_models.GitContext) (*common_models.UpstreamSyncResult, error) {
+// 				panic("mock out the SyncUpstream method")
+// 			},
 // 		}
 //
 // 		// use mockedIGit in code that requires common.IGit
@@ -78,6 +96,18 @@ type IGitMock struct {
 	// CreateBranchFunc mocks the CreateBranch method.
 	CreateBranchFunc func(gitContext common_models.GitContext, branch string, sourceBranch string) error
 
+	// CreateTagFunc mocks the CreateTag method.
+	CreateTagFunc func(gitContext common_models.GitContext, tag string, branch string, message string) (string, error)
+
+	// DeleteTagFunc mocks the DeleteTag method.
+	DeleteTagFunc func(gitContext common_models.GitContext, tag string) error
+
+	// GetBranchesFunc mocks the GetBranches method.
+	GetBranchesFunc func(gitContext common_models.GitContext) ([]string, error)
+
+	// GetCurrentBranchFunc mocks the GetCurrentBranch method.
+	GetCurrentBranchFunc func(gitContext common_models.GitContext) (string, error)
+
 	// GetCurrentRevisionFunc mocks the GetCurrentRevision method.
 	GetCurrentRevisionFunc func(gitContext common_models.GitContext) (string, error)
 
@@ -87,6 +117,9 @@ type IGitMock struct {
 	// GetFileRevisionFunc mocks the GetFileRevision method.
 	GetFileRevisionFunc func(gitContext common_models.GitContext, revision string, file string) ([]byte, error)
 
+	// GetTagsFunc mocks the GetTags method.
+	GetTagsFunc func(gitContext common_models.GitContext) ([]common_models.GitTag, error)
+
 	// MigrateProjectFunc mocks the MigrateProject method.
 	MigrateProjectFunc func(gitContext common_models.GitContext, newMetadatacontent []byte) error
 
@@ -111,6 +144,9 @@ type IGitMock struct {
 	// StageAndCommitAllFunc mocks the StageAndCommitAll method.
 	StageAndCommitAllFunc func(gitContext common_models.GitContext, message string) (string, error)
 
+	// SyncUpstreamFunc mocks the SyncUpstream method.
+	SyncUpstreamFunc func(gitContext common_models.GitContext) (*common_models.UpstreamSyncResult, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CheckUpstreamConnection holds details about calls to the CheckUpstreamConnection method.
@@ -139,6 +175,34 @@ type IGitMock struct {
 			// SourceBranch is the sourceBranch argument value.
 			SourceBranch string
 		}
+		// CreateTag holds details about calls to the CreateTag method.
+		CreateTag []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Tag is the tag argument value.
+			Tag string
+			// Branch is the branch argument value.
+			Branch string
+			// Message is the message argument value.
+			Message string
+		}
+		// DeleteTag holds details about calls to the DeleteTag method.
+		DeleteTag []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Tag is the tag argument value.
+			Tag string
+		}
+		// GetBranches holds details about calls to the GetBranches method.
+		GetBranches []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+		}
+		// GetCurrentBranch holds details about calls to the GetCurrentBranch method.
+		GetCurrentBranch []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+		}
 		// GetCurrentRevision holds details about calls to the GetCurrentRevision method.
 		GetCurrentRevision []struct {
 			// GitContext is the gitContext argument value.
@@ -158,6 +222,11 @@ type IGitMock struct {
 			// File is the file argument value.
 			File string
 		}
+		// GetTags holds details about calls to the GetTags method.
+		GetTags []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+		}
 		// MigrateProject holds details about calls to the MigrateProject method.
 		MigrateProject []struct {
 			// GitContext is the gitContext argument value.
@@ -206,14 +275,24 @@ type IGitMock struct {
 			// Message is the message argument value.
 			Message string
 		}
+		// SyncUpstream holds details about calls to the SyncUpstream method.
+		SyncUpstream []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+		}
 	}
 	lockCheckUpstreamConnection sync.RWMutex
 	lockCheckoutBranch          sync.RWMutex
 	lockCloneRepo               sync.RWMutex
 	lockCreateBranch            sync.RWMutex
+	lockCreateTag               sync.RWMutex
+	lockDeleteTag               sync.RWMutex
+	lockGetBranches             sync.RWMutex
+	lockGetCurrentBranch        sync.RWMutex
 	lockGetCurrentRevision      sync.RWMutex
 	lockGetDefaultBranch        sync.RWMutex
 	lockGetFileRevision         sync.RWMutex
+	lockGetTags                 sync.RWMutex
 	lockMigrateProject          sync.RWMutex
 	lockMoveToNewUpstream       sync.RWMutex
 	lockProjectExists           sync.RWMutex
@@ -222,6 +301,7 @@ type IGitMock struct {
 	lockPush                    sync.RWMutex
 	lockResetHard               sync.RWMutex
 	lockStageAndCommitAll       sync.RWMutex
+	lockSyncUpstream            sync.RWMutex
 }
 
 // CheckUpstreamConnection calls CheckUpstreamConnectionFunc.
@@ -360,6 +440,146 @@ func (mock *IGitMock) CreateBranchCalls() []struct {
 	return calls
 }
 
+// CreateTag calls CreateTagFunc.
+func (mock *IGitMock) CreateTag(gitContext common_models.GitContext, tag string, branch string, message string) (string, error) {
+	if mock.CreateTagFunc == nil {
+		panic("IGitMock.CreateTagFunc: method is nil but IGit.CreateTag was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Tag        string
+		Branch     string
+		Message    string
+	}{
+		GitContext: gitContext,
+		Tag:        tag,
+		Branch:     branch,
+		Message:    message,
+	}
+	mock.lockCreateTag.Lock()
+	mock.calls.CreateTag = append(mock.calls.CreateTag, callInfo)
+	mock.lockCreateTag.Unlock()
+	return mock.CreateTagFunc(gitContext, tag, branch, message)
+}
+
+// CreateTagCalls gets all the calls that were made to CreateTag.
+// Check the length with:
+//     len(mockedIGit.CreateTagCalls())
+func (mock *IGitMock) CreateTagCalls() []struct {
+	GitContext common_models.GitContext
+	Tag        string
+	Branch     string
+	Message    string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Tag        string
+		Branch     string
+		Message    string
+	}
+	mock.lockCreateTag.RLock()
+	calls = mock.calls.CreateTag
+	mock.lockCreateTag.RUnlock()
+	return calls
+}
+
+// DeleteTag calls DeleteTagFunc.
+func (mock *IGitMock) DeleteTag(gitContext common_models.GitContext, tag string) error {
+	if mock.DeleteTagFunc == nil {
+		panic("IGitMock.DeleteTagFunc: method is nil but IGit.DeleteTag was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Tag        string
+	}{
+		GitContext: gitContext,
+		Tag:        tag,
+	}
+	mock.lockDeleteTag.Lock()
+	mock.calls.DeleteTag = append(mock.calls.DeleteTag, callInfo)
+	mock.lockDeleteTag.Unlock()
+	return mock.DeleteTagFunc(gitContext, tag)
+}
+
+// DeleteTagCalls gets all the calls that were made to DeleteTag.
+// Check the length with:
+//     len(mockedIGit.DeleteTagCalls())
+func (mock *IGitMock) DeleteTagCalls() []struct {
+	GitContext common_models.GitContext
+	Tag        string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Tag        string
+	}
+	mock.lockDeleteTag.RLock()
+	calls = mock.calls.DeleteTag
+	mock.lockDeleteTag.RUnlock()
+	return calls
+}
+
+// GetBranches calls GetBranchesFunc.
+func (mock *IGitMock) GetBranches(gitContext common_models.GitContext) ([]string, error) {
+	if mock.GetBranchesFunc == nil {
+		panic("IGitMock.GetBranchesFunc: method is nil but IGit.GetBranches was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+	}{
+		GitContext: gitContext,
+	}
+	mock.lockGetBranches.Lock()
+	mock.calls.GetBranches = append(mock.calls.GetBranches, callInfo)
+	mock.lockGetBranches.Unlock()
+	return mock.GetBranchesFunc(gitContext)
+}
+
+// GetBranchesCalls gets all the calls that were made to GetBranches.
+// Check the length with:
+//     len(mockedIGit.GetBranchesCalls())
+func (mock *IGitMock) GetBranchesCalls() []struct {
+	GitContext common_models.GitContext
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+	}
+	mock.lockGetBranches.RLock()
+	calls = mock.calls.GetBranches
+	mock.lockGetBranches.RUnlock()
+	return calls
+}
+
+// GetCurrentBranch calls GetCurrentBranchFunc.
+func (mock *IGitMock) GetCurrentBranch(gitContext common_models.GitContext) (string, error) {
+	if mock.GetCurrentBranchFunc == nil {
+		panic("IGitMock.GetCurrentBranchFunc: method is nil but IGit.GetCurrentBranch was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+	}{
+		GitContext: gitContext,
+	}
+	mock.lockGetCurrentBranch.Lock()
+	mock.calls.GetCurrentBranch = append(mock.calls.GetCurrentBranch, callInfo)
+	mock.lockGetCurrentBranch.Unlock()
+	return mock.GetCurrentBranchFunc(gitContext)
+}
+
+// GetCurrentBranchCalls gets all the calls that were made to GetCurrentBranch.
+// Check the length with:
+//     len(mockedIGit.GetCurrentBranchCalls())
+func (mock *IGitMock) GetCurrentBranchCalls() []struct {
+	GitContext common_models.GitContext
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+	}
+	mock.lockGetCurrentBranch.RLock()
+	calls = mock.calls.GetCurrentBranch
+	mock.lockGetCurrentBranch.RUnlock()
+	return calls
+}
+
 // GetCurrentRevision calls GetCurrentRevisionFunc.
 func (mock *IGitMock) GetCurrentRevision(gitContext common_models.GitContext) (string, error) {
 	if mock.GetCurrentRevisionFunc == nil {
@@ -461,6 +681,37 @@ func (mock *IGitMock) GetFileRevisionCalls() []struct {
 	return calls
 }
 
+// GetTags calls GetTagsFunc.
+func (mock *IGitMock) GetTags(gitContext common_models.GitContext) ([]common_models.GitTag, error) {
+	if mock.GetTagsFunc == nil {
+		panic("IGitMock.GetTagsFunc: method is nil but IGit.GetTags was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+	}{
+		GitContext: gitContext,
+	}
+	mock.lockGetTags.Lock()
+	mock.calls.GetTags = append(mock.calls.GetTags, callInfo)
+	mock.lockGetTags.Unlock()
+	return mock.GetTagsFunc(gitContext)
+}
+
+// GetTagsCalls gets all the calls that were made to GetTags.
+// Check the length with:
+//     len(mockedIGit.GetTagsCalls())
+func (mock *IGitMock) GetTagsCalls() []struct {
+	GitContext common_models.GitContext
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+	}
+	mock.lockGetTags.RLock()
+	calls = mock.calls.GetTags
+	mock.lockGetTags.RUnlock()
+	return calls
+}
+
 // MigrateProject calls MigrateProjectFunc.
 func (mock *IGitMock) MigrateProject(gitContext common_models.GitContext, newMetadatacontent []byte) error {
 	if mock.MigrateProjectFunc == nil {
@@ -724,3 +975,34 @@ func (mock *IGitMock) StageAndCommitAllCalls() []struct {
 	mock.lockStageAndCommitAll.RUnlock()
 	return calls
 }
+
+// SyncUpstream calls SyncUpstreamFunc.
+func (mock *IGitMock) SyncUpstream(gitContext common_models.GitContext) (*common_models.UpstreamSyncResult, error) {
+	if mock.SyncUpstreamFunc == nil {
+		panic("IGitMock.SyncUpstreamFunc: method is nil but IGit.SyncUpstream was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+	}{
+		GitContext: gitContext,
+	}
+	mock.lockSyncUpstream.Lock()
+	mock.calls.SyncUpstream = append(mock.calls.SyncUpstream, callInfo)
+	mock.lockSyncUpstream.Unlock()
+	return mock.SyncUpstreamFunc(gitContext)
+}
+
+// SyncUpstreamCalls gets all the calls that were made to SyncUpstream.
+// Check the length with:
+//     len(mockedIGit.SyncUpstreamCalls())
+func (mock *IGitMock) SyncUpstreamCalls() []struct {
+	GitContext common_models.GitContext
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+	}
+	mock.lockSyncUpstream.RLock()
+	calls = mock.calls.SyncUpstream
+	mock.lockSyncUpstream.RUnlock()
+	return calls
+}
@@ -27,6 +27,10 @@ type IFileSystem interface {
 	FileExists(path string) bool
 	MakeDir(path string) error
 	WalkPath(path string, walkFunc filepath.WalkFunc) error
+	ListDirectories(path string) ([]string, error)
+	WriteTempFile(content io.Reader) (string, int64, error)
+	CopyFile(source string, target string) error
+	OpenFile(filename string) (io.ReadCloser, int64, error)
 }
 
 type FileSystem struct {
@@ -133,6 +137,138 @@ func (fw FileSystem) ReadFile(filename string) ([]byte, error) {
 	return ioutil.ReadFile(filename)
 }
 
+// WriteTempFile streams the given content into a new temporary file and returns its path and size.
+// The caller is responsible for deleting the file once it is not needed anymore
+func (fw FileSystem) WriteTempFile(content io.Reader) (string, int64, error) {
+	file, err := ioutil.TempFile(fw.tmpDirLocation, "upload-*")
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+
+	size, err := io.Copy(file, content)
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return "", 0, err
+	}
+	if err := file.Sync(); err != nil {
+		_ = os.Remove(file.Name())
+		return "", 0, err
+	}
+	return file.Name(), size, nil
+}
+
+// CopyFile copies the file located at source to target, replacing the target if it already exists
+func (fw FileSystem) CopyFile(source string, target string) error {
+	sourceFile, err := os.Open(filepath.Clean(source))
+	if err != nil {
+		return err
+	}
+	defer sourceFile.Close()
+
+	if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+		return err
+	}
+	// remove the existing file instead of truncating it, so that readers which still have it opened are not affected
+	if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	targetFile, err := os.Create(filepath.Clean(target))
+	if err != nil {
+		return err
+	}
+	defer targetFile.Close()
+
+	if _, err := io.Copy(targetFile, sourceFile); err != nil {
+		return err
+	}
+	return targetFile.Sync()
+}
+
+// OpenFile opens the given file for streaming its content. Helm charts are packaged into a temporary archive,
+// which is removed as soon as the returned reader is closed
+func (fw FileSystem) OpenFile(filename string) (io.ReadCloser, int64, error) {
+	filename = filepath.Clean(filename)
+	if IsHelmChartPath(filename) {
+		chartDir := strings.TrimSuffix(filename, ".tgz")
+		if !fw.FileExists(chartDir) {
+			return nil, 0, errors2.ErrResourceNotFound
+		}
+		isEmpty, err := IsEmpty(chartDir)
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not check directory content: %w", err)
+		}
+		if isEmpty {
+			return nil, 0, errors2.ErrResourceNotFound
+		}
+		tmpDir, err := ioutil.TempDir(fw.tmpDirLocation, "*")
+		if err != nil {
+			return nil, 0, err
+		}
+		archivePath := filepath.Join(tmpDir, filepath.Base(filename))
+		if err := archive.Archive([]string{chartDir}, archivePath); err != nil {
+			_ = os.RemoveAll(tmpDir)
+			return nil, 0, err
+		}
+		file, size, err := openFile(archivePath)
+		if err != nil {
+			_ = os.RemoveAll(tmpDir)
+			return nil, 0, err
+		}
+		return &tempFileReader{File: file, dir: tmpDir}, size, nil
+	}
+	if !fw.FileExists(filename) {
+		return nil, 0, errors2.ErrResourceNotFound
+	}
+	return openFile(filename)
+}
+
+func openFile(filename string) (*os.File, int64, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	if info.IsDir() {
+		file.Close()
+		return nil, 0, errors2.ErrResourceNotFound
+	}
+	return file, info.Size(), nil
+}
+
+// tempFileReader removes the directory containing the file when being closed
+type tempFileReader struct {
+	*os.File
+	dir string
+}
+
+func (r *tempFileReader) Close() error {
+	err := r.File.Close()
+	if removeErr := os.RemoveAll(r.dir); removeErr != nil {
+		logger.Errorf("Could not remove directory %s: %v", r.dir, removeErr)
+	}
+	return err
+}
+
+// ListDirectories returns the names of the directories that are located directly within the given path
+func (FileSystem) ListDirectories(path string) ([]string, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	directories := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			directories = append(directories, entry.Name())
+		}
+	}
+	return directories, nil
+}
+
 func (FileSystem) DeleteFile(path string) error {
 	var err = os.RemoveAll(path)
 	if err != nil {
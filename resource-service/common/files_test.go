@@ -1,8 +1,12 @@
 package common
 
 import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	errors2 "github.com/keptn/keptn/resource-service/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -36,6 +40,76 @@ func TestFileSystem_WriteAndReadFile(t *testing.T) {
 	require.False(t, fileExists)
 }
 
+func TestFileSystem_ListDirectories(t *testing.T) {
+	dir := t.TempDir()
+
+	fs := FileSystem{}
+
+	require.Nil(t, fs.MakeDir(dir+"/project-a"))
+	require.Nil(t, fs.MakeDir(dir+"/project-b"))
+	require.Nil(t, fs.WriteFile(dir+"/my-file", []byte("content")))
+
+	directories, err := fs.ListDirectories(dir)
+	require.Nil(t, err)
+	require.Equal(t, []string{"project-a", "project-b"}, directories)
+
+	_, err = fs.ListDirectories(dir + "/not-existing")
+	require.NotNil(t, err)
+}
+
+func TestFileSystem_WriteTempFileAndCopyFile(t *testing.T) {
+	dir := t.TempDir()
+
+	fs := NewFileSystem(dir)
+
+	tmpFile, size, err := fs.WriteTempFile(strings.NewReader("content"))
+	require.Nil(t, err)
+	require.Equal(t, int64(7), size)
+	require.Equal(t, dir, filepath.Dir(tmpFile))
+
+	require.Nil(t, fs.WriteFile(dir+"/project/file", []byte("old content")))
+	oldFile, _, err := fs.OpenFile(dir + "/project/file")
+	require.Nil(t, err)
+	defer oldFile.Close()
+
+	require.Nil(t, fs.CopyFile(tmpFile, dir+"/project/file"))
+	require.Nil(t, fs.CopyFile(tmpFile, dir+"/project/sub/file"))
+
+	content, err := fs.ReadFile(dir + "/project/file")
+	require.Nil(t, err)
+	require.Equal(t, "content", string(content))
+	content, err = fs.ReadFile(dir + "/project/sub/file")
+	require.Nil(t, err)
+	require.Equal(t, "content", string(content))
+
+	// readers that opened the file before it has been replaced still get the previous content
+	content, err = ioutil.ReadAll(oldFile)
+	require.Nil(t, err)
+	require.Equal(t, "old content", string(content))
+}
+
+func TestFileSystem_OpenFile(t *testing.T) {
+	dir := t.TempDir()
+
+	fs := NewFileSystem(dir)
+
+	require.Nil(t, fs.WriteFile(dir+"/my-file", []byte("content")))
+
+	file, size, err := fs.OpenFile(dir + "/my-file")
+	require.Nil(t, err)
+	require.Equal(t, int64(7), size)
+	content, err := ioutil.ReadAll(file)
+	require.Nil(t, err)
+	require.Equal(t, "content", string(content))
+	require.Nil(t, file.Close())
+
+	_, _, err = fs.OpenFile(dir + "/not-existing")
+	require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+
+	_, _, err = fs.OpenFile(dir)
+	require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+}
+
 func TestIsHelmChartPath(t *testing.T) {
 	type args struct {
 		resourcePath string
@@ -102,3 +176,29 @@ func TestFileSystem_WriteHelmChart(t *testing.T) {
 
 	require.Equal(t, "test\n", string(res))
 }
+
+func TestFileSystem_OpenFile_HelmChart(t *testing.T) {
+	dir := t.TempDir()
+	tmpDir := t.TempDir()
+
+	fs := NewFileSystem(tmpDir)
+
+	filePath := dir + "/helm/my-chart.tgz"
+	require.Nil(t, fs.WriteBase64EncodedFile(filePath, testTgzContent))
+	require.Nil(t, fs.WriteHelmChart(filePath))
+
+	file, size, err := fs.OpenFile(filePath)
+	require.Nil(t, err)
+	require.Greater(t, size, int64(0))
+
+	content, err := ioutil.ReadAll(file)
+	require.Nil(t, err)
+	require.Len(t, content, int(size))
+
+	// the temporary archive is removed when the file is closed
+	require.Nil(t, file.Close())
+	directories, err := fs.ListDirectories(tmpDir)
+	require.Nil(t, err)
+	require.Empty(t, directories)
+	require.False(t, fs.FileExists(filePath))
+}
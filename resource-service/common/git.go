@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -24,6 +25,7 @@ import (
 )
 
 const gitHeadFilePath = "/.git/HEAD"
+const remoteBranchRefPrefix = "refs/remotes/origin/"
 
 // IGit provides functions to interact with the git repository of a project
 //
@@ -44,6 +46,12 @@ type IGit interface {
 	ResetHard(gitContext common_models.GitContext, revision string) error
 	MoveToNewUpstream(currentContext common_models.GitContext, newContext common_models.GitContext) error
 	CheckUpstreamConnection(gitContext common_models.GitContext) error
+	GetBranches(gitContext common_models.GitContext) ([]string, error)
+	GetCurrentBranch(gitContext common_models.GitContext) (string, error)
+	CreateTag(gitContext common_models.GitContext, tag string, branch string, message string) (string, error)
+	GetTags(gitContext common_models.GitContext) ([]common_models.GitTag, error)
+	DeleteTag(gitContext common_models.GitContext, tag string) error
+	SyncUpstream(gitContext common_models.GitContext) (*common_models.UpstreamSyncResult, error)
 }
 
 type Git struct {
@@ -594,6 +602,10 @@ func (g *Git) GetFileRevision(gitContext common_models.GitContext, revision stri
 	h, err := r.ResolveRevision(plumbing.Revision(revision))
 	if err != nil {
 		logger.Debugf("GetFileRevision(): Could not resolve revision for %s: %s", revision, err.Error())
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return []byte{},
+				fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, kerrors.ErrResolveRevision)
+		}
 		return []byte{},
 			fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
 	}
@@ -867,6 +879,292 @@ func (g *Git) migrateBranch(branch *plumbing.Reference, oldRepoWorktree *git.Wor
 	return nil
 }
 
+func (g *Git) GetBranches(gitContext common_models.GitContext) ([]string, error) {
+	r, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		logger.Debugf("GetBranches(): Could not get worktree for project '%s': %s", gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "get branches of", gitContext.Project, mapError(err))
+	}
+	if err := g.fetch(gitContext, r); err != nil {
+		logger.Debugf("GetBranches(): Could not fetch project '%s': %s", gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "fetch", gitContext.Project, mapError(err))
+	}
+	branchRefs, err := r.Branches()
+	if err != nil {
+		logger.Debugf("GetBranches(): Could not get branches of project '%s': %s", gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "get branches of", gitContext.Project, mapError(err))
+	}
+	branches := []string{}
+	err = branchRefs.ForEach(func(branch *plumbing.Reference) error {
+		branches = append(branches, branch.Name().Short())
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "get branches of", gitContext.Project, mapError(err))
+	}
+	return branches, nil
+}
+
+func (g *Git) GetCurrentBranch(gitContext common_models.GitContext) (string, error) {
+	r, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		logger.Debugf("GetCurrentBranch(): Could not get worktree for project '%s': %s", gitContext.Project, err.Error())
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "get current branch of", gitContext.Project, mapError(err))
+	}
+	head, err := r.Head()
+	if err != nil {
+		logger.Debugf("GetCurrentBranch(): Could not get head for project '%s': %s", gitContext.Project, err.Error())
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "get current branch of", gitContext.Project, mapError(err))
+	}
+	return head.Name().Short(), nil
+}
+
+// CreateTag creates an annotated tag pointing to the head of the given branch and pushes it to the upstream.
+// It returns the ID of the tagged commit
+func (g *Git) CreateTag(gitContext common_models.GitContext, tag string, branch string, message string) (string, error) {
+	r, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		logger.Debugf("CreateTag(): Could not get worktree for project '%s': %s", gitContext.Project, err.Error())
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "tag", gitContext.Project, mapError(err))
+	}
+
+	branchRef, err := r.Reference(plumbing.NewBranchReferenceName(branch), true)
+	if err != nil {
+		logger.Debugf("CreateTag(): Could not resolve branch '%s' of project '%s': %s", branch, gitContext.Project, err.Error())
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "tag", gitContext.Project, kerrors.ErrBranchNotFound)
+		}
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "tag", gitContext.Project, mapError(err))
+	}
+
+	_, err = r.CreateTag(tag, branchRef.Hash(), &git.CreateTagOptions{
+		Tagger: &object.Signature{
+			Name:  getGitKeptnUser(),
+			Email: getGitKeptnEmail(),
+			When:  time.Now(),
+		},
+		Message: message,
+	})
+	if err != nil {
+		logger.Debugf("CreateTag(): Could not create tag '%s' for project '%s': %s", tag, gitContext.Project, err.Error())
+		if errors.Is(err, git.ErrTagExists) {
+			return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "tag", gitContext.Project, kerrors.ErrTagExists)
+		}
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "tag", gitContext.Project, mapError(err))
+	}
+
+	tagRefSpec := config.RefSpec(fmt.Sprintf("%s:%s", plumbing.NewTagReferenceName(tag), plumbing.NewTagReferenceName(tag)))
+	if err := g.pushRefSpec(gitContext, r, tagRefSpec); err != nil {
+		logger.Debugf("CreateTag(): Could not push tag '%s' for project '%s': %s", tag, gitContext.Project, err.Error())
+		if err := r.DeleteTag(tag); err != nil {
+			logger.Warnf("CreateTag(): Could not remove tag '%s' after failed push: %v", tag, err)
+		}
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, mapError(err))
+	}
+	return branchRef.Hash().String(), nil
+}
+
+func (g *Git) GetTags(gitContext common_models.GitContext) ([]common_models.GitTag, error) {
+	r, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		logger.Debugf("GetTags(): Could not get worktree for project '%s': %s", gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "get tags of", gitContext.Project, mapError(err))
+	}
+	if err := g.fetch(gitContext, r); err != nil {
+		logger.Debugf("GetTags(): Could not fetch project '%s': %s", gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "fetch", gitContext.Project, mapError(err))
+	}
+
+	tagRefs, err := r.Tags()
+	if err != nil {
+		logger.Debugf("GetTags(): Could not get tags of project '%s': %s", gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "get tags of", gitContext.Project, mapError(err))
+	}
+
+	tags := []common_models.GitTag{}
+	err = tagRefs.ForEach(func(ref *plumbing.Reference) error {
+		tag := common_models.GitTag{
+			Name:     ref.Name().Short(),
+			CommitID: ref.Hash().String(),
+		}
+		// annotated tags point to a tag object containing the message and the tagged commit,
+		// lightweight tags point to the commit directly
+		tagObject, err := r.TagObject(ref.Hash())
+		if err == nil {
+			tag.CommitID = tagObject.Target.String()
+			tag.Message = strings.TrimSpace(tagObject.Message)
+			tag.Date = tagObject.Tagger.When
+		} else if !errors.Is(err, plumbing.ErrObjectNotFound) {
+			return err
+		}
+		tags = append(tags, tag)
+		return nil
+	})
+	if err != nil {
+		logger.Debugf("GetTags(): Could not read tags of project '%s': %s", gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "get tags of", gitContext.Project, mapError(err))
+	}
+	return tags, nil
+}
+
+// DeleteTag removes the given tag from the local repository as well as from the upstream
+func (g *Git) DeleteTag(gitContext common_models.GitContext, tag string) error {
+	r, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		logger.Debugf("DeleteTag(): Could not get worktree for project '%s': %s", gitContext.Project, err.Error())
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "delete tag of", gitContext.Project, mapError(err))
+	}
+	if err := r.DeleteTag(tag); err != nil {
+		logger.Debugf("DeleteTag(): Could not delete tag '%s' of project '%s': %s", tag, gitContext.Project, err.Error())
+		if errors.Is(err, git.ErrTagNotFound) {
+			return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "delete tag of", gitContext.Project, kerrors.ErrTagNotFound)
+		}
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "delete tag of", gitContext.Project, mapError(err))
+	}
+
+	deleteRefSpec := config.RefSpec(":" + plumbing.NewTagReferenceName(tag).String())
+	if err := g.pushRefSpec(gitContext, r, deleteRefSpec); err != nil {
+		logger.Debugf("DeleteTag(): Could not push deletion of tag '%s' for project '%s': %s", tag, gitContext.Project, err.Error())
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, mapError(err))
+	}
+	return nil
+}
+
+// SyncUpstream fetches all branches of the upstream and fast-forwards the local branches to the state of the upstream.
+// Local branches that have diverged from the upstream are left untouched and reported as conflicting
+func (g *Git) SyncUpstream(gitContext common_models.GitContext) (*common_models.UpstreamSyncResult, error) {
+	r, w, err := g.getWorkTree(gitContext)
+	if err != nil {
+		logger.Debugf("SyncUpstream(): Could not get worktree for project '%s': %s", gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "sync", gitContext.Project, mapError(err))
+	}
+
+	err = r.Fetch(&git.FetchOptions{
+		RemoteName:      "origin",
+		RefSpecs:        []config.RefSpec{"+refs/heads/*:refs/remotes/origin/*"},
+		Force:           true,
+		Auth:            gitContext.AuthMethod.GoGitAuth,
+		InsecureSkipTLS: retrieveInsecureSkipTLS(gitContext.Credentials),
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) && !errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		logger.Debugf("SyncUpstream(): Could not fetch project '%s': %s", gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "fetch", gitContext.Project, mapError(err))
+	}
+
+	head, err := r.Head()
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "sync", gitContext.Project, mapError(err))
+	}
+
+	remoteRefs, err := getRemoteBranchReferences(r)
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "sync", gitContext.Project, mapError(err))
+	}
+
+	result := &common_models.UpstreamSyncResult{
+		UpdatedBranches:     []string{},
+		ConflictingBranches: []string{},
+	}
+	for branch, remoteRef := range remoteRefs {
+		localRefName := plumbing.NewBranchReferenceName(branch)
+		updated, err := g.fastForwardBranch(r, localRefName, remoteRef.Hash())
+		if errors.Is(err, kerrors.ErrNonFastForwardUpdate) {
+			logger.Warnf("SyncUpstream(): Branch '%s' of project '%s' has diverged from the upstream", branch, gitContext.Project)
+			result.ConflictingBranches = append(result.ConflictingBranches, branch)
+			continue
+		} else if err != nil {
+			logger.Debugf("SyncUpstream(): Could not update branch '%s' of project '%s': %s", branch, gitContext.Project, err.Error())
+			return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "sync", gitContext.Project, mapError(err))
+		}
+		if !updated {
+			continue
+		}
+		result.UpdatedBranches = append(result.UpdatedBranches, branch)
+		if localRefName == head.Name() {
+			// the worktree needs to reflect the new state of the branch that is currently checked out
+			if err := w.Reset(&git.ResetOptions{Commit: remoteRef.Hash(), Mode: git.HardReset}); err != nil {
+				return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "sync", gitContext.Project, mapError(err))
+			}
+		}
+	}
+	sort.Strings(result.UpdatedBranches)
+	sort.Strings(result.ConflictingBranches)
+
+	head, err = r.Head()
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "sync", gitContext.Project, mapError(err))
+	}
+	result.Revision = head.Hash().String()
+	return result, nil
+}
+
+// fastForwardBranch sets the given local branch to the target commit if this is possible without losing any local commits.
+// Local branches that contain commits which are not part of the target are not changed
+func (g *Git) fastForwardBranch(r *git.Repository, branch plumbing.ReferenceName, target plumbing.Hash) (bool, error) {
+	localRef, err := r.Reference(branch, true)
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		// the branch has been created in the upstream
+		return true, r.Storer.SetReference(plumbing.NewHashReference(branch, target))
+	} else if err != nil {
+		return false, err
+	}
+	if localRef.Hash() == target {
+		return false, nil
+	}
+
+	localCommit, err := r.CommitObject(localRef.Hash())
+	if err != nil {
+		return false, err
+	}
+	targetCommit, err := r.CommitObject(target)
+	if err != nil {
+		return false, err
+	}
+
+	if isFastForward, err := localCommit.IsAncestor(targetCommit); err != nil {
+		return false, err
+	} else if isFastForward {
+		return true, r.Storer.SetReference(plumbing.NewHashReference(branch, target))
+	}
+
+	if isAhead, err := targetCommit.IsAncestor(localCommit); err != nil {
+		return false, err
+	} else if isAhead {
+		// the local branch contains commits that have not been pushed yet
+		return false, nil
+	}
+	return false, kerrors.ErrNonFastForwardUpdate
+}
+
+func getRemoteBranchReferences(r *git.Repository) (map[string]*plumbing.Reference, error) {
+	refs, err := r.References()
+	if err != nil {
+		return nil, err
+	}
+	remoteRefs := map[string]*plumbing.Reference{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference || !strings.HasPrefix(ref.Name().String(), remoteBranchRefPrefix) {
+			return nil
+		}
+		remoteRefs[strings.TrimPrefix(ref.Name().String(), remoteBranchRefPrefix)] = ref
+		return nil
+	})
+	return remoteRefs, err
+}
+
+func (g *Git) pushRefSpec(gitContext common_models.GitContext, r *git.Repository, refSpec config.RefSpec) error {
+	err := r.Push(&git.PushOptions{
+		RemoteName:      "origin",
+		RefSpecs:        []config.RefSpec{refSpec},
+		Auth:            gitContext.AuthMethod.GoGitAuth,
+		InsecureSkipTLS: retrieveInsecureSkipTLS(gitContext.Credentials),
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return err
+	}
+	return nil
+}
+
 func (g *Git) getWorkTree(gitContext common_models.GitContext) (*git.Repository, *git.Worktree, error) {
 	projectConfigPath := GetProjectConfigPath(gitContext.Project)
 	// check if we already have a repository
@@ -917,6 +917,115 @@ func (s *BaseSuite) TestGit_CheckoutBranch(c *C) {
 	}
 }
 
+func (s *BaseSuite) TestGit_CreateAndDeleteTag(c *C) {
+	g := NewGit(s.NewTestGit())
+	gitContext := s.NewGitContext()
+
+	head, err := s.Repository.Head()
+	c.Assert(err, IsNil)
+
+	commitID, err := g.CreateTag(gitContext, "snapshots/release-1.0/master", "master", "release 1.0")
+	c.Assert(err, IsNil)
+	c.Assert(commitID, Equals, head.Hash().String())
+
+	// the tag must have been pushed to the upstream
+	remote, err := git.PlainOpen(s.url)
+	c.Assert(err, IsNil)
+	_, err = remote.Tag("snapshots/release-1.0/master")
+	c.Assert(err, IsNil)
+
+	_, err = g.CreateTag(gitContext, "snapshots/release-1.0/master", "master", "release 1.0")
+	c.Assert(errors.Is(err, kerrors.ErrTagExists), Equals, true)
+
+	_, err = g.CreateTag(gitContext, "snapshots/release-1.0/dev", "dev", "release 1.0")
+	c.Assert(errors.Is(err, kerrors.ErrBranchNotFound), Equals, true)
+
+	tags, err := g.GetTags(gitContext)
+	c.Assert(err, IsNil)
+	found := false
+	for _, tag := range tags {
+		if tag.Name == "snapshots/release-1.0/master" {
+			found = true
+			c.Assert(tag.CommitID, Equals, head.Hash().String())
+			c.Assert(tag.Message, Equals, "release 1.0")
+		}
+	}
+	c.Assert(found, Equals, true)
+
+	// the file content of the tagged revision can be retrieved
+	_, err = g.GetFileRevision(gitContext, "refs/tags/snapshots/release-1.0/master", "CHANGELOG")
+	c.Assert(err, IsNil)
+
+	err = g.DeleteTag(gitContext, "snapshots/release-1.0/master")
+	c.Assert(err, IsNil)
+	_, err = remote.Tag("snapshots/release-1.0/master")
+	c.Assert(err, Equals, git.ErrTagNotFound)
+
+	err = g.DeleteTag(gitContext, "snapshots/release-1.0/master")
+	c.Assert(errors.Is(err, kerrors.ErrTagNotFound), Equals, true)
+}
+
+func (s *BaseSuite) TestGit_GetBranches(c *C) {
+	g := NewGit(s.NewTestGit())
+	gitContext := s.NewGitContext()
+
+	err := g.CreateBranch(gitContext, "dev", "master")
+	c.Assert(err, IsNil)
+
+	branches, err := g.GetBranches(gitContext)
+	c.Assert(err, IsNil)
+	c.Assert(branches, DeepEquals, []string{"dev", "master"})
+
+	currentBranch, err := g.GetCurrentBranch(gitContext)
+	c.Assert(err, IsNil)
+	c.Assert(currentBranch, Equals, "dev")
+}
+
+func (s *BaseSuite) TestGit_SyncUpstream(c *C) {
+	g := NewGit(s.NewTestGit())
+	gitContext := s.NewGitContext()
+
+	// another clone of the upstream is used to simulate direct commits to the upstream
+	other, err := git.PlainClone(TESTPATH+"/other", false, &git.CloneOptions{URL: s.url})
+	c.Assert(err, IsNil)
+	otherWorktree, err := other.Worktree()
+	c.Assert(err, IsNil)
+
+	c.Assert(write("upstream.txt", "content", c, otherWorktree), IsNil)
+	upstreamCommit := commit("upstream.txt", c, otherWorktree)
+	push(other, c)
+
+	result, err := g.SyncUpstream(gitContext)
+	c.Assert(err, IsNil)
+	c.Assert(result.UpdatedBranches, DeepEquals, []string{"master"})
+	c.Assert(result.ConflictingBranches, DeepEquals, []string{})
+	c.Assert(result.Revision, Equals, upstreamCommit.String())
+
+	// the worktree must contain the file that has been committed to the upstream
+	w, err := s.Repository.Worktree()
+	c.Assert(err, IsNil)
+	_, err = w.Filesystem.Stat("upstream.txt")
+	c.Assert(err, IsNil)
+
+	// nothing to do if the local repository is already up to date
+	result, err = g.SyncUpstream(gitContext)
+	c.Assert(err, IsNil)
+	c.Assert(result.UpdatedBranches, DeepEquals, []string{})
+
+	// commit to both the local repository and the upstream to let them diverge
+	c.Assert(write("local.txt", "local", c, w), IsNil)
+	localCommit := commit("local.txt", c, w)
+	c.Assert(write("upstream.txt", "changed", c, otherWorktree), IsNil)
+	commit("upstream.txt", c, otherWorktree)
+	push(other, c)
+
+	result, err = g.SyncUpstream(gitContext)
+	c.Assert(err, IsNil)
+	c.Assert(result.UpdatedBranches, DeepEquals, []string{})
+	c.Assert(result.ConflictingBranches, DeepEquals, []string{"master"})
+	c.Assert(result.Revision, Equals, localCommit.String())
+}
+
 func (s *BaseSuite) TestGit_GetFileRevision(c *C) {
 
 	tests := []struct {
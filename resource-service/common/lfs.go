@@ -0,0 +1,304 @@
+package common
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+)
+
+const lfsMediaType = "application/vnd.git-lfs+json"
+const lfsOperationUpload = "upload"
+const lfsOperationDownload = "download"
+
+// MaxLFSPointerSize is the maximum size of a file that is considered to be a Git LFS pointer
+const MaxLFSPointerSize = 1024
+
+var lfsPointerRegex = regexp.MustCompile(`^version ` + regexp.QuoteMeta(common_models.LFSPointerVersion) + `\noid sha256:([0-9a-f]{64})\nsize ([0-9]+)\n$`)
+
+// ParseLFSPointer returns the pointer contained in the given file content, or false if the content is not a pointer file
+func ParseLFSPointer(content []byte) (*common_models.LFSPointer, bool) {
+	if len(content) > MaxLFSPointerSize {
+		return nil, false
+	}
+	matches := lfsPointerRegex.FindStringSubmatch(string(content))
+	if matches == nil {
+		return nil, false
+	}
+	size, err := strconv.ParseInt(matches[2], 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	return &common_models.LFSPointer{OID: matches[1], Size: size}, true
+}
+
+// IsLFSTracked checks whether the given path, relative to the root of the repository, is configured to be stored
+// in Git LFS by the patterns of the provided .gitattributes file. As in git, the last matching pattern wins
+func IsLFSTracked(gitAttributes []byte, filePath string) bool {
+	tracked := false
+	for _, line := range strings.Split(string(gitAttributes), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if !matchesGitAttributesPattern(fields[0], filePath) {
+			continue
+		}
+		for _, attribute := range fields[1:] {
+			if attribute == "filter=lfs" {
+				tracked = true
+			} else if strings.HasPrefix(attribute, "filter=") || attribute == "-filter" || attribute == "!filter" {
+				tracked = false
+			}
+		}
+	}
+	return tracked
+}
+
+func matchesGitAttributesPattern(pattern string, filePath string) bool {
+	filePath = strings.TrimPrefix(filePath, "/")
+	if strings.HasSuffix(pattern, "/**") {
+		return strings.HasPrefix(filePath, strings.TrimPrefix(strings.TrimSuffix(pattern, "**"), "/"))
+	}
+	pattern = strings.TrimPrefix(pattern, "**/")
+	if !strings.Contains(pattern, "/") {
+		// patterns without a slash match the file name in any directory
+		matched, _ := path.Match(pattern, path.Base(filePath))
+		return matched
+	}
+	matched, _ := path.Match(strings.TrimPrefix(pattern, "/"), filePath)
+	return matched
+}
+
+// ILFSClient transfers objects from and to the Git LFS server of an upstream repository
+//
+//go:generate moq -pkg common_mock -skip-ensure -out ./fake/lfs_client_mock.go . ILFSClient
+type ILFSClient interface {
+	Upload(gitContext common_models.GitContext, pointer common_models.LFSPointer, content io.Reader) error
+	Download(gitContext common_models.GitContext, pointer common_models.LFSPointer) (io.ReadCloser, error)
+}
+
+// LFSClient implements the basic transfer adapter of the Git LFS batch API
+// (see https://github.com/git-lfs/git-lfs/blob/main/docs/api/batch.md)
+type LFSClient struct{}
+
+func NewLFSClient() *LFSClient {
+	return &LFSClient{}
+}
+
+type lfsBatchRequest struct {
+	Operation string           `json:"operation"`
+	Transfers []string         `json:"transfers"`
+	Objects   []lfsBatchObject `json:"objects"`
+	HashAlgo  string           `json:"hash_algo"`
+}
+
+type lfsBatchObject struct {
+	OID     string               `json:"oid"`
+	Size    int64                `json:"size"`
+	Actions map[string]lfsAction `json:"actions,omitempty"`
+	Error   *lfsObjectError      `json:"error,omitempty"`
+}
+
+type lfsAction struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header,omitempty"`
+}
+
+type lfsObjectError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type lfsBatchResponse struct {
+	Objects []lfsBatchObject `json:"objects"`
+}
+
+func (c LFSClient) Upload(gitContext common_models.GitContext, pointer common_models.LFSPointer, content io.Reader) error {
+	httpClient, err := c.getHTTPClient(gitContext)
+	if err != nil {
+		return err
+	}
+	object, err := c.batch(httpClient, gitContext, lfsOperationUpload, pointer)
+	if err != nil {
+		return err
+	}
+	upload, ok := object.Actions[lfsOperationUpload]
+	if !ok {
+		// the object is already stored on the server
+		return nil
+	}
+
+	req, err := http.NewRequest(http.MethodPut, upload.Href, content)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = pointer.Size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	setHeaders(req, upload.Header)
+	if err := c.do(httpClient, req, nil); err != nil {
+		return fmt.Errorf("could not upload git lfs object %s: %w", pointer.OID, err)
+	}
+
+	if verify, ok := object.Actions["verify"]; ok {
+		body, err := json.Marshal(lfsBatchObject{OID: pointer.OID, Size: pointer.Size})
+		if err != nil {
+			return err
+		}
+		req, err := http.NewRequest(http.MethodPost, verify.Href, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", lfsMediaType)
+		setHeaders(req, verify.Header)
+		if err := c.do(httpClient, req, nil); err != nil {
+			return fmt.Errorf("could not verify git lfs object %s: %w", pointer.OID, err)
+		}
+	}
+	return nil
+}
+
+func (c LFSClient) Download(gitContext common_models.GitContext, pointer common_models.LFSPointer) (io.ReadCloser, error) {
+	httpClient, err := c.getHTTPClient(gitContext)
+	if err != nil {
+		return nil, err
+	}
+	object, err := c.batch(httpClient, gitContext, lfsOperationDownload, pointer)
+	if err != nil {
+		return nil, err
+	}
+	download, ok := object.Actions[lfsOperationDownload]
+	if !ok {
+		return nil, kerrors.ErrLFSObjectNotFound
+	}
+
+	req, err := http.NewRequest(http.MethodGet, download.Href, nil)
+	if err != nil {
+		return nil, err
+	}
+	setHeaders(req, download.Header)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not download git lfs object %s: %w", pointer.OID, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, kerrors.ErrLFSObjectNotFound
+		}
+		return nil, fmt.Errorf("could not download git lfs object %s: received status code %d", pointer.OID, resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+func (c LFSClient) batch(httpClient *http.Client, gitContext common_models.GitContext, operation string, pointer common_models.LFSPointer) (*lfsBatchObject, error) {
+	endpoint, err := getLFSEndpoint(gitContext.Credentials.RemoteURL)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(lfsBatchRequest{
+		Operation: operation,
+		Transfers: []string{"basic"},
+		Objects:   []lfsBatchObject{{OID: pointer.OID, Size: pointer.Size}},
+		HashAlgo:  "sha256",
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint+"/objects/batch", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", lfsMediaType)
+	req.Header.Set("Content-Type", lfsMediaType)
+	if gitContext.Credentials.HttpsAuth != nil && gitContext.Credentials.HttpsAuth.Token != "" {
+		req.SetBasicAuth(gitContext.Credentials.User, gitContext.Credentials.HttpsAuth.Token)
+	}
+
+	batchResponse := &lfsBatchResponse{}
+	if err := c.do(httpClient, req, batchResponse); err != nil {
+		return nil, fmt.Errorf("could not execute git lfs %s batch request: %w", operation, err)
+	}
+	for _, object := range batchResponse.Objects {
+		if object.OID != pointer.OID {
+			continue
+		}
+		if object.Error != nil {
+			if object.Error.Code == http.StatusNotFound {
+				return nil, kerrors.ErrLFSObjectNotFound
+			}
+			return nil, fmt.Errorf("git lfs server rejected object %s: %s", pointer.OID, object.Error.Message)
+		}
+		return &object, nil
+	}
+	return nil, fmt.Errorf("git lfs server did not return object %s", pointer.OID)
+}
+
+func (c LFSClient) do(httpClient *http.Client, req *http.Request, result interface{}) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return kerrors.ErrAuthorizationFailed
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("received status code %d", resp.StatusCode)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func (c LFSClient) getHTTPClient(gitContext common_models.GitContext) (*http.Client, error) {
+	if gitContext.Credentials == nil || gitContext.Credentials.SshAuth != nil {
+		return nil, kerrors.ErrLFSRequiresHTTPS
+	}
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if httpsAuth := gitContext.Credentials.HttpsAuth; httpsAuth != nil {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: httpsAuth.InsecureSkipTLS}
+		if httpsAuth.Proxy != nil {
+			transport.Proxy = http.ProxyURL(&url.URL{
+				Scheme: httpsAuth.Proxy.Scheme,
+				User:   url.UserPassword(httpsAuth.Proxy.User, httpsAuth.Proxy.Password),
+				Host:   httpsAuth.Proxy.URL,
+			})
+		}
+	}
+	// transfers of large objects may take a while, therefore only the connection setup is limited
+	transport.ResponseHeaderTimeout = 60 * time.Second
+	return &http.Client{Transport: transport}, nil
+}
+
+// getLFSEndpoint derives the URL of the Git LFS server from the remote URL of the repository,
+// as described in https://github.com/git-lfs/git-lfs/blob/main/docs/api/server-discovery.md
+func getLFSEndpoint(remoteURL string) (string, error) {
+	if !strings.HasPrefix(remoteURL, "http://") && !strings.HasPrefix(remoteURL, "https://") {
+		return "", kerrors.ErrLFSRequiresHTTPS
+	}
+	endpoint := strings.TrimSuffix(remoteURL, "/")
+	if !strings.HasSuffix(endpoint, ".git") {
+		endpoint += ".git"
+	}
+	return endpoint + "/info/lfs", nil
+}
+
+func setHeaders(req *http.Request, headers map[string]string) {
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+}
@@ -0,0 +1,172 @@
+package common
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+)
+
+var testLFSPointer = common_models.LFSPointer{OID: strings.Repeat("ab", 32), Size: 7}
+
+func TestParseLFSPointer(t *testing.T) {
+	pointer, ok := ParseLFSPointer([]byte(testLFSPointer.String()))
+	require.True(t, ok)
+	require.Equal(t, testLFSPointer, *pointer)
+
+	_, ok = ParseLFSPointer([]byte("some content"))
+	require.False(t, ok)
+
+	_, ok = ParseLFSPointer([]byte("version https://git-lfs.github.com/spec/v1\noid sha256:invalid\nsize 7\n"))
+	require.False(t, ok)
+}
+
+func TestIsLFSTracked(t *testing.T) {
+	gitAttributes := `# binaries
+*.bin filter=lfs diff=lfs merge=lfs -text
+/artifacts/** filter=lfs diff=lfs merge=lfs -text
+docs/*.pdf filter=lfs diff=lfs merge=lfs -text
+small.bin -filter
+`
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "file.bin", want: true},
+		{path: "dev/my-service/file.bin", want: true},
+		{path: "artifacts/app/model.dat", want: true},
+		{path: "docs/manual.pdf", want: true},
+		{path: "other/docs/manual.pdf", want: false},
+		{path: "small.bin", want: false},
+		{path: "shipyard.yaml", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			require.Equal(t, tt.want, IsLFSTracked([]byte(gitAttributes), tt.path))
+		})
+	}
+}
+
+func TestGetLFSEndpoint(t *testing.T) {
+	endpoint, err := getLFSEndpoint("https://github.com/keptn/my-repo")
+	require.Nil(t, err)
+	require.Equal(t, "https://github.com/keptn/my-repo.git/info/lfs", endpoint)
+
+	endpoint, err = getLFSEndpoint("https://github.com/keptn/my-repo.git")
+	require.Nil(t, err)
+	require.Equal(t, "https://github.com/keptn/my-repo.git/info/lfs", endpoint)
+
+	_, err = getLFSEndpoint("ssh://git@github.com/keptn/my-repo.git")
+	require.ErrorIs(t, err, kerrors.ErrLFSRequiresHTTPS)
+}
+
+// newTestLFSServer provides a minimal implementation of the Git LFS batch API that stores the objects in memory
+func newTestLFSServer(t *testing.T, objects map[string]string) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, token, ok := r.BasicAuth()
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/my-repo.git/info/lfs/objects/batch":
+			if !ok || user != "my-user" || token != "my-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			request := &lfsBatchRequest{}
+			require.Nil(t, json.NewDecoder(r.Body).Decode(request))
+			object := request.Objects[0]
+			_, exists := objects[object.OID]
+			href := server.URL + "/objects/" + object.OID
+			if request.Operation == lfsOperationUpload && !exists {
+				object.Actions = map[string]lfsAction{lfsOperationUpload: {Href: href, Header: map[string]string{"X-Upload-Token": "secret"}}}
+			} else if request.Operation == lfsOperationDownload && exists {
+				object.Actions = map[string]lfsAction{lfsOperationDownload: {Href: href}}
+			} else if request.Operation == lfsOperationDownload {
+				object.Error = &lfsObjectError{Code: http.StatusNotFound, Message: "not found"}
+			}
+			w.Header().Set("Content-Type", lfsMediaType)
+			require.Nil(t, json.NewEncoder(w).Encode(lfsBatchResponse{Objects: []lfsBatchObject{object}}))
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/objects/"):
+			require.Equal(t, "secret", r.Header.Get("X-Upload-Token"))
+			content, err := ioutil.ReadAll(r.Body)
+			require.Nil(t, err)
+			objects[strings.TrimPrefix(r.URL.Path, "/objects/")] = string(content)
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/objects/"):
+			_, _ = w.Write([]byte(objects[strings.TrimPrefix(r.URL.Path, "/objects/")]))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+func TestLFSClient_UploadAndDownload(t *testing.T) {
+	objects := map[string]string{}
+	server := newTestLFSServer(t, objects)
+	defer server.Close()
+
+	gitContext := common_models.GitContext{
+		Project: "my-project",
+		Credentials: &common_models.GitCredentials{
+			User:      "my-user",
+			RemoteURL: server.URL + "/my-repo",
+			HttpsAuth: &apimodels.HttpsGitAuth{Token: "my-token"},
+		},
+	}
+	client := NewLFSClient()
+
+	err := client.Upload(gitContext, testLFSPointer, strings.NewReader("content"))
+	require.Nil(t, err)
+	require.Equal(t, "content", objects[testLFSPointer.OID])
+
+	content, err := client.Download(gitContext, testLFSPointer)
+	require.Nil(t, err)
+	defer content.Close()
+	data, err := ioutil.ReadAll(content)
+	require.Nil(t, err)
+	require.Equal(t, "content", string(data))
+
+	// uploading an object that already exists does not transfer it again
+	err = client.Upload(gitContext, testLFSPointer, strings.NewReader("other content"))
+	require.Nil(t, err)
+	require.Equal(t, "content", objects[testLFSPointer.OID])
+
+	_, err = client.Download(gitContext, common_models.LFSPointer{OID: strings.Repeat("cd", 32), Size: 1})
+	require.ErrorIs(t, err, kerrors.ErrLFSObjectNotFound)
+}
+
+func TestLFSClient_InvalidCredentials(t *testing.T) {
+	server := newTestLFSServer(t, map[string]string{})
+	defer server.Close()
+
+	gitContext := common_models.GitContext{
+		Project: "my-project",
+		Credentials: &common_models.GitCredentials{
+			User:      "my-user",
+			RemoteURL: server.URL + "/my-repo",
+			HttpsAuth: &apimodels.HttpsGitAuth{Token: "invalid-token"},
+		},
+	}
+
+	err := NewLFSClient().Upload(gitContext, testLFSPointer, strings.NewReader("content"))
+	require.ErrorIs(t, err, kerrors.ErrAuthorizationFailed)
+}
+
+func TestLFSClient_SSHNotSupported(t *testing.T) {
+	gitContext := common_models.GitContext{
+		Project: "my-project",
+		Credentials: &common_models.GitCredentials{
+			RemoteURL: "ssh://git@github.com/keptn/my-repo.git",
+			SshAuth:   &apimodels.SshGitAuth{PrivateKey: "key"},
+		},
+	}
+
+	_, err := NewLFSClient().Download(gitContext, testLFSPointer)
+	require.ErrorIs(t, err, kerrors.ErrLFSRequiresHTTPS)
+}
@@ -2,11 +2,18 @@ package common
 
 import (
 	"fmt"
+	"mime"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 const StageDirectoryName = ".keptn-stages"
 
+// SnapshotTagPrefix is the namespace of the tags that are created for project snapshots
+const SnapshotTagPrefix = "snapshots"
+
 func GetProjectConfigPath(project string) string {
 	return fmt.Sprintf("%s/%s", GetConfigDir(), project)
 }
@@ -30,3 +37,30 @@ func ensureDirectoryExists(path string) error {
 	}
 	return nil
 }
+
+// GetSnapshotTagName returns the name of the tag that marks the given branch as part of a snapshot
+func GetSnapshotTagName(snapshot, branch string) string {
+	return fmt.Sprintf("%s/%s/%s", SnapshotTagPrefix, snapshot, branch)
+}
+
+// ParseSnapshotTagName returns the snapshot and branch a tag has been created for.
+// If the tag does not belong to a snapshot, ok is false
+func ParseSnapshotTagName(tag string) (snapshot string, branch string, ok bool) {
+	if !strings.HasPrefix(tag, SnapshotTagPrefix+"/") {
+		return "", "", false
+	}
+	split := strings.SplitN(strings.TrimPrefix(tag, SnapshotTagPrefix+"/"), "/", 2)
+	if len(split) != 2 || split[0] == "" || split[1] == "" {
+		return "", "", false
+	}
+	return split[0], split[1], true
+}
+
+// DetectContentType determines the media type of a resource based on its file extension. If the extension is not known,
+// the type is derived from the first bytes of the content
+func DetectContentType(filename string, head []byte) string {
+	if contentType := mime.TypeByExtension(filepath.Ext(filename)); contentType != "" {
+		return contentType
+	}
+	return http.DetectContentType(head)
+}
@@ -81,3 +81,73 @@ func TestGetServiceConfigPath(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSnapshotTagName(t *testing.T) {
+	got := GetSnapshotTagName("release-1.0", "dev")
+	if got != "snapshots/release-1.0/dev" {
+		t.Errorf("GetSnapshotTagName() = %v, want %v", got, "snapshots/release-1.0/dev")
+	}
+}
+
+func TestParseSnapshotTagName(t *testing.T) {
+	tests := []struct {
+		name         string
+		tag          string
+		wantSnapshot string
+		wantBranch   string
+		wantOk       bool
+	}{
+		{
+			name:         "snapshot tag",
+			tag:          "snapshots/release-1.0/dev",
+			wantSnapshot: "release-1.0",
+			wantBranch:   "dev",
+			wantOk:       true,
+		},
+		{
+			name:         "snapshot tag of branch containing slash",
+			tag:          "snapshots/release-1.0/feature/x",
+			wantSnapshot: "release-1.0",
+			wantBranch:   "feature/x",
+			wantOk:       true,
+		},
+		{
+			name:   "other tag",
+			tag:    "v1.0.0",
+			wantOk: false,
+		},
+		{
+			name:   "snapshot tag without branch",
+			tag:    "snapshots/release-1.0",
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot, branch, ok := ParseSnapshotTagName(tt.tag)
+			if snapshot != tt.wantSnapshot || branch != tt.wantBranch || ok != tt.wantOk {
+				t.Errorf("ParseSnapshotTagName() = %v, %v, %v, want %v, %v, %v", snapshot, branch, ok, tt.wantSnapshot, tt.wantBranch, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestDetectContentType(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		head     []byte
+		want     string
+	}{
+		{name: "known extension", filename: "my-file.json", head: []byte("{}"), want: "application/json"},
+		{name: "detect from content", filename: "my-image", head: []byte("\x89PNG\x0D\x0A\x1A\x0A"), want: "image/png"},
+		{name: "unknown binary content", filename: "my-file", head: []byte{0x00, 0x01, 0x02}, want: "application/octet-stream"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectContentType(tt.filename, tt.head); got != tt.want {
+				t.Errorf("DetectContentType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
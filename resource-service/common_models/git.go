@@ -1,9 +1,11 @@
 package common_models
 
 import (
+	"fmt"
 	git2go "github.com/libgit2/git2go/v34"
 	"net/url"
 	"strings"
+	"time"
 
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
 
@@ -22,6 +24,24 @@ type Git2GoAuth struct {
 	ProxyOptions git2go.ProxyOptions
 }
 
+// GitTag contains the information about a tag of a project repository
+type GitTag struct {
+	Name     string
+	CommitID string
+	Message  string
+	Date     time.Time
+}
+
+// UpstreamSyncResult contains the outcome of synchronizing the local repository of a project with its upstream
+type UpstreamSyncResult struct {
+	// Revision is the commit ID the currently checked out branch points to after the synchronization
+	Revision string
+	// UpdatedBranches contains the branches that have been updated to the state of the upstream
+	UpdatedBranches []string
+	// ConflictingBranches contains the branches that have diverged from the upstream
+	ConflictingBranches []string
+}
+
 type AuthMethod struct {
 	GoGitAuth  transport.AuthMethod
 	Git2GoAuth Git2GoAuth
@@ -73,3 +93,17 @@ func (g GitCredentials) validateRemoteURLAndToken() error {
 	}
 	return nil
 }
+
+// LFSPointerVersion is the version of the Git LFS pointer file format
+const LFSPointerVersion = "https://git-lfs.github.com/spec/v1"
+
+// LFSPointer references an object that is stored on the Git LFS server of the upstream repository
+type LFSPointer struct {
+	OID  string
+	Size int64
+}
+
+// String returns the content of the pointer file that is committed to the repository instead of the object
+func (p LFSPointer) String() string {
+	return fmt.Sprintf("version %s\noid sha256:%s\nsize %d\n", LFSPointerVersion, p.OID, p.Size)
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/keptn/keptn/resource-service/common_models"
 	"github.com/sirupsen/logrus"
 )
@@ -8,9 +10,24 @@ import (
 var Global EnvConfig
 
 type EnvConfig struct {
-	LogLevel                         string `envconfig:"LOG_LEVEL" default:"info"`
-	DirectoryStageStructure          bool   `envconfig:"DIRECTORY_STAGE_STRUCTURE" default:"false"`
-	DefaultRemoteGitRepositoryBranch string `envconfig:"DEFAULT_REMOTE_GIT_BRANCH" default:"master"`
+	LogLevel                         string        `envconfig:"LOG_LEVEL" default:"info"`
+	DirectoryStageStructure          bool          `envconfig:"DIRECTORY_STAGE_STRUCTURE" default:"false"`
+	DefaultRemoteGitRepositoryBranch string        `envconfig:"DEFAULT_REMOTE_GIT_BRANCH" default:"master"`
+	ResourceValidationEnabled        bool          `envconfig:"RESOURCE_VALIDATION_ENABLED" default:"false"`
+	UpstreamSyncInterval             time.Duration `envconfig:"UPSTREAM_SYNC_INTERVAL" default:"0s"`
+	UpstreamSyncWebhookSecret        string        `envconfig:"UPSTREAM_SYNC_WEBHOOK_SECRET" default:""`
+	MaxResourceUploadSizeMB          int64         `envconfig:"MAX_RESOURCE_UPLOAD_SIZE_MB" default:"100"`
+	GitLFSEnabled                    bool          `envconfig:"GIT_LFS_ENABLED" default:"false"`
+}
+
+// UpstreamSyncEnabled returns true if the projects should be synchronized with their upstream in the background
+func (e EnvConfig) UpstreamSyncEnabled() bool {
+	return e.UpstreamSyncInterval > 0
+}
+
+// MaxResourceUploadSize returns the maximum size of a resource that can be uploaded as file in bytes
+func (e EnvConfig) MaxResourceUploadSize() int64 {
+	return e.MaxResourceUploadSizeMB * 1024 * 1024
 }
 
 func (e EnvConfig) RetrieveDefaultBranchFromEnv() string {
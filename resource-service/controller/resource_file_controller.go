@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/resource-service/handler"
+)
+
+type ResourceFileController struct {
+	ResourceFileHandler handler.IResourceFileHandler
+}
+
+func NewResourceFileController(resourceFileHandler handler.IResourceFileHandler) Controller {
+	return &ResourceFileController{ResourceFileHandler: resourceFileHandler}
+}
+
+func (controller ResourceFileController) Inject(apiGroup *gin.RouterGroup) {
+	apiGroup.GET("/project/:projectName/resource/:resourceURI/file", controller.ResourceFileHandler.DownloadResource)
+	apiGroup.PUT("/project/:projectName/resource/:resourceURI/file", controller.ResourceFileHandler.UploadResource)
+	apiGroup.GET("/project/:projectName/stage/:stageName/resource/:resourceURI/file", controller.ResourceFileHandler.DownloadResource)
+	apiGroup.PUT("/project/:projectName/stage/:stageName/resource/:resourceURI/file", controller.ResourceFileHandler.UploadResource)
+	apiGroup.GET("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/file", controller.ResourceFileHandler.DownloadResource)
+	apiGroup.PUT("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/file", controller.ResourceFileHandler.UploadResource)
+}
@@ -0,0 +1,21 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/resource-service/handler"
+)
+
+type SnapshotController struct {
+	SnapshotHandler handler.ISnapshotHandler
+}
+
+func NewSnapshotController(snapshotHandler handler.ISnapshotHandler) Controller {
+	return &SnapshotController{SnapshotHandler: snapshotHandler}
+}
+
+func (controller SnapshotController) Inject(apiGroup *gin.RouterGroup) {
+	apiGroup.POST("/project/:projectName/snapshot", controller.SnapshotHandler.CreateSnapshot)
+	apiGroup.GET("/project/:projectName/snapshot", controller.SnapshotHandler.GetSnapshots)
+	apiGroup.GET("/project/:projectName/snapshot/:snapshotName", controller.SnapshotHandler.GetSnapshot)
+	apiGroup.DELETE("/project/:projectName/snapshot/:snapshotName", controller.SnapshotHandler.DeleteSnapshot)
+}
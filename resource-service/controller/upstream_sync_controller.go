@@ -0,0 +1,19 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/resource-service/handler"
+)
+
+type UpstreamSyncController struct {
+	UpstreamSyncHandler handler.IUpstreamSyncHandler
+}
+
+func NewUpstreamSyncController(upstreamSyncHandler handler.IUpstreamSyncHandler) Controller {
+	return &UpstreamSyncController{UpstreamSyncHandler: upstreamSyncHandler}
+}
+
+func (controller UpstreamSyncController) Inject(apiGroup *gin.RouterGroup) {
+	apiGroup.POST("/project/:projectName/sync", controller.UpstreamSyncHandler.SyncProject)
+	apiGroup.GET("/project/:projectName/sync", controller.UpstreamSyncHandler.GetSyncStatus)
+}
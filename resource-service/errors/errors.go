@@ -41,6 +41,13 @@ var ErrResourceNotFound = New("resource not found")
 var ErrResourceAlreadyExists = New("resource already exists")
 var ErrResourceNotBase64Encoded = New("resource content is not base64 encoded")
 var ErrResourceInvalidResourceURI = New("invalid resource uri")
+var ErrResourceTooLarge = New("resource exceeds the maximum upload size")
+var ErrResourceFileMissing = New("multipart form does not contain a file")
+
+// Snapshot specific errors
+
+var ErrSnapshotNotFound = New("snapshot not found")
+var ErrSnapshotAlreadyExists = New("snapshot already exists")
 
 // Git specific errors
 
@@ -85,6 +92,11 @@ var ErrDeleteRefNotSupported = New("server does not support delete-refs")
 var ErrForceNeeded = New("some refs were not updated")
 var ErrExactSHA1NotSupported = New("server does not support exact SHA1 refspec")
 
+// Git LFS specific errors
+
+var ErrLFSRequiresHTTPS = New("git lfs is only supported for upstream repositories accessed via http(s)")
+var ErrLFSObjectNotFound = New("git lfs object not found")
+
 // Credential specific errors
 
 var ErrCredentialsNotFound = New("could not find upstream repository credentials")
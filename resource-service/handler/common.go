@@ -26,11 +26,15 @@ const pathParamProjectName = "projectName"
 const pathParamStageName = "stageName"
 const pathParamServiceName = "serviceName"
 const pathParamResourceURI = "resourceURI"
+const pathParamSnapshotName = "snapshotName"
 
 func OnAPIError(c *gin.Context, err error) {
 	logger.Infof("Could not complete request %s %s: %v", c.Request.Method, c.Request.RequestURI, err)
 
-	if check, resourceType := alreadyExists(err); check {
+	var validationErr *models.ValidationError
+	if errors.As(err, &validationErr) {
+		SetValidationErrorResponse(c, validationErr)
+	} else if check, resourceType := alreadyExists(err); check {
 		SetConflictErrorResponse(c, resourceType+" already exists")
 	} else if errors.Is(err, errors2.ErrProjectRepositoryNotEmpty) {
 		SetConflictErrorResponse(c, "Project already exists with an already initialized GIT repository")
@@ -44,6 +48,10 @@ func OnAPIError(c *gin.Context, err error) {
 		SetBadRequestErrorResponse(c, "Upstream repository not found")
 	} else if errors.Is(err, errors2.ErrRepositoryNotFound) {
 		SetNotFoundErrorResponse(c, "Upstream repository not found")
+	} else if errors.Is(err, errors2.ErrResourceTooLarge) {
+		SetRequestEntityTooLargeErrorResponse(c, "Resource exceeds the maximum upload size")
+	} else if errors.Is(err, errors2.ErrLFSRequiresHTTPS) {
+		SetFailedDependencyErrorResponse(c, "Git LFS is only supported for upstream repositories accessed via http(s)")
 	} else if check, resourceType := resourceNotFound(err); check {
 		SetNotFoundErrorResponse(c, resourceType+" not found")
 	} else {
@@ -59,6 +67,8 @@ func alreadyExists(err error) (bool, string) {
 		return true, "Stage"
 	} else if errors.Is(err, errors2.ErrServiceAlreadyExists) {
 		return true, "Service"
+	} else if errors.Is(err, errors2.ErrSnapshotAlreadyExists) {
+		return true, "Snapshot"
 	}
 	return false, ""
 }
@@ -72,6 +82,8 @@ func resourceNotFound(err error) (bool, string) {
 		return true, "Service"
 	} else if errors.Is(err, errors2.ErrResourceNotFound) {
 		return true, "Resource"
+	} else if errors.Is(err, errors2.ErrSnapshotNotFound) {
+		return true, "Snapshot"
 	}
 	return false, ""
 }
@@ -196,6 +208,29 @@ func SetBadRequestErrorResponse(c *gin.Context, msg string) {
 	})
 }
 
+func SetValidationErrorResponse(c *gin.Context, validationErr *models.ValidationError) {
+	c.JSON(http.StatusBadRequest, models.ValidationError{
+		Code:        http.StatusBadRequest,
+		Message:     validationErr.Message,
+		ResourceURI: validationErr.ResourceURI,
+		Field:       validationErr.Field,
+	})
+}
+
+func SetUnauthorizedErrorResponse(c *gin.Context, msg string) {
+	c.JSON(http.StatusUnauthorized, models.Error{
+		Code:    http.StatusUnauthorized,
+		Message: msg,
+	})
+}
+
+func SetRequestEntityTooLargeErrorResponse(c *gin.Context, msg string) {
+	c.JSON(http.StatusRequestEntityTooLarge, models.Error{
+		Code:    http.StatusRequestEntityTooLarge,
+		Message: msg,
+	})
+}
+
 func SetConflictErrorResponse(c *gin.Context, msg string) {
 	c.JSON(http.StatusConflict, models.Error{
 		Code:    http.StatusConflict,
@@ -167,7 +167,8 @@ func (ph *ProjectResourceHandler) UpdateProjectResources(c *gin.Context) {
 // @Produce      json
 // @Param        projectName                                 path    string  true  "The name of the project"
 // @Param        resourceURI                           path  string  true    "The path of the resource file"
-// @Param        gitCommitID  query     string  false  "The commit ID to be checked out"
+// @Param        gitCommitID  query     string  false  "The commit ID or tag to be checked out"
+// @Param        snapshot     query     string  false  "The name of the snapshot to be checked out"
 // @Success      200          {object}  models.GetResourceResponse
 // @Failure      400          {object}  models.Error  "Invalid payload"
 // @Failure      500          {object}  models.Error  "Internal error"
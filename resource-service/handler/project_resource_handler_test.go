@@ -182,6 +182,29 @@ func TestProjectResourceHandler_CreateProjectResources(t *testing.T) {
 			},
 			wantStatus: http.StatusFailedDependency,
 		},
+		{
+			name: "resource content validation failed",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{CreateResourcesFunc: func(project models.CreateResourcesParams) (*models.WriteResourceResponse, error) {
+					return nil, &models.ValidationError{ResourceURI: "resource.yaml", Field: "spec.stages", Message: "shipyard must contain at least one stage"}
+				}},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/resource", bytes.NewBuffer([]byte(createResourcesTestPayload))),
+			wantParams: &models.CreateResourcesParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				CreateResourcesPayload: models.CreateResourcesPayload{
+					Resources: []models.Resource{
+						{
+							ResourceURI:     "resource.yaml",
+							ResourceContent: "c3RyaW5n",
+						},
+					},
+				},
+			},
+			wantStatus: http.StatusBadRequest,
+		},
 		{
 			name: "internal error",
 			fields: fields{
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/gin-gonic/gin"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+)
+
+const formFieldFile = "file"
+const headerResourceVersion = "X-Keptn-Resource-Version"
+
+type IResourceFileHandler interface {
+	UploadResource(context *gin.Context)
+	DownloadResource(context *gin.Context)
+}
+
+type ResourceFileHandler struct {
+	ResourceFileManager IResourceFileManager
+	maxUploadSize       int64
+}
+
+// NewResourceFileHandler creates a new ResourceFileHandler. Uploads with more than maxUploadSize bytes are rejected
+func NewResourceFileHandler(resourceFileManager IResourceFileManager, maxUploadSize int64) *ResourceFileHandler {
+	return &ResourceFileHandler{
+		ResourceFileManager: resourceFileManager,
+		maxUploadSize:       maxUploadSize,
+	}
+}
+
+// UploadResource godoc
+// @Summary      Upload the content of a resource as file
+// @Description  Stores the content of the uploaded file as resource without requiring it to be base64 encoded. If the upstream repository tracks the path of the resource with Git LFS, the content is stored on the Git LFS server
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Resource File
+// @Security     ApiKeyAuth
+// @Accept       mpfd
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        stageName    path      string  false  "The name of the stage"
+// @Param        serviceName  path      string  false  "The name of the service"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Param        file         formData  file    true   "The content of the resource"
+// @Success      200          {object}  models.UploadResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Project, stage or service not found"
+// @Failure      413          {object}  models.Error  "Resource exceeds the maximum upload size"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/file [put]
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/file [put]
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/file [put]
+func (rh *ResourceFileHandler) UploadResource(c *gin.Context) {
+	params := &models.UploadResourceParams{
+		ResourceContext: getResourceContext(c),
+		ResourceURI:     c.Param(pathParamResourceURI),
+	}
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		SetBadRequestErrorResponse(c, kerrors.ErrMsgInvalidRequestFormat)
+		return
+	}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			SetBadRequestErrorResponse(c, kerrors.ErrResourceFileMissing.Error())
+			return
+		} else if err != nil {
+			SetBadRequestErrorResponse(c, kerrors.ErrMsgInvalidRequestFormat)
+			return
+		}
+		if part.FormName() == formFieldFile {
+			params.Content = &sizeLimitedReader{reader: part, remaining: rh.maxUploadSize}
+			break
+		}
+	}
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := rh.ResourceFileManager.UploadResource(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DownloadResource godoc
+// @Summary      Download the content of a resource as file
+// @Description  Streams the raw content of a resource. Resources stored in Git LFS are retrieved from the Git LFS server of the upstream repository
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Resource File
+// @Security     ApiKeyAuth
+// @Produce      octet-stream
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        stageName    path      string  false  "The name of the stage"
+// @Param        serviceName  path      string  false  "The name of the service"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Param        gitCommitID  query     string  false  "The commit ID or tag to be checked out"
+// @Param        snapshot     query     string  false  "The name of the snapshot to be checked out"
+// @Success      200          {file}    file
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/file [get]
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/file [get]
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/file [get]
+func (rh *ResourceFileHandler) DownloadResource(c *gin.Context) {
+	params := &models.GetResourceParams{
+		ResourceContext: getResourceContext(c),
+		ResourceURI:     c.Param(pathParamResourceURI),
+	}
+
+	if err := c.ShouldBindQuery(&params.GetResourceQuery); err != nil {
+		SetBadRequestErrorResponse(c, kerrors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	resourceFile, err := rh.ResourceFileManager.DownloadResource(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+	defer resourceFile.Content.Close()
+
+	fileName := params.ResourceURI
+	if unescapedResourceURI, err := url.QueryUnescape(params.ResourceURI); err == nil {
+		fileName = unescapedResourceURI
+	}
+	c.DataFromReader(http.StatusOK, resourceFile.Size, resourceFile.ContentType, resourceFile.Content, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(fileName)}),
+		headerResourceVersion: resourceFile.Metadata.Version,
+	})
+}
+
+// getResourceContext returns the project, stage and service a resource belongs to, based on the path parameters of the request
+func getResourceContext(c *gin.Context) models.ResourceContext {
+	resourceContext := models.ResourceContext{
+		Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+	}
+	if stageName := c.Param(pathParamStageName); stageName != "" {
+		resourceContext.Stage = &models.Stage{StageName: stageName}
+	}
+	if serviceName := c.Param(pathParamServiceName); serviceName != "" {
+		resourceContext.Service = &models.Service{ServiceName: serviceName}
+	}
+	return resourceContext
+}
+
+// sizeLimitedReader fails with ErrResourceTooLarge as soon as more than the allowed number of bytes have been read
+type sizeLimitedReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+func (r *sizeLimitedReader) Read(p []byte) (int, error) {
+	if r.remaining < 0 {
+		return 0, kerrors.ErrResourceTooLarge
+	}
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+	n, err := r.reader.Read(p)
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		return n, kerrors.ErrResourceTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	errors2 "github.com/keptn/keptn/resource-service/errors"
+	handler_mock "github.com/keptn/keptn/resource-service/handler/fake"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+func newUploadRequest(t *testing.T, url string, fieldName string, content string) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(fieldName, "file.bin")
+	require.Nil(t, err)
+	_, err = io.WriteString(part, content)
+	require.Nil(t, err)
+	require.Nil(t, writer.Close())
+
+	request := httptest.NewRequest(http.MethodPut, url, body)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	return request
+}
+
+func TestResourceFileHandler_UploadResource(t *testing.T) {
+	drainUpload := func(params models.UploadResourceParams) (*models.UploadResourceResponse, error) {
+		data, err := ioutil.ReadAll(params.Content)
+		if err != nil {
+			return nil, err
+		}
+		return &models.UploadResourceResponse{Size: int64(len(data))}, nil
+	}
+	tests := []struct {
+		name          string
+		maxUploadSize int64
+		request       *http.Request
+		wantParams    *models.UploadResourceParams
+		wantStatus    int
+	}{
+		{
+			name:          "upload project resource",
+			maxUploadSize: 1024,
+			request:       newUploadRequest(t, "/project/my-project/resource/file.bin/file", "file", "content"),
+			wantParams: &models.UploadResourceParams{
+				ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+				ResourceURI:     "file.bin",
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "upload service resource",
+			maxUploadSize: 1024,
+			request:       newUploadRequest(t, "/project/my-project/stage/my-stage/service/my-service/resource/dir%2Ffile.bin/file", "file", "content"),
+			wantParams: &models.UploadResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "dir%2Ffile.bin",
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "resource too large",
+			maxUploadSize: 4,
+			request:       newUploadRequest(t, "/project/my-project/resource/file.bin/file", "file", "content"),
+			wantParams: &models.UploadResourceParams{
+				ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+				ResourceURI:     "file.bin",
+			},
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:          "file field missing",
+			maxUploadSize: 1024,
+			request:       newUploadRequest(t, "/project/my-project/resource/file.bin/file", "other", "content"),
+			wantStatus:    http.StatusBadRequest,
+		},
+		{
+			name:          "no multipart request",
+			maxUploadSize: 1024,
+			request:       httptest.NewRequest(http.MethodPut, "/project/my-project/resource/file.bin/file", strings.NewReader("content")),
+			wantStatus:    http.StatusBadRequest,
+		},
+		{
+			name:          "invalid resource URI",
+			maxUploadSize: 1024,
+			request:       newUploadRequest(t, "/project/my-project/resource/..%2Ffile.bin/file", "file", "content"),
+			wantStatus:    http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceFileManager := &handler_mock.IResourceFileManagerMock{UploadResourceFunc: drainUpload}
+			rh := NewResourceFileHandler(resourceFileManager, tt.maxUploadSize)
+
+			router := gin.Default()
+			router.UseRawPath = true
+			router.UnescapePathValues = false
+			router.PUT("/project/:projectName/resource/:resourceURI/file", rh.UploadResource)
+			router.PUT("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/file", rh.UploadResource)
+
+			resp := performRequest(router, tt.request)
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantParams != nil {
+				require.Len(t, resourceFileManager.UploadResourceCalls(), 1)
+				params := resourceFileManager.UploadResourceCalls()[0].Params
+				require.Equal(t, tt.wantParams.ResourceContext, params.ResourceContext)
+				require.Equal(t, tt.wantParams.ResourceURI, params.ResourceURI)
+			} else {
+				require.Empty(t, resourceFileManager.UploadResourceCalls())
+			}
+		})
+	}
+}
+
+func TestResourceFileHandler_DownloadResource(t *testing.T) {
+	tests := []struct {
+		name         string
+		downloadFunc func(params models.GetResourceParams) (*models.ResourceFile, error)
+		url          string
+		wantParams   *models.GetResourceParams
+		wantStatus   int
+		wantHeaders  map[string]string
+		wantContent  string
+	}{
+		{
+			name: "download stage resource",
+			downloadFunc: func(params models.GetResourceParams) (*models.ResourceFile, error) {
+				return &models.ResourceFile{
+					Content:     ioutil.NopCloser(strings.NewReader("content")),
+					Size:        7,
+					ContentType: "application/octet-stream",
+					Metadata:    models.Version{Version: "my-revision"},
+				}, nil
+			},
+			url: "/project/my-project/stage/my-stage/resource/dir%2Ffile.bin/file?gitCommitID=my-commit",
+			wantParams: &models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+				},
+				ResourceURI:      "dir%2Ffile.bin",
+				GetResourceQuery: models.GetResourceQuery{GitCommitID: "my-commit"},
+			},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Content-Type":             "application/octet-stream",
+				"Content-Length":           "7",
+				"Content-Disposition":      "attachment; filename=file.bin",
+				"X-Keptn-Resource-Version": "my-revision",
+			},
+			wantContent: "content",
+		},
+		{
+			name: "resource not found",
+			downloadFunc: func(params models.GetResourceParams) (*models.ResourceFile, error) {
+				return nil, errors2.ErrResourceNotFound
+			},
+			url: "/project/my-project/stage/my-stage/resource/file.bin/file",
+			wantParams: &models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+				},
+				ResourceURI: "file.bin",
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "invalid snapshot name",
+			url:        "/project/my-project/stage/my-stage/resource/file.bin/file?snapshot=.invalid",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceFileManager := &handler_mock.IResourceFileManagerMock{DownloadResourceFunc: tt.downloadFunc}
+			rh := NewResourceFileHandler(resourceFileManager, 1024)
+
+			router := gin.Default()
+			router.UseRawPath = true
+			router.UnescapePathValues = false
+			router.GET("/project/:projectName/stage/:stageName/resource/:resourceURI/file", rh.DownloadResource)
+
+			resp := performRequest(router, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+			for header, value := range tt.wantHeaders {
+				require.Equal(t, value, resp.Header().Get(header))
+			}
+			if tt.wantContent != "" {
+				require.Equal(t, tt.wantContent, resp.Body.String())
+			}
+
+			if tt.wantParams != nil {
+				require.Len(t, resourceFileManager.DownloadResourceCalls(), 1)
+				require.Equal(t, *tt.wantParams, resourceFileManager.DownloadResourceCalls()[0].Params)
+			} else {
+				require.Empty(t, resourceFileManager.DownloadResourceCalls())
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/common/retry"
+	"github.com/keptn/keptn/resource-service/common"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/keptn/keptn/resource-service/validation"
+	logger "github.com/sirupsen/logrus"
+)
+
+// number of bytes that are inspected for detecting the content type of a resource
+const contentTypeSniffLength = 512
+
+// IResourceFileManager provides an interface for streaming the raw content of resources from and to the repository
+//
+//go:generate moq -pkg handler_mock -skip-ensure -out ./fake/resource_file_manager_mock.go . IResourceFileManager
+type IResourceFileManager interface {
+	UploadResource(params models.UploadResourceParams) (*models.UploadResourceResponse, error)
+	DownloadResource(params models.GetResourceParams) (*models.ResourceFile, error)
+}
+
+// ResourceFileManager stores large and binary resources without requiring them to be held in memory.
+// If an LFS client is provided, resources whose path is tracked by Git LFS in the .gitattributes file of the
+// repository are uploaded to the LFS server of the upstream, and only their pointer file is committed
+type ResourceFileManager struct {
+	resourceManager ResourceManager
+	lfsClient       common.ILFSClient
+}
+
+// NewResourceFileManager creates a new ResourceFileManager. If lfsClient is nil, resources are always committed
+// to the repository directly. Uploaded resources of well-known Keptn file types are checked by the resourceValidator
+func NewResourceFileManager(git common.IGit, credentialReader common.CredentialReader, fileSystem common.IFileSystem, configurationContext IConfigurationContext, resourceValidator validation.IResourceValidator, lfsClient common.ILFSClient, pullBeforeRead bool) *ResourceFileManager {
+	return &ResourceFileManager{
+		resourceManager: ResourceManager{
+			git:                  git,
+			credentialReader:     credentialReader,
+			fileSystem:           fileSystem,
+			configurationContext: configurationContext,
+			resourceValidator:    resourceValidator,
+			pullBeforeRead:       pullBeforeRead,
+		},
+		lfsClient: lfsClient,
+	}
+}
+
+func (m ResourceFileManager) UploadResource(params models.UploadResourceParams) (*models.UploadResourceResponse, error) {
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	// the content is buffered in a temporary file before acquiring the project lock,
+	// so that slow uploads do not block other operations on the project
+	hash := sha256.New()
+	head := &headBuffer{limit: contentTypeSniffLength}
+	tmpFile, size, err := m.resourceManager.fileSystem.WriteTempFile(io.TeeReader(params.Content, io.MultiWriter(hash, head)))
+	if err != nil {
+		return nil, fmt.Errorf("could not receive content of resource %s: %w", unescapedResourceName, err)
+	}
+	defer func() {
+		if err := m.resourceManager.fileSystem.DeleteFile(tmpFile); err != nil {
+			logger.Errorf("Could not delete temporary file %s: %v", tmpFile, err)
+		}
+	}()
+
+	if err := m.validateResource(unescapedResourceName, tmpFile); err != nil {
+		return nil, err
+	}
+
+	pointer := common_models.LFSPointer{OID: hex.EncodeToString(hash.Sum(nil)), Size: size}
+	result := &models.UploadResourceResponse{
+		Size:        size,
+		ContentType: common.DetectContentType(unescapedResourceName, head.Bytes()),
+	}
+
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := m.resourceManager.establishContext(params.Project, params.Stage, params.Service)
+	if err != nil {
+		return nil, err
+	}
+	resourcePath := configPath + "/" + unescapedResourceName
+
+	var resultErr error
+	lfsObjectUploaded := false
+	_ = retry.Retry(func() error {
+		if err := m.resourceManager.git.Pull(*gitContext); err != nil {
+			resultErr = err
+			return nil
+		}
+
+		// the .gitattributes file is read after pulling, since it might have been changed in the upstream
+		result.StoredInLFS = m.isLFSTracked(gitContext.Project, resourcePath)
+		if result.StoredInLFS {
+			if !lfsObjectUploaded {
+				if err := m.uploadLFSObject(*gitContext, pointer, tmpFile); err != nil {
+					resultErr = err
+					return nil
+				}
+				lfsObjectUploaded = true
+			}
+			err = m.resourceManager.fileSystem.WriteFile(resourcePath, []byte(pointer.String()))
+		} else {
+			err = m.storeFile(tmpFile, resourcePath)
+		}
+		if err != nil {
+			resultErr = err
+			return nil
+		}
+
+		commit, err := m.resourceManager.stageAndCommit(gitContext, "Uploaded resource")
+		if err != nil {
+			if errors.Is(err, kerrors.ErrNonFastForwardUpdate) || errors.Is(err, kerrors.ErrForceNeeded) {
+				return err
+			}
+			resultErr = err
+			return nil
+		}
+		result.WriteResourceResponse = *commit
+		resultErr = nil
+		return nil
+	}, retry.NumberOfRetries(5), retry.DelayBetweenRetries(1*time.Second))
+
+	if resultErr != nil {
+		return nil, resultErr
+	}
+	return result, nil
+}
+
+func (m ResourceFileManager) DownloadResource(params models.GetResourceParams) (*models.ResourceFile, error) {
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	content, size, revision, gitContext, err := m.openResource(params, unescapedResourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	// the first bytes of the resource are needed to detect pointer files and the content type
+	reader := bufio.NewReaderSize(content, common.MaxLFSPointerSize)
+	head, _ := reader.Peek(common.MaxLFSPointerSize)
+	resourceFile := &models.ResourceFile{
+		Content: readCloser{Reader: reader, Closer: content},
+		Size:    size,
+		Metadata: models.Version{
+			UpstreamURL: gitContext.Credentials.RemoteURL,
+			Version:     revision,
+		},
+	}
+
+	if m.lfsClient != nil {
+		if pointer, ok := common.ParseLFSPointer(head); ok && int64(len(head)) == size {
+			content.Close()
+			lfsContent, err := m.lfsClient.Download(*gitContext, *pointer)
+			if err != nil {
+				if errors.Is(err, kerrors.ErrLFSObjectNotFound) {
+					return nil, kerrors.ErrResourceNotFound
+				}
+				return nil, fmt.Errorf("could not download resource %s from git lfs: %w", unescapedResourceName, err)
+			}
+			reader = bufio.NewReaderSize(lfsContent, contentTypeSniffLength)
+			head, _ = reader.Peek(contentTypeSniffLength)
+			resourceFile.Content = readCloser{Reader: reader, Closer: lfsContent}
+			resourceFile.Size = pointer.Size
+		}
+	}
+
+	if len(head) > contentTypeSniffLength {
+		head = head[:contentTypeSniffLength]
+	}
+	resourceFile.ContentType = common.DetectContentType(unescapedResourceName, head)
+	return resourceFile, nil
+}
+
+// openResource opens the resource either from the working tree or, if a specific revision has been requested,
+// from the history of the repository. The project is only locked until the resource has been opened
+func (m ResourceFileManager) openResource(params models.GetResourceParams, resourceName string) (io.ReadCloser, int64, string, *common_models.GitContext, error) {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := m.resourceManager.establishContext(params.Project, params.Stage, params.Service)
+	if err != nil {
+		return nil, 0, "", nil, err
+	}
+
+	if params.Snapshot != "" || isRevisionRequested(params.GitCommitID) {
+		fileContent, revision, err := m.resourceManager.readResourceContent(gitContext, params, configPath, resourceName)
+		if err != nil {
+			return nil, 0, "", nil, err
+		}
+		return ioutil.NopCloser(bytes.NewReader(fileContent)), int64(len(fileContent)), revision, gitContext, nil
+	}
+
+	if m.resourceManager.pullBeforeRead {
+		if err := m.resourceManager.git.Pull(*gitContext); err != nil {
+			return nil, 0, "", nil, err
+		}
+	}
+	content, size, err := m.resourceManager.fileSystem.OpenFile(configPath + "/" + resourceName)
+	if err != nil {
+		return nil, 0, "", nil, err
+	}
+	revision, err := m.resourceManager.git.GetCurrentRevision(*gitContext)
+	if err != nil {
+		content.Close()
+		return nil, 0, "", nil, err
+	}
+	return content, size, revision, gitContext, nil
+}
+
+// validateResource checks the content of well-known Keptn file types before it is committed. The content of other
+// resources is not read, since it might be large
+func (m ResourceFileManager) validateResource(resourceName string, tmpFile string) error {
+	if !validation.IsWellKnownResource(resourceName) {
+		return nil
+	}
+	content, err := m.resourceManager.fileSystem.ReadFile(tmpFile)
+	if err != nil {
+		return fmt.Errorf("could not read content of resource %s: %w", resourceName, err)
+	}
+	return m.resourceManager.resourceValidator.Validate(resourceName, models.ResourceContent(base64.StdEncoding.EncodeToString(content)))
+}
+
+func (m ResourceFileManager) storeFile(tmpFile string, resourcePath string) error {
+	if err := m.resourceManager.fileSystem.CopyFile(tmpFile, resourcePath); err != nil {
+		return err
+	}
+	if common.IsHelmChartPath(resourcePath) {
+		return m.resourceManager.fileSystem.WriteHelmChart(resourcePath)
+	}
+	return nil
+}
+
+func (m ResourceFileManager) uploadLFSObject(gitContext common_models.GitContext, pointer common_models.LFSPointer, tmpFile string) error {
+	content, _, err := m.resourceManager.fileSystem.OpenFile(tmpFile)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	if err := m.lfsClient.Upload(gitContext, pointer, content); err != nil {
+		return fmt.Errorf("could not upload resource to git lfs: %w", err)
+	}
+	return nil
+}
+
+// isLFSTracked checks whether the resource should be stored in Git LFS. Helm charts are never stored in Git LFS,
+// since they are unpacked into the repository
+func (m ResourceFileManager) isLFSTracked(project string, resourcePath string) bool {
+	if m.lfsClient == nil || common.IsHelmChartPath(resourcePath) {
+		return false
+	}
+	projectPath := common.GetProjectConfigPath(project)
+	gitAttributesPath := projectPath + "/.gitattributes"
+	if !m.resourceManager.fileSystem.FileExists(gitAttributesPath) {
+		return false
+	}
+	gitAttributes, err := m.resourceManager.fileSystem.ReadFile(gitAttributesPath)
+	if err != nil {
+		logger.Errorf("Could not read .gitattributes of project %s: %v", project, err)
+		return false
+	}
+	return common.IsLFSTracked(gitAttributes, strings.TrimPrefix(resourcePath, projectPath+"/"))
+}
+
+// headBuffer keeps the first bytes that are written to it and discards the rest
+type headBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *headBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - b.Len(); remaining > 0 {
+		if len(p) > remaining {
+			b.Buffer.Write(p[:remaining])
+		} else {
+			b.Buffer.Write(p)
+		}
+	}
+	return len(p), nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,404 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	common_mock "github.com/keptn/keptn/resource-service/common/fake"
+	"github.com/keptn/keptn/resource-service/common_models"
+	errors2 "github.com/keptn/keptn/resource-service/errors"
+	handler_mock "github.com/keptn/keptn/resource-service/handler/fake"
+	"github.com/keptn/keptn/resource-service/models"
+	validation_mock "github.com/keptn/keptn/resource-service/validation/fake"
+	"github.com/stretchr/testify/require"
+)
+
+const testResourceFileContent = "my binary content"
+const testGitAttributes = "*.bin filter=lfs diff=lfs merge=lfs -text\n"
+
+type testResourceFileManagerFields struct {
+	git               *common_mock.IGitMock
+	credentialReader  *common_mock.CredentialReaderMock
+	fileSystem        *common_mock.IFileSystemMock
+	stageContext      *handler_mock.IConfigurationContextMock
+	resourceValidator *validation_mock.IResourceValidatorMock
+	lfsClient         *common_mock.ILFSClientMock
+	files             map[string][]byte
+	lfsObjects        map[string][]byte
+}
+
+func TestResourceFileManager_UploadResource(t *testing.T) {
+	fields := getTestResourceFileManagerFields()
+	m := NewResourceFileManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, fields.lfsClient, true)
+
+	result, err := m.UploadResource(models.UploadResourceParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		ResourceURI:     "file.txt",
+		Content:         strings.NewReader(testResourceFileContent),
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.UploadResourceResponse{
+		WriteResourceResponse: models.WriteResourceResponse{
+			CommitID: "my-revision",
+			Metadata: models.Version{UpstreamURL: "https://my-repo", Version: "my-revision"},
+		},
+		Size:        int64(len(testResourceFileContent)),
+		ContentType: "text/plain; charset=utf-8",
+		StoredInLFS: false,
+	}, result)
+
+	require.Len(t, fields.fileSystem.CopyFileCalls(), 1)
+	require.Equal(t, testConfigDir+"/file.txt", fields.fileSystem.CopyFileCalls()[0].Target)
+	require.Equal(t, testResourceFileContent, string(fields.files[testConfigDir+"/file.txt"]))
+	require.Empty(t, fields.lfsClient.UploadCalls())
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	// only well-known Keptn file types are validated
+	require.Empty(t, fields.resourceValidator.ValidateCalls())
+
+	// the temporary file must be removed after the upload
+	require.Len(t, fields.fileSystem.DeleteFileCalls(), 1)
+	require.Equal(t, "/tmp/upload-1", fields.fileSystem.DeleteFileCalls()[0].Path)
+}
+
+func TestResourceFileManager_UploadResource_ValidationFails(t *testing.T) {
+	fields := getTestResourceFileManagerFields()
+	fields.resourceValidator.ValidateFunc = func(resourceURI string, content models.ResourceContent) error {
+		return &models.ValidationError{ResourceURI: resourceURI, Field: "spec.stages", Message: "shipyard must contain at least one stage"}
+	}
+	m := NewResourceFileManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, fields.lfsClient, true)
+
+	result, err := m.UploadResource(models.UploadResourceParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		ResourceURI:     "shipyard.yaml",
+		Content:         strings.NewReader(testResourceFileContent),
+	})
+
+	validationErr := &models.ValidationError{}
+	require.ErrorAs(t, err, &validationErr)
+	require.Equal(t, "spec.stages", validationErr.Field)
+	require.Nil(t, result)
+
+	require.Len(t, fields.resourceValidator.ValidateCalls(), 1)
+	require.Equal(t, "shipyard.yaml", fields.resourceValidator.ValidateCalls()[0].ResourceURI)
+	require.Equal(t, models.ResourceContent(base64.StdEncoding.EncodeToString([]byte(testResourceFileContent))), fields.resourceValidator.ValidateCalls()[0].Content)
+
+	require.Empty(t, fields.stageContext.EstablishCalls())
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+	require.Len(t, fields.fileSystem.DeleteFileCalls(), 1)
+}
+
+func TestResourceFileManager_UploadResource_HelmChart(t *testing.T) {
+	fields := getTestResourceFileManagerFields()
+	m := NewResourceFileManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, fields.lfsClient, true)
+
+	_, err := m.UploadResource(models.UploadResourceParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		ResourceURI:     "helm%2Fchart.tgz",
+		Content:         strings.NewReader(testResourceFileContent),
+	})
+
+	require.Nil(t, err)
+	require.Len(t, fields.fileSystem.WriteHelmChartCalls(), 1)
+	require.Equal(t, testConfigDir+"/helm/chart.tgz", fields.fileSystem.WriteHelmChartCalls()[0].Path)
+}
+
+func TestResourceFileManager_UploadResource_LFS(t *testing.T) {
+	fields := getTestResourceFileManagerFields()
+	fields.files["/data/config/my-project/.gitattributes"] = []byte(testGitAttributes)
+	m := NewResourceFileManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, fields.lfsClient, true)
+
+	result, err := m.UploadResource(models.UploadResourceParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		ResourceURI:     "file.bin",
+		Content:         strings.NewReader(testResourceFileContent),
+	})
+
+	require.Nil(t, err)
+	require.True(t, result.StoredInLFS)
+
+	hash := sha256.Sum256([]byte(testResourceFileContent))
+	expectedPointer := common_models.LFSPointer{OID: hex.EncodeToString(hash[:]), Size: int64(len(testResourceFileContent))}
+
+	require.Len(t, fields.lfsClient.UploadCalls(), 1)
+	require.Equal(t, expectedPointer, fields.lfsClient.UploadCalls()[0].Pointer)
+	require.Equal(t, testResourceFileContent, string(fields.lfsObjects[expectedPointer.OID]))
+	require.Empty(t, fields.fileSystem.CopyFileCalls())
+	require.Equal(t, expectedPointer.String(), string(fields.files[testConfigDir+"/file.bin"]))
+}
+
+func TestResourceFileManager_UploadResource_LFSDisabled(t *testing.T) {
+	fields := getTestResourceFileManagerFields()
+	fields.files["/data/config/my-project/.gitattributes"] = []byte(testGitAttributes)
+	m := NewResourceFileManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, nil, true)
+
+	result, err := m.UploadResource(models.UploadResourceParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		ResourceURI:     "file.bin",
+		Content:         strings.NewReader(testResourceFileContent),
+	})
+
+	require.Nil(t, err)
+	require.False(t, result.StoredInLFS)
+	require.Equal(t, testResourceFileContent, string(fields.files[testConfigDir+"/file.bin"]))
+}
+
+func TestResourceFileManager_UploadResource_TooLarge(t *testing.T) {
+	fields := getTestResourceFileManagerFields()
+	m := NewResourceFileManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, fields.lfsClient, true)
+
+	result, err := m.UploadResource(models.UploadResourceParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		ResourceURI:     "file.txt",
+		Content:         &sizeLimitedReader{reader: strings.NewReader(testResourceFileContent), remaining: 4},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResourceTooLarge)
+	require.Nil(t, result)
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+}
+
+func TestResourceFileManager_UploadResource_ProjectNotFound(t *testing.T) {
+	fields := getTestResourceFileManagerFields()
+	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
+		return false
+	}
+	m := NewResourceFileManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, fields.lfsClient, true)
+
+	result, err := m.UploadResource(models.UploadResourceParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		ResourceURI:     "file.txt",
+		Content:         strings.NewReader(testResourceFileContent),
+	})
+
+	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
+	require.Nil(t, result)
+	// the temporary file must also be removed if the upload fails
+	require.Len(t, fields.fileSystem.DeleteFileCalls(), 1)
+}
+
+func TestResourceFileManager_DownloadResource(t *testing.T) {
+	fields := getTestResourceFileManagerFields()
+	fields.files[testConfigDir+"/file.txt"] = []byte(testResourceFileContent)
+	m := NewResourceFileManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, fields.lfsClient, true)
+
+	result, err := m.DownloadResource(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		ResourceURI:     "file.txt",
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, int64(len(testResourceFileContent)), result.Size)
+	require.Equal(t, "text/plain; charset=utf-8", result.ContentType)
+	require.Equal(t, models.Version{UpstreamURL: "https://my-repo", Version: "my-revision"}, result.Metadata)
+	require.Equal(t, testResourceFileContent, readResourceFile(t, result))
+	require.Len(t, fields.git.PullCalls(), 1)
+	require.Empty(t, fields.lfsClient.DownloadCalls())
+}
+
+func TestResourceFileManager_DownloadResource_WithoutPull(t *testing.T) {
+	fields := getTestResourceFileManagerFields()
+	fields.files[testConfigDir+"/file.txt"] = []byte(testResourceFileContent)
+	m := NewResourceFileManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, fields.lfsClient, false)
+
+	result, err := m.DownloadResource(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		ResourceURI:     "file.txt",
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, testResourceFileContent, readResourceFile(t, result))
+	require.Empty(t, fields.git.PullCalls())
+}
+
+func TestResourceFileManager_DownloadResource_DetectContentType(t *testing.T) {
+	fields := getTestResourceFileManagerFields()
+	pngHeader := []byte("\x89PNG\x0D\x0A\x1A\x0A")
+	fields.files[testConfigDir+"/image"] = pngHeader
+	m := NewResourceFileManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, fields.lfsClient, true)
+
+	result, err := m.DownloadResource(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		ResourceURI:     "image",
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, "image/png", result.ContentType)
+	require.Equal(t, string(pngHeader), readResourceFile(t, result))
+}
+
+func TestResourceFileManager_DownloadResource_LFS(t *testing.T) {
+	fields := getTestResourceFileManagerFields()
+	hash := sha256.Sum256([]byte(testResourceFileContent))
+	pointer := common_models.LFSPointer{OID: hex.EncodeToString(hash[:]), Size: int64(len(testResourceFileContent))}
+	fields.files[testConfigDir+"/file.bin"] = []byte(pointer.String())
+	m := NewResourceFileManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, fields.lfsClient, true)
+
+	result, err := m.DownloadResource(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		ResourceURI:     "file.bin",
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, pointer.Size, result.Size)
+	require.Equal(t, testResourceFileContent, readResourceFile(t, result))
+	require.Len(t, fields.lfsClient.DownloadCalls(), 1)
+	require.Equal(t, pointer, fields.lfsClient.DownloadCalls()[0].Pointer)
+}
+
+func TestResourceFileManager_DownloadResource_LFSObjectNotFound(t *testing.T) {
+	fields := getTestResourceFileManagerFields()
+	pointer := common_models.LFSPointer{OID: strings.Repeat("a", 64), Size: 10}
+	fields.files[testConfigDir+"/file.bin"] = []byte(pointer.String())
+	fields.lfsClient.DownloadFunc = func(gitContext common_models.GitContext, pointer common_models.LFSPointer) (io.ReadCloser, error) {
+		return nil, errors2.ErrLFSObjectNotFound
+	}
+	m := NewResourceFileManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, fields.lfsClient, true)
+
+	result, err := m.DownloadResource(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		ResourceURI:     "file.bin",
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+	require.Nil(t, result)
+}
+
+func TestResourceFileManager_DownloadResource_GitCommitID(t *testing.T) {
+	fields := getTestResourceFileManagerFields()
+	m := NewResourceFileManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, fields.lfsClient, true)
+
+	result, err := m.DownloadResource(models.GetResourceParams{
+		ResourceContext:  models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		ResourceURI:      "file.txt",
+		GetResourceQuery: models.GetResourceQuery{GitCommitID: "my-commit"},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, "file-content", readResourceFile(t, result))
+	require.Equal(t, "my-commit", result.Metadata.Version)
+	require.Len(t, fields.git.GetFileRevisionCalls(), 1)
+	require.Equal(t, "my-commit", fields.git.GetFileRevisionCalls()[0].Revision)
+	require.Empty(t, fields.fileSystem.OpenFileCalls())
+}
+
+func TestResourceFileManager_DownloadResource_NotFound(t *testing.T) {
+	fields := getTestResourceFileManagerFields()
+	m := NewResourceFileManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, fields.lfsClient, true)
+
+	result, err := m.DownloadResource(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		ResourceURI:     "file.txt",
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+	require.Nil(t, result)
+}
+
+func readResourceFile(t *testing.T, resourceFile *models.ResourceFile) string {
+	defer resourceFile.Content.Close()
+	content, err := ioutil.ReadAll(resourceFile.Content)
+	require.Nil(t, err)
+	return string(content)
+}
+
+// getTestResourceFileManagerFields returns mocks that keep the written files in memory
+func getTestResourceFileManagerFields() testResourceFileManagerFields {
+	files := map[string][]byte{}
+	lfsObjects := map[string][]byte{}
+	return testResourceFileManagerFields{
+		files:      files,
+		lfsObjects: lfsObjects,
+		git: &common_mock.IGitMock{
+			GetCurrentRevisionFunc: func(gitContext common_models.GitContext) (string, error) { return "my-revision", nil },
+			GetFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+				return []byte("file-content"), nil
+			},
+			ProjectExistsFunc:     func(gitContext common_models.GitContext) bool { return true },
+			PullFunc:              func(gitContext common_models.GitContext) error { return nil },
+			StageAndCommitAllFunc: func(gitContext common_models.GitContext, message string) (string, error) { return "my-revision", nil },
+		},
+		credentialReader: &common_mock.CredentialReaderMock{
+			GetCredentialsFunc: func(project string) (*common_models.GitCredentials, error) {
+				return &common_models.GitCredentials{
+					User:      "user",
+					HttpsAuth: &apimodels.HttpsGitAuth{Token: "token"},
+					RemoteURL: "https://my-repo",
+				}, nil
+			},
+		},
+		fileSystem: &common_mock.IFileSystemMock{
+			WriteTempFileFunc: func(content io.Reader) (string, int64, error) {
+				data, err := ioutil.ReadAll(content)
+				if err != nil {
+					return "", 0, err
+				}
+				files["/tmp/upload-1"] = data
+				return "/tmp/upload-1", int64(len(data)), nil
+			},
+			CopyFileFunc: func(source string, target string) error {
+				files[target] = files[source]
+				return nil
+			},
+			WriteFileFunc: func(path string, content []byte) error {
+				files[path] = content
+				return nil
+			},
+			WriteHelmChartFunc: func(path string) error {
+				return nil
+			},
+			DeleteFileFunc: func(path string) error {
+				delete(files, path)
+				return nil
+			},
+			FileExistsFunc: func(path string) bool {
+				_, ok := files[path]
+				return ok
+			},
+			ReadFileFunc: func(filename string) ([]byte, error) {
+				content, ok := files[filename]
+				if !ok {
+					return nil, errors2.ErrResourceNotFound
+				}
+				return content, nil
+			},
+			OpenFileFunc: func(filename string) (io.ReadCloser, int64, error) {
+				content, ok := files[filename]
+				if !ok {
+					return nil, 0, errors2.ErrResourceNotFound
+				}
+				return ioutil.NopCloser(bytes.NewReader(content)), int64(len(content)), nil
+			},
+		},
+		stageContext: &handler_mock.IConfigurationContextMock{
+			EstablishFunc: func(params common_models.ConfigurationContextParams) (string, error) {
+				return testConfigDir, nil
+			},
+		},
+		resourceValidator: &validation_mock.IResourceValidatorMock{
+			ValidateFunc: func(resourceURI string, content models.ResourceContent) error {
+				return nil
+			},
+		},
+		lfsClient: &common_mock.ILFSClientMock{
+			UploadFunc: func(gitContext common_models.GitContext, pointer common_models.LFSPointer, content io.Reader) error {
+				data, err := ioutil.ReadAll(content)
+				if err != nil {
+					return err
+				}
+				lfsObjects[pointer.OID] = data
+				return nil
+			},
+			DownloadFunc: func(gitContext common_models.GitContext, pointer common_models.LFSPointer) (io.ReadCloser, error) {
+				return ioutil.NopCloser(strings.NewReader(testResourceFileContent)), nil
+			},
+		},
+	}
+}
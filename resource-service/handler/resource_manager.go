@@ -13,6 +13,7 @@ import (
 	"github.com/keptn/keptn/resource-service/common_models"
 	kerrors "github.com/keptn/keptn/resource-service/errors"
 	"github.com/keptn/keptn/resource-service/models"
+	"github.com/keptn/keptn/resource-service/validation"
 )
 
 // IResourceManager provides an interface for resource CRUD operations
@@ -32,19 +33,29 @@ type ResourceManager struct {
 	credentialReader     common.CredentialReader
 	fileSystem           common.IFileSystem
 	configurationContext IConfigurationContext
+	resourceValidator    validation.IResourceValidator
+	pullBeforeRead       bool
 }
 
-func NewResourceManager(git common.IGit, credentialReader common.CredentialReader, fileWriter common.IFileSystem, stageContext IConfigurationContext) *ResourceManager {
+// NewResourceManager creates a new ResourceManager. If pullBeforeRead is false, resources are read from the local
+// repository without pulling the upstream first, which is the case if the projects are synchronized in the background
+func NewResourceManager(git common.IGit, credentialReader common.CredentialReader, fileWriter common.IFileSystem, stageContext IConfigurationContext, resourceValidator validation.IResourceValidator, pullBeforeRead bool) *ResourceManager {
 	projectResourceManager := &ResourceManager{
 		git:                  git,
 		credentialReader:     credentialReader,
 		fileSystem:           fileWriter,
 		configurationContext: stageContext,
+		resourceValidator:    resourceValidator,
+		pullBeforeRead:       pullBeforeRead,
 	}
 	return projectResourceManager
 }
 
 func (p ResourceManager) CreateResources(params models.CreateResourcesParams) (*models.WriteResourceResponse, error) {
+	if err := p.validateResources(params.Resources); err != nil {
+		return nil, err
+	}
+
 	common.LockProject(params.ProjectName)
 	defer common.UnlockProject(params.ProjectName)
 
@@ -66,8 +77,10 @@ func (p ResourceManager) GetResources(params models.GetResourcesParams) (*models
 	}
 	// since we do not automatically fetch each time when we check out a branch, we need to pull
 	// here to get the latest state from the upstream
-	if err := p.git.Pull(*gitContext); err != nil {
-		return nil, err
+	if p.pullBeforeRead {
+		if err := p.git.Pull(*gitContext); err != nil {
+			return nil, err
+		}
 	}
 	revision, err := p.git.GetCurrentRevision(*gitContext)
 	if err != nil {
@@ -87,6 +100,10 @@ func (p ResourceManager) GetResources(params models.GetResourcesParams) (*models
 }
 
 func (p ResourceManager) UpdateResources(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error) {
+	if err := p.validateResources(params.Resources); err != nil {
+		return nil, err
+	}
+
 	common.LockProject(params.ProjectName)
 	defer common.UnlockProject(params.ProjectName)
 
@@ -116,6 +133,15 @@ func (p ResourceManager) GetResource(params models.GetResourceParams) (*models.G
 }
 
 func (p ResourceManager) UpdateResource(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	if err := p.resourceValidator.Validate(unescapedResourceName, params.ResourceContent); err != nil {
+		return nil, err
+	}
+
 	common.LockProject(params.ProjectName)
 	defer common.UnlockProject(params.ProjectName)
 
@@ -124,11 +150,6 @@ func (p ResourceManager) UpdateResource(params models.UpdateResourceParams) (*mo
 		return nil, err
 	}
 
-	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
-	if err != nil {
-		return nil, kerrors.ErrResourceInvalidResourceURI
-	}
-
 	resourcePath := configPath + "/" + unescapedResourceName
 
 	return p.writeAndCommitResource(gitContext, resourcePath, string(params.ResourceContent))
@@ -207,45 +228,82 @@ func (p ResourceManager) establishContext(project models.Project, stage *models.
 	return &gitContext, configPath, nil
 }
 
+func (p ResourceManager) validateResources(resources []models.Resource) error {
+	for _, res := range resources {
+		if err := p.resourceValidator.Validate(res.ResourceURI, res.ResourceContent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (p ResourceManager) readResource(gitContext *common_models.GitContext, params models.GetResourceParams, configPath string, resourceName string) (*models.GetResourceResponse, error) {
+	fileContent, revision, err := p.readResourceContent(gitContext, params, configPath, resourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceContent := base64.StdEncoding.EncodeToString(fileContent)
+
+	return &models.GetResourceResponse{
+		Resource: models.Resource{
+			ResourceURI:     params.ResourceURI,
+			ResourceContent: models.ResourceContent(resourceContent),
+		},
+		Metadata: models.Version{
+			UpstreamURL: gitContext.Credentials.RemoteURL,
+			Version:     revision,
+		},
+	}, nil
+}
+
+// readResourceContent returns the content of the resource and the revision it has been read from
+func (p ResourceManager) readResourceContent(gitContext *common_models.GitContext, params models.GetResourceParams, configPath string, resourceName string) ([]byte, string, error) {
 	var fileContent []byte
 	var revision string
 	var err error
 
-	if params.GitCommitID != "" && params.GitCommitID != "\"\"" {
+	gitCommitID := params.GitCommitID
+	if params.Snapshot != "" {
+		// snapshots are stored as one tag per branch, so we need to look up the tag of the branch that has been checked out
+		branch, err := p.git.GetCurrentBranch(*gitContext)
+		if err != nil {
+			return nil, "", err
+		}
+		gitCommitID = "refs/tags/" + common.GetSnapshotTagName(params.Snapshot, branch)
+	}
+
+	if isRevisionRequested(gitCommitID) {
 		// if commit ID is set, path needs to be relative to the project directory
 		configPath = strings.TrimPrefix(configPath, common.GetProjectConfigPath(params.ProjectName))
 		// resource path must not start with "/", otherwise git is not able to resolve the revision
 		resourcePath := strings.TrimPrefix(configPath+"/"+resourceName, "/")
-		fileContent, err = p.git.GetFileRevision(*gitContext, params.GitCommitID, resourcePath)
-		revision = params.GitCommitID
+		fileContent, err = p.git.GetFileRevision(*gitContext, gitCommitID, resourcePath)
+		if params.Snapshot != "" && errors.Is(err, kerrors.ErrResolveRevision) {
+			return nil, "", kerrors.ErrSnapshotNotFound
+		}
+		revision = gitCommitID
 	} else {
 		resourcePath := configPath + "/" + resourceName
-		if err := p.git.Pull(*gitContext); err != nil {
-			return nil, err
+		if p.pullBeforeRead {
+			if err := p.git.Pull(*gitContext); err != nil {
+				return nil, "", err
+			}
 		}
 		fileContent, err = p.fileSystem.ReadFile(resourcePath)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 		revision, err = p.git.GetCurrentRevision(*gitContext)
 	}
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
+	return fileContent, revision, nil
+}
 
-	resourceContent := base64.StdEncoding.EncodeToString(fileContent)
-
-	return &models.GetResourceResponse{
-		Resource: models.Resource{
-			ResourceURI:     params.ResourceURI,
-			ResourceContent: models.ResourceContent(resourceContent),
-		},
-		Metadata: models.Version{
-			UpstreamURL: gitContext.Credentials.RemoteURL,
-			Version:     revision,
-		},
-	}, nil
+func isRevisionRequested(gitCommitID string) bool {
+	return gitCommitID != "" && gitCommitID != "\"\""
 }
 
 func (p ResourceManager) writeAndCommitResource(gitContext *common_models.GitContext, resourcePath, resourceContent string) (*models.WriteResourceResponse, error) {
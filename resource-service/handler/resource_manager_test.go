@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -16,6 +17,7 @@ import (
 	errors2 "github.com/keptn/keptn/resource-service/errors"
 	handler_mock "github.com/keptn/keptn/resource-service/handler/fake"
 	"github.com/keptn/keptn/resource-service/models"
+	validation_mock "github.com/keptn/keptn/resource-service/validation/fake"
 	"github.com/stretchr/testify/require"
 )
 
@@ -23,16 +25,17 @@ const testConfigDir = "/data/config/my-project"
 const testServiceConfigDir = "/data/config/my-project/my-service"
 
 type testResourceManagerFields struct {
-	git              *common_mock.IGitMock
-	credentialReader *common_mock.CredentialReaderMock
-	fileSystem       *common_mock.IFileSystemMock
-	stageContext     *handler_mock.IConfigurationContextMock
+	git               *common_mock.IGitMock
+	credentialReader  *common_mock.CredentialReaderMock
+	fileSystem        *common_mock.IFileSystemMock
+	stageContext      *handler_mock.IConfigurationContextMock
+	resourceValidator *validation_mock.IResourceValidatorMock
 }
 
 func TestResourceManager_CreateResources_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -71,7 +74,7 @@ func TestResourceManager_CreateResources_ProjectResource(t *testing.T) {
 func TestResourceManager_CreateResources_StageResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -111,7 +114,7 @@ func TestResourceManager_CreateResources_StageResource(t *testing.T) {
 func TestResourceManager_CreateResources_ServiceResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -152,7 +155,7 @@ func TestResourceManager_CreateResources_ServiceResource(t *testing.T) {
 func TestResourceManager_CreateResources_ServiceResource_HelmChart(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -190,7 +193,7 @@ func TestResourceManager_CreateResources_ServiceResource_HelmChartWriteFails(t *
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -227,7 +230,7 @@ func TestResourceManager_CreateResources_ProjectResource_ProjectNotFound(t *test
 	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
 		return false
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -264,7 +267,7 @@ func TestResourceManager_CreateResources_ProjectResource_CannotReadCredentials(t
 	fields.credentialReader.GetCredentialsFunc = func(project string) (*common_models.GitCredentials, error) {
 		return nil, errors2.ErrMalformedCredentials
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -301,7 +304,7 @@ func TestResourceManager_CreateResources_ProjectResource_CannotEstablishContext(
 	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
 		return "", errors.New("oops")
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -330,10 +333,54 @@ func TestResourceManager_CreateResources_ProjectResource_CannotEstablishContext(
 	require.Empty(t, fields.fileSystem.WriteBase64EncodedFileCalls())
 }
 
+func TestResourceManager_CreateResources_ProjectResource_ValidationFails(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.resourceValidator.ValidateFunc = func(resourceURI string, content models.ResourceContent) error {
+		if resourceURI == "shipyard.yaml" {
+			return &models.ValidationError{ResourceURI: resourceURI, Field: "spec.stages", Message: "shipyard must contain at least one stage"}
+		}
+		return nil
+	}
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
+
+	revision, err := rm.CreateResources(models.CreateResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		CreateResourcesPayload: models.CreateResourcesPayload{
+			Resources: []models.Resource{
+				{
+					ResourceContent: "c3RyaW5n",
+					ResourceURI:     "file1",
+				},
+				{
+					ResourceContent: "c3RyaW5n",
+					ResourceURI:     "shipyard.yaml",
+				},
+			},
+		},
+	})
+
+	validationErr := &models.ValidationError{}
+	require.ErrorAs(t, err, &validationErr)
+	require.Equal(t, "spec.stages", validationErr.Field)
+
+	require.Nil(t, revision)
+
+	require.Len(t, fields.resourceValidator.ValidateCalls(), 2)
+
+	require.Empty(t, fields.stageContext.EstablishCalls())
+
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+
+	require.Empty(t, fields.fileSystem.WriteBase64EncodedFileCalls())
+}
+
 func TestResourceManager_UpdateResources_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -371,7 +418,7 @@ func TestResourceManager_UpdateResources_ProjectResource_ProjectNotFound(t *test
 		return false
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -409,7 +456,7 @@ func TestResourceManager_UpdateResources_ProjectResource_WritingFileFails(t *tes
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -446,7 +493,7 @@ func TestResourceManager_UpdateResources_ProjectResource_CommitFails(t *testing.
 		return "", errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -484,7 +531,7 @@ func TestResourceManager_UpdateResources_ProjectResource_PullFails(t *testing.T)
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -526,7 +573,7 @@ func TestResourceManager_UpdateResources_ProjectResource_CommitFailsOnFirstTry(t
 		return "my-revision", nil
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -560,7 +607,7 @@ func TestResourceManager_UpdateResources_ProjectResource_CommitFailsOnFirstTry(t
 func TestResourceManager_UpdateResource_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -585,7 +632,7 @@ func TestResourceManager_UpdateResource_ProjectResource(t *testing.T) {
 func TestResourceManager_UpdateResource_ProjectResourceWebhook(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -607,6 +654,37 @@ func TestResourceManager_UpdateResource_ProjectResourceWebhook(t *testing.T) {
 	require.Equal(t, testConfigDir+"/file1/file1", fields.fileSystem.WriteBase64EncodedFileCalls()[0].Path)
 }
 
+func TestResourceManager_UpdateResource_ProjectResource_ValidationFails(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.resourceValidator.ValidateFunc = func(resourceURI string, content models.ResourceContent) error {
+		return &models.ValidationError{ResourceURI: resourceURI, Field: "objectives[0].sli", Message: "sli must not be empty"}
+	}
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
+
+	revision, err := rm.UpdateResource(models.UpdateResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "my-service%2Fslo.yaml",
+		UpdateResourcePayload: models.UpdateResourcePayload{
+			ResourceContent: "c3RyaW5n",
+		},
+	})
+
+	validationErr := &models.ValidationError{}
+	require.ErrorAs(t, err, &validationErr)
+
+	require.Nil(t, revision)
+
+	require.Len(t, fields.resourceValidator.ValidateCalls(), 1)
+	require.Equal(t, "my-service/slo.yaml", fields.resourceValidator.ValidateCalls()[0].ResourceURI)
+
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+
+	require.Empty(t, fields.fileSystem.WriteBase64EncodedFileCalls())
+}
+
 func TestResourceManager_UpdateResource_ProjectResource_ProjectNotFound(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
@@ -614,7 +692,7 @@ func TestResourceManager_UpdateResource_ProjectResource_ProjectNotFound(t *testi
 		return false
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -642,7 +720,7 @@ func TestResourceManager_UpdateResource_ProjectResource_WritingFileFails(t *test
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -671,7 +749,7 @@ func TestResourceManager_UpdateResource_ProjectResource_CommitFails(t *testing.T
 		return "", errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -700,7 +778,7 @@ func TestResourceManager_UpdateResource_ProjectResource_PullFails(t *testing.T)
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -734,7 +812,7 @@ func TestResourceManager_UpdateResource_ProjectResource_CommitFailsOnFirstTry(t
 		return "my-revision", nil
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -759,7 +837,7 @@ func TestResourceManager_UpdateResource_ProjectResource_CommitFailsOnFirstTry(t
 func TestResourceManager_DeleteResource_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -784,7 +862,7 @@ func TestResourceManager_DeleteResource_ProjectResource_ProjectNotFound(t *testi
 	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
 		return false
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -811,7 +889,7 @@ func TestResourceManager_DeleteResource_ProjectResource_ResourceNotFound(t *test
 		}
 		return true
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -835,7 +913,7 @@ func TestResourceManager_DeleteResource_ProjectResource_DeleteFails(t *testing.T
 	fields.fileSystem.DeleteFileFunc = func(path string) error {
 		return errors.New("oops")
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -870,7 +948,7 @@ func TestResourceManager_DeleteResource_Git_ThrowsNonFastForward_Or_ThrowsForceN
 		return "my-revision", nil
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -897,7 +975,7 @@ func TestResourceManager_DeleteResource_ProjectResource_CommitFails(t *testing.T
 	fields.fileSystem.DeleteFileFunc = func(path string) error {
 		return errors.New("oops")
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -921,7 +999,7 @@ func TestResourceManager_DeleteResource_ProjectResource_CommitFails(t *testing.T
 func TestResourceManager_GetResource_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -951,10 +1029,29 @@ func TestResourceManager_GetResource_ProjectResource(t *testing.T) {
 	require.Len(t, fields.fileSystem.ReadFileCalls(), 1)
 }
 
+func TestResourceManager_GetResource_ProjectResource_WithoutPull(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, false)
+
+	result, err := rm.GetResource(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, "ZmlsZS1jb250ZW50", string(result.ResourceContent))
+
+	require.Empty(t, fields.git.PullCalls())
+	require.Len(t, fields.fileSystem.ReadFileCalls(), 1)
+}
+
 func TestResourceManager_GetResource_ProjectResource_ProvideGitCommitID(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -993,7 +1090,7 @@ func TestResourceManager_GetResource_ServiceResource_ProvideGitCommitID(t *testi
 		return testConfigDir + "/my-service", nil
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1027,13 +1124,82 @@ func TestResourceManager_GetResource_ServiceResource_ProvideGitCommitID(t *testi
 	require.Equal(t, "my-service/file1", fields.git.GetFileRevisionCalls()[0].File)
 }
 
+func TestResourceManager_GetResource_ServiceResource_ProvideSnapshot(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return testConfigDir + "/my-service", nil
+	}
+	fields.git.GetCurrentBranchFunc = func(gitContext common_models.GitContext) (string, error) {
+		return "my-stage", nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
+
+	result, err := rm.GetResource(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "my-stage"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		ResourceURI: "file1",
+		GetResourceQuery: models.GetResourceQuery{
+			Snapshot: "release-1.0",
+		},
+	})
+
+	require.Nil(t, err)
+
+	require.Equal(t, &models.GetResourceResponse{
+		Resource: models.Resource{
+			ResourceContent: "ZmlsZS1jb250ZW50",
+			ResourceURI:     "file1",
+		},
+		Metadata: models.Version{
+			UpstreamURL: "remote-url",
+			Version:     "refs/tags/snapshots/release-1.0/my-stage",
+		},
+	}, result)
+
+	require.Len(t, fields.git.GetFileRevisionCalls(), 1)
+	require.Equal(t, "refs/tags/snapshots/release-1.0/my-stage", fields.git.GetFileRevisionCalls()[0].Revision)
+	require.Equal(t, "my-service/file1", fields.git.GetFileRevisionCalls()[0].File)
+	require.Empty(t, fields.git.PullCalls())
+}
+
+func TestResourceManager_GetResource_ProjectResource_SnapshotNotFound(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.GetCurrentBranchFunc = func(gitContext common_models.GitContext) (string, error) {
+		return "main", nil
+	}
+	fields.git.GetFileRevisionFunc = func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+		return nil, fmt.Errorf(errors2.ErrMsgCouldNotGitAction, "retrieve revision in ", "my-project", errors2.ErrResolveRevision)
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
+
+	result, err := rm.GetResource(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		GetResourceQuery: models.GetResourceQuery{
+			Snapshot: "release-1.0",
+		},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrSnapshotNotFound)
+	require.Nil(t, result)
+}
+
 func TestResourceManager_GetResource_ProjectResource_PullFails(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
 	fields.git.PullFunc = func(gitContext common_models.GitContext) error {
 		return errors.New("oops")
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1060,7 +1226,7 @@ func TestResourceManager_GetResource_ProjectResource_ProjectNotFound(t *testing.
 	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
 		return false
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1085,7 +1251,7 @@ func TestResourceManager_GetResource_ServiceResource_ServiceNotFound(t *testing.
 	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
 		return "", errors2.ErrServiceNotFound
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1116,7 +1282,7 @@ func TestResourceManager_GetResource_ProjectResource_ResourceNotFound(t *testing
 	fields.fileSystem.ReadFileFunc = func(filename string) ([]byte, error) {
 		return nil, errors2.ErrResourceNotFound
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1142,7 +1308,7 @@ func TestResourceManager_GetResource_ProjectResource_CannotReadFIle(t *testing.T
 	fields.fileSystem.ReadFileFunc = func(filename string) ([]byte, error) {
 		return nil, errors.New("oops")
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1165,7 +1331,7 @@ func TestResourceManager_GetResource_ProjectResource_CannotReadFIle(t *testing.T
 func TestResourceManager_GetResource_ProjectResource_InvalidResourceName(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1189,7 +1355,7 @@ func TestResourceManager_GetResource_ProjectResource_InvalidResourceName(t *test
 func TestResourceManager_GetResources(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	result, err := rm.GetResources(models.GetResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -1257,7 +1423,7 @@ func TestResourceManager_GetResourcesPullFailed(t *testing.T) {
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	result, err := rm.GetResources(models.GetResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -1288,7 +1454,7 @@ func TestResourceManager_GetCurrentRevisionFailed(t *testing.T) {
 		return "", errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.resourceValidator, true)
 
 	result, err := rm.GetResources(models.GetResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -1410,5 +1576,10 @@ func getTestResourceManagerFields() testResourceManagerFields {
 				return testConfigDir, nil
 			},
 		},
+		resourceValidator: &validation_mock.IResourceValidatorMock{
+			ValidateFunc: func(resourceURI string, content models.ResourceContent) error {
+				return nil
+			},
+		},
 	}
 }
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+)
+
+type ISnapshotHandler interface {
+	CreateSnapshot(context *gin.Context)
+	GetSnapshots(context *gin.Context)
+	GetSnapshot(context *gin.Context)
+	DeleteSnapshot(context *gin.Context)
+}
+
+type SnapshotHandler struct {
+	SnapshotManager ISnapshotManager
+}
+
+func NewSnapshotHandler(snapshotManager ISnapshotManager) *SnapshotHandler {
+	return &SnapshotHandler{
+		SnapshotManager: snapshotManager,
+	}
+}
+
+// CreateSnapshot godoc
+// @Summary      Creates a snapshot of a project
+// @Description  Tags the current state of all branches of the project in the upstream repository
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Snapshot
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string                        true  "The name of the project"
+// @Param        snapshot     body      models.CreateSnapshotPayload  true  "Snapshot"
+// @Success      201          {object}  models.SnapshotInfo
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Project not found"
+// @Failure      409          {object}  models.Error  "Snapshot already exists"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/snapshot [post]
+func (sh *SnapshotHandler) CreateSnapshot(c *gin.Context) {
+	params := &models.CreateSnapshotParams{
+		Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+	}
+
+	createSnapshot := &models.CreateSnapshotPayload{}
+	if err := c.ShouldBindJSON(createSnapshot); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.CreateSnapshotPayload = *createSnapshot
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	snapshot, err := sh.SnapshotManager.CreateSnapshot(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, snapshot)
+}
+
+// GetSnapshots godoc
+// @Summary      Get snapshots of a project
+// @Description  Get the list of snapshots of a project
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Snapshot
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true  "The name of the project"
+// @Success      200          {object}  models.GetSnapshotsResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Project not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/snapshot [get]
+func (sh *SnapshotHandler) GetSnapshots(c *gin.Context) {
+	params := &models.GetSnapshotsParams{
+		Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+	}
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	snapshots, err := sh.SnapshotManager.GetSnapshots(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, snapshots)
+}
+
+// GetSnapshot godoc
+// @Summary      Get a snapshot of a project
+// @Description  Get the revisions of all branches included in a snapshot
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Snapshot
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName   path      string  true  "The name of the project"
+// @Param        snapshotName  path      string  true  "The name of the snapshot"
+// @Success      200           {object}  models.SnapshotInfo
+// @Failure      400           {object}  models.Error  "Invalid payload"
+// @Failure      404           {object}  models.Error  "Project or snapshot not found"
+// @Failure      500           {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/snapshot/{snapshotName} [get]
+func (sh *SnapshotHandler) GetSnapshot(c *gin.Context) {
+	params := &models.GetSnapshotParams{
+		Project:  models.Project{ProjectName: c.Param(pathParamProjectName)},
+		Snapshot: models.Snapshot{SnapshotName: c.Param(pathParamSnapshotName)},
+	}
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	snapshot, err := sh.SnapshotManager.GetSnapshot(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, snapshot)
+}
+
+// DeleteSnapshot godoc
+// @Summary      Deletes a snapshot of a project
+// @Description  Removes the tags of a snapshot from the upstream repository. The configuration of the project is not changed
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:delete</span>
+// @Tags         Snapshot
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName   path      string        true  "The name of the project"
+// @Param        snapshotName  path      string        true  "The name of the snapshot"
+// @Success      204           {string}  string        "ok"
+// @Failure      400           {object}  models.Error  "Invalid payload"
+// @Failure      404           {object}  models.Error  "Project or snapshot not found"
+// @Failure      500           {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/snapshot/{snapshotName} [delete]
+func (sh *SnapshotHandler) DeleteSnapshot(c *gin.Context) {
+	params := &models.DeleteSnapshotParams{
+		Project:  models.Project{ProjectName: c.Param(pathParamProjectName)},
+		Snapshot: models.Snapshot{SnapshotName: c.Param(pathParamSnapshotName)},
+	}
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	if err := sh.SnapshotManager.DeleteSnapshot(*params); err != nil {
+		OnAPIError(c, err)
+		return
+	}
+	c.String(http.StatusNoContent, "")
+}
@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	errors2 "github.com/keptn/keptn/resource-service/errors"
+	handler_mock "github.com/keptn/keptn/resource-service/handler/fake"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+const createSnapshotTestPayload = `{"snapshotName": "release-1.0", "message": "release 1.0"}`
+const createSnapshotInvalidNameTestPayload = `{"snapshotName": "release/1.0"}`
+
+func TestSnapshotHandler_CreateSnapshot(t *testing.T) {
+	type fields struct {
+		SnapshotManager *handler_mock.ISnapshotManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.CreateSnapshotParams
+		wantStatus int
+	}{
+		{
+			name: "create snapshot successful",
+			fields: fields{
+				SnapshotManager: &handler_mock.ISnapshotManagerMock{CreateSnapshotFunc: func(params models.CreateSnapshotParams) (*models.SnapshotInfo, error) {
+					return &models.SnapshotInfo{Snapshot: params.Snapshot}, nil
+				}},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/snapshot", bytes.NewBuffer([]byte(createSnapshotTestPayload))),
+			wantParams: &models.CreateSnapshotParams{
+				Project: models.Project{ProjectName: "my-project"},
+				CreateSnapshotPayload: models.CreateSnapshotPayload{
+					Snapshot: models.Snapshot{SnapshotName: "release-1.0"},
+					Message:  "release 1.0",
+				},
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "invalid snapshot name",
+			fields: fields{
+				SnapshotManager: &handler_mock.ISnapshotManagerMock{CreateSnapshotFunc: func(params models.CreateSnapshotParams) (*models.SnapshotInfo, error) {
+					return nil, errors.New("should not have been called")
+				}},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/snapshot", bytes.NewBuffer([]byte(createSnapshotInvalidNameTestPayload))),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid payload",
+			fields: fields{
+				SnapshotManager: &handler_mock.ISnapshotManagerMock{CreateSnapshotFunc: func(params models.CreateSnapshotParams) (*models.SnapshotInfo, error) {
+					return nil, errors.New("should not have been called")
+				}},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/snapshot", bytes.NewBuffer([]byte("invalid"))),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "snapshot already exists",
+			fields: fields{
+				SnapshotManager: &handler_mock.ISnapshotManagerMock{CreateSnapshotFunc: func(params models.CreateSnapshotParams) (*models.SnapshotInfo, error) {
+					return nil, errors2.ErrSnapshotAlreadyExists
+				}},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/snapshot", bytes.NewBuffer([]byte(createSnapshotTestPayload))),
+			wantParams: &models.CreateSnapshotParams{
+				Project: models.Project{ProjectName: "my-project"},
+				CreateSnapshotPayload: models.CreateSnapshotPayload{
+					Snapshot: models.Snapshot{SnapshotName: "release-1.0"},
+					Message:  "release 1.0",
+				},
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "project not found",
+			fields: fields{
+				SnapshotManager: &handler_mock.ISnapshotManagerMock{CreateSnapshotFunc: func(params models.CreateSnapshotParams) (*models.SnapshotInfo, error) {
+					return nil, errors2.ErrProjectNotFound
+				}},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/snapshot", bytes.NewBuffer([]byte(createSnapshotTestPayload))),
+			wantParams: &models.CreateSnapshotParams{
+				Project: models.Project{ProjectName: "my-project"},
+				CreateSnapshotPayload: models.CreateSnapshotPayload{
+					Snapshot: models.Snapshot{SnapshotName: "release-1.0"},
+					Message:  "release 1.0",
+				},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := NewSnapshotHandler(tt.fields.SnapshotManager)
+
+			router := gin.Default()
+			router.POST("/project/:projectName/snapshot", sh.CreateSnapshot)
+
+			resp := performRequest(router, tt.request)
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.SnapshotManager.CreateSnapshotCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.SnapshotManager.CreateSnapshotCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.SnapshotManager.CreateSnapshotCalls())
+			}
+		})
+	}
+}
+
+func TestSnapshotHandler_GetSnapshot(t *testing.T) {
+	type fields struct {
+		SnapshotManager *handler_mock.ISnapshotManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetSnapshotParams
+		wantStatus int
+	}{
+		{
+			name: "get snapshot successful",
+			fields: fields{
+				SnapshotManager: &handler_mock.ISnapshotManagerMock{GetSnapshotFunc: func(params models.GetSnapshotParams) (*models.SnapshotInfo, error) {
+					return &models.SnapshotInfo{Snapshot: params.Snapshot}, nil
+				}},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/snapshot/release-1.0", nil),
+			wantParams: &models.GetSnapshotParams{
+				Project:  models.Project{ProjectName: "my-project"},
+				Snapshot: models.Snapshot{SnapshotName: "release-1.0"},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "snapshot not found",
+			fields: fields{
+				SnapshotManager: &handler_mock.ISnapshotManagerMock{GetSnapshotFunc: func(params models.GetSnapshotParams) (*models.SnapshotInfo, error) {
+					return nil, errors2.ErrSnapshotNotFound
+				}},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/snapshot/release-1.0", nil),
+			wantParams: &models.GetSnapshotParams{
+				Project:  models.Project{ProjectName: "my-project"},
+				Snapshot: models.Snapshot{SnapshotName: "release-1.0"},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "invalid snapshot name",
+			fields: fields{
+				SnapshotManager: &handler_mock.ISnapshotManagerMock{GetSnapshotFunc: func(params models.GetSnapshotParams) (*models.SnapshotInfo, error) {
+					return nil, errors.New("should not have been called")
+				}},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/my-project/snapshot/.release", nil),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := NewSnapshotHandler(tt.fields.SnapshotManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/snapshot/:snapshotName", sh.GetSnapshot)
+
+			resp := performRequest(router, tt.request)
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.SnapshotManager.GetSnapshotCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.SnapshotManager.GetSnapshotCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.SnapshotManager.GetSnapshotCalls())
+			}
+		})
+	}
+}
+
+func TestSnapshotHandler_DeleteSnapshot(t *testing.T) {
+	type fields struct {
+		SnapshotManager *handler_mock.ISnapshotManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.DeleteSnapshotParams
+		wantStatus int
+	}{
+		{
+			name: "delete snapshot successful",
+			fields: fields{
+				SnapshotManager: &handler_mock.ISnapshotManagerMock{DeleteSnapshotFunc: func(params models.DeleteSnapshotParams) error {
+					return nil
+				}},
+			},
+			request: httptest.NewRequest(http.MethodDelete, "/project/my-project/snapshot/release-1.0", nil),
+			wantParams: &models.DeleteSnapshotParams{
+				Project:  models.Project{ProjectName: "my-project"},
+				Snapshot: models.Snapshot{SnapshotName: "release-1.0"},
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "snapshot not found",
+			fields: fields{
+				SnapshotManager: &handler_mock.ISnapshotManagerMock{DeleteSnapshotFunc: func(params models.DeleteSnapshotParams) error {
+					return errors2.ErrSnapshotNotFound
+				}},
+			},
+			request: httptest.NewRequest(http.MethodDelete, "/project/my-project/snapshot/release-1.0", nil),
+			wantParams: &models.DeleteSnapshotParams{
+				Project:  models.Project{ProjectName: "my-project"},
+				Snapshot: models.Snapshot{SnapshotName: "release-1.0"},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			fields: fields{
+				SnapshotManager: &handler_mock.ISnapshotManagerMock{DeleteSnapshotFunc: func(params models.DeleteSnapshotParams) error {
+					return errors.New("oops")
+				}},
+			},
+			request: httptest.NewRequest(http.MethodDelete, "/project/my-project/snapshot/release-1.0", nil),
+			wantParams: &models.DeleteSnapshotParams{
+				Project:  models.Project{ProjectName: "my-project"},
+				Snapshot: models.Snapshot{SnapshotName: "release-1.0"},
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := NewSnapshotHandler(tt.fields.SnapshotManager)
+
+			router := gin.Default()
+			router.DELETE("/project/:projectName/snapshot/:snapshotName", sh.DeleteSnapshot)
+
+			resp := performRequest(router, tt.request)
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.SnapshotManager.DeleteSnapshotCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.SnapshotManager.DeleteSnapshotCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.SnapshotManager.DeleteSnapshotCalls())
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/keptn/keptn/resource-service/common"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	logger "github.com/sirupsen/logrus"
+)
+
+// ISnapshotManager provides an interface for creating, retrieving and deleting snapshots of a project
+//
+//go:generate moq -pkg handler_mock -skip-ensure -out ./fake/snapshot_manager_mock.go . ISnapshotManager
+type ISnapshotManager interface {
+	CreateSnapshot(params models.CreateSnapshotParams) (*models.SnapshotInfo, error)
+	GetSnapshots(params models.GetSnapshotsParams) (*models.GetSnapshotsResponse, error)
+	GetSnapshot(params models.GetSnapshotParams) (*models.SnapshotInfo, error)
+	DeleteSnapshot(params models.DeleteSnapshotParams) error
+}
+
+// SnapshotManager stores snapshots as annotated tags in the upstream repository of a project.
+// For each branch of the repository, a tag named snapshots/<snapshot>/<branch> is created, which means that
+// snapshots cover all stages, regardless of whether these are stored in branches or directories
+type SnapshotManager struct {
+	git              common.IGit
+	credentialReader common.CredentialReader
+}
+
+func NewSnapshotManager(git common.IGit, credentialReader common.CredentialReader) *SnapshotManager {
+	return &SnapshotManager{
+		git:              git,
+		credentialReader: credentialReader,
+	}
+}
+
+func (s SnapshotManager) CreateSnapshot(params models.CreateSnapshotParams) (*models.SnapshotInfo, error) {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, err := s.establishGitContext(params.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := s.getSnapshots(*gitContext)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := snapshots[params.SnapshotName]; ok {
+		return nil, kerrors.ErrSnapshotAlreadyExists
+	}
+
+	branches, err := s.git.GetBranches(*gitContext)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve branches of project %s: %w", params.ProjectName, err)
+	}
+
+	snapshot := &models.SnapshotInfo{
+		Snapshot:  params.Snapshot,
+		Message:   params.Message,
+		Revisions: []models.SnapshotRevision{},
+	}
+
+	createdTags := []string{}
+	rollbackFunc := func() {
+		for _, tag := range createdTags {
+			logger.Debugf("Rollback: try to delete tag %s of project %s", tag, params.ProjectName)
+			if err := s.git.DeleteTag(*gitContext, tag); err != nil {
+				logger.Errorf("Rollback failed: could not delete tag %s of project %s: %s", tag, params.ProjectName, err.Error())
+			}
+		}
+	}
+
+	for _, branch := range branches {
+		tag := common.GetSnapshotTagName(params.SnapshotName, branch)
+		commitID, err := s.git.CreateTag(*gitContext, tag, branch, params.Message)
+		if err != nil {
+			rollbackFunc()
+			if errors.Is(err, kerrors.ErrTagExists) {
+				return nil, kerrors.ErrSnapshotAlreadyExists
+			}
+			return nil, fmt.Errorf("could not create snapshot %s of project %s: %w", params.SnapshotName, params.ProjectName, err)
+		}
+		createdTags = append(createdTags, tag)
+		snapshot.Revisions = append(snapshot.Revisions, models.SnapshotRevision{
+			Branch:   branch,
+			CommitID: commitID,
+		})
+	}
+
+	return snapshot, nil
+}
+
+func (s SnapshotManager) GetSnapshots(params models.GetSnapshotsParams) (*models.GetSnapshotsResponse, error) {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, err := s.establishGitContext(params.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := s.getSnapshots(*gitContext)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.GetSnapshotsResponse{Snapshots: []models.SnapshotInfo{}}
+	for _, snapshot := range snapshots {
+		result.Snapshots = append(result.Snapshots, *snapshot)
+	}
+	sort.Slice(result.Snapshots, func(i, j int) bool {
+		return result.Snapshots[i].SnapshotName < result.Snapshots[j].SnapshotName
+	})
+	return result, nil
+}
+
+func (s SnapshotManager) GetSnapshot(params models.GetSnapshotParams) (*models.SnapshotInfo, error) {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, err := s.establishGitContext(params.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := s.getSnapshots(*gitContext)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, ok := snapshots[params.SnapshotName]
+	if !ok {
+		return nil, kerrors.ErrSnapshotNotFound
+	}
+	return snapshot, nil
+}
+
+func (s SnapshotManager) DeleteSnapshot(params models.DeleteSnapshotParams) error {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, err := s.establishGitContext(params.Project)
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := s.getSnapshots(*gitContext)
+	if err != nil {
+		return err
+	}
+
+	snapshot, ok := snapshots[params.SnapshotName]
+	if !ok {
+		return kerrors.ErrSnapshotNotFound
+	}
+
+	for _, revision := range snapshot.Revisions {
+		tag := common.GetSnapshotTagName(snapshot.SnapshotName, revision.Branch)
+		if err := s.git.DeleteTag(*gitContext, tag); err != nil && !errors.Is(err, kerrors.ErrTagNotFound) {
+			return fmt.Errorf("could not delete snapshot %s of project %s: %w", params.SnapshotName, params.ProjectName, err)
+		}
+	}
+	return nil
+}
+
+// getSnapshots groups the snapshot tags of the project repository by the name of the snapshot
+func (s SnapshotManager) getSnapshots(gitContext common_models.GitContext) (map[string]*models.SnapshotInfo, error) {
+	tags, err := s.git.GetTags(gitContext)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve tags of project %s: %w", gitContext.Project, err)
+	}
+
+	snapshots := map[string]*models.SnapshotInfo{}
+	for _, tag := range tags {
+		snapshotName, branch, ok := common.ParseSnapshotTagName(tag.Name)
+		if !ok {
+			continue
+		}
+		snapshot, ok := snapshots[snapshotName]
+		if !ok {
+			snapshot = &models.SnapshotInfo{
+				Snapshot:     models.Snapshot{SnapshotName: snapshotName},
+				Message:      tag.Message,
+				CreationDate: tag.Date,
+				Revisions:    []models.SnapshotRevision{},
+			}
+			snapshots[snapshotName] = snapshot
+		}
+		if tag.Date.Before(snapshot.CreationDate) {
+			snapshot.CreationDate = tag.Date
+		}
+		snapshot.Revisions = append(snapshot.Revisions, models.SnapshotRevision{
+			Branch:   branch,
+			CommitID: tag.CommitID,
+		})
+	}
+	return snapshots, nil
+}
+
+func (s SnapshotManager) establishGitContext(project models.Project) (*common_models.GitContext, error) {
+	credentials, err := s.credentialReader.GetCredentials(project.ProjectName)
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotRetrieveCredentials, project.ProjectName, err)
+	}
+
+	auth, err := getAuthMethod(credentials)
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotEstablishAuthMethod, project.ProjectName, err)
+	}
+
+	gitContext := common_models.GitContext{
+		Project:     project.ProjectName,
+		Credentials: credentials,
+		AuthMethod:  *auth,
+	}
+
+	if !s.git.ProjectExists(gitContext) {
+		return nil, kerrors.ErrProjectNotFound
+	}
+	return &gitContext, nil
+}
@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	common_mock "github.com/keptn/keptn/resource-service/common/fake"
+	"github.com/keptn/keptn/resource-service/common_models"
+	errors2 "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+type snapshotManagerTestFields struct {
+	git              *common_mock.IGitMock
+	credentialReader *common_mock.CredentialReaderMock
+}
+
+var testSnapshotDate = time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+
+func TestSnapshotManager_CreateSnapshot(t *testing.T) {
+	params := models.CreateSnapshotParams{
+		Project: models.Project{ProjectName: "my-project"},
+		CreateSnapshotPayload: models.CreateSnapshotPayload{
+			Snapshot: models.Snapshot{SnapshotName: "release-1.0"},
+			Message:  "release 1.0",
+		},
+	}
+
+	fields := getTestSnapshotManagerFields()
+	s := NewSnapshotManager(fields.git, fields.credentialReader)
+
+	snapshot, err := s.CreateSnapshot(params)
+
+	require.Nil(t, err)
+	require.Equal(t, &models.SnapshotInfo{
+		Snapshot: models.Snapshot{SnapshotName: "release-1.0"},
+		Message:  "release 1.0",
+		Revisions: []models.SnapshotRevision{
+			{Branch: "main", CommitID: "commit-main"},
+			{Branch: "dev", CommitID: "commit-dev"},
+		},
+	}, snapshot)
+
+	require.Len(t, fields.git.CreateTagCalls(), 2)
+	require.Equal(t, "snapshots/release-1.0/main", fields.git.CreateTagCalls()[0].Tag)
+	require.Equal(t, "main", fields.git.CreateTagCalls()[0].Branch)
+	require.Equal(t, "release 1.0", fields.git.CreateTagCalls()[0].Message)
+	require.Equal(t, "snapshots/release-1.0/dev", fields.git.CreateTagCalls()[1].Tag)
+	require.Equal(t, "dev", fields.git.CreateTagCalls()[1].Branch)
+}
+
+func TestSnapshotManager_CreateSnapshot_ProjectNotFound(t *testing.T) {
+	params := models.CreateSnapshotParams{
+		Project: models.Project{ProjectName: "my-project"},
+		CreateSnapshotPayload: models.CreateSnapshotPayload{
+			Snapshot: models.Snapshot{SnapshotName: "release-1.0"},
+		},
+	}
+
+	fields := getTestSnapshotManagerFields()
+	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
+		return false
+	}
+	s := NewSnapshotManager(fields.git, fields.credentialReader)
+
+	snapshot, err := s.CreateSnapshot(params)
+
+	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
+	require.Nil(t, snapshot)
+	require.Empty(t, fields.git.CreateTagCalls())
+}
+
+func TestSnapshotManager_CreateSnapshot_AlreadyExists(t *testing.T) {
+	params := models.CreateSnapshotParams{
+		Project: models.Project{ProjectName: "my-project"},
+		CreateSnapshotPayload: models.CreateSnapshotPayload{
+			Snapshot: models.Snapshot{SnapshotName: "release-0.9"},
+		},
+	}
+
+	fields := getTestSnapshotManagerFields()
+	s := NewSnapshotManager(fields.git, fields.credentialReader)
+
+	snapshot, err := s.CreateSnapshot(params)
+
+	require.ErrorIs(t, err, errors2.ErrSnapshotAlreadyExists)
+	require.Nil(t, snapshot)
+	require.Empty(t, fields.git.CreateTagCalls())
+}
+
+func TestSnapshotManager_CreateSnapshot_CreateTagFails(t *testing.T) {
+	params := models.CreateSnapshotParams{
+		Project: models.Project{ProjectName: "my-project"},
+		CreateSnapshotPayload: models.CreateSnapshotPayload{
+			Snapshot: models.Snapshot{SnapshotName: "release-1.0"},
+		},
+	}
+
+	fields := getTestSnapshotManagerFields()
+	fields.git.CreateTagFunc = func(gitContext common_models.GitContext, tag string, branch string, message string) (string, error) {
+		if branch == "dev" {
+			return "", errors.New("oops")
+		}
+		return "commit-" + branch, nil
+	}
+	s := NewSnapshotManager(fields.git, fields.credentialReader)
+
+	snapshot, err := s.CreateSnapshot(params)
+
+	require.NotNil(t, err)
+	require.Nil(t, snapshot)
+
+	// the tag that has already been created should be removed again
+	require.Len(t, fields.git.DeleteTagCalls(), 1)
+	require.Equal(t, "snapshots/release-1.0/main", fields.git.DeleteTagCalls()[0].Tag)
+}
+
+func TestSnapshotManager_GetSnapshots(t *testing.T) {
+	fields := getTestSnapshotManagerFields()
+	s := NewSnapshotManager(fields.git, fields.credentialReader)
+
+	snapshots, err := s.GetSnapshots(models.GetSnapshotsParams{Project: models.Project{ProjectName: "my-project"}})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.GetSnapshotsResponse{
+		Snapshots: []models.SnapshotInfo{
+			{
+				Snapshot:     models.Snapshot{SnapshotName: "release-0.8"},
+				Message:      "release 0.8",
+				CreationDate: testSnapshotDate.Add(-time.Hour),
+				Revisions: []models.SnapshotRevision{
+					{Branch: "main", CommitID: "old-commit-main"},
+				},
+			},
+			{
+				Snapshot:     models.Snapshot{SnapshotName: "release-0.9"},
+				Message:      "release 0.9",
+				CreationDate: testSnapshotDate,
+				Revisions: []models.SnapshotRevision{
+					{Branch: "main", CommitID: "commit-main"},
+					{Branch: "dev", CommitID: "commit-dev"},
+				},
+			},
+		},
+	}, snapshots)
+}
+
+func TestSnapshotManager_GetSnapshot_NotFound(t *testing.T) {
+	fields := getTestSnapshotManagerFields()
+	s := NewSnapshotManager(fields.git, fields.credentialReader)
+
+	snapshot, err := s.GetSnapshot(models.GetSnapshotParams{
+		Project:  models.Project{ProjectName: "my-project"},
+		Snapshot: models.Snapshot{SnapshotName: "release-1.0"},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrSnapshotNotFound)
+	require.Nil(t, snapshot)
+}
+
+func TestSnapshotManager_DeleteSnapshot(t *testing.T) {
+	fields := getTestSnapshotManagerFields()
+	s := NewSnapshotManager(fields.git, fields.credentialReader)
+
+	err := s.DeleteSnapshot(models.DeleteSnapshotParams{
+		Project:  models.Project{ProjectName: "my-project"},
+		Snapshot: models.Snapshot{SnapshotName: "release-0.9"},
+	})
+
+	require.Nil(t, err)
+	require.Len(t, fields.git.DeleteTagCalls(), 2)
+	require.Equal(t, "snapshots/release-0.9/main", fields.git.DeleteTagCalls()[0].Tag)
+	require.Equal(t, "snapshots/release-0.9/dev", fields.git.DeleteTagCalls()[1].Tag)
+}
+
+func TestSnapshotManager_DeleteSnapshot_NotFound(t *testing.T) {
+	fields := getTestSnapshotManagerFields()
+	s := NewSnapshotManager(fields.git, fields.credentialReader)
+
+	err := s.DeleteSnapshot(models.DeleteSnapshotParams{
+		Project:  models.Project{ProjectName: "my-project"},
+		Snapshot: models.Snapshot{SnapshotName: "release-1.0"},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrSnapshotNotFound)
+	require.Empty(t, fields.git.DeleteTagCalls())
+}
+
+func getTestSnapshotManagerFields() snapshotManagerTestFields {
+	return snapshotManagerTestFields{
+		git: &common_mock.IGitMock{
+			ProjectExistsFunc: func(gitContext common_models.GitContext) bool {
+				return true
+			},
+			GetBranchesFunc: func(gitContext common_models.GitContext) ([]string, error) {
+				return []string{"main", "dev"}, nil
+			},
+			GetTagsFunc: func(gitContext common_models.GitContext) ([]common_models.GitTag, error) {
+				return []common_models.GitTag{
+					{Name: "v0.1.0", CommitID: "some-commit"},
+					{Name: "snapshots/release-0.9/main", CommitID: "commit-main", Message: "release 0.9", Date: testSnapshotDate},
+					{Name: "snapshots/release-0.9/dev", CommitID: "commit-dev", Message: "release 0.9", Date: testSnapshotDate},
+					{Name: "snapshots/release-0.8/main", CommitID: "old-commit-main", Message: "release 0.8", Date: testSnapshotDate.Add(-time.Hour)},
+				}, nil
+			},
+			CreateTagFunc: func(gitContext common_models.GitContext, tag string, branch string, message string) (string, error) {
+				return "commit-" + branch, nil
+			},
+			DeleteTagFunc: func(gitContext common_models.GitContext, tag string) error {
+				return nil
+			},
+		},
+		credentialReader: &common_mock.CredentialReaderMock{
+			GetCredentialsFunc: func(project string) (*common_models.GitCredentials, error) {
+				return &common_models.GitCredentials{
+					User: "my-user",
+					HttpsAuth: &apimodels.HttpsGitAuth{
+						Token: "my-token",
+					},
+					RemoteURL: "my-remote-uri",
+				}, nil
+			},
+		},
+	}
+}
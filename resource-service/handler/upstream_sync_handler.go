@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/resource-service/models"
+)
+
+const headerHubSignature = "X-Hub-Signature-256"
+const headerGitlabToken = "X-Gitlab-Token"
+const hubSignaturePrefix = "sha256="
+
+type IUpstreamSyncHandler interface {
+	SyncProject(context *gin.Context)
+	GetSyncStatus(context *gin.Context)
+}
+
+type UpstreamSyncHandler struct {
+	UpstreamSyncManager IUpstreamSyncManager
+	webhookSecret       string
+}
+
+// NewUpstreamSyncHandler creates a new UpstreamSyncHandler. If webhookSecret is not empty, requests for
+// triggering a synchronization must either be signed with the webhook secret of the project (GitHub, Gitea) or contain
+// it as token (GitLab). The webhook secret of a project is derived from webhookSecret, see ProjectWebhookSecret
+func NewUpstreamSyncHandler(upstreamSyncManager IUpstreamSyncManager, webhookSecret string) *UpstreamSyncHandler {
+	return &UpstreamSyncHandler{
+		UpstreamSyncManager: upstreamSyncManager,
+		webhookSecret:       webhookSecret,
+	}
+}
+
+// SyncProject godoc
+// @Summary      Synchronize a project with its upstream
+// @Description  Fetches the upstream repository of the project and fast-forwards all branches. This endpoint can be used as target for push webhooks of Git hosting services
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Project
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true  "The name of the project"
+// @Success      200          {object}  models.UpstreamSyncStatus
+// @Failure      400          {object}  models.Error               "Invalid payload"
+// @Failure      401          {object}  models.Error               "Invalid webhook signature"
+// @Failure      404          {object}  models.Error               "Project not found"
+// @Failure      409          {object}  models.UpstreamSyncStatus  "Local branches have diverged from the upstream"
+// @Failure      500          {object}  models.Error               "Internal error"
+// @Router       /project/{projectName}/sync [post]
+func (sh *UpstreamSyncHandler) SyncProject(c *gin.Context) {
+	params := &models.SyncProjectParams{
+		Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+	}
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	if !sh.isAuthorized(c, params.ProjectName) {
+		SetUnauthorizedErrorResponse(c, "Invalid webhook signature")
+		return
+	}
+
+	status, err := sh.UpstreamSyncManager.SyncProject(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	if status.HasConflicts() {
+		c.JSON(http.StatusConflict, status)
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// GetSyncStatus godoc
+// @Summary      Get the synchronization status of a project
+// @Description  Get the outcome of the last synchronization of a project with its upstream
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Project
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true  "The name of the project"
+// @Success      200          {object}  models.UpstreamSyncStatus
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Project not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/sync [get]
+func (sh *UpstreamSyncHandler) GetSyncStatus(c *gin.Context) {
+	params := &models.GetSyncStatusParams{
+		Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+	}
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	status, err := sh.UpstreamSyncManager.GetSyncStatus(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// ProjectWebhookSecret returns the secret the webhooks of a project are signed with. It is the hex encoded
+// HMAC-SHA256 of the project name, keyed with the configured webhook secret, so that a request signed for one project
+// is not accepted for another one
+func ProjectWebhookSecret(webhookSecret string, projectName string) string {
+	mac := hmac.New(sha256.New, []byte(webhookSecret))
+	mac.Write([]byte(projectName))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (sh *UpstreamSyncHandler) isAuthorized(c *gin.Context, projectName string) bool {
+	if sh.webhookSecret == "" {
+		return true
+	}
+	projectSecret := ProjectWebhookSecret(sh.webhookSecret, projectName)
+
+	if token := c.GetHeader(headerGitlabToken); token != "" {
+		return subtle.ConstantTimeCompare([]byte(token), []byte(projectSecret)) == 1
+	}
+
+	signature := c.GetHeader(headerHubSignature)
+	if !strings.HasPrefix(signature, hubSignaturePrefix) {
+		return false
+	}
+	receivedMAC, err := hex.DecodeString(strings.TrimPrefix(signature, hubSignaturePrefix))
+	if err != nil {
+		return false
+	}
+	payload, err := c.GetRawData()
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(projectSecret))
+	mac.Write(payload)
+	return hmac.Equal(receivedMAC, mac.Sum(nil))
+}
@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	errors2 "github.com/keptn/keptn/resource-service/errors"
+	handler_mock "github.com/keptn/keptn/resource-service/handler/fake"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+const syncWebhookTestPayload = `{"ref": "refs/heads/dev"}`
+const syncWebhookTestSecret = "my-secret"
+
+func signSyncWebhookPayload(payload string, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// syncWebhookTestProjectSecret is the webhook secret of my-project derived from syncWebhookTestSecret
+var syncWebhookTestProjectSecret = ProjectWebhookSecret(syncWebhookTestSecret, "my-project")
+
+func newSyncRequest(headers map[string]string) *http.Request {
+	request := httptest.NewRequest(http.MethodPost, "/project/my-project/sync", bytes.NewBuffer([]byte(syncWebhookTestPayload)))
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	return request
+}
+
+func TestUpstreamSyncHandler_SyncProject(t *testing.T) {
+	successfulSync := func(params models.SyncProjectParams) (*models.UpstreamSyncStatus, error) {
+		return &models.UpstreamSyncStatus{ProjectName: params.ProjectName, Revision: "my-revision"}, nil
+	}
+	tests := []struct {
+		name          string
+		webhookSecret string
+		syncFunc      func(params models.SyncProjectParams) (*models.UpstreamSyncStatus, error)
+		request       *http.Request
+		wantCalled    bool
+		wantStatus    int
+	}{
+		{
+			name:       "sync without secret",
+			syncFunc:   successfulSync,
+			request:    newSyncRequest(nil),
+			wantCalled: true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "sync with valid signature",
+			webhookSecret: syncWebhookTestSecret,
+			syncFunc:      successfulSync,
+			request:       newSyncRequest(map[string]string{headerHubSignature: signSyncWebhookPayload(syncWebhookTestPayload, syncWebhookTestProjectSecret)}),
+			wantCalled:    true,
+			wantStatus:    http.StatusOK,
+		},
+		{
+			name:          "sync with valid gitlab token",
+			webhookSecret: syncWebhookTestSecret,
+			syncFunc:      successfulSync,
+			request:       newSyncRequest(map[string]string{headerGitlabToken: syncWebhookTestProjectSecret}),
+			wantCalled:    true,
+			wantStatus:    http.StatusOK,
+		},
+		{
+			name:          "sync with invalid signature",
+			webhookSecret: syncWebhookTestSecret,
+			syncFunc:      successfulSync,
+			request:       newSyncRequest(map[string]string{headerHubSignature: signSyncWebhookPayload(syncWebhookTestPayload, "other-secret")}),
+			wantCalled:    false,
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "sync with signature of another project",
+			webhookSecret: syncWebhookTestSecret,
+			syncFunc:      successfulSync,
+			request:       newSyncRequest(map[string]string{headerHubSignature: signSyncWebhookPayload(syncWebhookTestPayload, ProjectWebhookSecret(syncWebhookTestSecret, "other-project"))}),
+			wantCalled:    false,
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "sync with signature of the configured secret",
+			webhookSecret: syncWebhookTestSecret,
+			syncFunc:      successfulSync,
+			request:       newSyncRequest(map[string]string{headerHubSignature: signSyncWebhookPayload(syncWebhookTestPayload, syncWebhookTestSecret)}),
+			wantCalled:    false,
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "sync with gitlab token of another project",
+			webhookSecret: syncWebhookTestSecret,
+			syncFunc:      successfulSync,
+			request:       newSyncRequest(map[string]string{headerGitlabToken: ProjectWebhookSecret(syncWebhookTestSecret, "other-project")}),
+			wantCalled:    false,
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "sync with invalid gitlab token",
+			webhookSecret: syncWebhookTestSecret,
+			syncFunc:      successfulSync,
+			request:       newSyncRequest(map[string]string{headerGitlabToken: "other-secret"}),
+			wantCalled:    false,
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "sync without signature",
+			webhookSecret: syncWebhookTestSecret,
+			syncFunc:      successfulSync,
+			request:       newSyncRequest(nil),
+			wantCalled:    false,
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name: "branches diverged",
+			syncFunc: func(params models.SyncProjectParams) (*models.UpstreamSyncStatus, error) {
+				return &models.UpstreamSyncStatus{ProjectName: params.ProjectName, ConflictingBranches: []string{"dev"}}, nil
+			},
+			request:    newSyncRequest(nil),
+			wantCalled: true,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "project not found",
+			syncFunc: func(params models.SyncProjectParams) (*models.UpstreamSyncStatus, error) {
+				return nil, errors2.ErrProjectNotFound
+			},
+			request:    newSyncRequest(nil),
+			wantCalled: true,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syncManager := &handler_mock.IUpstreamSyncManagerMock{SyncProjectFunc: tt.syncFunc}
+			sh := NewUpstreamSyncHandler(syncManager, tt.webhookSecret)
+
+			router := gin.Default()
+			router.POST("/project/:projectName/sync", sh.SyncProject)
+
+			resp := performRequest(router, tt.request)
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantCalled {
+				require.Len(t, syncManager.SyncProjectCalls(), 1)
+				require.Equal(t, "my-project", syncManager.SyncProjectCalls()[0].Params.ProjectName)
+			} else {
+				require.Empty(t, syncManager.SyncProjectCalls())
+			}
+		})
+	}
+}
+
+func TestProjectWebhookSecret(t *testing.T) {
+	mac := hmac.New(sha256.New, []byte(syncWebhookTestSecret))
+	mac.Write([]byte("my-project"))
+	require.Equal(t, hex.EncodeToString(mac.Sum(nil)), ProjectWebhookSecret(syncWebhookTestSecret, "my-project"))
+	require.NotEqual(t, ProjectWebhookSecret(syncWebhookTestSecret, "my-project"), ProjectWebhookSecret(syncWebhookTestSecret, "other-project"))
+}
+
+func TestUpstreamSyncHandler_GetSyncStatus(t *testing.T) {
+	syncManager := &handler_mock.IUpstreamSyncManagerMock{
+		GetSyncStatusFunc: func(params models.GetSyncStatusParams) (*models.UpstreamSyncStatus, error) {
+			return &models.UpstreamSyncStatus{ProjectName: params.ProjectName, ConflictingBranches: []string{"dev"}}, nil
+		},
+	}
+	sh := NewUpstreamSyncHandler(syncManager, "")
+
+	router := gin.Default()
+	router.GET("/project/:projectName/sync", sh.GetSyncStatus)
+
+	resp := performRequest(router, httptest.NewRequest(http.MethodGet, "/project/my-project/sync", nil))
+
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Contains(t, resp.Body.String(), `"conflictingBranches":["dev"]`)
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/keptn/keptn/resource-service/common"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	logger "github.com/sirupsen/logrus"
+)
+
+// IUpstreamSyncManager provides an interface for synchronizing projects with their upstream repository
+//
+//go:generate moq -pkg handler_mock -skip-ensure -out ./fake/upstream_sync_manager_mock.go . IUpstreamSyncManager
+type IUpstreamSyncManager interface {
+	SyncProject(params models.SyncProjectParams) (*models.UpstreamSyncStatus, error)
+	GetSyncStatus(params models.GetSyncStatusParams) (*models.UpstreamSyncStatus, error)
+}
+
+// UpstreamSyncManager keeps the local repositories of the projects up to date with their upstream.
+// The outcome of the last synchronization of each project is kept in memory
+type UpstreamSyncManager struct {
+	git              common.IGit
+	credentialReader common.CredentialReader
+	fileSystem       common.IFileSystem
+	status           map[string]models.UpstreamSyncStatus
+	statusMutex      sync.RWMutex
+}
+
+func NewUpstreamSyncManager(git common.IGit, credentialReader common.CredentialReader, fileSystem common.IFileSystem) *UpstreamSyncManager {
+	return &UpstreamSyncManager{
+		git:              git,
+		credentialReader: credentialReader,
+		fileSystem:       fileSystem,
+		status:           map[string]models.UpstreamSyncStatus{},
+	}
+}
+
+// Run synchronizes all projects with their upstream in the given interval until the context is cancelled
+func (s *UpstreamSyncManager) Run(ctx context.Context, interval time.Duration) {
+	logger.Infof("Synchronizing projects with their upstream every %s", interval.String())
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.SyncAllProjects()
+		}
+	}
+}
+
+// SyncAllProjects synchronizes each project that has a local repository with its upstream
+func (s *UpstreamSyncManager) SyncAllProjects() {
+	projectNames, err := s.fileSystem.ListDirectories(common.GetConfigDir())
+	if err != nil {
+		logger.Errorf("Could not retrieve projects to be synchronized: %v", err)
+		return
+	}
+
+	for _, projectName := range projectNames {
+		if !s.git.ProjectRepoExists(projectName) {
+			continue
+		}
+		status, err := s.SyncProject(models.SyncProjectParams{Project: models.Project{ProjectName: projectName}})
+		if err != nil {
+			logger.Errorf("Could not synchronize project %s with its upstream: %v", projectName, err)
+			continue
+		}
+		if status.HasConflicts() {
+			logger.Warnf("Branches %v of project %s have diverged from the upstream", status.ConflictingBranches, projectName)
+		}
+	}
+}
+
+func (s *UpstreamSyncManager) SyncProject(params models.SyncProjectParams) (*models.UpstreamSyncStatus, error) {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, err := s.establishGitContext(params.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	status := models.UpstreamSyncStatus{
+		ProjectName: params.ProjectName,
+		LastSync:    &now,
+	}
+
+	result, err := s.git.SyncUpstream(*gitContext)
+	if err != nil {
+		status.Error = err.Error()
+		s.setStatus(status)
+		return nil, fmt.Errorf("could not synchronize project %s with its upstream: %w", params.ProjectName, err)
+	}
+
+	status.Revision = result.Revision
+	status.UpdatedBranches = result.UpdatedBranches
+	status.ConflictingBranches = result.ConflictingBranches
+	s.setStatus(status)
+	return &status, nil
+}
+
+func (s *UpstreamSyncManager) GetSyncStatus(params models.GetSyncStatusParams) (*models.UpstreamSyncStatus, error) {
+	if !s.git.ProjectRepoExists(params.ProjectName) {
+		return nil, kerrors.ErrProjectNotFound
+	}
+
+	s.statusMutex.RLock()
+	defer s.statusMutex.RUnlock()
+
+	status, ok := s.status[params.ProjectName]
+	if !ok {
+		// the project has not been synchronized yet
+		return &models.UpstreamSyncStatus{ProjectName: params.ProjectName}, nil
+	}
+	return &status, nil
+}
+
+func (s *UpstreamSyncManager) setStatus(status models.UpstreamSyncStatus) {
+	s.statusMutex.Lock()
+	defer s.statusMutex.Unlock()
+	s.status[status.ProjectName] = status
+}
+
+func (s *UpstreamSyncManager) establishGitContext(project models.Project) (*common_models.GitContext, error) {
+	credentials, err := s.credentialReader.GetCredentials(project.ProjectName)
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotRetrieveCredentials, project.ProjectName, err)
+	}
+
+	auth, err := getAuthMethod(credentials)
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotEstablishAuthMethod, project.ProjectName, err)
+	}
+
+	gitContext := common_models.GitContext{
+		Project:     project.ProjectName,
+		Credentials: credentials,
+		AuthMethod:  *auth,
+	}
+
+	if !s.git.ProjectExists(gitContext) {
+		return nil, kerrors.ErrProjectNotFound
+	}
+	return &gitContext, nil
+}
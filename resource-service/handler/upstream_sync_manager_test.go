@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"errors"
+	"testing"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	common_mock "github.com/keptn/keptn/resource-service/common/fake"
+	"github.com/keptn/keptn/resource-service/common_models"
+	errors2 "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+type upstreamSyncManagerTestFields struct {
+	git              *common_mock.IGitMock
+	credentialReader *common_mock.CredentialReaderMock
+	fileSystem       *common_mock.IFileSystemMock
+}
+
+func TestUpstreamSyncManager_SyncProject(t *testing.T) {
+	fields := getTestUpstreamSyncManagerFields()
+	s := NewUpstreamSyncManager(fields.git, fields.credentialReader, fields.fileSystem)
+
+	status, err := s.SyncProject(models.SyncProjectParams{Project: models.Project{ProjectName: "my-project"}})
+
+	require.Nil(t, err)
+	require.Equal(t, "my-project", status.ProjectName)
+	require.Equal(t, "my-revision", status.Revision)
+	require.Equal(t, []string{"dev"}, status.UpdatedBranches)
+	require.Empty(t, status.ConflictingBranches)
+	require.NotNil(t, status.LastSync)
+	require.False(t, status.HasConflicts())
+
+	require.Len(t, fields.git.SyncUpstreamCalls(), 1)
+	require.Equal(t, "my-project", fields.git.SyncUpstreamCalls()[0].GitContext.Project)
+
+	storedStatus, err := s.GetSyncStatus(models.GetSyncStatusParams{Project: models.Project{ProjectName: "my-project"}})
+	require.Nil(t, err)
+	require.Equal(t, status, storedStatus)
+}
+
+func TestUpstreamSyncManager_SyncProject_Conflict(t *testing.T) {
+	fields := getTestUpstreamSyncManagerFields()
+	fields.git.SyncUpstreamFunc = func(gitContext common_models.GitContext) (*common_models.UpstreamSyncResult, error) {
+		return &common_models.UpstreamSyncResult{
+			Revision:            "my-revision",
+			UpdatedBranches:     []string{},
+			ConflictingBranches: []string{"production"},
+		}, nil
+	}
+	s := NewUpstreamSyncManager(fields.git, fields.credentialReader, fields.fileSystem)
+
+	status, err := s.SyncProject(models.SyncProjectParams{Project: models.Project{ProjectName: "my-project"}})
+
+	require.Nil(t, err)
+	require.True(t, status.HasConflicts())
+	require.Equal(t, []string{"production"}, status.ConflictingBranches)
+}
+
+func TestUpstreamSyncManager_SyncProject_SyncFails(t *testing.T) {
+	fields := getTestUpstreamSyncManagerFields()
+	fields.git.SyncUpstreamFunc = func(gitContext common_models.GitContext) (*common_models.UpstreamSyncResult, error) {
+		return nil, errors2.ErrAuthenticationRequired
+	}
+	s := NewUpstreamSyncManager(fields.git, fields.credentialReader, fields.fileSystem)
+
+	status, err := s.SyncProject(models.SyncProjectParams{Project: models.Project{ProjectName: "my-project"}})
+
+	require.ErrorIs(t, err, errors2.ErrAuthenticationRequired)
+	require.Nil(t, status)
+
+	// the failure should be reflected in the status of the project
+	storedStatus, err := s.GetSyncStatus(models.GetSyncStatusParams{Project: models.Project{ProjectName: "my-project"}})
+	require.Nil(t, err)
+	require.NotEmpty(t, storedStatus.Error)
+	require.NotNil(t, storedStatus.LastSync)
+}
+
+func TestUpstreamSyncManager_SyncProject_ProjectNotFound(t *testing.T) {
+	fields := getTestUpstreamSyncManagerFields()
+	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
+		return false
+	}
+	s := NewUpstreamSyncManager(fields.git, fields.credentialReader, fields.fileSystem)
+
+	status, err := s.SyncProject(models.SyncProjectParams{Project: models.Project{ProjectName: "my-project"}})
+
+	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
+	require.Nil(t, status)
+	require.Empty(t, fields.git.SyncUpstreamCalls())
+}
+
+func TestUpstreamSyncManager_GetSyncStatus_NotSynchronizedYet(t *testing.T) {
+	fields := getTestUpstreamSyncManagerFields()
+	s := NewUpstreamSyncManager(fields.git, fields.credentialReader, fields.fileSystem)
+
+	status, err := s.GetSyncStatus(models.GetSyncStatusParams{Project: models.Project{ProjectName: "my-project"}})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.UpstreamSyncStatus{ProjectName: "my-project"}, status)
+}
+
+func TestUpstreamSyncManager_GetSyncStatus_ProjectNotFound(t *testing.T) {
+	fields := getTestUpstreamSyncManagerFields()
+	fields.git.ProjectRepoExistsFunc = func(projectName string) bool {
+		return false
+	}
+	s := NewUpstreamSyncManager(fields.git, fields.credentialReader, fields.fileSystem)
+
+	status, err := s.GetSyncStatus(models.GetSyncStatusParams{Project: models.Project{ProjectName: "my-project"}})
+
+	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
+	require.Nil(t, status)
+}
+
+func TestUpstreamSyncManager_SyncAllProjects(t *testing.T) {
+	fields := getTestUpstreamSyncManagerFields()
+	fields.fileSystem.ListDirectoriesFunc = func(path string) ([]string, error) {
+		return []string{"project-a", "tmp_projects_migration", "project-b"}, nil
+	}
+	fields.git.ProjectRepoExistsFunc = func(projectName string) bool {
+		return projectName != "tmp_projects_migration"
+	}
+	fields.git.SyncUpstreamFunc = func(gitContext common_models.GitContext) (*common_models.UpstreamSyncResult, error) {
+		if gitContext.Project == "project-a" {
+			return nil, errors.New("oops")
+		}
+		return &common_models.UpstreamSyncResult{Revision: "my-revision"}, nil
+	}
+	s := NewUpstreamSyncManager(fields.git, fields.credentialReader, fields.fileSystem)
+
+	s.SyncAllProjects()
+
+	// a failing project must not prevent the other projects from being synchronized
+	require.Len(t, fields.git.SyncUpstreamCalls(), 2)
+	require.Equal(t, "project-a", fields.git.SyncUpstreamCalls()[0].GitContext.Project)
+	require.Equal(t, "project-b", fields.git.SyncUpstreamCalls()[1].GitContext.Project)
+}
+
+func getTestUpstreamSyncManagerFields() upstreamSyncManagerTestFields {
+	return upstreamSyncManagerTestFields{
+		git: &common_mock.IGitMock{
+			ProjectExistsFunc: func(gitContext common_models.GitContext) bool {
+				return true
+			},
+			ProjectRepoExistsFunc: func(projectName string) bool {
+				return true
+			},
+			SyncUpstreamFunc: func(gitContext common_models.GitContext) (*common_models.UpstreamSyncResult, error) {
+				return &common_models.UpstreamSyncResult{
+					Revision:            "my-revision",
+					UpdatedBranches:     []string{"dev"},
+					ConflictingBranches: []string{},
+				}, nil
+			},
+		},
+		credentialReader: &common_mock.CredentialReaderMock{
+			GetCredentialsFunc: func(project string) (*common_models.GitCredentials, error) {
+				return &common_models.GitCredentials{
+					User: "my-user",
+					HttpsAuth: &apimodels.HttpsGitAuth{
+						Token: "my-token",
+					},
+					RemoteURL: "my-remote-uri",
+				}, nil
+			},
+		},
+		fileSystem: &common_mock.IFileSystemMock{
+			ListDirectoriesFunc: func(path string) ([]string, error) {
+				return []string{"my-project"}, nil
+			},
+		},
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/keptn/keptn/resource-service/config"
 	"github.com/keptn/keptn/resource-service/controller"
 	"github.com/keptn/keptn/resource-service/handler"
+	"github.com/keptn/keptn/resource-service/validation"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -87,6 +88,7 @@ func main() {
 
 	git := common.NewGit(&common.GogitReal{})
 	configurationContext := createConfigurationContext(git, fileSystem)
+	resourceValidator := createResourceValidator()
 
 	projectManager := handler.NewProjectManager(git, credentialReader, fileSystem)
 	projectHandler := handler.NewProjectHandler(projectManager)
@@ -103,21 +105,40 @@ func main() {
 	serviceController := controller.NewServiceController(serviceHandler)
 	serviceController.Inject(apiV1)
 
-	projectResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext)
+	projectResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext, resourceValidator, !config.Global.UpstreamSyncEnabled())
 	projectResourceHandler := handler.NewProjectResourceHandler(projectResourceManager)
 	projectResourceController := controller.NewProjectResourceController(projectResourceHandler)
 	projectResourceController.Inject(apiV1)
 
-	stageResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext)
+	stageResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext, resourceValidator, !config.Global.UpstreamSyncEnabled())
 	stageResourceHandler := handler.NewStageResourceHandler(stageResourceManager)
 	stageResourceController := controller.NewStageResourceController(stageResourceHandler)
 	stageResourceController.Inject(apiV1)
 
-	serviceResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext)
+	serviceResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext, resourceValidator, !config.Global.UpstreamSyncEnabled())
 	serviceResourceHandler := handler.NewServiceResourceHandler(serviceResourceManager)
 	serviceResourceController := controller.NewServiceResourceController(serviceResourceHandler)
 	serviceResourceController.Inject(apiV1)
 
+	resourceFileManager := handler.NewResourceFileManager(git, credentialReader, fileSystem, configurationContext, resourceValidator, createLFSClient(), !config.Global.UpstreamSyncEnabled())
+	resourceFileHandler := handler.NewResourceFileHandler(resourceFileManager, config.Global.MaxResourceUploadSize())
+	resourceFileController := controller.NewResourceFileController(resourceFileHandler)
+	resourceFileController.Inject(apiV1)
+
+	snapshotManager := handler.NewSnapshotManager(git, credentialReader)
+	snapshotHandler := handler.NewSnapshotHandler(snapshotManager)
+	snapshotController := controller.NewSnapshotController(snapshotHandler)
+	snapshotController.Inject(apiV1)
+
+	upstreamSyncManager := handler.NewUpstreamSyncManager(git, credentialReader, fileSystem)
+	upstreamSyncHandler := handler.NewUpstreamSyncHandler(upstreamSyncManager, config.Global.UpstreamSyncWebhookSecret)
+	upstreamSyncController := controller.NewUpstreamSyncController(upstreamSyncHandler)
+	upstreamSyncController.Inject(apiV1)
+
+	if config.Global.UpstreamSyncEnabled() {
+		go upstreamSyncManager.Run(ctx, config.Global.UpstreamSyncInterval)
+	}
+
 	healthHandler := handler.NewHealthHandler()
 	healthController := controller.NewHealthController(healthHandler)
 	healthController.Inject(apiHealth)
@@ -150,6 +171,20 @@ func createConfigurationContext(git *common.Git, fileSystem *common.FileSystem)
 	return configContext
 }
 
+func createResourceValidator() validation.IResourceValidator {
+	if config.Global.ResourceValidationEnabled {
+		return validation.NewResourceValidator()
+	}
+	return validation.NoOpResourceValidator{}
+}
+
+func createLFSClient() common.ILFSClient {
+	if config.Global.GitLFSEnabled {
+		return common.NewLFSClient()
+	}
+	return nil
+}
+
 func createStageManager(configurationContext handler.IConfigurationContext, git common.IGit, fileSystem common.IFileSystem, credentialReader common.CredentialReader) handler.IStageManager {
 	var stageManager handler.IStageManager
 	if config.Global.DirectoryStageStructure {
@@ -1,5 +1,7 @@
 package models
 
+import "fmt"
+
 // Error error
 // swagger:model Error
 type Error struct {
@@ -15,3 +17,28 @@ type Error struct {
 func (m *Error) Error() string {
 	return m.Message
 }
+
+// ValidationError is returned if the content of a resource does not pass the validation for its file type
+// swagger:model ValidationError
+type ValidationError struct {
+
+	// Error code
+	Code int64 `json:"code,omitempty"`
+
+	// Error message
+	// Required: true
+	Message string `json:"message"`
+
+	// URI of the resource that failed the validation
+	ResourceURI string `json:"resourceURI,omitempty"`
+
+	// Path of the offending field within the resource, e.g. spec.stages[0].name
+	Field string `json:"field,omitempty"`
+}
+
+func (m *ValidationError) Error() string {
+	if m.Field == "" {
+		return fmt.Sprintf("invalid resource %s: %s", m.ResourceURI, m.Message)
+	}
+	return fmt.Sprintf("invalid resource %s: field %s: %s", m.ResourceURI, m.Field, m.Message)
+}
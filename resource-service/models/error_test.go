@@ -33,3 +33,29 @@ func TestError_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestValidationError_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		err  ValidationError
+		want string
+	}{
+		{
+			name: "error with field",
+			err:  ValidationError{Message: "must not be empty", ResourceURI: "shipyard.yaml", Field: "spec.stages[0].name"},
+			want: "invalid resource shipyard.yaml: field spec.stages[0].name: must not be empty",
+		},
+		{
+			name: "error without field",
+			err:  ValidationError{Message: "invalid yaml", ResourceURI: "slo.yaml"},
+			want: "invalid resource slo.yaml: invalid yaml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
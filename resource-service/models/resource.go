@@ -83,6 +83,7 @@ func (p GetResourcesParams) Validate() error {
 
 type GetResourceQuery struct {
 	GitCommitID string `json:"gitCommitID,omitempty" form:"gitCommitID"`
+	Snapshot    string `json:"snapshot,omitempty" form:"snapshot"`
 }
 
 type GetResourceParams struct {
@@ -98,6 +99,12 @@ func (p GetResourceParams) Validate() error {
 	if err := validateResourceURI(p.ResourceURI); err != nil {
 		return err
 	}
+	if p.Snapshot != "" {
+		if p.GitCommitID != "" {
+			return errors.New("gitCommitID and snapshot must not be set at the same time")
+		}
+		return validateSnapshotName(p.Snapshot)
+	}
 	return nil
 }
 
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"errors"
+	"io"
+)
+
+// UploadResourceParams contains the information about a resource whose content is streamed from a file upload
+type UploadResourceParams struct {
+	ResourceContext
+	ResourceURI string
+	// Content the raw (not base64 encoded) content of the resource
+	Content io.Reader
+}
+
+func (p UploadResourceParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if err := validateResourceURI(p.ResourceURI); err != nil {
+		return err
+	}
+	if p.Content == nil {
+		return errors.New("content of the resource must be provided")
+	}
+	return nil
+}
+
+// UploadResourceResponse contains the result of a file upload
+//
+// swagger:model UploadResourceResponse
+type UploadResourceResponse struct {
+	WriteResourceResponse
+	// Size the size of the uploaded resource in bytes
+	Size int64 `json:"size"`
+	// ContentType the detected media type of the uploaded resource
+	ContentType string `json:"contentType"`
+	// StoredInLFS indicates whether the content has been stored in the Git LFS server of the upstream repository
+	StoredInLFS bool `json:"storedInLFS"`
+}
+
+// ResourceFile contains the content of a resource that is streamed to the client
+type ResourceFile struct {
+	// Content the raw content of the resource. Must be closed by the receiver
+	Content     io.ReadCloser
+	Size        int64
+	ContentType string
+	Metadata    Version
+}
@@ -489,6 +489,42 @@ func TestGetResourceParams_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid snapshot",
+			fields: fields{
+				ResourceContext: ResourceContext{
+					Project: Project{ProjectName: "my-project"},
+					Stage:   &Stage{StageName: "my-stage"},
+				},
+				ResourceURI:      "my-resource.txt",
+				GetResourceQuery: GetResourceQuery{Snapshot: "release-1.0"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid snapshot name",
+			fields: fields{
+				ResourceContext: ResourceContext{
+					Project: Project{ProjectName: "my-project"},
+					Stage:   &Stage{StageName: "my-stage"},
+				},
+				ResourceURI:      "my-resource.txt",
+				GetResourceQuery: GetResourceQuery{Snapshot: "release/1.0"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "snapshot and commit ID set",
+			fields: fields{
+				ResourceContext: ResourceContext{
+					Project: Project{ProjectName: "my-project"},
+					Stage:   &Stage{StageName: "my-stage"},
+				},
+				ResourceURI:      "my-resource.txt",
+				GetResourceQuery: GetResourceQuery{Snapshot: "release-1.0", GitCommitID: "commit-id"},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
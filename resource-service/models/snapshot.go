@@ -0,0 +1,123 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var snapshotNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+type Snapshot struct {
+	// SnapshotName the name of the snapshot
+	SnapshotName string `form:"snapshotName" json:"snapshotName,omitempty"`
+}
+
+func (s Snapshot) Validate() error {
+	return validateSnapshotName(s.SnapshotName)
+}
+
+func validateSnapshotName(name string) error {
+	if name == "" {
+		return errors.New("snapshot name must not be empty")
+	}
+	// the snapshot name becomes part of a git tag, so it must be a valid git reference name as well
+	if !snapshotNameRegex.MatchString(name) || strings.Contains(name, "..") || strings.HasSuffix(name, ".lock") {
+		return errors.New("snapshot name must only contain alphanumeric characters, '.', '_' or '-'")
+	}
+	return nil
+}
+
+// SnapshotRevision contains the commit a branch of the project pointed to when the snapshot was created
+//
+// swagger:model SnapshotRevision
+type SnapshotRevision struct {
+	// Branch the branch of the project repository
+	Branch string `json:"branch"`
+	// CommitID the commit ID of the branch at the time of the snapshot
+	CommitID string `json:"commitID"`
+}
+
+// SnapshotInfo contains information about a snapshot
+//
+// swagger:model SnapshotInfo
+type SnapshotInfo struct {
+	Snapshot
+	// Message the message that has been provided when creating the snapshot
+	Message string `json:"message,omitempty"`
+	// CreationDate the time the snapshot has been created
+	CreationDate time.Time `json:"creationDate,omitempty"`
+	// Revisions the revisions of all branches included in the snapshot
+	Revisions []SnapshotRevision `json:"revisions"`
+}
+
+type CreateSnapshotPayload struct {
+	Snapshot
+	// Message describes the snapshot, e.g. the release it belongs to
+	Message string `json:"message,omitempty"`
+}
+
+// CreateSnapshotParams contains information about the snapshot to be created
+//
+// swagger:model CreateSnapshotParams
+type CreateSnapshotParams struct {
+	Project
+	CreateSnapshotPayload
+}
+
+func (s CreateSnapshotParams) Validate() error {
+	if err := s.Project.Validate(); err != nil {
+		return err
+	}
+	return s.Snapshot.Validate()
+}
+
+// GetSnapshotsParams contains information about the project whose snapshots should be retrieved
+//
+// swagger:model GetSnapshotsParams
+type GetSnapshotsParams struct {
+	Project
+}
+
+func (s GetSnapshotsParams) Validate() error {
+	return s.Project.Validate()
+}
+
+// GetSnapshotsResponse contains the snapshots of a project
+//
+// swagger:model GetSnapshotsResponse
+type GetSnapshotsResponse struct {
+	// Snapshots the snapshots of the project
+	Snapshots []SnapshotInfo `json:"snapshots"`
+}
+
+// GetSnapshotParams contains information about the snapshot to be retrieved
+//
+// swagger:model GetSnapshotParams
+type GetSnapshotParams struct {
+	Project
+	Snapshot
+}
+
+func (s GetSnapshotParams) Validate() error {
+	if err := s.Project.Validate(); err != nil {
+		return err
+	}
+	return s.Snapshot.Validate()
+}
+
+// DeleteSnapshotParams contains information about the snapshot to be deleted
+//
+// swagger:model DeleteSnapshotParams
+type DeleteSnapshotParams struct {
+	Project
+	Snapshot
+}
+
+func (s DeleteSnapshotParams) Validate() error {
+	if err := s.Project.Validate(); err != nil {
+		return err
+	}
+	return s.Snapshot.Validate()
+}
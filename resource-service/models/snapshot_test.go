@@ -0,0 +1,66 @@
+package models
+
+import "testing"
+
+func TestCreateSnapshotParams_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		projectName  string
+		snapshotName string
+		wantErr      bool
+	}{
+		{
+			name:         "valid",
+			projectName:  "my-project",
+			snapshotName: "release-1.0.0",
+			wantErr:      false,
+		},
+		{
+			name:         "invalid project name",
+			projectName:  "my project",
+			snapshotName: "release-1.0.0",
+			wantErr:      true,
+		},
+		{
+			name:         "empty snapshot name",
+			projectName:  "my-project",
+			snapshotName: "",
+			wantErr:      true,
+		},
+		{
+			name:         "snapshot name containing slash",
+			projectName:  "my-project",
+			snapshotName: "release/1.0.0",
+			wantErr:      true,
+		},
+		{
+			name:         "snapshot name starting with dot",
+			projectName:  "my-project",
+			snapshotName: ".release",
+			wantErr:      true,
+		},
+		{
+			name:         "snapshot name containing double dot",
+			projectName:  "my-project",
+			snapshotName: "release..1",
+			wantErr:      true,
+		},
+		{
+			name:         "snapshot name ending with .lock",
+			projectName:  "my-project",
+			snapshotName: "release.lock",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := CreateSnapshotParams{
+				Project:               Project{ProjectName: tt.projectName},
+				CreateSnapshotPayload: CreateSnapshotPayload{Snapshot: Snapshot{SnapshotName: tt.snapshotName}},
+			}
+			if err := s.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// UpstreamSyncStatus contains the outcome of the last synchronization of a project with its upstream
+//
+// swagger:model UpstreamSyncStatus
+type UpstreamSyncStatus struct {
+	// ProjectName the name of the project
+	ProjectName string `json:"projectName"`
+	// LastSync the time of the last synchronization attempt
+	LastSync *time.Time `json:"lastSync,omitempty"`
+	// Revision the commit ID of the checked out branch after the last synchronization
+	Revision string `json:"revision,omitempty"`
+	// UpdatedBranches the branches that have been updated during the last synchronization
+	UpdatedBranches []string `json:"updatedBranches,omitempty"`
+	// ConflictingBranches the branches whose local state has diverged from the upstream
+	ConflictingBranches []string `json:"conflictingBranches,omitempty"`
+	// Error describes why the last synchronization has failed
+	Error string `json:"error,omitempty"`
+}
+
+// HasConflicts returns true if at least one branch has diverged from the upstream
+func (s UpstreamSyncStatus) HasConflicts() bool {
+	return len(s.ConflictingBranches) > 0
+}
+
+// SyncProjectParams contains information about the project to be synchronized with its upstream
+//
+// swagger:model SyncProjectParams
+type SyncProjectParams struct {
+	Project
+}
+
+func (p SyncProjectParams) Validate() error {
+	return p.Project.Validate()
+}
+
+// GetSyncStatusParams contains information about the project whose synchronization status should be retrieved
+//
+// swagger:model GetSyncStatusParams
+type GetSyncStatusParams struct {
+	Project
+}
+
+func (p GetSyncStatusParams) Validate() error {
+	return p.Project.Validate()
+}
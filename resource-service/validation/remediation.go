@@ -0,0 +1,56 @@
+package validation
+
+import "fmt"
+
+const remediationAPIVersion = "spec.keptn.sh/0.1.4"
+const remediationKind = "Remediation"
+
+type remediation struct {
+	ApiVersion string          `yaml:"apiVersion"`
+	Kind       string          `yaml:"kind"`
+	Spec       remediationSpec `yaml:"spec"`
+}
+
+type remediationSpec struct {
+	Remediations []remediationMap `yaml:"remediations"`
+}
+
+type remediationMap struct {
+	ProblemType   string                     `yaml:"problemType"`
+	ActionsOnOpen []remediationActionsOnOpen `yaml:"actionsOnOpen"`
+}
+
+type remediationActionsOnOpen struct {
+	Action string `yaml:"action"`
+}
+
+// ValidateRemediation checks that the content is a remediation of version 0.1.4 with a problem type and actions for each remediation
+func ValidateRemediation(content []byte) error {
+	r := &remediation{}
+	if err := decodeYAML(content, r); err != nil {
+		return err
+	}
+
+	if r.ApiVersion != remediationAPIVersion {
+		return fieldError("apiVersion", "unsupported remediation version '%s', expected %s", r.ApiVersion, remediationAPIVersion)
+	}
+	if r.Kind != remediationKind {
+		return fieldError("kind", "expected '%s' but got '%s'", remediationKind, r.Kind)
+	}
+
+	for i, rem := range r.Spec.Remediations {
+		remediationField := fmt.Sprintf("spec.remediations[%d]", i)
+		if rem.ProblemType == "" {
+			return fieldError(remediationField+".problemType", "problem type must not be empty")
+		}
+		if len(rem.ActionsOnOpen) == 0 {
+			return fieldError(remediationField+".actionsOnOpen", "remediation must contain at least one action")
+		}
+		for j, action := range rem.ActionsOnOpen {
+			if action.Action == "" {
+				return fieldError(fmt.Sprintf("%s.actionsOnOpen[%d].action", remediationField, j), "action must not be empty")
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+const shipyardAPIVersionPrefix = "spec.keptn.sh/0.2"
+const shipyardKind = "Shipyard"
+
+type shipyard struct {
+	ApiVersion string       `yaml:"apiVersion"`
+	Kind       string       `yaml:"kind"`
+	Spec       shipyardSpec `yaml:"spec"`
+}
+
+type shipyardSpec struct {
+	Stages []shipyardStage `yaml:"stages"`
+}
+
+type shipyardStage struct {
+	Name      string             `yaml:"name"`
+	Sequences []shipyardSequence `yaml:"sequences"`
+}
+
+type shipyardSequence struct {
+	Name        string            `yaml:"name"`
+	TriggeredOn []shipyardTrigger `yaml:"triggeredOn"`
+	Tasks       []shipyardTask    `yaml:"tasks"`
+}
+
+type shipyardTrigger struct {
+	Event string `yaml:"event"`
+}
+
+type shipyardTask struct {
+	Name string `yaml:"name"`
+}
+
+// ValidateShipyard checks that the content is a shipyard of version 0.2.x with uniquely named stages, sequences and named tasks
+func ValidateShipyard(content []byte) error {
+	s := &shipyard{}
+	if err := decodeYAML(content, s); err != nil {
+		return err
+	}
+
+	if !strings.HasPrefix(s.ApiVersion, shipyardAPIVersionPrefix) {
+		return fieldError("apiVersion", "unsupported shipyard version '%s', expected %s.x", s.ApiVersion, shipyardAPIVersionPrefix)
+	}
+	if s.Kind != shipyardKind {
+		return fieldError("kind", "expected '%s' but got '%s'", shipyardKind, s.Kind)
+	}
+	if len(s.Spec.Stages) == 0 {
+		return fieldError("spec.stages", "shipyard must contain at least one stage")
+	}
+
+	stageNames := map[string]bool{}
+	for i, stage := range s.Spec.Stages {
+		stageField := fmt.Sprintf("spec.stages[%d]", i)
+		if !entityNameRegex.MatchString(stage.Name) {
+			return fieldError(stageField+".name", "stage name '%s' must start with a lower case letter, followed by lower case letters, numbers or hyphens", stage.Name)
+		}
+		if stageNames[stage.Name] {
+			return fieldError(stageField+".name", "duplicate stage '%s'", stage.Name)
+		}
+		stageNames[stage.Name] = true
+
+		if err := validateShipyardSequences(stageField, stage.Sequences); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateShipyardSequences(stageField string, sequences []shipyardSequence) error {
+	sequenceNames := map[string]bool{}
+	for i, sequence := range sequences {
+		sequenceField := fmt.Sprintf("%s.sequences[%d]", stageField, i)
+		if sequence.Name == "" {
+			return fieldError(sequenceField+".name", "sequence name must not be empty")
+		}
+		if sequenceNames[sequence.Name] {
+			return fieldError(sequenceField+".name", "duplicate sequence '%s'", sequence.Name)
+		}
+		sequenceNames[sequence.Name] = true
+
+		for j, trigger := range sequence.TriggeredOn {
+			if trigger.Event == "" {
+				return fieldError(fmt.Sprintf("%s.triggeredOn[%d].event", sequenceField, j), "trigger event must not be empty")
+			}
+		}
+		for j, task := range sequence.Tasks {
+			if task.Name == "" {
+				return fieldError(fmt.Sprintf("%s.tasks[%d].name", sequenceField, j), "task name must not be empty")
+			}
+		}
+	}
+	return nil
+}
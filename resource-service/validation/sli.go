@@ -0,0 +1,28 @@
+package validation
+
+import "fmt"
+
+type sliConfig struct {
+	Indicators map[string]string `yaml:"indicators"`
+}
+
+// ValidateSLI checks that an SLI file contains a non-empty query for each indicator
+func ValidateSLI(content []byte) error {
+	s := &sliConfig{}
+	if err := decodeYAML(content, s); err != nil {
+		return err
+	}
+
+	if len(s.Indicators) == 0 {
+		return fieldError("indicators", "sli file must contain at least one indicator")
+	}
+	for name, query := range s.Indicators {
+		if name == "" {
+			return fieldError("indicators", "indicator name must not be empty")
+		}
+		if query == "" {
+			return fieldError(fmt.Sprintf("indicators.%s", name), "query must not be empty")
+		}
+	}
+	return nil
+}
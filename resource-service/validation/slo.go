@@ -0,0 +1,132 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var sloCriteriaRegex = regexp.MustCompile(`^(<=|>=|<|>|=)[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)%?$`)
+
+var sloCompareWithValues = []string{"single_result", "several_results"}
+var sloIncludeResultWithScoreValues = []string{"all", "pass", "pass_or_warn"}
+var sloAggregateFunctionValues = []string{"avg", "p50", "p90", "p95"}
+
+type serviceLevelObjectives struct {
+	Comparison *sloComparison `yaml:"comparison"`
+	Objectives []*slo         `yaml:"objectives"`
+	TotalScore *sloScore      `yaml:"total_score"`
+}
+
+type sloComparison struct {
+	CompareWith               string `yaml:"compare_with"`
+	IncludeResultWithScore    string `yaml:"include_result_with_score"`
+	NumberOfComparisonResults int    `yaml:"number_of_comparison_results"`
+	AggregateFunction         string `yaml:"aggregate_function"`
+}
+
+type slo struct {
+	SLI     string         `yaml:"sli"`
+	Pass    []*sloCriteria `yaml:"pass"`
+	Warning []*sloCriteria `yaml:"warning"`
+	Weight  int            `yaml:"weight"`
+}
+
+type sloCriteria struct {
+	Criteria []string `yaml:"criteria"`
+}
+
+type sloScore struct {
+	Pass    string `yaml:"pass"`
+	Warning string `yaml:"warning"`
+}
+
+// ValidateSLO checks the comparison settings, objectives and total score of an SLO file
+func ValidateSLO(content []byte) error {
+	s := &serviceLevelObjectives{}
+	if err := decodeYAML(content, s); err != nil {
+		return err
+	}
+
+	if s.Comparison != nil {
+		if err := validateSLOComparison(s.Comparison); err != nil {
+			return err
+		}
+	}
+
+	for i, objective := range s.Objectives {
+		objectiveField := fmt.Sprintf("objectives[%d]", i)
+		if objective == nil || objective.SLI == "" {
+			return fieldError(objectiveField+".sli", "sli must not be empty")
+		}
+		if objective.Weight < 0 {
+			return fieldError(objectiveField+".weight", "weight must not be negative")
+		}
+		if err := validateSLOCriteria(objectiveField+".pass", objective.Pass); err != nil {
+			return err
+		}
+		if err := validateSLOCriteria(objectiveField+".warning", objective.Warning); err != nil {
+			return err
+		}
+	}
+
+	if s.TotalScore != nil {
+		if err := validateSLOScore("total_score.pass", s.TotalScore.Pass); err != nil {
+			return err
+		}
+		if err := validateSLOScore("total_score.warning", s.TotalScore.Warning); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateSLOComparison(comparison *sloComparison) error {
+	if comparison.CompareWith != "" && !contains(sloCompareWithValues, comparison.CompareWith) {
+		return fieldError("comparison.compare_with", "unsupported value '%s', expected one of %v", comparison.CompareWith, sloCompareWithValues)
+	}
+	if comparison.IncludeResultWithScore != "" && !contains(sloIncludeResultWithScoreValues, comparison.IncludeResultWithScore) {
+		return fieldError("comparison.include_result_with_score", "unsupported value '%s', expected one of %v", comparison.IncludeResultWithScore, sloIncludeResultWithScoreValues)
+	}
+	if comparison.AggregateFunction != "" && !contains(sloAggregateFunctionValues, comparison.AggregateFunction) {
+		return fieldError("comparison.aggregate_function", "unsupported value '%s', expected one of %v", comparison.AggregateFunction, sloAggregateFunctionValues)
+	}
+	if comparison.NumberOfComparisonResults < 0 {
+		return fieldError("comparison.number_of_comparison_results", "number of comparison results must not be negative")
+	}
+	return nil
+}
+
+func validateSLOCriteria(field string, criteria []*sloCriteria) error {
+	for i, c := range criteria {
+		if c == nil {
+			continue
+		}
+		for j, criterion := range c.Criteria {
+			if !sloCriteriaRegex.MatchString(strings.ReplaceAll(criterion, " ", "")) {
+				return fieldError(fmt.Sprintf("%s[%d].criteria[%d]", field, i, j), "invalid criteria '%s', expected an operator (<, <=, =, >=, >) followed by a number and an optional '%%'", criterion)
+			}
+		}
+	}
+	return nil
+}
+
+func validateSLOScore(field string, score string) error {
+	if score == "" {
+		return nil
+	}
+	if _, err := strconv.ParseFloat(strings.TrimSuffix(score, "%"), 64); err != nil {
+		return fieldError(field, "invalid score '%s', expected a percentage", score)
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
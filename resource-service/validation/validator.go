@@ -0,0 +1,104 @@
+package validation
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path"
+	"regexp"
+
+	"github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	"gopkg.in/yaml.v3"
+)
+
+const shipyardFileName = "shipyard.yaml"
+const sloFileName = "slo.yaml"
+const sliFileName = "sli.yaml"
+const remediationFileName = "remediation.yaml"
+const webhookFileName = "webhook.yaml"
+
+var entityNameRegex = regexp.MustCompile(`^[a-z][a-z0-9-]*[a-z0-9]$|^[a-z]$`)
+
+// IResourceValidator validates the content of resources before they are written to the repository
+//
+//go:generate moq -pkg validation_mock -skip-ensure -out ./fake/resource_validator_mock.go . IResourceValidator
+type IResourceValidator interface {
+	Validate(resourceURI string, content models.ResourceContent) error
+}
+
+// ContentValidator checks the decoded content of a resource of a well-known file type.
+// It returns a *models.ValidationError pointing at the offending field if the content is invalid
+type ContentValidator func(content []byte) error
+
+// ResourceValidator selects a ContentValidator based on the file name of a resource
+type ResourceValidator struct {
+	validators map[string]ContentValidator
+}
+
+// NewResourceValidator returns a ResourceValidator for the Keptn file types shipyard.yaml, slo.yaml, sli.yaml,
+// remediation.yaml and webhook.yaml
+func NewResourceValidator() *ResourceValidator {
+	return &ResourceValidator{
+		validators: map[string]ContentValidator{
+			shipyardFileName:    ValidateShipyard,
+			sloFileName:         ValidateSLO,
+			sliFileName:         ValidateSLI,
+			remediationFileName: ValidateRemediation,
+			webhookFileName:     ValidateWebhookConfig,
+		},
+	}
+}
+
+// Validate decodes the given content and validates it if the file name of the resource is a well-known Keptn file type.
+// Resources of other types are accepted without further checks
+func (r ResourceValidator) Validate(resourceURI string, content models.ResourceContent) error {
+	validate, ok := r.validators[path.Base(resourceURI)]
+	if !ok {
+		return nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(string(content))
+	if err != nil {
+		return errors.ErrResourceNotBase64Encoded
+	}
+
+	if err := validate(decoded); err != nil {
+		if validationErr, ok := err.(*models.ValidationError); ok {
+			validationErr.ResourceURI = resourceURI
+			return validationErr
+		}
+		return &models.ValidationError{ResourceURI: resourceURI, Message: err.Error()}
+	}
+	return nil
+}
+
+// IsWellKnownResource returns true if the file name of the resource is one of the Keptn file types that are validated
+// by a ResourceValidator
+func IsWellKnownResource(resourceURI string) bool {
+	switch path.Base(resourceURI) {
+	case shipyardFileName, sloFileName, sliFileName, remediationFileName, webhookFileName:
+		return true
+	}
+	return false
+}
+
+// NoOpResourceValidator accepts every resource. It is used if the resource validation is disabled
+type NoOpResourceValidator struct{}
+
+func (NoOpResourceValidator) Validate(string, models.ResourceContent) error {
+	return nil
+}
+
+func decodeYAML(content []byte, out interface{}) error {
+	if err := yaml.Unmarshal(content, out); err != nil {
+		return &models.ValidationError{Message: "could not decode yaml: " + err.Error()}
+	}
+	return nil
+}
+
+func fieldError(field string, format string, args ...interface{}) error {
+	return &models.ValidationError{
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,321 @@
+package validation
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+const validShipyard = `apiVersion: "spec.keptn.sh/0.2.3"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"
+            - name: "evaluation"
+    - name: "production"
+      sequences:
+        - name: "delivery"
+          triggeredOn:
+            - event: "dev.delivery.finished"
+          tasks:
+            - name: "deployment"
+`
+
+const validSLO = `spec_version: "0.1.1"
+comparison:
+  aggregate_function: "avg"
+  compare_with: "single_result"
+  include_result_with_score: "pass"
+  number_of_comparison_results: 1
+objectives:
+  - sli: "response_time_p95"
+    pass:
+      - criteria:
+          - "<=+10%"
+          - "<600"
+    warning:
+      - criteria:
+          - "<=800"
+    weight: 1
+total_score:
+  pass: "90%"
+  warning: "75%"
+`
+
+const validSLI = `spec_version: "1.0"
+indicators:
+  response_time_p95: "metricSelector=builtin:service.response.time:percentile(95)"
+`
+
+const validRemediation = `apiVersion: spec.keptn.sh/0.1.4
+kind: Remediation
+metadata:
+  name: service-remediation
+spec:
+  remediations:
+    - problemType: Response time degradation
+      actionsOnOpen:
+        - action: scale
+          name: scale
+          value: "1"
+`
+
+const validWebhookBeta = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      requests:
+        - url: "http://localhost:8080"
+          method: "POST"
+          headers:
+            - key: "Content-Type"
+              value: "application/json"
+          payload: "{}"
+`
+
+const validWebhookAlpha = `apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - "curl http://localhost:8080"
+`
+
+func encode(s string) models.ResourceContent {
+	return models.ResourceContent(base64.StdEncoding.EncodeToString([]byte(s)))
+}
+
+func TestResourceValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		resourceURI string
+		content     models.ResourceContent
+		wantErr     error
+		wantInvalid bool
+		wantField   string
+	}{
+		{
+			name:        "unknown file type is not validated",
+			resourceURI: "helm/chart.tgz",
+			content:     encode("not yaml: ["),
+		},
+		{
+			name:        "valid shipyard",
+			resourceURI: "shipyard.yaml",
+			content:     encode(validShipyard),
+		},
+		{
+			name:        "valid slo in service directory",
+			resourceURI: "my-service/slo.yaml",
+			content:     encode(validSLO),
+		},
+		{
+			name:        "valid sli in provider directory",
+			resourceURI: "dynatrace/sli.yaml",
+			content:     encode(validSLI),
+		},
+		{
+			name:        "valid remediation",
+			resourceURI: "remediation.yaml",
+			content:     encode(validRemediation),
+		},
+		{
+			name:        "valid beta webhook config",
+			resourceURI: "webhook/webhook.yaml",
+			content:     encode(validWebhookBeta),
+		},
+		{
+			name:        "valid alpha webhook config",
+			resourceURI: "webhook/webhook.yaml",
+			content:     encode(validWebhookAlpha),
+		},
+		{
+			name:        "content not base64 encoded",
+			resourceURI: "shipyard.yaml",
+			content:     "not-base64!",
+			wantErr:     errors.ErrResourceNotBase64Encoded,
+		},
+		{
+			name:        "invalid yaml",
+			resourceURI: "shipyard.yaml",
+			content:     encode("apiVersion: ["),
+			wantInvalid: true,
+		},
+		{
+			name:        "shipyard with unsupported version",
+			resourceURI: "shipyard.yaml",
+			content:     encode("apiVersion: spec.keptn.sh/0.1.7\nkind: Shipyard\n"),
+			wantInvalid: true,
+			wantField:   "apiVersion",
+		},
+		{
+			name:        "shipyard without stages",
+			resourceURI: "shipyard.yaml",
+			content:     encode("apiVersion: spec.keptn.sh/0.2.3\nkind: Shipyard\nspec:\n  stages: []\n"),
+			wantInvalid: true,
+			wantField:   "spec.stages",
+		},
+		{
+			name:        "shipyard with invalid stage name",
+			resourceURI: "shipyard.yaml",
+			content:     encode("apiVersion: spec.keptn.sh/0.2.3\nkind: Shipyard\nspec:\n  stages:\n    - name: dev\n    - name: Prod_1\n"),
+			wantInvalid: true,
+			wantField:   "spec.stages[1].name",
+		},
+		{
+			name:        "shipyard with duplicate stage",
+			resourceURI: "shipyard.yaml",
+			content:     encode("apiVersion: spec.keptn.sh/0.2.3\nkind: Shipyard\nspec:\n  stages:\n    - name: dev\n    - name: dev\n"),
+			wantInvalid: true,
+			wantField:   "spec.stages[1].name",
+		},
+		{
+			name:        "shipyard with duplicate sequence",
+			resourceURI: "shipyard.yaml",
+			content:     encode("apiVersion: spec.keptn.sh/0.2.3\nkind: Shipyard\nspec:\n  stages:\n    - name: dev\n      sequences:\n        - name: delivery\n        - name: delivery\n"),
+			wantInvalid: true,
+			wantField:   "spec.stages[0].sequences[1].name",
+		},
+		{
+			name:        "shipyard with unnamed task",
+			resourceURI: "shipyard.yaml",
+			content:     encode("apiVersion: spec.keptn.sh/0.2.3\nkind: Shipyard\nspec:\n  stages:\n    - name: dev\n      sequences:\n        - name: delivery\n          tasks:\n            - name: ''\n"),
+			wantInvalid: true,
+			wantField:   "spec.stages[0].sequences[0].tasks[0].name",
+		},
+		{
+			name:        "slo with invalid criteria",
+			resourceURI: "slo.yaml",
+			content:     encode("objectives:\n  - sli: response_time\n    pass:\n      - criteria:\n          - \"600\"\n"),
+			wantInvalid: true,
+			wantField:   "objectives[0].pass[0].criteria[0]",
+		},
+		{
+			name:        "slo without sli",
+			resourceURI: "slo.yaml",
+			content:     encode("objectives:\n  - displayName: response time\n"),
+			wantInvalid: true,
+			wantField:   "objectives[0].sli",
+		},
+		{
+			name:        "slo with invalid aggregate function",
+			resourceURI: "slo.yaml",
+			content:     encode("comparison:\n  aggregate_function: p99\n"),
+			wantInvalid: true,
+			wantField:   "comparison.aggregate_function",
+		},
+		{
+			name:        "slo with invalid total score",
+			resourceURI: "slo.yaml",
+			content:     encode("total_score:\n  pass: ninety\n"),
+			wantInvalid: true,
+			wantField:   "total_score.pass",
+		},
+		{
+			name:        "sli without indicators",
+			resourceURI: "sli.yaml",
+			content:     encode("spec_version: '1.0'\n"),
+			wantInvalid: true,
+			wantField:   "indicators",
+		},
+		{
+			name:        "sli with empty query",
+			resourceURI: "sli.yaml",
+			content:     encode("indicators:\n  throughput: ''\n"),
+			wantInvalid: true,
+			wantField:   "indicators.throughput",
+		},
+		{
+			name:        "remediation without action",
+			resourceURI: "remediation.yaml",
+			content:     encode("apiVersion: spec.keptn.sh/0.1.4\nkind: Remediation\nspec:\n  remediations:\n    - problemType: Response time degradation\n      actionsOnOpen:\n        - name: scale\n"),
+			wantInvalid: true,
+			wantField:   "spec.remediations[0].actionsOnOpen[0].action",
+		},
+		{
+			name:        "remediation with wrong kind",
+			resourceURI: "remediation.yaml",
+			content:     encode("apiVersion: spec.keptn.sh/0.1.4\nkind: Shipyard\n"),
+			wantInvalid: true,
+			wantField:   "kind",
+		},
+		{
+			name:        "webhook config with unsupported version",
+			resourceURI: "webhook.yaml",
+			content:     encode("apiVersion: webhookconfig.keptn.sh/v2\n"),
+			wantInvalid: true,
+			wantField:   "apiVersion",
+		},
+		{
+			name:        "webhook config without subscription id",
+			resourceURI: "webhook.yaml",
+			content:     encode("apiVersion: webhookconfig.keptn.sh/v1beta1\nspec:\n  webhooks:\n    - type: sh.keptn.event.webhook.triggered\n      requests:\n        - url: http://localhost\n          method: POST\n"),
+			wantInvalid: true,
+			wantField:   "spec.webhooks[0].subscriptionID",
+		},
+		{
+			name:        "webhook config with unsupported method",
+			resourceURI: "webhook.yaml",
+			content:     encode("apiVersion: webhookconfig.keptn.sh/v1beta1\nspec:\n  webhooks:\n    - type: sh.keptn.event.webhook.triggered\n      subscriptionID: my-id\n      requests:\n        - url: http://localhost\n          method: DELETE\n"),
+			wantInvalid: true,
+			wantField:   "spec.webhooks[0].requests[0].method",
+		},
+		{
+			name:        "beta webhook config with curl command",
+			resourceURI: "webhook.yaml",
+			content:     encode("apiVersion: webhookconfig.keptn.sh/v1beta1\nspec:\n  webhooks:\n    - type: sh.keptn.event.webhook.triggered\n      subscriptionID: my-id\n      requests:\n        - curl http://localhost\n"),
+			wantInvalid: true,
+			wantField:   "spec.webhooks[0].requests[0]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewResourceValidator().Validate(tt.resourceURI, tt.content)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantInvalid {
+				require.Nil(t, err)
+				return
+			}
+			validationErr := &models.ValidationError{}
+			require.ErrorAs(t, err, &validationErr)
+			require.Equal(t, tt.resourceURI, validationErr.ResourceURI)
+			require.Equal(t, tt.wantField, validationErr.Field)
+			require.NotEmpty(t, validationErr.Message)
+		})
+	}
+}
+
+func TestNoOpResourceValidator_Validate(t *testing.T) {
+	err := NoOpResourceValidator{}.Validate("shipyard.yaml", encode("apiVersion: ["))
+	require.Nil(t, err)
+}
+
+func TestIsWellKnownResource(t *testing.T) {
+	require.True(t, IsWellKnownResource("shipyard.yaml"))
+	require.True(t, IsWellKnownResource("dynatrace/sli.yaml"))
+	require.False(t, IsWellKnownResource("helm/carts.tgz"))
+	require.False(t, IsWellKnownResource("my-shipyard.yaml"))
+}
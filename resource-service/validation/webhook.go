@@ -0,0 +1,103 @@
+package validation
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+const webhookBetaAPIVersion = "webhookconfig.keptn.sh/v1beta1"
+const webhookAlphaAPIVersion = "webhookconfig.keptn.sh/v1alpha1"
+
+var webhookSupportedMethods = []string{"POST", "PUT", "GET", "HEAD"}
+
+type webhookConfig struct {
+	ApiVersion string            `yaml:"apiVersion"`
+	Spec       webhookConfigSpec `yaml:"spec"`
+}
+
+type webhookConfigSpec struct {
+	Webhooks []webhook `yaml:"webhooks"`
+}
+
+type webhook struct {
+	Type           string `yaml:"type"`
+	SubscriptionID string `yaml:"subscriptionID"`
+	// Requests are kept as nodes, since v1alpha1 requests are curl commands and v1beta1 requests are objects
+	Requests []yaml.Node `yaml:"requests"`
+}
+
+type webhookRequest struct {
+	URL     string          `yaml:"url"`
+	Method  string          `yaml:"method"`
+	Headers []webhookHeader `yaml:"headers"`
+}
+
+type webhookHeader struct {
+	Key   string `yaml:"key"`
+	Value string `yaml:"value"`
+}
+
+// ValidateWebhookConfig applies the same checks to a webhook configuration as the webhook-service does when executing it
+func ValidateWebhookConfig(content []byte) error {
+	w := &webhookConfig{}
+	if err := decodeYAML(content, w); err != nil {
+		return err
+	}
+
+	if w.ApiVersion != webhookAlphaAPIVersion && w.ApiVersion != webhookBetaAPIVersion {
+		return fieldError("apiVersion", "unsupported webhook configuration version '%s'", w.ApiVersion)
+	}
+	if len(w.Spec.Webhooks) == 0 {
+		return fieldError("spec.webhooks", "webhook configuration must contain at least one webhook")
+	}
+
+	for i, wh := range w.Spec.Webhooks {
+		webhookField := fmt.Sprintf("spec.webhooks[%d]", i)
+		if wh.Type == "" {
+			return fieldError(webhookField+".type", "type must not be empty")
+		}
+		if wh.SubscriptionID == "" {
+			return fieldError(webhookField+".subscriptionID", "subscriptionID must not be empty")
+		}
+		if len(wh.Requests) == 0 {
+			return fieldError(webhookField+".requests", "webhook must contain at least one request")
+		}
+		for j, request := range wh.Requests {
+			requestField := fmt.Sprintf("%s.requests[%d]", webhookField, j)
+			if w.ApiVersion == webhookAlphaAPIVersion {
+				if request.Kind != yaml.ScalarNode || request.Value == "" {
+					return fieldError(requestField, "request must be a non-empty curl command")
+				}
+				continue
+			}
+			if err := validateWebhookRequest(requestField, request); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateWebhookRequest(field string, request yaml.Node) error {
+	r := webhookRequest{}
+	if request.Kind != yaml.MappingNode {
+		return fieldError(field, "request must be an object containing url and method")
+	}
+	if err := request.Decode(&r); err != nil {
+		return fieldError(field, "could not decode request: %s", err.Error())
+	}
+
+	if r.URL == "" {
+		return fieldError(field+".url", "url must not be empty")
+	}
+	if !contains(webhookSupportedMethods, r.Method) {
+		return fieldError(field+".method", "unsupported method '%s', expected one of %v", r.Method, webhookSupportedMethods)
+	}
+	for k, header := range r.Headers {
+		if header.Key == "" || header.Value == "" {
+			return fieldError(fmt.Sprintf("%s.headers[%d]", field, k), "header key and value must not be empty")
+		}
+	}
+	return nil
+}
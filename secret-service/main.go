@@ -5,7 +5,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/keptn/go-utils/pkg/common/osutils"
 	_ "github.com/keptn/keptn/secret-service/docs"
+	"github.com/keptn/keptn/secret-service/pkg/audit"
 	"github.com/keptn/keptn/secret-service/pkg/backend"
+	"github.com/keptn/keptn/secret-service/pkg/common"
 	"github.com/keptn/keptn/secret-service/pkg/controller"
 	"github.com/keptn/keptn/secret-service/pkg/handler"
 	"github.com/keptn/keptn/secret-service/pkg/repository"
@@ -34,6 +36,8 @@ import (
 // @BasePath  /v1
 
 const envVarLogLevel = "LOG_LEVEL"
+const envVarSecretBackend = "SECRET_BACKEND"
+const envVarAuditSink = "SECRET_AUDIT_SINK"
 
 func main() {
 	log.SetLevel(log.InfoLevel)
@@ -62,9 +66,19 @@ func main() {
 	engine := gin.Default()
 	apiV1 := engine.Group("/v1")
 
-	// only kubernetes supported, so we hard code it for now
-	secretsBackend := backend.CreateBackend("kubernetes")
-	secretController := controller.NewSecretController(handler.NewSecretHandler(secretsBackend))
+	backendType := common.EnvBasedStringSupplier(envVarSecretBackend, backend.SecretBackendTypeK8s)()
+	if !backend.IsRegistered(backendType) {
+		log.Fatalf("Unknown secret backend type: %s", backendType)
+	}
+	log.Infof("Using secret backend: %s", backendType)
+	secretsBackend := backend.CreateBackend(backendType)
+
+	auditSinkType := common.EnvBasedStringSupplier(envVarAuditSink, audit.SinkTypeFile)()
+	if !audit.IsRegistered(auditSinkType) {
+		log.Fatalf("Unknown audit sink type: %s", auditSinkType)
+	}
+	log.Infof("Using audit sink: %s", auditSinkType)
+	secretController := controller.NewSecretController(handler.NewSecretHandler(secretsBackend, audit.CreateSink(auditSinkType)))
 	secretController.Inject(apiV1)
 
 	scopeController := controller.NewScopeController(handler.NewScopeHandler(secretsBackend))
@@ -0,0 +1,41 @@
+package audit
+
+import (
+	"github.com/keptn/keptn/secret-service/pkg/model"
+)
+
+// DefaultLimit is the number of records returned if the filter does not set a limit
+const DefaultLimit = 100
+
+// Sink stores the audit records of the operations performed on secrets
+//
+//go:generate moq -pkg fake -out ./fake/sink_mock.go . Sink
+type Sink interface {
+	// Write stores the given record
+	Write(record model.AuditRecord) error
+	// Read returns the records matching the filter, starting with the most recent one
+	Read(filter model.AuditRecordFilter) ([]model.AuditRecord, error)
+}
+
+var sinkRegistry = map[string]func() Sink{}
+
+func Register(name string, factory func() Sink) {
+	sinkRegistry[name] = factory
+}
+
+// IsRegistered returns true if a factory for the given sink type has been registered
+func IsRegistered(sinkType string) bool {
+	_, ok := sinkRegistry[sinkType]
+	return ok
+}
+
+func CreateSink(sinkType string) Sink {
+	return sinkRegistry[sinkType]()
+}
+
+func limit(filter model.AuditRecordFilter) int {
+	if filter.Limit <= 0 {
+		return DefaultLimit
+	}
+	return filter.Limit
+}
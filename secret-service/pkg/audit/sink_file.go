@@ -0,0 +1,95 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/keptn/keptn/secret-service/pkg/common"
+	"github.com/keptn/keptn/secret-service/pkg/model"
+	log "github.com/sirupsen/logrus"
+)
+
+const SinkTypeFile = "file"
+
+const envVarAuditFilePath = "SECRET_AUDIT_FILE_PATH"
+const defaultAuditFilePath = "secret-audit.log"
+
+// FileSink appends the audit records as JSON lines to a local file
+type FileSink struct {
+	FilePath string
+	mutex    *sync.Mutex
+}
+
+func NewFileSink(filePath string) *FileSink {
+	return &FileSink{
+		FilePath: filePath,
+		mutex:    &sync.Mutex{},
+	}
+}
+
+func (f FileSink) Write(record model.AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	file, err := os.OpenFile(f.FilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("could not open audit log %s: %w", f.FilePath, err)
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+func (f FileSink) Read(filter model.AuditRecordFilter) ([]model.AuditRecord, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	file, err := os.Open(f.FilePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []model.AuditRecord{}, nil
+		}
+		return nil, fmt.Errorf("could not open audit log %s: %w", f.FilePath, err)
+	}
+	defer file.Close()
+
+	records := []model.AuditRecord{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		record := model.AuditRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			log.Warnf("Skipping invalid line in audit log %s: %v", f.FilePath, err)
+			continue
+		}
+		if filter.Matches(record) {
+			records = append(records, record)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// records are appended in chronological order, but are returned starting with the most recent one
+	result := make([]model.AuditRecord, 0, len(records))
+	for i := len(records) - 1; i >= 0 && len(result) < limit(filter); i-- {
+		result = append(result, records[i])
+	}
+	return result, nil
+}
+
+func init() {
+	log.Info("Registering audit sink type: file")
+	Register(SinkTypeFile, func() Sink {
+		return NewFileSink(common.EnvBasedStringSupplier(envVarAuditFilePath, defaultAuditFilePath)())
+	})
+}
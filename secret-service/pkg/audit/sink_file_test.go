@@ -0,0 +1,82 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/secret-service/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestAuditRecord(name string, scope string, recordTime time.Time) model.AuditRecord {
+	return model.AuditRecord{
+		Time:      recordTime,
+		Actor:     "my-principal",
+		Operation: model.AuditOperationCreate,
+		Name:      name,
+		Scope:     scope,
+		Outcome:   model.AuditOutcomeSuccess,
+	}
+}
+
+func TestFileSink_ReadWithoutRecords(t *testing.T) {
+	sink := NewFileSink(filepath.Join(t.TempDir(), "audit.log"))
+
+	records, err := sink.Read(model.AuditRecordFilter{})
+	require.Nil(t, err)
+	assert.Empty(t, records)
+}
+
+func TestFileSink_WriteAndRead(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "audit.log")
+	sink := NewFileSink(filePath)
+
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Nil(t, sink.Write(createTestAuditRecord("my-secret", "my-scope", start)))
+	require.Nil(t, sink.Write(createTestAuditRecord("my-other-secret", "my-scope", start.Add(time.Hour))))
+	require.Nil(t, sink.Write(createTestAuditRecord("my-secret", "my-other-scope", start.Add(2*time.Hour))))
+
+	// a new instance reads the records written before
+	sink = NewFileSink(filePath)
+
+	records, err := sink.Read(model.AuditRecordFilter{})
+	require.Nil(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, start.Add(2*time.Hour), records[0].Time)
+	assert.Equal(t, start, records[2].Time)
+
+	records, err = sink.Read(model.AuditRecordFilter{Name: "my-secret"})
+	require.Nil(t, err)
+	require.Len(t, records, 2)
+
+	records, err = sink.Read(model.AuditRecordFilter{Name: "my-secret", Scope: "my-scope"})
+	require.Nil(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, start, records[0].Time)
+
+	from := start.Add(30 * time.Minute)
+	to := start.Add(90 * time.Minute)
+	records, err = sink.Read(model.AuditRecordFilter{From: &from, To: &to})
+	require.Nil(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "my-other-secret", records[0].Name)
+
+	records, err = sink.Read(model.AuditRecordFilter{Limit: 2})
+	require.Nil(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, start.Add(time.Hour), records[1].Time)
+}
+
+func TestFileSink_SkipsInvalidLines(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "audit.log")
+	sink := NewFileSink(filePath)
+	require.Nil(t, os.WriteFile(filePath, []byte("invalid\n"), 0600))
+	require.Nil(t, sink.Write(createTestAuditRecord("my-secret", "my-scope", time.Now().UTC())))
+
+	records, err := sink.Read(model.AuditRecordFilter{})
+	require.Nil(t, err)
+	assert.Len(t, records, 1)
+}
@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	keptnmongoutils "github.com/keptn/go-utils/pkg/common/mongoutils"
+	"github.com/keptn/keptn/secret-service/pkg/model"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const SinkTypeMongoDB = "mongodb"
+
+const auditLogCollectionName = "secret-audit-log"
+
+// MongoDBSink stores the audit records in a collection of Keptn's MongoDB. The connection is configured with the
+// same environment variables as for the other Keptn services (MONGODB_HOST, MONGODB_USER, ...)
+type MongoDBSink struct {
+	client       *mongo.Client
+	databaseName string
+	mutex        *sync.Mutex
+}
+
+func NewMongoDBSink() *MongoDBSink {
+	return &MongoDBSink{mutex: &sync.Mutex{}}
+}
+
+func (m *MongoDBSink) Write(record model.AuditRecord) error {
+	collection, err := m.getCollection()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err = collection.InsertOne(ctx, record)
+	return err
+}
+
+func (m *MongoDBSink) Read(filter model.AuditRecordFilter) ([]model.AuditRecord, error) {
+	collection, err := m.getCollection()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "time", Value: -1}}).SetLimit(int64(limit(filter)))
+	cursor, err := collection.Find(ctx, toMongoDBFilter(filter), findOptions)
+	if err != nil {
+		return nil, err
+	}
+	records := []model.AuditRecord{}
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func toMongoDBFilter(filter model.AuditRecordFilter) bson.M {
+	mongoFilter := bson.M{}
+	if filter.Name != "" {
+		mongoFilter["name"] = filter.Name
+	}
+	if filter.Scope != "" {
+		mongoFilter["scope"] = filter.Scope
+	}
+	timeFilter := bson.M{}
+	if filter.From != nil {
+		timeFilter["$gte"] = *filter.From
+	}
+	if filter.To != nil {
+		timeFilter["$lte"] = *filter.To
+	}
+	if len(timeFilter) > 0 {
+		mongoFilter["time"] = timeFilter
+	}
+	return mongoFilter
+}
+
+// getCollection returns the audit log collection and connects to the MongoDB if no connection has been established yet
+func (m *MongoDBSink) getCollection() (*mongo.Collection, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.client == nil {
+		connectionString, databaseName, err := keptnmongoutils.GetMongoConnectionStringFromEnv()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create mongo client: %w", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		client, err := mongo.Connect(ctx, options.Client().ApplyURI(connectionString).SetConnectTimeout(30*time.Second))
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect client to MongoDB: %w", err)
+		}
+		m.client = client
+		m.databaseName = databaseName
+	}
+	return m.client.Database(m.databaseName).Collection(auditLogCollectionName), nil
+}
+
+func init() {
+	log.Info("Registering audit sink type: mongodb")
+	Register(SinkTypeMongoDB, func() Sink {
+		return NewMongoDBSink()
+	})
+}
@@ -0,0 +1,22 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/secret-service/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestToMongoDBFilter(t *testing.T) {
+	from := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	assert.Equal(t, bson.M{}, toMongoDBFilter(model.AuditRecordFilter{}))
+	assert.Equal(t, bson.M{
+		"name":  "my-secret",
+		"scope": "my-scope",
+		"time":  bson.M{"$gte": from, "$lte": to},
+	}, toMongoDBFilter(model.AuditRecordFilter{Name: "my-secret", Scope: "my-scope", From: &from, To: &to, Limit: 10}))
+}
@@ -0,0 +1,306 @@
+// Package backendtest provides a test suite that verifies that an implementation of backend.SecretBackend
+// behaves like the other secret backends supported by the secret-service
+package backendtest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/secret-service/pkg/backend"
+	"github.com/keptn/keptn/secret-service/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ConformanceScopes returns the scopes that need to be provided by the scopes repository of backends under test
+func ConformanceScopes() model.Scopes {
+	return model.Scopes{
+		Scopes: map[string]model.Scope{
+			"my-scope": {
+				Capabilities: map[string]model.Capability{
+					"my-scope-read-secrets": {Permissions: []string{"get"}},
+				},
+			},
+			"my-other-scope": {
+				Capabilities: map[string]model.Capability{
+					"my-other-scope-read-secrets": {Permissions: []string{"get"}},
+				},
+			},
+		},
+	}
+}
+
+// RunConformanceTests runs the conformance test suite. newBackend must return an empty backend
+// whose scopes repository returns ConformanceScopes
+func RunConformanceTests(t *testing.T, newBackend func(t *testing.T) backend.SecretBackend) {
+	t.Run("create and get secret", func(t *testing.T) {
+		b := newBackend(t)
+		require.Nil(t, b.CreateSecret(newSecret("my-secret", "my-scope", "user", "password")))
+
+		secrets, err := b.GetSecrets(model.Secret{SecretMetadata: model.SecretMetadata{Name: "my-secret"}})
+		require.Nil(t, err)
+		require.Len(t, secrets, 1)
+		assert.Equal(t, "my-secret", secrets[0].Name)
+		assert.Equal(t, "my-scope", secrets[0].Scope)
+		assert.ElementsMatch(t, []string{"user", "password"}, secrets[0].Keys)
+	})
+
+	t.Run("create existing secret", func(t *testing.T) {
+		b := newBackend(t)
+		require.Nil(t, b.CreateSecret(newSecret("my-secret", "my-scope", "user")))
+
+		err := b.CreateSecret(newSecret("my-secret", "my-scope", "user"))
+		assert.True(t, errors.Is(err, backend.ErrSecretAlreadyExists))
+	})
+
+	t.Run("create secret with unknown scope", func(t *testing.T) {
+		b := newBackend(t)
+		err := b.CreateSecret(newSecret("my-secret", "unknown-scope", "user"))
+		assert.True(t, errors.Is(err, backend.ErrScopeNotFound))
+	})
+
+	t.Run("get secrets filtered by scope and name", func(t *testing.T) {
+		b := newBackend(t)
+		require.Nil(t, b.CreateSecret(newSecret("secret-1", "my-scope", "user")))
+		require.Nil(t, b.CreateSecret(newSecret("secret-2", "my-scope", "user")))
+		require.Nil(t, b.CreateSecret(newSecret("secret-3", "my-other-scope", "user")))
+
+		secrets, err := b.GetSecrets(model.Secret{})
+		require.Nil(t, err)
+		assert.ElementsMatch(t, []string{"secret-1", "secret-2", "secret-3"}, secretNames(secrets))
+
+		secrets, err = b.GetSecrets(model.Secret{SecretMetadata: model.SecretMetadata{Scope: "my-scope"}})
+		require.Nil(t, err)
+		assert.ElementsMatch(t, []string{"secret-1", "secret-2"}, secretNames(secrets))
+
+		secrets, err = b.GetSecrets(model.Secret{SecretMetadata: model.SecretMetadata{Name: "secret-3", Scope: "my-scope"}})
+		require.Nil(t, err)
+		assert.Empty(t, secrets)
+
+		secrets, err = b.GetSecrets(model.Secret{SecretMetadata: model.SecretMetadata{Name: "unknown"}})
+		require.Nil(t, err)
+		assert.Empty(t, secrets)
+	})
+
+	t.Run("bind secrets to projects and stages", func(t *testing.T) {
+		b := newBackend(t)
+		require.Nil(t, b.CreateSecret(newSecret("global", "my-scope", "user")))
+		projectSecret := newSecret("project-a", "my-scope", "user")
+		projectSecret.Project = "a"
+		require.Nil(t, b.CreateSecret(projectSecret))
+		stageSecret := newSecret("project-a-dev", "my-scope", "user")
+		stageSecret.Project = "a"
+		stageSecret.Stage = "dev"
+		require.Nil(t, b.CreateSecret(stageSecret))
+		otherProjectSecret := newSecret("project-b", "my-scope", "user")
+		otherProjectSecret.Project = "b"
+		require.Nil(t, b.CreateSecret(otherProjectSecret))
+
+		secrets, err := b.GetSecrets(model.Secret{SecretMetadata: model.SecretMetadata{Project: "a"}})
+		require.Nil(t, err)
+		assert.ElementsMatch(t, []string{"project-a", "project-a-dev"}, secretNames(secrets))
+
+		secrets, err = b.GetSecrets(model.Secret{SecretMetadata: model.SecretMetadata{Project: "a", Stage: "dev"}})
+		require.Nil(t, err)
+		require.Len(t, secrets, 1)
+		assert.Equal(t, "project-a-dev", secrets[0].Name)
+		assert.Equal(t, "a", secrets[0].Project)
+		assert.Equal(t, "dev", secrets[0].Stage)
+
+		secrets, err = b.GetSecrets(model.Secret{SecretMetadata: model.SecretMetadata{Name: "project-b", Project: "a"}})
+		require.Nil(t, err)
+		assert.Empty(t, secrets)
+
+		invalidSecret := newSecret("stage-only", "my-scope", "user")
+		invalidSecret.Stage = "dev"
+		err = b.CreateSecret(invalidSecret)
+		assert.True(t, errors.Is(err, backend.ErrStageWithoutProject))
+	})
+
+	t.Run("update secret", func(t *testing.T) {
+		b := newBackend(t)
+		require.Nil(t, b.CreateSecret(newSecret("my-secret", "my-scope", "user")))
+		require.Nil(t, b.UpdateSecret(newSecret("my-secret", "my-scope", "token")))
+
+		secrets, err := b.GetSecrets(model.Secret{SecretMetadata: model.SecretMetadata{Name: "my-secret"}})
+		require.Nil(t, err)
+		require.Len(t, secrets, 1)
+		assert.Equal(t, []string{"token"}, secrets[0].Keys)
+	})
+
+	t.Run("update unknown secret", func(t *testing.T) {
+		b := newBackend(t)
+		err := b.UpdateSecret(newSecret("my-secret", "my-scope", "user"))
+		assert.True(t, errors.Is(err, backend.ErrSecretNotFound))
+	})
+
+	t.Run("delete secret", func(t *testing.T) {
+		b := newBackend(t)
+		require.Nil(t, b.CreateSecret(newSecret("my-secret", "my-scope", "user")))
+		require.Nil(t, b.DeleteSecret(newSecret("my-secret", "my-scope")))
+
+		secrets, err := b.GetSecrets(model.Secret{})
+		require.Nil(t, err)
+		assert.Empty(t, secrets)
+
+		err = b.DeleteSecret(newSecret("my-secret", "my-scope"))
+		assert.True(t, errors.Is(err, backend.ErrSecretNotFound))
+	})
+
+	t.Run("recreate deleted secret", func(t *testing.T) {
+		b := newBackend(t)
+		require.Nil(t, b.CreateSecret(newSecret("my-secret", "my-scope", "user")))
+		require.Nil(t, b.UpdateSecret(newSecret("my-secret", "my-scope", "token")))
+		require.Nil(t, b.DeleteSecret(newSecret("my-secret", "my-scope")))
+		require.Nil(t, b.CreateSecret(newSecret("my-secret", "my-scope", "user")))
+
+		// the versions of the deleted secret are gone
+		versions, err := b.GetSecretVersions(newSecret("my-secret", "my-scope"))
+		require.Nil(t, err)
+		require.Len(t, versions, 1)
+		assert.Equal(t, 1, versions[0].Version)
+	})
+
+	t.Run("delete secret with wrong scope", func(t *testing.T) {
+		b := newBackend(t)
+		require.Nil(t, b.CreateSecret(newSecret("my-secret", "my-scope", "user")))
+
+		err := b.DeleteSecret(newSecret("my-secret", "my-other-scope"))
+		assert.True(t, errors.Is(err, backend.ErrSecretNotFound))
+
+		secrets, err := b.GetSecrets(model.Secret{SecretMetadata: model.SecretMetadata{Name: "my-secret"}})
+		require.Nil(t, err)
+		assert.Len(t, secrets, 1)
+	})
+
+	t.Run("keep previous versions", func(t *testing.T) {
+		b := newBackend(t)
+		require.Nil(t, b.CreateSecret(newSecret("my-secret", "my-scope", "user")))
+		require.Nil(t, b.UpdateSecret(newSecret("my-secret", "my-scope", "token")))
+
+		secrets, err := b.GetSecrets(model.Secret{SecretMetadata: model.SecretMetadata{Name: "my-secret"}})
+		require.Nil(t, err)
+		require.Len(t, secrets, 1)
+		assert.Equal(t, 2, secrets[0].Version)
+
+		versions, err := b.GetSecretVersions(newSecret("my-secret", "my-scope"))
+		require.Nil(t, err)
+		require.Len(t, versions, 2)
+		assert.Equal(t, 1, versions[0].Version)
+		assert.Equal(t, []string{"user"}, versions[0].Keys)
+		assert.False(t, versions[0].Current)
+		assert.Equal(t, 2, versions[1].Version)
+		assert.Equal(t, []string{"token"}, versions[1].Keys)
+		assert.True(t, versions[1].Current)
+	})
+
+	t.Run("limit number of versions", func(t *testing.T) {
+		b := newBackend(t)
+		require.Nil(t, b.CreateSecret(newSecret("my-secret", "my-scope", "key-1")))
+		for i := 2; i <= backend.DefaultMaxSecretVersions+2; i++ {
+			require.Nil(t, b.UpdateSecret(newSecret("my-secret", "my-scope", fmt.Sprintf("key-%d", i))))
+		}
+
+		versions, err := b.GetSecretVersions(newSecret("my-secret", "my-scope"))
+		require.Nil(t, err)
+		require.Len(t, versions, backend.DefaultMaxSecretVersions)
+		assert.Equal(t, 3, versions[0].Version)
+		assert.Equal(t, backend.DefaultMaxSecretVersions+2, versions[len(versions)-1].Version)
+	})
+
+	t.Run("get versions of secret with wrong scope", func(t *testing.T) {
+		b := newBackend(t)
+		require.Nil(t, b.CreateSecret(newSecret("my-secret", "my-scope", "user")))
+
+		_, err := b.GetSecretVersions(newSecret("my-secret", "my-other-scope"))
+		assert.True(t, errors.Is(err, backend.ErrSecretNotFound))
+
+		_, err = b.GetSecretVersions(newSecret("unknown", "my-scope"))
+		assert.True(t, errors.Is(err, backend.ErrSecretNotFound))
+	})
+
+	t.Run("roll back secret", func(t *testing.T) {
+		b := newBackend(t)
+		require.Nil(t, b.CreateSecret(newSecret("my-secret", "my-scope", "user")))
+		require.Nil(t, b.UpdateSecret(newSecret("my-secret", "my-scope", "token")))
+
+		require.Nil(t, b.RollbackSecret(newSecret("my-secret", "my-scope"), 1))
+
+		secrets, err := b.GetSecrets(model.Secret{SecretMetadata: model.SecretMetadata{Name: "my-secret"}})
+		require.Nil(t, err)
+		require.Len(t, secrets, 1)
+		assert.Equal(t, 3, secrets[0].Version)
+		assert.Equal(t, []string{"user"}, secrets[0].Keys)
+
+		// rolling back to the current version does not create a new version
+		require.Nil(t, b.RollbackSecret(newSecret("my-secret", "my-scope"), 3))
+		versions, err := b.GetSecretVersions(newSecret("my-secret", "my-scope"))
+		require.Nil(t, err)
+		assert.Len(t, versions, 3)
+	})
+
+	t.Run("roll back to unknown version", func(t *testing.T) {
+		b := newBackend(t)
+		require.Nil(t, b.CreateSecret(newSecret("my-secret", "my-scope", "user")))
+
+		err := b.RollbackSecret(newSecret("my-secret", "my-scope"), 5)
+		assert.True(t, errors.Is(err, backend.ErrSecretVersionNotFound))
+
+		err = b.RollbackSecret(newSecret("my-secret", "my-other-scope"), 1)
+		assert.True(t, errors.Is(err, backend.ErrSecretNotFound))
+	})
+
+	t.Run("store expiry and rotation metadata", func(t *testing.T) {
+		b := newBackend(t)
+		expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+		rotateAfter := time.Date(2029, 6, 1, 0, 0, 0, 0, time.UTC)
+		secret := newSecret("my-secret", "my-scope", "user")
+		secret.ExpiresAt = &expiresAt
+		secret.RotateAfter = &rotateAfter
+		require.Nil(t, b.CreateSecret(secret))
+
+		secrets, err := b.GetSecrets(model.Secret{SecretMetadata: model.SecretMetadata{Name: "my-secret"}})
+		require.Nil(t, err)
+		require.Len(t, secrets, 1)
+		require.NotNil(t, secrets[0].ExpiresAt)
+		require.NotNil(t, secrets[0].RotateAfter)
+		assert.True(t, expiresAt.Equal(*secrets[0].ExpiresAt))
+		assert.True(t, rotateAfter.Equal(*secrets[0].RotateAfter))
+
+		// metadata is replaced on update
+		require.Nil(t, b.UpdateSecret(newSecret("my-secret", "my-scope", "user")))
+		secrets, err = b.GetSecrets(model.Secret{SecretMetadata: model.SecretMetadata{Name: "my-secret"}})
+		require.Nil(t, err)
+		require.Len(t, secrets, 1)
+		assert.Nil(t, secrets[0].ExpiresAt)
+		assert.Nil(t, secrets[0].RotateAfter)
+	})
+
+	t.Run("get scopes", func(t *testing.T) {
+		b := newBackend(t)
+		scopes, err := b.GetScopes()
+		require.Nil(t, err)
+		assert.Equal(t, []string{"my-other-scope", "my-scope"}, scopes)
+	})
+}
+
+func newSecret(name string, scope string, keys ...string) model.Secret {
+	data := model.Data{}
+	for _, key := range keys {
+		data[key] = "value-of-" + key
+	}
+	return model.Secret{
+		SecretMetadata: model.SecretMetadata{Name: name, Scope: scope},
+		Data:           data,
+	}
+}
+
+func secretNames(secrets []model.GetSecretResponseItem) []string {
+	names := []string{}
+	for _, secret := range secrets {
+		names = append(names, secret.Name)
+	}
+	return names
+}
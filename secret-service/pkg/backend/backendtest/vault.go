@@ -0,0 +1,221 @@
+package backendtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// VaultServer is an in-memory fake of the KV version 2 secrets engine of HashiCorp Vault,
+// covering the subset of the API used by backend.VaultSecretBackend
+type VaultServer struct {
+	*httptest.Server
+	Token     string
+	Namespace string
+	// Secrets holds the stored secrets, indexed by their path below the mount
+	Secrets map[string]*VaultSecret
+	mutex   sync.Mutex
+}
+
+type VaultSecret struct {
+	CustomMetadata map[string]string
+	MaxVersions    int
+	CurrentVersion int
+	Versions       map[int]*VaultSecretVersion
+}
+
+type VaultSecretVersion struct {
+	Data        map[string]string
+	CreatedTime time.Time
+}
+
+// Data returns the data of the current version of the secret
+func (s *VaultSecret) Data() map[string]string {
+	if version, ok := s.Versions[s.CurrentVersion]; ok {
+		return version.Data
+	}
+	return nil
+}
+
+// NewVaultServer starts a fake Vault server with a KV version 2 engine mounted at "secret",
+// which is closed when the test finishes
+func NewVaultServer(t *testing.T, token string) *VaultServer {
+	vault := &VaultServer{Token: token, Secrets: map[string]*VaultSecret{}}
+	vault.Server = httptest.NewServer(http.HandlerFunc(vault.handle))
+	t.Cleanup(vault.Close)
+	return vault
+}
+
+// AddSecret stores a secret with the given data as its first version
+func (v *VaultServer) AddSecret(path string, data map[string]string, customMetadata map[string]string) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.Secrets[path] = &VaultSecret{
+		CustomMetadata: customMetadata,
+		CurrentVersion: 1,
+		Versions:       map[int]*VaultSecretVersion{1: {Data: data, CreatedTime: time.Now().UTC()}},
+	}
+}
+
+func (v *VaultServer) handle(w http.ResponseWriter, r *http.Request) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if r.Header.Get("X-Vault-Token") != v.Token || r.Header.Get("X-Vault-Namespace") != v.Namespace {
+		writeVaultErrors(w, http.StatusForbidden, "permission denied")
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/v1/secret/data/"):
+		v.handleData(w, r, strings.TrimPrefix(r.URL.Path, "/v1/secret/data/"))
+	case strings.HasPrefix(r.URL.Path, "/v1/secret/metadata/"):
+		v.handleMetadata(w, r, strings.TrimPrefix(r.URL.Path, "/v1/secret/metadata/"))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (v *VaultServer) handleData(w http.ResponseWriter, r *http.Request, path string) {
+	secret, exists := v.Secrets[path]
+	switch r.Method {
+	case http.MethodGet:
+		versionNumber := 0
+		if exists {
+			versionNumber = secret.CurrentVersion
+		}
+		if requested := r.URL.Query().Get("version"); requested != "" {
+			versionNumber, _ = strconv.Atoi(requested)
+		}
+		if !exists || secret.Versions[versionNumber] == nil {
+			writeVaultErrors(w, http.StatusNotFound)
+			return
+		}
+		version := secret.Versions[versionNumber]
+		response := map[string]interface{}{
+			"data": map[string]interface{}{
+				"data": version.Data,
+				"metadata": map[string]interface{}{
+					"version":         versionNumber,
+					"created_time":    version.CreatedTime,
+					"custom_metadata": secret.CustomMetadata,
+				},
+			},
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	case http.MethodPost, http.MethodPut:
+		request := struct {
+			Options map[string]int    `json:"options"`
+			Data    map[string]string `json:"data"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeVaultErrors(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if !exists {
+			secret = &VaultSecret{Versions: map[int]*VaultSecretVersion{}}
+		}
+		if cas, ok := request.Options["cas"]; ok && cas != secret.CurrentVersion {
+			writeVaultErrors(w, http.StatusBadRequest, "check-and-set parameter did not match the current version")
+			return
+		}
+		v.Secrets[path] = secret
+		secret.CurrentVersion++
+		secret.Versions[secret.CurrentVersion] = &VaultSecretVersion{Data: request.Data, CreatedTime: time.Now().UTC()}
+		secret.removeOldVersions()
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"version": secret.CurrentVersion}})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (v *VaultServer) handleMetadata(w http.ResponseWriter, r *http.Request, path string) {
+	secret, exists := v.Secrets[path]
+	switch {
+	case r.Method == http.MethodGet && r.URL.Query().Get("list") == "true":
+		keys := []string{}
+		for secretPath := range v.Secrets {
+			if strings.HasPrefix(secretPath, path) {
+				key := strings.TrimPrefix(secretPath, path)
+				if i := strings.Index(key, "/"); i >= 0 {
+					key = key[:i+1]
+				}
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) == 0 {
+			writeVaultErrors(w, http.StatusNotFound)
+			return
+		}
+		sort.Strings(keys)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"keys": keys}})
+	case r.Method == http.MethodGet:
+		if !exists {
+			writeVaultErrors(w, http.StatusNotFound)
+			return
+		}
+		versions := map[string]interface{}{}
+		for number, version := range secret.Versions {
+			versions[strconv.Itoa(number)] = map[string]interface{}{"created_time": version.CreatedTime, "destroyed": false}
+		}
+		response := map[string]interface{}{
+			"data": map[string]interface{}{
+				"current_version": secret.CurrentVersion,
+				"max_versions":    secret.MaxVersions,
+				"custom_metadata": secret.CustomMetadata,
+				"versions":        versions,
+			},
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	case r.Method == http.MethodPost || r.Method == http.MethodPut:
+		request := struct {
+			MaxVersions    int               `json:"max_versions"`
+			CustomMetadata map[string]string `json:"custom_metadata"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeVaultErrors(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if !exists {
+			secret = &VaultSecret{Versions: map[int]*VaultSecretVersion{}}
+			v.Secrets[path] = secret
+		}
+		secret.CustomMetadata = request.CustomMetadata
+		secret.MaxVersions = request.MaxVersions
+		secret.removeOldVersions()
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete:
+		delete(v.Secrets, path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// removeOldVersions removes the oldest versions exceeding the maximum number of versions, which defaults to 10
+func (s *VaultSecret) removeOldVersions() {
+	maxVersions := s.MaxVersions
+	if maxVersions == 0 {
+		maxVersions = 10
+	}
+	for number := range s.Versions {
+		if number <= s.CurrentVersion-maxVersions {
+			delete(s.Versions, number)
+		}
+	}
+}
+
+func writeVaultErrors(w http.ResponseWriter, status int, errors ...string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if errors == nil {
+		errors = []string{}
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"errors": errors})
+}
@@ -0,0 +1,40 @@
+package backend_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/keptn/keptn/secret-service/pkg/backend"
+	"github.com/keptn/keptn/secret-service/pkg/backend/backendtest"
+	"github.com/keptn/keptn/secret-service/pkg/model"
+	"github.com/keptn/keptn/secret-service/pkg/repository/fake"
+	"github.com/stretchr/testify/require"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newConformanceScopesRepository() *fake.ScopesRepositoryMock {
+	return &fake.ScopesRepositoryMock{
+		ReadFunc: func() (model.Scopes, error) { return backendtest.ConformanceScopes(), nil },
+	}
+}
+
+func TestK8sSecretBackend_Conformance(t *testing.T) {
+	backendtest.RunConformanceTests(t, func(t *testing.T) backend.SecretBackend {
+		return backend.NewK8sSecretBackend(k8sfake.NewSimpleClientset(), newConformanceScopesRepository())
+	})
+}
+
+func TestFileSecretBackend_Conformance(t *testing.T) {
+	backendtest.RunConformanceTests(t, func(t *testing.T) backend.SecretBackend {
+		fileBackend, err := backend.NewFileSecretBackend(filepath.Join(t.TempDir(), "secrets.json"), make([]byte, 32), newConformanceScopesRepository())
+		require.Nil(t, err)
+		return fileBackend
+	})
+}
+
+func TestVaultSecretBackend_Conformance(t *testing.T) {
+	backendtest.RunConformanceTests(t, func(t *testing.T) backend.SecretBackend {
+		vault := backendtest.NewVaultServer(t, "my-token")
+		return backend.NewVaultSecretBackend(vault.URL, func() string { return "my-token" }, newConformanceScopesRepository())
+	})
+}
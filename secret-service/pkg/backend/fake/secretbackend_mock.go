@@ -28,9 +28,15 @@ var _ backend.SecretBackend = &SecretBackendMock{}
 // 			GetScopesFunc: func() ([]string, error) {
 // 				panic("mock out the GetScopes method")
 // 			},
+// 			GetSecretVersionsFunc: func(secret model.Secret) ([]model.SecretVersion, error) {
+// 				panic("mock out the GetSecretVersions method")
+// 			},
 // 			GetSecretsFunc: func(secret model.Secret) ([]model.GetSecretResponseItem, error) {
 // 				panic("mock out the GetSecrets method")
 // 			},
+// 			RollbackSecretFunc: func(secret model.Secret, version int) error {
+// 				panic("mock out the RollbackSecret method")
+// 			},
 // 			UpdateSecretFunc: func(secret model.Secret) error {
 // 				panic("mock out the UpdateSecret method")
 // 			},
@@ -50,9 +56,15 @@ type SecretBackendMock struct {
 	// GetScopesFunc mocks the GetScopes method.
 	GetScopesFunc func() ([]string, error)
 
+	// GetSecretVersionsFunc mocks the GetSecretVersions method.
+	GetSecretVersionsFunc func(secret model.Secret) ([]model.SecretVersion, error)
+
 	// GetSecretsFunc mocks the GetSecrets method.
 	GetSecretsFunc func(secret model.Secret) ([]model.GetSecretResponseItem, error)
 
+	// RollbackSecretFunc mocks the RollbackSecret method.
+	RollbackSecretFunc func(secret model.Secret, version int) error
+
 	// UpdateSecretFunc mocks the UpdateSecret method.
 	UpdateSecretFunc func(secret model.Secret) error
 
@@ -71,9 +83,22 @@ type SecretBackendMock struct {
 		// GetScopes holds details about calls to the GetScopes method.
 		GetScopes []struct {
 		}
+		// GetSecretVersions holds details about calls to the GetSecretVersions method.
+		GetSecretVersions []struct {
+			// Secret is the secret argument value.
+			Secret model.Secret
+		}
 		// GetSecrets holds details about calls to the GetSecrets method.
 		GetSecrets []struct {
+			// Secret is the secret argument value.
+			Secret model.Secret
+		}
+		// RollbackSecret holds details about calls to the RollbackSecret method.
+		RollbackSecret []struct {
+			// Secret is the secret argument value.
 			Secret model.Secret
+			// Version is the version argument value.
+			Version int
 		}
 		// UpdateSecret holds details about calls to the UpdateSecret method.
 		UpdateSecret []struct {
@@ -81,11 +106,13 @@ type SecretBackendMock struct {
 			Secret model.Secret
 		}
 	}
-	lockCreateSecret sync.RWMutex
-	lockDeleteSecret sync.RWMutex
-	lockGetScopes    sync.RWMutex
-	lockGetSecrets   sync.RWMutex
-	lockUpdateSecret sync.RWMutex
+	lockCreateSecret      sync.RWMutex
+	lockDeleteSecret      sync.RWMutex
+	lockGetScopes         sync.RWMutex
+	lockGetSecretVersions sync.RWMutex
+	lockGetSecrets        sync.RWMutex
+	lockRollbackSecret    sync.RWMutex
+	lockUpdateSecret      sync.RWMutex
 }
 
 // CreateSecret calls CreateSecretFunc.
@@ -176,6 +203,37 @@ func (mock *SecretBackendMock) GetScopesCalls() []struct {
 	return calls
 }
 
+// GetSecretVersions calls GetSecretVersionsFunc.
+func (mock *SecretBackendMock) GetSecretVersions(secret model.Secret) ([]model.SecretVersion, error) {
+	if mock.GetSecretVersionsFunc == nil {
+		panic("SecretBackendMock.GetSecretVersionsFunc: method is nil but SecretBackend.GetSecretVersions was just called")
+	}
+	callInfo := struct {
+		Secret model.Secret
+	}{
+		Secret: secret,
+	}
+	mock.lockGetSecretVersions.Lock()
+	mock.calls.GetSecretVersions = append(mock.calls.GetSecretVersions, callInfo)
+	mock.lockGetSecretVersions.Unlock()
+	return mock.GetSecretVersionsFunc(secret)
+}
+
+// GetSecretVersionsCalls gets all the calls that were made to GetSecretVersions.
+// Check the length with:
+//     len(mockedSecretBackend.GetSecretVersionsCalls())
+func (mock *SecretBackendMock) GetSecretVersionsCalls() []struct {
+	Secret model.Secret
+} {
+	var calls []struct {
+		Secret model.Secret
+	}
+	mock.lockGetSecretVersions.RLock()
+	calls = mock.calls.GetSecretVersions
+	mock.lockGetSecretVersions.RUnlock()
+	return calls
+}
+
 // GetSecrets calls GetSecretsFunc.
 func (mock *SecretBackendMock) GetSecrets(secret model.Secret) ([]model.GetSecretResponseItem, error) {
 	if mock.GetSecretsFunc == nil {
@@ -183,7 +241,9 @@ func (mock *SecretBackendMock) GetSecrets(secret model.Secret) ([]model.GetSecre
 	}
 	callInfo := struct {
 		Secret model.Secret
-	}{}
+	}{
+		Secret: secret,
+	}
 	mock.lockGetSecrets.Lock()
 	mock.calls.GetSecrets = append(mock.calls.GetSecrets, callInfo)
 	mock.lockGetSecrets.Unlock()
@@ -205,6 +265,41 @@ func (mock *SecretBackendMock) GetSecretsCalls() []struct {
 	return calls
 }
 
+// RollbackSecret calls RollbackSecretFunc.
+func (mock *SecretBackendMock) RollbackSecret(secret model.Secret, version int) error {
+	if mock.RollbackSecretFunc == nil {
+		panic("SecretBackendMock.RollbackSecretFunc: method is nil but SecretBackend.RollbackSecret was just called")
+	}
+	callInfo := struct {
+		Secret  model.Secret
+		Version int
+	}{
+		Secret:  secret,
+		Version: version,
+	}
+	mock.lockRollbackSecret.Lock()
+	mock.calls.RollbackSecret = append(mock.calls.RollbackSecret, callInfo)
+	mock.lockRollbackSecret.Unlock()
+	return mock.RollbackSecretFunc(secret, version)
+}
+
+// RollbackSecretCalls gets all the calls that were made to RollbackSecret.
+// Check the length with:
+//     len(mockedSecretBackend.RollbackSecretCalls())
+func (mock *SecretBackendMock) RollbackSecretCalls() []struct {
+	Secret  model.Secret
+	Version int
+} {
+	var calls []struct {
+		Secret  model.Secret
+		Version int
+	}
+	mock.lockRollbackSecret.RLock()
+	calls = mock.calls.RollbackSecret
+	mock.lockRollbackSecret.RUnlock()
+	return calls
+}
+
 // UpdateSecret calls UpdateSecretFunc.
 func (mock *SecretBackendMock) UpdateSecret(secret model.Secret) error {
 	if mock.UpdateSecretFunc == nil {
@@ -7,13 +7,19 @@ func Register(name string, factory func() SecretBackend) {
 }
 
 func GetRegisteredBackends() []string {
-	r := make([]string, len(backendRegistry))
+	r := make([]string, 0, len(backendRegistry))
 	for i := range backendRegistry {
 		r = append(r, i)
 	}
 	return r
 }
 
+// IsRegistered returns true if a factory for the given backend type has been registered
+func IsRegistered(backendType string) bool {
+	_, ok := backendRegistry[backendType]
+	return ok
+}
+
 func CreateBackend(backendType string) SecretBackend {
 	return backendRegistry[backendType]()
 }
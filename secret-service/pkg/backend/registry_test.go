@@ -21,3 +21,14 @@ func Test_Register(t *testing.T) {
 	assert.Contains(t, backends, backend.SecretBackendTypeK8s)
 
 }
+
+func Test_IsRegistered(t *testing.T) {
+	backend.Register("c", func() backend.SecretBackend {
+		return &fake.SecretBackendMock{}
+	})
+
+	assert.True(t, backend.IsRegistered("c"))
+	assert.True(t, backend.IsRegistered(backend.SecretBackendTypeK8s))
+	assert.False(t, backend.IsRegistered("not-registered"))
+	assert.NotContains(t, backend.GetRegisteredBackends(), "")
+}
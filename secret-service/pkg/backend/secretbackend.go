@@ -1,16 +1,44 @@
 package backend
 
 import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keptn/keptn/secret-service/pkg/common"
 	"github.com/keptn/keptn/secret-service/pkg/model"
+	"github.com/keptn/keptn/secret-service/pkg/repository"
+	log "github.com/sirupsen/logrus"
 )
 
 const DefaultNamespace = "keptn"
 
+// DefaultMaxSecretVersions is the number of versions of a secret, including the current one, that are kept by default
+const DefaultMaxSecretVersions = 10
+
+const envVarMaxSecretVersions = "SECRET_MAX_VERSIONS"
+
+// maxKeySize is the maximum length of secret names and keys, which corresponds to the limit of Kubernetes
+const maxKeySize = 253
+
+var ErrSecretAlreadyExists = errors.New("secret already exists")
+var ErrSecretNotFound = errors.New("secret not found")
+var ErrTooBigKeySize = errors.New("name and key values must be no more than 253 characters")
+var ErrScopeNotFound = errors.New("scope not found")
+var ErrInvalidSecretName = errors.New("secret name must not contain '/'")
+var ErrSecretVersionNotFound = errors.New("secret version not found")
+var ErrStageWithoutProject = errors.New("a secret can only be bound to a stage if it is bound to a project")
+
 type SecretManager interface {
 	CreateSecret(model.Secret) error
 	UpdateSecret(model.Secret) error
 	DeleteSecret(model.Secret) error
 	GetSecrets(model.Secret) ([]model.GetSecretResponseItem, error)
+	GetSecretVersions(model.Secret) ([]model.SecretVersion, error)
+	RollbackSecret(secret model.Secret, version int) error
 }
 
 type ScopeManager interface {
@@ -22,3 +50,113 @@ type SecretBackend interface {
 	SecretManager
 	ScopeManager
 }
+
+func checkScopeDefined(scopesRepository repository.ScopesRepository, secret model.Secret) (model.Scopes, error) {
+	scopes, err := scopesRepository.Read()
+	if err != nil {
+		return model.Scopes{}, err
+	}
+	if _, ok := scopes.Scopes[secret.Scope]; !ok {
+		log.Warnf("Unable to find scope %s for secret %s", secret.Scope, secret.Name)
+		return model.Scopes{}, fmt.Errorf("unable to check defined scope %s for secret %s: %w", secret.Scope, secret.Name, ErrScopeNotFound)
+	}
+	return scopes, nil
+}
+
+func getScopeNames(scopesRepository repository.ScopesRepository) ([]string, error) {
+	scopes, err := scopesRepository.Read()
+	if err != nil {
+		return nil, err
+	}
+	scopeArray := make([]string, 0, len(scopes.Scopes))
+	for scope := range scopes.Scopes {
+		scopeArray = append(scopeArray, scope)
+	}
+	sort.Strings(scopeArray)
+	return scopeArray, nil
+}
+
+// validateSecret applies the restrictions of Kubernetes secrets to backends that would accept arbitrary
+// names and keys, so that secrets can be moved between backends
+func validateSecret(secret model.Secret) error {
+	if len(secret.Name) > maxKeySize {
+		return ErrTooBigKeySize
+	}
+	for key := range secret.Data {
+		if len(key) > maxKeySize {
+			return ErrTooBigKeySize
+		}
+	}
+	if strings.Contains(secret.Name, "/") {
+		return ErrInvalidSecretName
+	}
+	return validateProjectBinding(secret)
+}
+
+func validateProjectBinding(secret model.Secret) error {
+	if secret.Stage != "" && secret.Project == "" {
+		return ErrStageWithoutProject
+	}
+	return nil
+}
+
+// matchesFilter checks whether the metadata of a stored secret matches the scope, project and stage of the filter.
+// Empty values of the filter match all secrets
+func matchesFilter(metadata model.SecretMetadata, filter model.Secret) bool {
+	return (filter.Scope == "" || metadata.Scope == filter.Scope) &&
+		(filter.Project == "" || metadata.Project == filter.Project) &&
+		(filter.Stage == "" || metadata.Stage == filter.Stage)
+}
+
+func getSortedKeys(data model.Data) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// getMaxSecretVersions returns the number of versions to keep per secret as configured by the SECRET_MAX_VERSIONS
+// environment variable
+func getMaxSecretVersions() int {
+	value := common.EnvBasedStringSupplier(envVarMaxSecretVersions, strconv.Itoa(DefaultMaxSecretVersions))()
+	maxVersions, err := strconv.Atoi(value)
+	if err != nil || maxVersions < 1 {
+		log.Warnf("Invalid value for %s: %s. Using default value %d", envVarMaxSecretVersions, value, DefaultMaxSecretVersions)
+		return DefaultMaxSecretVersions
+	}
+	return maxVersions
+}
+
+// sortSecretVersions sorts the versions in ascending order and marks the latest one as the current version
+func sortSecretVersions(versions []model.SecretVersion) []model.SecretVersion {
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Version < versions[j].Version
+	})
+	if len(versions) > 0 {
+		versions[len(versions)-1].Current = true
+	}
+	return versions
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func parseTime(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		log.Warnf("Could not parse time %s: %v", value, err)
+		return nil
+	}
+	return &t
+}
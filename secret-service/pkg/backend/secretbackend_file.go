@@ -0,0 +1,362 @@
+package backend
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/keptn/keptn/secret-service/pkg/common"
+	"github.com/keptn/keptn/secret-service/pkg/model"
+	"github.com/keptn/keptn/secret-service/pkg/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+const SecretBackendTypeFile = "file"
+
+const envVarFilePath = "SECRET_BACKEND_FILE_PATH"
+const envVarFileEncryptionKey = "SECRET_BACKEND_FILE_ENCRYPTION_KEY"
+const defaultFilePath = "secrets.json"
+
+var ErrInvalidEncryptionKey = errors.New("encryption key must be 32 bytes long")
+
+// FileSecretBackend stores all secrets in a single local file. If an encryption key is provided, the file is
+// encrypted with AES-256-GCM. This backend is meant for local development and testing, since it neither
+// manages any permissions for the scopes of the secrets nor supports running multiple replicas
+type FileSecretBackend struct {
+	FilePath         string
+	EncryptionKey    []byte
+	ScopesRepository repository.ScopesRepository
+	// MaxVersions is the number of versions of a secret, including the current one, that are kept
+	MaxVersions int
+	mutex       *sync.Mutex
+}
+
+type fileSecretStore struct {
+	Secrets map[string]*fileSecret `json:"secrets"`
+}
+
+type fileSecret struct {
+	Scope       string              `json:"scope"`
+	Project     string              `json:"project,omitempty"`
+	Stage       string              `json:"stage,omitempty"`
+	Data        model.Data          `json:"data"`
+	Version     int                 `json:"version,omitempty"`
+	CreatedAt   time.Time           `json:"createdAt"`
+	ExpiresAt   *time.Time          `json:"expiresAt,omitempty"`
+	RotateAfter *time.Time          `json:"rotateAfter,omitempty"`
+	History     []fileSecretVersion `json:"history,omitempty"`
+}
+
+type fileSecretVersion struct {
+	Version   int        `json:"version"`
+	CreatedAt time.Time  `json:"createdAt"`
+	Data      model.Data `json:"data"`
+}
+
+// newVersion replaces the data of the secret and moves the previous data to its history
+func (s *fileSecret) newVersion(data model.Data, maxVersions int) {
+	s.History = append(s.History, fileSecretVersion{Version: s.Version, CreatedAt: s.CreatedAt, Data: s.Data})
+	if len(s.History) > maxVersions-1 {
+		s.History = s.History[len(s.History)-(maxVersions-1):]
+	}
+	s.Data = data
+	s.Version++
+	s.CreatedAt = time.Now().UTC()
+}
+
+func NewFileSecretBackend(filePath string, encryptionKey []byte, scopesRepository repository.ScopesRepository) (*FileSecretBackend, error) {
+	if len(encryptionKey) > 0 && len(encryptionKey) != 32 {
+		return nil, ErrInvalidEncryptionKey
+	}
+	return &FileSecretBackend{
+		FilePath:         filePath,
+		EncryptionKey:    encryptionKey,
+		ScopesRepository: scopesRepository,
+		MaxVersions:      DefaultMaxSecretVersions,
+		mutex:            &sync.Mutex{},
+	}, nil
+}
+
+func (f FileSecretBackend) CreateSecret(secret model.Secret) error {
+	log.Infof("Creating secret: %s with scope %s", secret.Name, secret.Scope)
+	if err := validateSecret(secret); err != nil {
+		return err
+	}
+	if _, err := checkScopeDefined(f.ScopesRepository, secret); err != nil {
+		return err
+	}
+
+	return f.update(func(store *fileSecretStore) error {
+		if _, ok := store.Secrets[secret.Name]; ok {
+			return ErrSecretAlreadyExists
+		}
+		store.Secrets[secret.Name] = &fileSecret{
+			Scope:       secret.Scope,
+			Project:     secret.Project,
+			Stage:       secret.Stage,
+			Data:        secret.Data,
+			Version:     1,
+			CreatedAt:   time.Now().UTC(),
+			ExpiresAt:   secret.ExpiresAt,
+			RotateAfter: secret.RotateAfter,
+		}
+		return nil
+	})
+}
+
+func (f FileSecretBackend) UpdateSecret(secret model.Secret) error {
+	log.Infof("Updating secret: %s with scope %s", secret.Name, secret.Scope)
+	if err := validateSecret(secret); err != nil {
+		return err
+	}
+	if _, err := checkScopeDefined(f.ScopesRepository, secret); err != nil {
+		return err
+	}
+
+	return f.update(func(store *fileSecretStore) error {
+		stored, ok := store.Secrets[secret.Name]
+		if !ok {
+			return ErrSecretNotFound
+		}
+		stored.newVersion(secret.Data, f.MaxVersions)
+		stored.Scope = secret.Scope
+		stored.Project = secret.Project
+		stored.Stage = secret.Stage
+		stored.ExpiresAt = secret.ExpiresAt
+		stored.RotateAfter = secret.RotateAfter
+		return nil
+	})
+}
+
+func (f FileSecretBackend) DeleteSecret(secret model.Secret) error {
+	log.Infof("Deleting secret: %s with scope %s", secret.Name, secret.Scope)
+	if _, err := checkScopeDefined(f.ScopesRepository, secret); err != nil {
+		return err
+	}
+
+	return f.update(func(store *fileSecretStore) error {
+		stored, ok := store.Secrets[secret.Name]
+		if !ok || stored.Scope != secret.Scope {
+			return fmt.Errorf("could not delete secret %s in scope %s: %w", secret.Name, secret.Scope, ErrSecretNotFound)
+		}
+		delete(store.Secrets, secret.Name)
+		return nil
+	})
+}
+
+func (f FileSecretBackend) GetSecrets(secret model.Secret) ([]model.GetSecretResponseItem, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	store, err := f.read()
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve secrets: %w", err)
+	}
+
+	result := []model.GetSecretResponseItem{}
+	for _, name := range getSortedSecretNames(store) {
+		stored := store.Secrets[name]
+		item := model.GetSecretResponseItem{
+			SecretMetadata: model.SecretMetadata{
+				Name:        name,
+				Scope:       stored.Scope,
+				Project:     stored.Project,
+				Stage:       stored.Stage,
+				ExpiresAt:   stored.ExpiresAt,
+				RotateAfter: stored.RotateAfter,
+			},
+			Keys:    getSortedKeys(stored.Data),
+			Version: stored.Version,
+		}
+		if (secret.Name != "" && name != secret.Name) || !matchesFilter(item.SecretMetadata, secret) {
+			continue
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+func (f FileSecretBackend) GetSecretVersions(secret model.Secret) ([]model.SecretVersion, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	store, err := f.read()
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve secret versions: %w", err)
+	}
+	stored, ok := store.Secrets[secret.Name]
+	if !ok || stored.Scope != secret.Scope {
+		return nil, fmt.Errorf("could not retrieve versions of secret %s in scope %s: %w", secret.Name, secret.Scope, ErrSecretNotFound)
+	}
+
+	versions := []model.SecretVersion{{Version: stored.Version, CreatedAt: stored.CreatedAt, Keys: getSortedKeys(stored.Data)}}
+	for _, version := range stored.History {
+		versions = append(versions, model.SecretVersion{Version: version.Version, CreatedAt: version.CreatedAt, Keys: getSortedKeys(version.Data)})
+	}
+	return sortSecretVersions(versions), nil
+}
+
+func (f FileSecretBackend) RollbackSecret(secret model.Secret, version int) error {
+	log.Infof("Rolling back secret: %s with scope %s to version %d", secret.Name, secret.Scope, version)
+	return f.update(func(store *fileSecretStore) error {
+		stored, ok := store.Secrets[secret.Name]
+		if !ok || stored.Scope != secret.Scope {
+			return fmt.Errorf("could not roll back secret %s in scope %s: %w", secret.Name, secret.Scope, ErrSecretNotFound)
+		}
+		if version == stored.Version {
+			return nil
+		}
+		for _, previous := range stored.History {
+			if previous.Version == version {
+				stored.newVersion(previous.Data, f.MaxVersions)
+				return nil
+			}
+		}
+		return fmt.Errorf("could not roll back secret %s to version %d: %w", secret.Name, version, ErrSecretVersionNotFound)
+	})
+}
+
+func (f FileSecretBackend) GetScopes() ([]string, error) {
+	return getScopeNames(f.ScopesRepository)
+}
+
+// update applies the given modification to the stored secrets and persists them if the modification succeeded
+func (f FileSecretBackend) update(modify func(store *fileSecretStore) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	store, err := f.read()
+	if err != nil {
+		return err
+	}
+	if err := modify(store); err != nil {
+		return err
+	}
+	return f.write(store)
+}
+
+func (f FileSecretBackend) read() (*fileSecretStore, error) {
+	store := &fileSecretStore{Secrets: map[string]*fileSecret{}}
+
+	content, err := ioutil.ReadFile(f.FilePath)
+	if os.IsNotExist(err) {
+		return store, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("could not read secrets file %s: %w", f.FilePath, err)
+	}
+
+	if len(f.EncryptionKey) > 0 {
+		if content, err = f.decrypt(content); err != nil {
+			return nil, fmt.Errorf("could not decrypt secrets file %s: %w", f.FilePath, err)
+		}
+	}
+	if err := json.Unmarshal(content, store); err != nil {
+		return nil, fmt.Errorf("could not decode secrets file %s: %w", f.FilePath, err)
+	}
+	if store.Secrets == nil {
+		store.Secrets = map[string]*fileSecret{}
+	}
+	for _, secret := range store.Secrets {
+		if secret.Version == 0 {
+			secret.Version = 1
+		}
+	}
+	return store, nil
+}
+
+func (f FileSecretBackend) write(store *fileSecretStore) error {
+	content, err := json.Marshal(store)
+	if err != nil {
+		return err
+	}
+	if len(f.EncryptionKey) > 0 {
+		if content, err = f.encrypt(content); err != nil {
+			return fmt.Errorf("could not encrypt secrets: %w", err)
+		}
+	}
+
+	// write to a temporary file first, so that the secrets file is never left in an incomplete state
+	tmpFile := f.FilePath + ".tmp"
+	if err := os.MkdirAll(filepath.Dir(f.FilePath), 0700); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(tmpFile, content, 0600); err != nil {
+		return fmt.Errorf("could not write secrets file %s: %w", f.FilePath, err)
+	}
+	return os.Rename(tmpFile, f.FilePath)
+}
+
+func (f FileSecretBackend) encrypt(plaintext []byte) ([]byte, error) {
+	gcm, err := f.newGCM()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (f FileSecretBackend) decrypt(ciphertext []byte) ([]byte, error) {
+	gcm, err := f.newGCM()
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func (f FileSecretBackend) newGCM() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(f.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func getSortedSecretNames(store *fileSecretStore) []string {
+	names := make([]string, 0, len(store.Secrets))
+	for name := range store.Secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	log.Info("Registering Secret Backend type: file")
+	Register(SecretBackendTypeFile, func() SecretBackend {
+		var encryptionKey []byte
+		if encodedKey := os.Getenv(envVarFileEncryptionKey); encodedKey != "" {
+			key, err := base64.StdEncoding.DecodeString(encodedKey)
+			if err != nil {
+				log.Fatalf("Unable to decode %s: %s", envVarFileEncryptionKey, err)
+			}
+			encryptionKey = key
+		} else {
+			log.Warnf("%s is not set, secrets will be stored unencrypted", envVarFileEncryptionKey)
+		}
+		filePath := common.EnvBasedStringSupplier(envVarFilePath, defaultFilePath)()
+		fileBackend, err := NewFileSecretBackend(filePath, encryptionKey, repository.NewFileBasedScopesRepository())
+		if err != nil {
+			log.Fatalf("Unable to create file secret backend: %s", err)
+		}
+		fileBackend.MaxVersions = getMaxSecretVersions()
+		return fileBackend
+	})
+}
@@ -0,0 +1,74 @@
+package backend
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/keptn/keptn/secret-service/pkg/model"
+	"github.com/keptn/keptn/secret-service/pkg/repository/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFileSecretBackend(t *testing.T, filePath string, key []byte) *FileSecretBackend {
+	scopesRepository := &fake.ScopesRepositoryMock{}
+	scopesRepository.ReadFunc = func() (model.Scopes, error) { return createTestScopes(), nil }
+
+	backend, err := NewFileSecretBackend(filePath, key, scopesRepository)
+	require.Nil(t, err)
+	return backend
+}
+
+func TestNewFileSecretBackend_InvalidKey(t *testing.T) {
+	backend, err := NewFileSecretBackend("secrets.json", []byte("too-short"), &fake.ScopesRepositoryMock{})
+	assert.Nil(t, backend)
+	assert.Equal(t, ErrInvalidEncryptionKey, err)
+}
+
+func TestFileSecretBackend_EncryptsSecrets(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "secrets.json")
+	key := []byte(strings.Repeat("k", 32))
+
+	backend := newTestFileSecretBackend(t, filePath, key)
+	require.Nil(t, backend.CreateSecret(createTestSecret("my-secret", "my-scope")))
+
+	content, err := ioutil.ReadFile(filePath)
+	require.Nil(t, err)
+	assert.NotContains(t, string(content), "my-secret")
+	assert.NotContains(t, string(content), "keptn")
+
+	// a new instance with the same key can read the secrets
+	secrets, err := newTestFileSecretBackend(t, filePath, key).GetSecrets(model.Secret{})
+	require.Nil(t, err)
+	require.Len(t, secrets, 1)
+	assert.Equal(t, "my-secret", secrets[0].Name)
+	assert.Equal(t, []string{"password"}, secrets[0].Keys)
+
+	// a different key cannot
+	_, err = newTestFileSecretBackend(t, filePath, []byte(strings.Repeat("x", 32))).GetSecrets(model.Secret{})
+	assert.NotNil(t, err)
+}
+
+func TestFileSecretBackend_Unencrypted(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "secrets.json")
+
+	backend := newTestFileSecretBackend(t, filePath, nil)
+	require.Nil(t, backend.CreateSecret(createTestSecret("my-secret", "my-scope")))
+
+	content, err := ioutil.ReadFile(filePath)
+	require.Nil(t, err)
+	assert.Contains(t, string(content), `"my-secret":{"scope":"my-scope","data":{"password":"keptn"},"version":1`)
+}
+
+func TestFileSecretBackend_InvalidSecretName(t *testing.T) {
+	backend := newTestFileSecretBackend(t, filepath.Join(t.TempDir(), "secrets.json"), nil)
+
+	err := backend.CreateSecret(createTestSecret("my/secret", "my-scope"))
+	assert.True(t, errors.Is(err, ErrInvalidSecretName))
+
+	err = backend.CreateSecret(createTestSecret(strings.Repeat("s", maxKeySize+1), "my-scope"))
+	assert.True(t, errors.Is(err, ErrTooBigKeySize))
+}
@@ -2,10 +2,14 @@ package backend
 
 import (
 	"context"
-	"errors"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/keptn/keptn/secret-service/pkg/common"
 	"github.com/keptn/keptn/secret-service/pkg/model"
@@ -22,15 +26,32 @@ import (
 const SecretBackendTypeK8s = "kubernetes"
 const SecretServiceName = "keptn-secret-service"
 
-var ErrSecretAlreadyExists = errors.New("secret already exists")
-var ErrSecretNotFound = errors.New("secret not found")
-var ErrTooBigKeySize = errors.New("name and key values must be no more than 253 characters")
-var ErrScopeNotFound = errors.New("scope not found")
+// SecretHistoryManagedBy is the value of the 'managed-by' label of the secrets holding the previous versions of a secret
+const SecretHistoryManagedBy = "keptn-secret-service-history"
+
+// labels binding a secret to a project and stage. Integrations reading secrets directly from Kubernetes must only
+// use secrets without a project label, or secrets whose project and stage labels match the event they are processing
+const LabelProject = "keptn.sh/project"
+const LabelStage = "keptn.sh/stage"
+
+const annotationSecretName = "keptn.sh/secret-name"
+const annotationSecretVersion = "keptn.sh/secret-version"
+const annotationSecretCreatedAt = "keptn.sh/secret-version-created-at"
+const annotationSecretExpiresAt = "keptn.sh/secret-expires-at"
+const annotationSecretRotateAfter = "keptn.sh/secret-rotate-after"
+
+// k8sSecretVersion is a previous version of a secret as stored in its history secret
+type k8sSecretVersion struct {
+	CreatedAt time.Time  `json:"createdAt"`
+	Data      model.Data `json:"data"`
+}
 
 type K8sSecretBackend struct {
 	KubeAPI                kubernetes.Interface
 	KeptnNamespaceProvider common.StringSupplier
 	ScopesRepository       repository.ScopesRepository
+	// MaxVersions is the number of versions of a secret, including the current one, that are kept
+	MaxVersions int
 }
 
 func NewK8sSecretBackend(kubeAPI kubernetes.Interface, scopesRepository repository.ScopesRepository) *K8sSecretBackend {
@@ -38,29 +59,25 @@ func NewK8sSecretBackend(kubeAPI kubernetes.Interface, scopesRepository reposito
 		KubeAPI:                kubeAPI,
 		KeptnNamespaceProvider: common.EnvBasedStringSupplier("POD_NAMESPACE", DefaultNamespace),
 		ScopesRepository:       scopesRepository,
+		MaxVersions:            DefaultMaxSecretVersions,
 	}
 }
 
 func (k K8sSecretBackend) checkScopeDefined(secret model.Secret) (model.Scopes, error) {
-	scopes, err := k.ScopesRepository.Read()
-	if err != nil {
-		return model.Scopes{}, err
-	}
-	if _, ok := scopes.Scopes[secret.Scope]; !ok {
-		log.Warnf("Unable to find scope %s for secret %s", secret.Scope, secret.Name)
-		return model.Scopes{}, fmt.Errorf("unable to check defined scope %s for secret %s: %w", secret.Scope, secret.Name, ErrScopeNotFound)
-	}
-	return scopes, nil
+	return checkScopeDefined(k.ScopesRepository, secret)
 }
 
 func (k K8sSecretBackend) CreateSecret(secret model.Secret) error {
 	log.Infof("Creating secret: %s with scope %s", secret.Name, secret.Scope)
+	if err := validateProjectBinding(secret); err != nil {
+		return err
+	}
 	scopes, err := k.checkScopeDefined(secret)
 	if err != nil {
 		return err
 	}
 	namespace := k.KeptnNamespaceProvider()
-	_, err = k.KubeAPI.CoreV1().Secrets(namespace).Create(context.TODO(), k.createK8sSecretObj(secret, namespace), metav1.CreateOptions{})
+	_, err = k.KubeAPI.CoreV1().Secrets(namespace).Create(context.TODO(), k.createK8sSecretObj(secret, namespace, 1), metav1.CreateOptions{})
 	if err != nil {
 		couldNotCreateSecretMsg := "Could not create secret %s with scope %s: %v"
 		if statusError, isStatus := err.(*k8serr.StatusError); isStatus && statusError.Status().Reason == metav1.StatusReasonInvalid && strings.Contains(statusError.Status().Message, "must be no more than 253 characters") {
@@ -175,6 +192,12 @@ func (k K8sSecretBackend) DeleteSecret(secret model.Secret) error {
 		return err
 	}
 
+	// the previous versions are not needed anymore
+	err = k.KubeAPI.CoreV1().Secrets(namespace).Delete(context.TODO(), getHistorySecretName(secretName), metav1.DeleteOptions{})
+	if err != nil && !k8serr.IsNotFound(err) {
+		log.Warnf("Could not delete previous versions of secret %s: %v", secret.Name, err)
+	}
+
 	return nil
 }
 
@@ -188,8 +211,10 @@ func (k K8sSecretBackend) GetSecrets(secret model.Secret) ([]model.GetSecretResp
 		if statusError, isStatus := err.(*k8serr.StatusError); isStatus && statusError.Status().Reason != metav1.StatusReasonNotFound {
 			return nil, fmt.Errorf("could not retrieve secrets: %w", err)
 		}
-		if s != nil && (secret.Scope == "" || s.Labels["app.kubernetes.io/scope"] == secret.Scope) {
-			result = append(result, createGetResponseItem(s))
+		if s != nil {
+			if item := createGetResponseItem(s); matchesFilter(item.SecretMetadata, secret) {
+				result = append(result, item)
+			}
 		}
 		return result, nil
 
@@ -200,6 +225,12 @@ func (k K8sSecretBackend) GetSecrets(secret model.Secret) ([]model.GetSecretResp
 		if secret.Scope != "" {
 			options.LabelSelector += ",app.kubernetes.io/scope=" + secret.Scope
 		}
+		if secret.Project != "" {
+			options.LabelSelector += "," + LabelProject + "=" + secret.Project
+		}
+		if secret.Stage != "" {
+			options.LabelSelector += "," + LabelStage + "=" + secret.Stage
+		}
 
 		list, err := k.KubeAPI.CoreV1().Secrets(namespace).List(context.TODO(), options)
 		if err != nil {
@@ -213,6 +244,206 @@ func (k K8sSecretBackend) GetSecrets(secret model.Secret) ([]model.GetSecretResp
 	return result, nil
 }
 
+func (k K8sSecretBackend) GetSecretVersions(secret model.Secret) ([]model.SecretVersion, error) {
+	namespace := k.KeptnNamespaceProvider()
+	current, err := k.getSecretInScope(secret, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve versions of secret %s in scope %s: %w", secret.Name, secret.Scope, err)
+	}
+	history, err := k.getHistory(secret.Name, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve versions of secret %s in scope %s: %w", secret.Name, secret.Scope, err)
+	}
+
+	versions := []model.SecretVersion{{
+		Version:   getSecretVersion(current),
+		CreatedAt: getSecretCreatedAt(current),
+		Keys:      getSortedKeys(getSecretData(current)),
+	}}
+	for version, previous := range history {
+		versions = append(versions, model.SecretVersion{Version: version, CreatedAt: previous.CreatedAt, Keys: getSortedKeys(previous.Data)})
+	}
+	return sortSecretVersions(versions), nil
+}
+
+func (k K8sSecretBackend) RollbackSecret(secret model.Secret, version int) error {
+	log.Infof("Rolling back secret: %s with scope %s to version %d", secret.Name, secret.Scope, version)
+	if _, err := k.checkScopeDefined(secret); err != nil {
+		return err
+	}
+	namespace := k.KeptnNamespaceProvider()
+	current, err := k.getSecretInScope(secret, namespace)
+	if err != nil {
+		return fmt.Errorf("could not roll back secret %s in scope %s: %w", secret.Name, secret.Scope, err)
+	}
+	if version == getSecretVersion(current) {
+		return nil
+	}
+	history, err := k.getHistory(secret.Name, namespace)
+	if err != nil {
+		return err
+	}
+	previous, ok := history[version]
+	if !ok {
+		return fmt.Errorf("could not roll back secret %s to version %d: %w", secret.Name, version, ErrSecretVersionNotFound)
+	}
+
+	// the metadata of the secret is not versioned and therefore kept
+	secret.Data = previous.Data
+	secret.Project = current.Labels[LabelProject]
+	secret.Stage = current.Labels[LabelStage]
+	secret.ExpiresAt = parseTime(current.Annotations[annotationSecretExpiresAt])
+	secret.RotateAfter = parseTime(current.Annotations[annotationSecretRotateAfter])
+	return k.replaceSecret(current, secret, namespace)
+}
+
+// getSecretInScope returns the secret if it exists and belongs to the scope of the given secret
+func (k K8sSecretBackend) getSecretInScope(secret model.Secret, namespace string) (*corev1.Secret, error) {
+	current, err := k.KubeAPI.CoreV1().Secrets(namespace).Get(context.TODO(), secret.Name, metav1.GetOptions{})
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			return nil, ErrSecretNotFound
+		}
+		return nil, err
+	}
+	if current.Labels["app.kubernetes.io/scope"] != secret.Scope {
+		return nil, ErrSecretNotFound
+	}
+	return current, nil
+}
+
+// replaceSecret stores the data of the current secret in its history and replaces it with the given secret
+func (k K8sSecretBackend) replaceSecret(current *corev1.Secret, secret model.Secret, namespace string) error {
+	if err := k.addToHistory(current, namespace); err != nil {
+		log.Errorf("Could not store previous version of secret %s: %v", secret.Name, err)
+		return err
+	}
+	_, err := k.KubeAPI.CoreV1().Secrets(namespace).Update(context.TODO(), k.createK8sSecretObj(secret, namespace, getSecretVersion(current)+1), metav1.UpdateOptions{})
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			log.Warnf("Could not update secret %s: %v", secret.Name, ErrSecretNotFound)
+			return ErrSecretNotFound
+		}
+		log.Warnf("Could not update secret %s: %v", secret.Name, err)
+		return err
+	}
+	return nil
+}
+
+// getHistory returns the previous versions of a secret, indexed by their version
+func (k K8sSecretBackend) getHistory(secretName string, namespace string) (map[int]k8sSecretVersion, error) {
+	history := map[int]k8sSecretVersion{}
+	historySecret, err := k.KubeAPI.CoreV1().Secrets(namespace).Get(context.TODO(), getHistorySecretName(secretName), metav1.GetOptions{})
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			return history, nil
+		}
+		return nil, err
+	}
+	for key, value := range getSecretData(historySecret) {
+		version, err := strconv.Atoi(key)
+		if err != nil {
+			continue
+		}
+		previous := k8sSecretVersion{}
+		if err := json.Unmarshal([]byte(value), &previous); err != nil {
+			log.Warnf("Could not decode version %d of secret %s: %v", version, secretName, err)
+			continue
+		}
+		history[version] = previous
+	}
+	return history, nil
+}
+
+// addToHistory stores the data of the given secret in its history secret and removes the oldest versions
+// exceeding the maximum number of versions
+func (k K8sSecretBackend) addToHistory(current *corev1.Secret, namespace string) error {
+	previous, err := json.Marshal(k8sSecretVersion{CreatedAt: getSecretCreatedAt(current), Data: getSecretData(current)})
+	if err != nil {
+		return err
+	}
+
+	historySecret, err := k.KubeAPI.CoreV1().Secrets(namespace).Get(context.TODO(), getHistorySecretName(current.Name), metav1.GetOptions{})
+	exists := err == nil
+	if err != nil && !k8serr.IsNotFound(err) {
+		return err
+	}
+	if !exists {
+		historySecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        getHistorySecretName(current.Name),
+				Namespace:   namespace,
+				Labels:      map[string]string{"app.kubernetes.io/managed-by": SecretHistoryManagedBy},
+				Annotations: map[string]string{annotationSecretName: current.Name},
+			},
+			Type: "Opaque",
+		}
+	}
+	if historySecret.Data == nil {
+		historySecret.Data = map[string][]byte{}
+	}
+	historySecret.Data[strconv.Itoa(getSecretVersion(current))] = previous
+
+	versions := []int{}
+	for key := range historySecret.Data {
+		if version, err := strconv.Atoi(key); err == nil {
+			versions = append(versions, version)
+		}
+	}
+	sort.Ints(versions)
+	for i := 0; i < len(versions)-(k.maxVersions()-1); i++ {
+		delete(historySecret.Data, strconv.Itoa(versions[i]))
+	}
+
+	if exists {
+		_, err = k.KubeAPI.CoreV1().Secrets(namespace).Update(context.TODO(), historySecret, metav1.UpdateOptions{})
+	} else {
+		_, err = k.KubeAPI.CoreV1().Secrets(namespace).Create(context.TODO(), historySecret, metav1.CreateOptions{})
+	}
+	return err
+}
+
+func (k K8sSecretBackend) maxVersions() int {
+	if k.MaxVersions < 1 {
+		return DefaultMaxSecretVersions
+	}
+	return k.MaxVersions
+}
+
+// getHistorySecretName returns the name of the secret holding the previous versions of a secret. The name is derived
+// from a hash, so that it does not exceed the maximum length of secret names
+func getHistorySecretName(secretName string) string {
+	hash := sha256.Sum256([]byte(secretName))
+	return "keptn-secret-history-" + hex.EncodeToString(hash[:16])
+}
+
+func getSecretData(secretItem *corev1.Secret) model.Data {
+	data := model.Data{}
+	for key, value := range secretItem.Data {
+		data[key] = string(value)
+	}
+	for key, value := range secretItem.StringData {
+		data[key] = value
+	}
+	return data
+}
+
+// getSecretVersion returns the version of the secret. Secrets created before versioning was introduced are at version 1
+func getSecretVersion(secretItem *corev1.Secret) int {
+	version, err := strconv.Atoi(secretItem.Annotations[annotationSecretVersion])
+	if err != nil || version < 1 {
+		return 1
+	}
+	return version
+}
+
+func getSecretCreatedAt(secretItem *corev1.Secret) time.Time {
+	if createdAt := parseTime(secretItem.Annotations[annotationSecretCreatedAt]); createdAt != nil {
+		return *createdAt
+	}
+	return secretItem.CreationTimestamp.Time
+}
+
 func createGetResponseItem(secretItem *corev1.Secret) model.GetSecretResponseItem {
 	keys := []string{}
 	for key := range secretItem.StringData {
@@ -227,16 +458,24 @@ func createGetResponseItem(secretItem *corev1.Secret) model.GetSecretResponseIte
 	}
 	return model.GetSecretResponseItem{
 		SecretMetadata: model.SecretMetadata{
-			Name:  secretItem.Name,
-			Scope: secretItem.Labels["app.kubernetes.io/scope"],
+			Name:        secretItem.Name,
+			Scope:       secretItem.Labels["app.kubernetes.io/scope"],
+			Project:     secretItem.Labels[LabelProject],
+			Stage:       secretItem.Labels[LabelStage],
+			ExpiresAt:   parseTime(secretItem.Annotations[annotationSecretExpiresAt]),
+			RotateAfter: parseTime(secretItem.Annotations[annotationSecretRotateAfter]),
 		},
-		Keys: keys,
+		Keys:    keys,
+		Version: getSecretVersion(secretItem),
 	}
 
 }
 
 func (k K8sSecretBackend) UpdateSecret(secret model.Secret) error {
 	log.Infof("Updating secret: %s with scope %s", secret.Name, secret.Scope)
+	if err := validateProjectBinding(secret); err != nil {
+		return err
+	}
 
 	_, err := k.checkScopeDefined(secret)
 	if err != nil {
@@ -244,18 +483,16 @@ func (k K8sSecretBackend) UpdateSecret(secret model.Secret) error {
 	}
 
 	namespace := k.KeptnNamespaceProvider()
-	kubeSecret := k.createK8sSecretObj(secret, namespace)
-
-	_, err = k.KubeAPI.CoreV1().Secrets(namespace).Update(context.TODO(), kubeSecret, metav1.UpdateOptions{})
+	current, err := k.KubeAPI.CoreV1().Secrets(namespace).Get(context.TODO(), secret.Name, metav1.GetOptions{})
 	if err != nil {
-		if statusError, isStatus := err.(*k8serr.StatusError); isStatus && statusError.Status().Reason == metav1.StatusReasonNotFound {
+		if k8serr.IsNotFound(err) {
 			log.Warnf("Could not update secret %s: %v", secret.Name, ErrSecretNotFound)
 			return ErrSecretNotFound
 		}
 		log.Warnf("Could not update secret %s: %v", secret.Name, err)
 		return err
 	}
-	return nil
+	return k.replaceSecret(current, secret, namespace)
 
 }
 
@@ -323,19 +560,37 @@ func (k K8sSecretBackend) createK8sRoleBindingObj(secret model.Secret, roles []r
 	return roleBinding
 }
 
-func (k K8sSecretBackend) createK8sSecretObj(secret model.Secret, namespace string) *corev1.Secret {
+func (k K8sSecretBackend) createK8sSecretObj(secret model.Secret, namespace string, version int) *corev1.Secret {
+	annotations := map[string]string{
+		annotationSecretVersion:   strconv.Itoa(version),
+		annotationSecretCreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	if secret.ExpiresAt != nil {
+		annotations[annotationSecretExpiresAt] = formatTime(secret.ExpiresAt)
+	}
+	if secret.RotateAfter != nil {
+		annotations[annotationSecretRotateAfter] = formatTime(secret.RotateAfter)
+	}
+	labels := map[string]string{
+		"app.kubernetes.io/managed-by": SecretServiceName, // add a 'managed-by' label so we can identify secrets managed by the secret-service
+		"app.kubernetes.io/scope":      secret.Scope,
+	}
+	if secret.Project != "" {
+		labels[LabelProject] = secret.Project
+	}
+	if secret.Stage != "" {
+		labels[LabelStage] = secret.Stage
+	}
 	return &corev1.Secret{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Secret",
 			APIVersion: "apps/v1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      secret.Name,
-			Namespace: namespace,
-			Labels: map[string]string{
-				"app.kubernetes.io/managed-by": SecretServiceName, // add a 'managed-by' label so we can identify secrets managed by the secret-service
-				"app.kubernetes.io/scope":      secret.Scope,
-			},
+			Name:        secret.Name,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
 		},
 		StringData: secret.Data,
 		Type:       "Opaque",
@@ -343,16 +598,7 @@ func (k K8sSecretBackend) createK8sSecretObj(secret model.Secret, namespace stri
 }
 
 func (k K8sSecretBackend) GetScopes() ([]string, error) {
-	scopes, err := k.ScopesRepository.Read()
-	if err != nil {
-		return nil, err
-	}
-	scopeArray := make([]string, 0, len(scopes.Scopes))
-	for scope := range scopes.Scopes {
-		scopeArray = append(scopeArray, scope)
-	}
-	sort.Strings(scopeArray)
-	return scopeArray, nil
+	return getScopeNames(k.ScopesRepository)
 }
 
 func remove(s []string, r string) []string {
@@ -397,6 +643,8 @@ func init() {
 			log.Fatalf("Unable to create kubernetes client: %s", err)
 		}
 		scopesRepository := repository.NewFileBasedScopesRepository()
-		return NewK8sSecretBackend(kubeAPI, scopesRepository)
+		k8sBackend := NewK8sSecretBackend(kubeAPI, scopesRepository)
+		k8sBackend.MaxVersions = getMaxSecretVersions()
+		return k8sBackend
 	})
 }
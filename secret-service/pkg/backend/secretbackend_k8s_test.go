@@ -214,7 +214,8 @@ func TestGetSecret_ReturnsAllSecrets(t *testing.T) {
 				Name:  "my-secret",
 				Scope: "my-scope",
 			},
-			Keys: []string{"password"},
+			Keys:    []string{"password"},
+			Version: 1,
 		},
 	}, secrets)
 }
@@ -257,14 +258,16 @@ func TestGetSecret_ReturnsSecretsByScope(t *testing.T) {
 				Name:  "my-secret",
 				Scope: "my-scope",
 			},
-			Keys: []string{"password"},
+			Keys:    []string{"password"},
+			Version: 1,
 		},
 		{
 			SecretMetadata: model.SecretMetadata{
 				Name:  "my-secret2",
 				Scope: "my-scope",
 			},
-			Keys: []string{"password"},
+			Keys:    []string{"password"},
+			Version: 1,
 		},
 	}, secrets)
 
@@ -304,7 +307,8 @@ func TestGetSecret_ReturnsSecretsByName(t *testing.T) {
 				Name:  "my-secret",
 				Scope: "my-scope",
 			},
-			Keys: []string{"password"},
+			Keys:    []string{"password"},
+			Version: 1,
 		},
 	}, secrets)
 }
@@ -343,7 +347,8 @@ func TestGetSecret_ReturnsSecretsByNameAndScope(t *testing.T) {
 				Name:  "my-secret",
 				Scope: "my-scope",
 			},
-			Keys: []string{"password"},
+			Keys:    []string{"password"},
+			Version: 1,
 		},
 	}, secrets)
 }
@@ -662,13 +667,24 @@ func TestUpdateSecret(t *testing.T) {
 		ScopesRepository:       scopesRepository,
 	}
 
-	kubernetes.Fake.PrependReactor("update", "secrets", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
-		return true, nil, nil
-	})
-
 	secret := createTestSecret("my-secret", "my-scope")
+	require.Nil(t, backend.CreateSecret(secret))
+
+	secret.Data = map[string]string{"token": "keptn"}
 	err := backend.UpdateSecret(secret)
 	assert.Nil(t, err)
+
+	k8sSecret, err := kubernetes.CoreV1().Secrets(FakeNamespaceProvider()()).Get(context.TODO(), "my-secret", metav1.GetOptions{})
+	require.Nil(t, err)
+	assert.Equal(t, map[string]string{"token": "keptn"}, k8sSecret.StringData)
+	assert.Equal(t, "2", k8sSecret.Annotations[annotationSecretVersion])
+
+	// the previous version is kept in a separate secret that is not bound to the scope
+	history, err := kubernetes.CoreV1().Secrets(FakeNamespaceProvider()()).Get(context.TODO(), getHistorySecretName("my-secret"), metav1.GetOptions{})
+	require.Nil(t, err)
+	assert.Equal(t, SecretHistoryManagedBy, history.Labels["app.kubernetes.io/managed-by"])
+	assert.Empty(t, history.Labels["app.kubernetes.io/scope"])
+	assert.Contains(t, history.Data, "1")
 }
 
 func TestUpdateSecret_SecretNotFound(t *testing.T) {
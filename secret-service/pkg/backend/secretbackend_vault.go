@@ -0,0 +1,408 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keptn/keptn/secret-service/pkg/common"
+	"github.com/keptn/keptn/secret-service/pkg/model"
+	"github.com/keptn/keptn/secret-service/pkg/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+const SecretBackendTypeVault = "vault"
+
+const envVarVaultAddress = "VAULT_ADDR"
+const envVarVaultToken = "VAULT_TOKEN"
+const envVarVaultTokenFile = "VAULT_TOKEN_FILE"
+const envVarVaultMountPath = "VAULT_KV_MOUNT"
+const envVarVaultPathPrefix = "VAULT_KV_PATH_PREFIX"
+const envVarVaultNamespace = "VAULT_NAMESPACE"
+
+const defaultVaultMountPath = "secret"
+const defaultVaultPathPrefix = "keptn"
+
+// keys of the custom metadata entries holding the metadata of a secret
+const vaultScopeMetadataKey = "keptn-scope"
+const vaultProjectMetadataKey = "keptn-project"
+const vaultStageMetadataKey = "keptn-stage"
+const vaultExpiresAtMetadataKey = "keptn-expires-at"
+const vaultRotateAfterMetadataKey = "keptn-rotate-after"
+
+var errVaultCheckAndSetFailed = errors.New("check-and-set parameter did not match the current version")
+
+// VaultSecretBackend stores secrets in a KV version 2 secrets engine of HashiCorp Vault.
+// Each secret is stored at <MountPath>/<PathPrefix>/<name>, and its scope is kept in the custom metadata of the secret
+type VaultSecretBackend struct {
+	Address          string
+	TokenProvider    common.StringSupplier
+	MountPath        string
+	PathPrefix       string
+	Namespace        string
+	HTTPClient       *http.Client
+	ScopesRepository repository.ScopesRepository
+	// MaxVersions is the number of versions of a secret, including the current one, that are kept by Vault
+	MaxVersions int
+}
+
+type vaultSecretRequest struct {
+	Options map[string]interface{} `json:"options,omitempty"`
+	Data    model.Data             `json:"data"`
+}
+
+type vaultMetadataRequest struct {
+	MaxVersions    int               `json:"max_versions"`
+	CustomMetadata map[string]string `json:"custom_metadata"`
+}
+
+type vaultSecretResponse struct {
+	Data struct {
+		Data     model.Data `json:"data"`
+		Metadata struct {
+			Version        int               `json:"version"`
+			CreatedTime    time.Time         `json:"created_time"`
+			CustomMetadata map[string]string `json:"custom_metadata"`
+		} `json:"metadata"`
+	} `json:"data"`
+}
+
+type vaultMetadataResponse struct {
+	Data struct {
+		CurrentVersion int                             `json:"current_version"`
+		Versions       map[string]vaultVersionMetadata `json:"versions"`
+	} `json:"data"`
+}
+
+type vaultVersionMetadata struct {
+	CreatedTime  time.Time `json:"created_time"`
+	DeletionTime string    `json:"deletion_time"`
+	Destroyed    bool      `json:"destroyed"`
+}
+
+type vaultListResponse struct {
+	Data struct {
+		Keys []string `json:"keys"`
+	} `json:"data"`
+}
+
+type vaultErrorResponse struct {
+	Errors []string `json:"errors"`
+}
+
+func NewVaultSecretBackend(address string, tokenProvider common.StringSupplier, scopesRepository repository.ScopesRepository) *VaultSecretBackend {
+	return &VaultSecretBackend{
+		Address:          strings.TrimSuffix(address, "/"),
+		TokenProvider:    tokenProvider,
+		MountPath:        defaultVaultMountPath,
+		PathPrefix:       defaultVaultPathPrefix,
+		HTTPClient:       &http.Client{Timeout: 10 * time.Second},
+		ScopesRepository: scopesRepository,
+		MaxVersions:      DefaultMaxSecretVersions,
+	}
+}
+
+func (v VaultSecretBackend) CreateSecret(secret model.Secret) error {
+	log.Infof("Creating secret: %s with scope %s", secret.Name, secret.Scope)
+	if err := validateSecret(secret); err != nil {
+		return err
+	}
+	if _, err := checkScopeDefined(v.ScopesRepository, secret); err != nil {
+		return err
+	}
+
+	// a check-and-set value of 0 only allows the write if the secret does not exist yet
+	request := vaultSecretRequest{Options: map[string]interface{}{"cas": 0}, Data: secret.Data}
+	if err := v.do(http.MethodPost, v.dataPath(secret.Name), request, nil); err != nil {
+		if errors.Is(err, errVaultCheckAndSetFailed) {
+			log.Warnf("Could not create secret %s with scope %s: %v", secret.Name, secret.Scope, ErrSecretAlreadyExists)
+			return ErrSecretAlreadyExists
+		}
+		log.Errorf("Could not create secret %s with scope %s: %v", secret.Name, secret.Scope, err)
+		return err
+	}
+	return v.writeMetadata(secret)
+}
+
+func (v VaultSecretBackend) UpdateSecret(secret model.Secret) error {
+	log.Infof("Updating secret: %s with scope %s", secret.Name, secret.Scope)
+	if err := validateSecret(secret); err != nil {
+		return err
+	}
+	if _, err := checkScopeDefined(v.ScopesRepository, secret); err != nil {
+		return err
+	}
+	if _, err := v.readSecret(secret.Name); err != nil {
+		return err
+	}
+
+	if err := v.do(http.MethodPost, v.dataPath(secret.Name), vaultSecretRequest{Data: secret.Data}, nil); err != nil {
+		log.Errorf("Could not update secret %s with scope %s: %v", secret.Name, secret.Scope, err)
+		return err
+	}
+	return v.writeMetadata(secret)
+}
+
+func (v VaultSecretBackend) DeleteSecret(secret model.Secret) error {
+	log.Infof("Deleting secret: %s with scope %s", secret.Name, secret.Scope)
+	if _, err := checkScopeDefined(v.ScopesRepository, secret); err != nil {
+		return err
+	}
+
+	stored, err := v.readSecret(secret.Name)
+	if err != nil {
+		return fmt.Errorf("could not delete secret %s in scope %s: %w", secret.Name, secret.Scope, err)
+	}
+	if stored.Data.Metadata.CustomMetadata[vaultScopeMetadataKey] != secret.Scope {
+		return fmt.Errorf("could not delete secret %s in scope %s: %w", secret.Name, secret.Scope, ErrSecretNotFound)
+	}
+
+	// deleting the metadata removes all versions of the secret
+	return v.do(http.MethodDelete, v.metadataPath(secret.Name), nil, nil)
+}
+
+func (v VaultSecretBackend) GetSecrets(secret model.Secret) ([]model.GetSecretResponseItem, error) {
+	names := []string{secret.Name}
+	if secret.Name == "" {
+		list := &vaultListResponse{}
+		if err := v.do(http.MethodGet, v.metadataPath("")+"?list=true", nil, list); err != nil && !errors.Is(err, ErrSecretNotFound) {
+			return nil, fmt.Errorf("could not retrieve secrets: %w", err)
+		}
+		names = list.Data.Keys
+	}
+
+	result := []model.GetSecretResponseItem{}
+	for _, name := range names {
+		if strings.HasSuffix(name, "/") {
+			// nested paths have not been created by keptn
+			continue
+		}
+		stored, err := v.readSecret(name)
+		if errors.Is(err, ErrSecretNotFound) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("could not retrieve secret %s: %w", name, err)
+		}
+		customMetadata := stored.Data.Metadata.CustomMetadata
+		item := model.GetSecretResponseItem{
+			SecretMetadata: model.SecretMetadata{
+				Name:        name,
+				Scope:       customMetadata[vaultScopeMetadataKey],
+				Project:     customMetadata[vaultProjectMetadataKey],
+				Stage:       customMetadata[vaultStageMetadataKey],
+				ExpiresAt:   parseTime(customMetadata[vaultExpiresAtMetadataKey]),
+				RotateAfter: parseTime(customMetadata[vaultRotateAfterMetadataKey]),
+			},
+			Keys:    getSortedKeys(stored.Data.Data),
+			Version: stored.Data.Metadata.Version,
+		}
+		if !matchesFilter(item.SecretMetadata, secret) {
+			continue
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+func (v VaultSecretBackend) GetSecretVersions(secret model.Secret) ([]model.SecretVersion, error) {
+	if err := v.checkSecretInScope(secret); err != nil {
+		return nil, fmt.Errorf("could not retrieve versions of secret %s in scope %s: %w", secret.Name, secret.Scope, err)
+	}
+	metadata := &vaultMetadataResponse{}
+	if err := v.do(http.MethodGet, v.metadataPath(secret.Name), nil, metadata); err != nil {
+		return nil, fmt.Errorf("could not retrieve versions of secret %s in scope %s: %w", secret.Name, secret.Scope, err)
+	}
+
+	versions := []model.SecretVersion{}
+	for key, versionMetadata := range metadata.Data.Versions {
+		version, err := strconv.Atoi(key)
+		if err != nil || versionMetadata.Destroyed || versionMetadata.DeletionTime != "" {
+			continue
+		}
+		stored, err := v.readSecretVersion(secret.Name, version)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve version %d of secret %s: %w", version, secret.Name, err)
+		}
+		versions = append(versions, model.SecretVersion{Version: version, CreatedAt: versionMetadata.CreatedTime, Keys: getSortedKeys(stored.Data.Data)})
+	}
+	return sortSecretVersions(versions), nil
+}
+
+func (v VaultSecretBackend) RollbackSecret(secret model.Secret, version int) error {
+	log.Infof("Rolling back secret: %s with scope %s to version %d", secret.Name, secret.Scope, version)
+	if _, err := checkScopeDefined(v.ScopesRepository, secret); err != nil {
+		return err
+	}
+	current, err := v.readSecret(secret.Name)
+	if err == nil && current.Data.Metadata.CustomMetadata[vaultScopeMetadataKey] != secret.Scope {
+		err = ErrSecretNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("could not roll back secret %s in scope %s: %w", secret.Name, secret.Scope, err)
+	}
+	currentVersion := current.Data.Metadata.Version
+	if version == currentVersion {
+		return nil
+	}
+
+	previous, err := v.readSecretVersion(secret.Name, version)
+	if err != nil {
+		if errors.Is(err, ErrSecretNotFound) {
+			return fmt.Errorf("could not roll back secret %s to version %d: %w", secret.Name, version, ErrSecretVersionNotFound)
+		}
+		return err
+	}
+
+	// the previous data is written as a new version, which fails if the secret has been modified in the meantime
+	request := vaultSecretRequest{Options: map[string]interface{}{"cas": currentVersion}, Data: previous.Data.Data}
+	if err := v.do(http.MethodPost, v.dataPath(secret.Name), request, nil); err != nil {
+		log.Errorf("Could not roll back secret %s to version %d: %v", secret.Name, version, err)
+		return err
+	}
+	return nil
+}
+
+func (v VaultSecretBackend) GetScopes() ([]string, error) {
+	return getScopeNames(v.ScopesRepository)
+}
+
+func (v VaultSecretBackend) readSecret(name string) (*vaultSecretResponse, error) {
+	response := &vaultSecretResponse{}
+	if err := v.do(http.MethodGet, v.dataPath(name), nil, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// readSecretVersion returns the given version of a secret. Deleted or destroyed versions are reported as ErrSecretNotFound
+func (v VaultSecretBackend) readSecretVersion(name string, version int) (*vaultSecretResponse, error) {
+	response := &vaultSecretResponse{}
+	if err := v.do(http.MethodGet, v.dataPath(name)+"?version="+strconv.Itoa(version), nil, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (v VaultSecretBackend) checkSecretInScope(secret model.Secret) error {
+	stored, err := v.readSecret(secret.Name)
+	if err != nil {
+		return err
+	}
+	if stored.Data.Metadata.CustomMetadata[vaultScopeMetadataKey] != secret.Scope {
+		return ErrSecretNotFound
+	}
+	return nil
+}
+
+func (v VaultSecretBackend) writeMetadata(secret model.Secret) error {
+	customMetadata := map[string]string{vaultScopeMetadataKey: secret.Scope}
+	if secret.Project != "" {
+		customMetadata[vaultProjectMetadataKey] = secret.Project
+	}
+	if secret.Stage != "" {
+		customMetadata[vaultStageMetadataKey] = secret.Stage
+	}
+	if secret.ExpiresAt != nil {
+		customMetadata[vaultExpiresAtMetadataKey] = formatTime(secret.ExpiresAt)
+	}
+	if secret.RotateAfter != nil {
+		customMetadata[vaultRotateAfterMetadataKey] = formatTime(secret.RotateAfter)
+	}
+	request := vaultMetadataRequest{MaxVersions: v.MaxVersions, CustomMetadata: customMetadata}
+	if err := v.do(http.MethodPost, v.metadataPath(secret.Name), request, nil); err != nil {
+		log.Errorf("Could not store metadata of secret %s: %v", secret.Name, err)
+		return err
+	}
+	return nil
+}
+
+func (v VaultSecretBackend) dataPath(name string) string {
+	return v.path("data", name)
+}
+
+func (v VaultSecretBackend) metadataPath(name string) string {
+	return v.path("metadata", name)
+}
+
+func (v VaultSecretBackend) path(kind string, name string) string {
+	segments := []string{"v1", strings.Trim(v.MountPath, "/"), kind}
+	if prefix := strings.Trim(v.PathPrefix, "/"); prefix != "" {
+		segments = append(segments, prefix)
+	}
+	return "/" + strings.Join(segments, "/") + "/" + name
+}
+
+// do sends a request to the Vault API and decodes the response into out, if provided.
+// A 404 response is reported as ErrSecretNotFound
+func (v VaultSecretBackend) do(method string, path string, in interface{}, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, v.Address+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", v.TokenProvider())
+	if v.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.Namespace)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := v.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not reach vault: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrSecretNotFound
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		errorResponse := &vaultErrorResponse{}
+		_ = json.NewDecoder(resp.Body).Decode(errorResponse)
+		message := strings.Join(errorResponse.Errors, ", ")
+		if strings.Contains(message, errVaultCheckAndSetFailed.Error()) {
+			return errVaultCheckAndSetFailed
+		}
+		return fmt.Errorf("vault returned status %d: %s", resp.StatusCode, message)
+	}
+	if out != nil && resp.StatusCode != http.StatusNoContent {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
+
+func init() {
+	log.Info("Registering Secret Backend type: vault")
+	Register(SecretBackendTypeVault, func() SecretBackend {
+		address := os.Getenv(envVarVaultAddress)
+		if address == "" {
+			log.Fatalf("%s must be set when using the vault secret backend", envVarVaultAddress)
+		}
+		tokenProvider := common.EnvBasedStringSupplier(envVarVaultToken, "")
+		if tokenFile := os.Getenv(envVarVaultTokenFile); tokenFile != "" {
+			tokenProvider = common.FileBasedStringSupplier(tokenFile, "")
+		}
+		vaultBackend := NewVaultSecretBackend(address, tokenProvider, repository.NewFileBasedScopesRepository())
+		vaultBackend.MountPath = common.EnvBasedStringSupplier(envVarVaultMountPath, defaultVaultMountPath)()
+		vaultBackend.PathPrefix = common.EnvBasedStringSupplier(envVarVaultPathPrefix, defaultVaultPathPrefix)()
+		vaultBackend.Namespace = os.Getenv(envVarVaultNamespace)
+		vaultBackend.MaxVersions = getMaxSecretVersions()
+		return vaultBackend
+	})
+}
@@ -0,0 +1,67 @@
+package backend_test
+
+import (
+	"testing"
+
+	"github.com/keptn/keptn/secret-service/pkg/backend"
+	"github.com/keptn/keptn/secret-service/pkg/backend/backendtest"
+	"github.com/keptn/keptn/secret-service/pkg/model"
+	"github.com/keptn/keptn/secret-service/pkg/repository/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestVaultSecretBackend(vault *backendtest.VaultServer, token string) *backend.VaultSecretBackend {
+	scopesRepository := &fake.ScopesRepositoryMock{}
+	scopesRepository.ReadFunc = func() (model.Scopes, error) { return backendtest.ConformanceScopes(), nil }
+	return backend.NewVaultSecretBackend(vault.URL, func() string { return token }, scopesRepository)
+}
+
+func TestVaultSecretBackend_CreateSecret(t *testing.T) {
+	vault := backendtest.NewVaultServer(t, "my-token")
+	vault.Namespace = "my-namespace"
+
+	vaultBackend := newTestVaultSecretBackend(vault, "my-token")
+	vaultBackend.Namespace = "my-namespace"
+	vaultBackend.PathPrefix = "my/prefix"
+
+	err := vaultBackend.CreateSecret(newVaultTestSecret("my-secret", "my-scope"))
+	require.Nil(t, err)
+
+	require.Contains(t, vault.Secrets, "my/prefix/my-secret")
+	stored := vault.Secrets["my/prefix/my-secret"]
+	assert.Equal(t, map[string]string{"password": "keptn"}, stored.Data())
+	assert.Equal(t, map[string]string{"keptn-scope": "my-scope"}, stored.CustomMetadata)
+}
+
+func TestVaultSecretBackend_InvalidToken(t *testing.T) {
+	vault := backendtest.NewVaultServer(t, "my-token")
+	vaultBackend := newTestVaultSecretBackend(vault, "invalid-token")
+
+	err := vaultBackend.CreateSecret(newVaultTestSecret("my-secret", "my-scope"))
+	assert.NotNil(t, err)
+
+	secrets, err := vaultBackend.GetSecrets(model.Secret{})
+	assert.NotNil(t, err)
+	assert.Nil(t, secrets)
+}
+
+func TestVaultSecretBackend_IgnoresNestedPaths(t *testing.T) {
+	vault := backendtest.NewVaultServer(t, "my-token")
+	vault.AddSecret("keptn/nested/other-secret", map[string]string{"key": "value"}, nil)
+
+	vaultBackend := newTestVaultSecretBackend(vault, "my-token")
+	require.Nil(t, vaultBackend.CreateSecret(newVaultTestSecret("my-secret", "my-scope")))
+
+	secrets, err := vaultBackend.GetSecrets(model.Secret{})
+	require.Nil(t, err)
+	require.Len(t, secrets, 1)
+	assert.Equal(t, "my-secret", secrets[0].Name)
+}
+
+func newVaultTestSecret(name, scope string) model.Secret {
+	return model.Secret{
+		SecretMetadata: model.SecretMetadata{Name: name, Scope: scope},
+		Data:           map[string]string{"password": "keptn"},
+	}
+}
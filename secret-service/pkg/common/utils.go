@@ -1,6 +1,9 @@
 package common
 
-import "os"
+import (
+	"os"
+	"strings"
+)
 
 type StringSupplier func() string
 
@@ -13,3 +16,16 @@ func EnvBasedStringSupplier(envVarName, defaultVal string) StringSupplier {
 		return defaultVal
 	}
 }
+
+// FileBasedStringSupplier reads the value from the given file each time it is called, so that
+// changes to the file (e.g. a rotated token mounted from a secret) are picked up. If the file cannot
+// be read, the default value is returned
+func FileBasedStringSupplier(fileName, defaultVal string) StringSupplier {
+	return func() string {
+		content, err := os.ReadFile(fileName)
+		if err != nil {
+			return defaultVal
+		}
+		return strings.TrimSpace(string(content))
+	}
+}
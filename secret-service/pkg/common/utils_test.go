@@ -1,6 +1,8 @@
 package common
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,3 +16,15 @@ func Test_EnvBasedStringSupplier(t *testing.T) {
 	val = EnvBasedStringSupplier("THIS_ENV_VAR_IS_NOT_PRESENT", "DEFAULT_VAL")()
 	assert.Equal(t, "DEFAULT_VAL", val)
 }
+
+func Test_FileBasedStringSupplier(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "token")
+	err := os.WriteFile(fileName, []byte("my-token\n"), 0600)
+	assert.Nil(t, err)
+
+	val := FileBasedStringSupplier(fileName, "")()
+	assert.Equal(t, "my-token", val)
+
+	val = FileBasedStringSupplier(filepath.Join(t.TempDir(), "missing"), "DEFAULT_VAL")()
+	assert.Equal(t, "DEFAULT_VAL", val)
+}
@@ -4,27 +4,67 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/secret-service/pkg/audit"
 	"github.com/keptn/keptn/secret-service/pkg/backend"
 	"github.com/keptn/keptn/secret-service/pkg/model"
+	log "github.com/sirupsen/logrus"
 )
 
+// PrincipalHeader contains the principal of the API request, as set by the API gateway after authenticating the request
+const PrincipalHeader = "X-Keptn-Principal"
+
+const unknownActor = "unknown"
+
 type ISecretHandler interface {
 	CreateSecret(c *gin.Context)
 	UpdateSecret(c *gin.Context)
 	DeleteSecret(c *gin.Context)
 	GetSecrets(c *gin.Context)
+	GetSecretVersions(c *gin.Context)
+	RollbackSecret(c *gin.Context)
+	GetSecretRotation(c *gin.Context)
+	GetAuditRecords(c *gin.Context)
 }
 
-func NewSecretHandler(backend backend.SecretManager) *SecretHandler {
+func NewSecretHandler(backend backend.SecretManager, auditSink audit.Sink) *SecretHandler {
 	return &SecretHandler{
 		SecretManager: backend,
+		AuditSink:     auditSink,
 	}
 }
 
 type SecretHandler struct {
 	SecretManager backend.SecretManager
+	AuditSink     audit.Sink
+}
+
+// audit writes an audit record for an operation on a secret. Failing to write the record does not fail the request,
+// since the operation has already been performed at this point
+func (s SecretHandler) audit(c *gin.Context, operation model.AuditOperation, secret model.SecretMetadata, err error) {
+	actor := c.GetHeader(PrincipalHeader)
+	if actor == "" {
+		actor = unknownActor
+	}
+	record := model.AuditRecord{
+		Time:      time.Now().UTC(),
+		Actor:     actor,
+		Operation: operation,
+		Name:      secret.Name,
+		Scope:     secret.Scope,
+		Project:   secret.Project,
+		Stage:     secret.Stage,
+		Outcome:   model.AuditOutcomeSuccess,
+	}
+	if err != nil {
+		record.Outcome = model.AuditOutcomeFailure
+		record.Error = err.Error()
+	}
+	if err := s.AuditSink.Write(record); err != nil {
+		log.Errorf("Unable to write audit record for %s of secret %s: %v", operation, secret.Name, err)
+	}
 }
 
 // CreateSecret godoc
@@ -53,12 +93,13 @@ func (s SecretHandler) CreateSecret(c *gin.Context) {
 	}
 
 	err := s.SecretManager.CreateSecret(secret)
+	s.audit(c, model.AuditOperationCreate, secret.SecretMetadata, err)
 	if err != nil {
 		if errors.Is(err, backend.ErrSecretAlreadyExists) {
 			SetConflictErrorResponse(c, fmt.Sprintf(ErrCreateSecretMsg, err.Error()))
 			return
 		}
-		if errors.Is(err, backend.ErrTooBigKeySize) || errors.Is(err, backend.ErrScopeNotFound) {
+		if errors.Is(err, backend.ErrTooBigKeySize) || errors.Is(err, backend.ErrScopeNotFound) || errors.Is(err, backend.ErrInvalidSecretName) || errors.Is(err, backend.ErrStageWithoutProject) {
 			SetBadRequestErrorResponse(c, fmt.Sprintf(ErrCreateSecretMsg, err.Error()))
 			return
 		}
@@ -91,12 +132,13 @@ func (s SecretHandler) UpdateSecret(c *gin.Context) {
 	}
 
 	err := s.SecretManager.UpdateSecret(secret)
+	s.audit(c, model.AuditOperationUpdate, secret.SecretMetadata, err)
 	if err != nil {
 		if errors.Is(err, backend.ErrSecretNotFound) {
 			SetNotFoundErrorResponse(c, fmt.Sprintf(ErrUpdateSecretMsg, err.Error()))
 			return
 		}
-		if errors.Is(err, backend.ErrScopeNotFound) {
+		if errors.Is(err, backend.ErrTooBigKeySize) || errors.Is(err, backend.ErrScopeNotFound) || errors.Is(err, backend.ErrInvalidSecretName) || errors.Is(err, backend.ErrStageWithoutProject) {
 			SetBadRequestErrorResponse(c, fmt.Sprintf(ErrUpdateSecretMsg, err.Error()))
 			return
 		}
@@ -135,6 +177,7 @@ func (s SecretHandler) DeleteSecret(c *gin.Context) {
 		Data: nil,
 	}
 	err := s.SecretManager.DeleteSecret(secret)
+	s.audit(c, model.AuditOperationDelete, secret.SecretMetadata, err)
 	if err != nil {
 		if errors.Is(err, backend.ErrSecretNotFound) {
 			SetNotFoundErrorResponse(c, fmt.Sprintf(ErrDeleteSecretMsg, err.Error()))
@@ -158,9 +201,12 @@ func (s SecretHandler) DeleteSecret(c *gin.Context) {
 // @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}secrets:read</span>
 // @Tags         Secrets
 // @Security     ApiKeyAuth
-// @Param        name   query  string  false  "The name of the secret"
-// @Param        scope  query  string  false  "The scope of the secret"
+// @Param        name     query  string  false  "The name of the secret"
+// @Param        scope    query  string  false  "The scope of the secret"
+// @Param        project  query  string  false  "The project the secrets are bound to"
+// @Param        stage    query  string  false  "The stage the secrets are bound to"
 // @Success      200  {object}  model.GetSecretsResponse  "OK"
+// @Failure      400  {object}  model.Error               "Invalid payload"
 // @Failure      500  {object}  model.Error               "Internal Server Error"
 // @Router       /secret [get]
 func (s SecretHandler) GetSecrets(c *gin.Context) {
@@ -169,10 +215,16 @@ func (s SecretHandler) GetSecrets(c *gin.Context) {
 		SetBadRequestErrorResponse(c, fmt.Sprintf(ErrInvalidRequestFormatMsg, err.Error()))
 		return
 	}
+	if params.Stage != "" && params.Project == "" {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(ErrInvalidRequestFormatMsg, backend.ErrStageWithoutProject.Error()))
+		return
+	}
 	secret := model.Secret{
 		SecretMetadata: model.SecretMetadata{
-			Name:  params.Name,
-			Scope: params.Scope,
+			Name:    params.Name,
+			Scope:   params.Scope,
+			Project: params.Project,
+			Stage:   params.Stage,
 		},
 		Data: nil,
 	}
@@ -185,3 +237,189 @@ func (s SecretHandler) GetSecrets(c *gin.Context) {
 	c.Status(http.StatusOK)
 	c.JSON(http.StatusOK, model.GetSecretsResponse{Secrets: secrets})
 }
+
+// GetSecretVersions godoc
+// @Summary      Get secret versions
+// @Description  Get the versions of a secret that are retained by the secret backend
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}secrets:read</span>
+// @Tags         Secrets
+// @Security     ApiKeyAuth
+// @Param        name   query     string                           true  "The name of the secret"
+// @Param        scope  query     string                           true  "The scope of the secret"
+// @Success      200    {object}  model.GetSecretVersionsResponse  "OK"
+// @Failure      400    {object}  model.Error                      "Invalid payload"
+// @Failure      404    {object}  model.Error                      "Not Found"
+// @Failure      500    {object}  model.Error                      "Internal Server Error"
+// @Router       /secret/versions [get]
+func (s SecretHandler) GetSecretVersions(c *gin.Context) {
+	params := &model.GetSecretVersionsQueryParams{}
+	if err := c.ShouldBindQuery(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(ErrInvalidRequestFormatMsg, err.Error()))
+		return
+	}
+
+	secret := model.Secret{
+		SecretMetadata: model.SecretMetadata{
+			Name:  params.Name,
+			Scope: params.Scope,
+		},
+	}
+	versions, err := s.SecretManager.GetSecretVersions(secret)
+	if err != nil {
+		if errors.Is(err, backend.ErrSecretNotFound) {
+			SetNotFoundErrorResponse(c, fmt.Sprintf(ErrGetSecretVersionsMsg, err.Error()))
+			return
+		}
+		SetInternalServerErrorResponse(c, fmt.Sprintf(ErrGetSecretVersionsMsg, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.GetSecretVersionsResponse{Versions: versions})
+}
+
+// RollbackSecret godoc
+// @Summary      Roll back a Secret
+// @Description  Roll back a Secret to a previous version. The data of that version is stored as a new version of the secret
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}secrets:write</span>
+// @Tags         Secrets
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Param        rollback  body  model.RollbackSecretRequest  true  "The secret and the version to roll back to"
+// @Success      200       "OK"
+// @Failure      400       {object}  model.Error  "Invalid payload"
+// @Failure      404       {object}  model.Error  "Not Found"
+// @Failure      500       {object}  model.Error  "Internal Server Error"
+// @Router       /secret/rollback [post]
+func (s SecretHandler) RollbackSecret(c *gin.Context) {
+	request := model.RollbackSecretRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(ErrInvalidRequestFormatMsg, err.Error()))
+		return
+	}
+
+	secret := model.Secret{
+		SecretMetadata: model.SecretMetadata{
+			Name:  request.Name,
+			Scope: request.Scope,
+		},
+	}
+	err := s.SecretManager.RollbackSecret(secret, request.Version)
+	s.audit(c, model.AuditOperationRollback, secret.SecretMetadata, err)
+	if err != nil {
+		if errors.Is(err, backend.ErrSecretNotFound) || errors.Is(err, backend.ErrSecretVersionNotFound) {
+			SetNotFoundErrorResponse(c, fmt.Sprintf(ErrRollbackSecretMsg, err.Error()))
+			return
+		}
+		if errors.Is(err, backend.ErrScopeNotFound) {
+			SetBadRequestErrorResponse(c, fmt.Sprintf(ErrRollbackSecretMsg, err.Error()))
+			return
+		}
+		SetInternalServerErrorResponse(c, fmt.Sprintf(ErrRollbackSecretMsg, err.Error()))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// GetSecretRotation godoc
+// @Summary      Get secrets that are expired or due for rotation
+// @Description  Get all secrets whose expiresAt or rotateAfter timestamp has passed
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}secrets:read</span>
+// @Tags         Secrets
+// @Security     ApiKeyAuth
+// @Param        scope      query     string                           false  "The scope of the secrets"
+// @Param        dueWithin  query     string                           false  "Also include secrets that expire or are due for rotation within this duration, e.g. 168h"
+// @Success      200        {object}  model.GetSecretRotationResponse  "OK"
+// @Failure      400        {object}  model.Error                      "Invalid payload"
+// @Failure      500        {object}  model.Error                      "Internal Server Error"
+// @Router       /secret/rotation [get]
+func (s SecretHandler) GetSecretRotation(c *gin.Context) {
+	params := &model.GetSecretRotationQueryParams{}
+	if err := c.ShouldBindQuery(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(ErrInvalidRequestFormatMsg, err.Error()))
+		return
+	}
+	dueWithin := time.Duration(0)
+	if params.DueWithin != "" {
+		duration, err := time.ParseDuration(params.DueWithin)
+		if err != nil || duration < 0 {
+			SetBadRequestErrorResponse(c, fmt.Sprintf(ErrInvalidRequestFormatMsg, "invalid value for dueWithin: "+params.DueWithin))
+			return
+		}
+		dueWithin = duration
+	}
+
+	secrets, err := s.SecretManager.GetSecrets(model.Secret{SecretMetadata: model.SecretMetadata{Scope: params.Scope}})
+	if err != nil {
+		SetInternalServerErrorResponse(c, fmt.Sprintf(ErrGetSecretMsg, err.Error()))
+		return
+	}
+
+	deadline := time.Now().Add(dueWithin)
+	result := []model.SecretRotationStatus{}
+	for _, secret := range secrets {
+		status := model.SecretRotationStatus{
+			GetSecretResponseItem: secret,
+			Expired:               secret.ExpiresAt != nil && !secret.ExpiresAt.After(deadline),
+			RotationDue:           secret.RotateAfter != nil && !secret.RotateAfter.After(deadline),
+		}
+		if status.Expired || status.RotationDue {
+			result = append(result, status)
+		}
+	}
+
+	c.JSON(http.StatusOK, model.GetSecretRotationResponse{Secrets: result})
+}
+
+// GetAuditRecords godoc
+// @Summary      Get the audit log of secrets
+// @Description  Get the records of the operations performed on secrets, starting with the most recent one. The records never contain the data of the secrets
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}secrets:read</span>
+// @Tags         Secrets
+// @Security     ApiKeyAuth
+// @Param        name   query     string                         false  "The name of the secret"
+// @Param        scope  query     string                         false  "The scope of the secret"
+// @Param        from   query     string                         false  "Only include records created at or after this time (RFC 3339)"
+// @Param        to     query     string                         false  "Only include records created at or before this time (RFC 3339)"
+// @Param        limit  query     int                            false  "The maximum number of records (default 100)"
+// @Success      200    {object}  model.GetAuditRecordsResponse  "OK"
+// @Failure      400    {object}  model.Error                    "Invalid payload"
+// @Failure      500    {object}  model.Error                    "Internal Server Error"
+// @Router       /secret/audit [get]
+func (s SecretHandler) GetAuditRecords(c *gin.Context) {
+	params := &model.GetAuditRecordsQueryParams{}
+	if err := c.ShouldBindQuery(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(ErrInvalidRequestFormatMsg, err.Error()))
+		return
+	}
+
+	filter := model.AuditRecordFilter{
+		Name:  params.Name,
+		Scope: params.Scope,
+		Limit: params.Limit,
+	}
+	if params.From != "" {
+		from, err := time.Parse(time.RFC3339, params.From)
+		if err != nil {
+			SetBadRequestErrorResponse(c, fmt.Sprintf(ErrInvalidRequestFormatMsg, "invalid value for from: "+params.From))
+			return
+		}
+		filter.From = &from
+	}
+	if params.To != "" {
+		to, err := time.Parse(time.RFC3339, params.To)
+		if err != nil {
+			SetBadRequestErrorResponse(c, fmt.Sprintf(ErrInvalidRequestFormatMsg, "invalid value for to: "+params.To))
+			return
+		}
+		filter.To = &to
+	}
+
+	records, err := s.AuditSink.Read(filter)
+	if err != nil {
+		SetInternalServerErrorResponse(c, fmt.Sprintf(ErrGetAuditRecordsMsg, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.GetAuditRecordsResponse{Records: records})
+}
@@ -2,13 +2,16 @@ package handler_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	auditfake "github.com/keptn/keptn/secret-service/pkg/audit/fake"
 	"github.com/keptn/keptn/secret-service/pkg/backend"
 	"github.com/keptn/keptn/secret-service/pkg/backend/fake"
 	"github.com/keptn/keptn/secret-service/pkg/handler"
@@ -16,9 +19,15 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func newAuditSinkMock() *auditfake.SinkMock {
+	return &auditfake.SinkMock{
+		WriteFunc: func(record model.AuditRecord) error { return nil },
+	}
+}
+
 func Test_CreateNewHandler(t *testing.T) {
 	secretsBackend := fake.SecretBackendMock{}
-	secretsHandler := handler.NewSecretHandler(&secretsBackend)
+	secretsHandler := handler.NewSecretHandler(&secretsBackend, newAuditSinkMock())
 	assert.NotNil(t, secretsHandler)
 }
 
@@ -84,6 +93,26 @@ func TestHandler_CreateSecret(t *testing.T) {
 			request:            httptest.NewRequest("POST", "/secret", bytes.NewBuffer([]byte(`{"verylongname":"my-secret","scope":"my-scope","data":{"username":"keptn"}}`))),
 			expectedHTTPStatus: http.StatusBadRequest,
 		},
+		{
+			name: "POST Create Secret - invalid name",
+			fields: fields{
+				Backend: &fake.SecretBackendMock{
+					CreateSecretFunc: func(secret model.Secret) error { return backend.ErrInvalidSecretName },
+				},
+			},
+			request:            httptest.NewRequest("POST", "/secret", bytes.NewBuffer([]byte(`{"name":"my/secret","scope":"my-scope","data":{"username":"keptn"}}`))),
+			expectedHTTPStatus: http.StatusBadRequest,
+		},
+		{
+			name: "POST Create Secret - stage without project",
+			fields: fields{
+				Backend: &fake.SecretBackendMock{
+					CreateSecretFunc: func(secret model.Secret) error { return backend.ErrStageWithoutProject },
+				},
+			},
+			request:            httptest.NewRequest("POST", "/secret", bytes.NewBuffer([]byte(`{"name":"my-secret","scope":"my-scope","stage":"dev","data":{"username":"keptn"}}`))),
+			expectedHTTPStatus: http.StatusBadRequest,
+		},
 		{
 			name: "POST Create Secret - Input INVALID",
 			fields: fields{
@@ -119,7 +148,7 @@ func TestHandler_CreateSecret(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
-			secretsHandler := handler.NewSecretHandler(tt.fields.Backend)
+			secretsHandler := handler.NewSecretHandler(tt.fields.Backend, newAuditSinkMock())
 			handler := func(w http.ResponseWriter, r *http.Request) {
 				c, _ := gin.CreateTestContext(w)
 				c.Request = r
@@ -213,7 +242,7 @@ func TestHandler_DeleteSecret(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
-			secretsHandler := handler.NewSecretHandler(tt.fields.Backend)
+			secretsHandler := handler.NewSecretHandler(tt.fields.Backend, newAuditSinkMock())
 			handler := func(w http.ResponseWriter, r *http.Request) {
 				c, _ := gin.CreateTestContext(w)
 				c.Request = r
@@ -307,7 +336,7 @@ func TestHandler_Update(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
-			secretsHandler := handler.NewSecretHandler(tt.fields.Backend)
+			secretsHandler := handler.NewSecretHandler(tt.fields.Backend, newAuditSinkMock())
 			handler := func(w http.ResponseWriter, r *http.Request) {
 				c, _ := gin.CreateTestContext(w)
 				c.Request = r
@@ -347,6 +376,14 @@ func TestHandler_GetSecrets(t *testing.T) {
 			request:            httptest.NewRequest("GET", "/secret", nil),
 			expectedHTTPStatus: http.StatusOK,
 		},
+		{
+			name: "GET Secret - stage without project",
+			fields: fields{
+				Backend: &fake.SecretBackendMock{},
+			},
+			request:            httptest.NewRequest("GET", "/secret?stage=dev", nil),
+			expectedHTTPStatus: http.StatusBadRequest,
+		},
 		{
 			name: "GET Secret - Backend some error",
 			fields: fields{
@@ -363,7 +400,7 @@ func TestHandler_GetSecrets(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
-			secretsHandler := handler.NewSecretHandler(tt.fields.Backend)
+			secretsHandler := handler.NewSecretHandler(tt.fields.Backend, newAuditSinkMock())
 			handler := func(w http.ResponseWriter, r *http.Request) {
 				c, _ := gin.CreateTestContext(w)
 				c.Request = r
@@ -379,3 +416,374 @@ func TestHandler_GetSecrets(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_GetSecretVersions(t *testing.T) {
+	type fields struct {
+		Backend backend.SecretBackend
+	}
+
+	tests := []struct {
+		name               string
+		fields             fields
+		expectedHTTPStatus int
+		request            *http.Request
+	}{
+		{
+			name: "GET Secret Versions - SUCCESS",
+			fields: fields{
+				Backend: &fake.SecretBackendMock{
+					GetSecretVersionsFunc: func(secret model.Secret) ([]model.SecretVersion, error) {
+						return []model.SecretVersion{{Version: 1, Keys: []string{"username"}, Current: true}}, nil
+					},
+				},
+			},
+			request:            httptest.NewRequest("GET", "/secret/versions?name=my-secret&scope=my-scope", nil),
+			expectedHTTPStatus: http.StatusOK,
+		},
+		{
+			name: "GET Secret Versions - missing scope",
+			fields: fields{
+				Backend: &fake.SecretBackendMock{},
+			},
+			request:            httptest.NewRequest("GET", "/secret/versions?name=my-secret", nil),
+			expectedHTTPStatus: http.StatusBadRequest,
+		},
+		{
+			name: "GET Secret Versions - secret not found",
+			fields: fields{
+				Backend: &fake.SecretBackendMock{
+					GetSecretVersionsFunc: func(secret model.Secret) ([]model.SecretVersion, error) {
+						return nil, backend.ErrSecretNotFound
+					},
+				},
+			},
+			request:            httptest.NewRequest("GET", "/secret/versions?name=my-secret&scope=my-scope", nil),
+			expectedHTTPStatus: http.StatusNotFound,
+		},
+		{
+			name: "GET Secret Versions - Backend some error",
+			fields: fields{
+				Backend: &fake.SecretBackendMock{
+					GetSecretVersionsFunc: func(secret model.Secret) ([]model.SecretVersion, error) {
+						return nil, errors.New("oops")
+					},
+				},
+			},
+			request:            httptest.NewRequest("GET", "/secret/versions?name=my-secret&scope=my-scope", nil),
+			expectedHTTPStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			secretsHandler := handler.NewSecretHandler(tt.fields.Backend, newAuditSinkMock())
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				c, _ := gin.CreateTestContext(w)
+				c.Request = r
+				secretsHandler.GetSecretVersions(c)
+			}
+
+			w := httptest.NewRecorder()
+			handler(w, tt.request)
+
+			resp := w.Result()
+			assert.Equal(t, tt.expectedHTTPStatus, resp.StatusCode)
+
+		})
+	}
+}
+
+func TestHandler_RollbackSecret(t *testing.T) {
+	type fields struct {
+		Backend backend.SecretBackend
+	}
+
+	tests := []struct {
+		name               string
+		fields             fields
+		expectedHTTPStatus int
+		request            *http.Request
+	}{
+		{
+			name: "POST Rollback Secret - SUCCESS",
+			fields: fields{
+				Backend: &fake.SecretBackendMock{
+					RollbackSecretFunc: func(secret model.Secret, version int) error { return nil },
+				},
+			},
+			request:            httptest.NewRequest("POST", "/secret/rollback", bytes.NewBuffer([]byte(`{"name":"my-secret","scope":"my-scope","version":1}`))),
+			expectedHTTPStatus: http.StatusOK,
+		},
+		{
+			name: "POST Rollback Secret - missing version",
+			fields: fields{
+				Backend: &fake.SecretBackendMock{},
+			},
+			request:            httptest.NewRequest("POST", "/secret/rollback", bytes.NewBuffer([]byte(`{"name":"my-secret","scope":"my-scope"}`))),
+			expectedHTTPStatus: http.StatusBadRequest,
+		},
+		{
+			name: "POST Rollback Secret - version not found",
+			fields: fields{
+				Backend: &fake.SecretBackendMock{
+					RollbackSecretFunc: func(secret model.Secret, version int) error { return backend.ErrSecretVersionNotFound },
+				},
+			},
+			request:            httptest.NewRequest("POST", "/secret/rollback", bytes.NewBuffer([]byte(`{"name":"my-secret","scope":"my-scope","version":1}`))),
+			expectedHTTPStatus: http.StatusNotFound,
+		},
+		{
+			name: "POST Rollback Secret - not existing scope",
+			fields: fields{
+				Backend: &fake.SecretBackendMock{
+					RollbackSecretFunc: func(secret model.Secret, version int) error { return backend.ErrScopeNotFound },
+				},
+			},
+			request:            httptest.NewRequest("POST", "/secret/rollback", bytes.NewBuffer([]byte(`{"name":"my-secret","scope":"my-other-scope","version":1}`))),
+			expectedHTTPStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			secretsHandler := handler.NewSecretHandler(tt.fields.Backend, newAuditSinkMock())
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				c, _ := gin.CreateTestContext(w)
+				c.Request = r
+				secretsHandler.RollbackSecret(c)
+			}
+
+			w := httptest.NewRecorder()
+			handler(w, tt.request)
+
+			resp := w.Result()
+			assert.Equal(t, tt.expectedHTTPStatus, resp.StatusCode)
+
+		})
+	}
+}
+
+func TestHandler_GetSecretRotation(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	soon := time.Now().Add(time.Hour)
+	later := time.Now().Add(24 * time.Hour)
+	secretsBackend := &fake.SecretBackendMock{
+		GetSecretsFunc: func(secret model.Secret) ([]model.GetSecretResponseItem, error) {
+			return []model.GetSecretResponseItem{
+				{SecretMetadata: model.SecretMetadata{Name: "expired", ExpiresAt: &past}},
+				{SecretMetadata: model.SecretMetadata{Name: "rotation-due", RotateAfter: &past, ExpiresAt: &later}},
+				{SecretMetadata: model.SecretMetadata{Name: "rotation-due-soon", RotateAfter: &soon}},
+				{SecretMetadata: model.SecretMetadata{Name: "no-metadata"}},
+			}, nil
+		},
+	}
+
+	tests := []struct {
+		name               string
+		request            *http.Request
+		expectedHTTPStatus int
+		expectedSecrets    []model.SecretRotationStatus
+	}{
+		{
+			name:               "GET Secret Rotation - SUCCESS",
+			request:            httptest.NewRequest("GET", "/secret/rotation", nil),
+			expectedHTTPStatus: http.StatusOK,
+			expectedSecrets:    []model.SecretRotationStatus{{Expired: true}, {RotationDue: true}},
+		},
+		{
+			name:               "GET Secret Rotation - due within",
+			request:            httptest.NewRequest("GET", "/secret/rotation?dueWithin=2h", nil),
+			expectedHTTPStatus: http.StatusOK,
+			expectedSecrets:    []model.SecretRotationStatus{{Expired: true}, {RotationDue: true}, {RotationDue: true}},
+		},
+		{
+			name:               "GET Secret Rotation - invalid duration",
+			request:            httptest.NewRequest("GET", "/secret/rotation?dueWithin=soon", nil),
+			expectedHTTPStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			secretsHandler := handler.NewSecretHandler(secretsBackend, newAuditSinkMock())
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				c, _ := gin.CreateTestContext(w)
+				c.Request = r
+				secretsHandler.GetSecretRotation(c)
+			}
+
+			w := httptest.NewRecorder()
+			handler(w, tt.request)
+
+			resp := w.Result()
+			assert.Equal(t, tt.expectedHTTPStatus, resp.StatusCode)
+			if tt.expectedSecrets == nil {
+				return
+			}
+
+			response := model.GetSecretRotationResponse{}
+			assert.Nil(t, json.NewDecoder(resp.Body).Decode(&response))
+			assert.Len(t, response.Secrets, len(tt.expectedSecrets))
+			for i := range response.Secrets {
+				assert.Equal(t, tt.expectedSecrets[i].Expired, response.Secrets[i].Expired)
+				assert.Equal(t, tt.expectedSecrets[i].RotationDue, response.Secrets[i].RotationDue)
+			}
+		})
+	}
+}
+
+func TestHandler_AuditRecords(t *testing.T) {
+	tests := []struct {
+		name            string
+		backend         backend.SecretBackend
+		request         *http.Request
+		operation       func(secretsHandler *handler.SecretHandler, c *gin.Context)
+		expectedRecord  model.AuditRecord
+		expectedFailure bool
+	}{
+		{
+			name: "create secret",
+			backend: &fake.SecretBackendMock{
+				CreateSecretFunc: func(secret model.Secret) error { return nil },
+			},
+			request: httptest.NewRequest("POST", "/secret", bytes.NewBuffer([]byte(`{"name":"my-secret","scope":"my-scope","project":"my-project","data":{"username":"keptn"}}`))),
+			operation: func(secretsHandler *handler.SecretHandler, c *gin.Context) {
+				secretsHandler.CreateSecret(c)
+			},
+			expectedRecord: model.AuditRecord{Actor: "my-principal", Operation: model.AuditOperationCreate, Name: "my-secret", Scope: "my-scope", Project: "my-project", Outcome: model.AuditOutcomeSuccess},
+		},
+		{
+			name: "update secret fails",
+			backend: &fake.SecretBackendMock{
+				UpdateSecretFunc: func(secret model.Secret) error { return backend.ErrSecretNotFound },
+			},
+			request: httptest.NewRequest("PUT", "/secret", bytes.NewBuffer([]byte(`{"name":"my-secret","scope":"my-scope","data":{"username":"keptn"}}`))),
+			operation: func(secretsHandler *handler.SecretHandler, c *gin.Context) {
+				secretsHandler.UpdateSecret(c)
+			},
+			expectedRecord:  model.AuditRecord{Actor: "my-principal", Operation: model.AuditOperationUpdate, Name: "my-secret", Scope: "my-scope", Outcome: model.AuditOutcomeFailure},
+			expectedFailure: true,
+		},
+		{
+			name: "delete secret",
+			backend: &fake.SecretBackendMock{
+				DeleteSecretFunc: func(secret model.Secret) error { return nil },
+			},
+			request: httptest.NewRequest("DELETE", "/secret?name=my-secret&scope=my-scope", nil),
+			operation: func(secretsHandler *handler.SecretHandler, c *gin.Context) {
+				secretsHandler.DeleteSecret(c)
+			},
+			expectedRecord: model.AuditRecord{Actor: "my-principal", Operation: model.AuditOperationDelete, Name: "my-secret", Scope: "my-scope", Outcome: model.AuditOutcomeSuccess},
+		},
+		{
+			name: "roll back secret",
+			backend: &fake.SecretBackendMock{
+				RollbackSecretFunc: func(secret model.Secret, version int) error { return nil },
+			},
+			request: httptest.NewRequest("POST", "/secret/rollback", bytes.NewBuffer([]byte(`{"name":"my-secret","scope":"my-scope","version":1}`))),
+			operation: func(secretsHandler *handler.SecretHandler, c *gin.Context) {
+				secretsHandler.RollbackSecret(c)
+			},
+			expectedRecord: model.AuditRecord{Actor: "my-principal", Operation: model.AuditOperationRollback, Name: "my-secret", Scope: "my-scope", Outcome: model.AuditOutcomeSuccess},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditSink := newAuditSinkMock()
+			secretsHandler := handler.NewSecretHandler(tt.backend, auditSink)
+
+			tt.request.Header.Set(handler.PrincipalHeader, "my-principal")
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = tt.request
+			tt.operation(secretsHandler, c)
+
+			assert.Len(t, auditSink.WriteCalls(), 1)
+			record := auditSink.WriteCalls()[0].Record
+			assert.False(t, record.Time.IsZero())
+			assert.Equal(t, tt.expectedFailure, record.Error != "")
+			record.Time = time.Time{}
+			record.Error = ""
+			assert.Equal(t, tt.expectedRecord, record)
+		})
+	}
+}
+
+func TestHandler_AuditRecordWithoutPrincipal(t *testing.T) {
+	auditSink := newAuditSinkMock()
+	secretsHandler := handler.NewSecretHandler(&fake.SecretBackendMock{
+		CreateSecretFunc: func(secret model.Secret) error { return nil },
+	}, auditSink)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("POST", "/secret", bytes.NewBuffer([]byte(`{"name":"my-secret","scope":"my-scope","data":{"username":"keptn"}}`)))
+	secretsHandler.CreateSecret(c)
+
+	assert.Len(t, auditSink.WriteCalls(), 1)
+	assert.Equal(t, "unknown", auditSink.WriteCalls()[0].Record.Actor)
+}
+
+func TestHandler_GetAuditRecords(t *testing.T) {
+	from := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name               string
+		readErr            error
+		request            *http.Request
+		expectedHTTPStatus int
+		expectedFilter     *model.AuditRecordFilter
+	}{
+		{
+			name:               "GET Audit Records - SUCCESS",
+			request:            httptest.NewRequest("GET", "/secret/audit?name=my-secret&scope=my-scope&from=2022-01-01T00:00:00Z&to=2022-02-01T00:00:00Z&limit=10", nil),
+			expectedHTTPStatus: http.StatusOK,
+			expectedFilter:     &model.AuditRecordFilter{Name: "my-secret", Scope: "my-scope", From: &from, To: &to, Limit: 10},
+		},
+		{
+			name:               "GET Audit Records - no filter",
+			request:            httptest.NewRequest("GET", "/secret/audit", nil),
+			expectedHTTPStatus: http.StatusOK,
+			expectedFilter:     &model.AuditRecordFilter{},
+		},
+		{
+			name:               "GET Audit Records - invalid time range",
+			request:            httptest.NewRequest("GET", "/secret/audit?from=yesterday", nil),
+			expectedHTTPStatus: http.StatusBadRequest,
+		},
+		{
+			name:               "GET Audit Records - invalid limit",
+			request:            httptest.NewRequest("GET", "/secret/audit?limit=5000", nil),
+			expectedHTTPStatus: http.StatusBadRequest,
+		},
+		{
+			name:               "GET Audit Records - sink error",
+			readErr:            errors.New("oops"),
+			request:            httptest.NewRequest("GET", "/secret/audit", nil),
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedFilter:     &model.AuditRecordFilter{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditSink := &auditfake.SinkMock{
+				ReadFunc: func(filter model.AuditRecordFilter) ([]model.AuditRecord, error) {
+					return []model.AuditRecord{}, tt.readErr
+				},
+			}
+			secretsHandler := handler.NewSecretHandler(&fake.SecretBackendMock{}, auditSink)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = tt.request
+			secretsHandler.GetAuditRecords(c)
+
+			assert.Equal(t, tt.expectedHTTPStatus, w.Result().StatusCode)
+			if tt.expectedFilter == nil {
+				assert.Empty(t, auditSink.ReadCalls())
+				return
+			}
+			assert.Len(t, auditSink.ReadCalls(), 1)
+			assert.Equal(t, *tt.expectedFilter, auditSink.ReadCalls()[0].Filter)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package model
+
+import "time"
+
+type AuditOperation string
+
+const (
+	AuditOperationCreate   AuditOperation = "create"
+	AuditOperationUpdate   AuditOperation = "update"
+	AuditOperationDelete   AuditOperation = "delete"
+	AuditOperationRollback AuditOperation = "rollback"
+)
+
+type AuditOutcome string
+
+const (
+	AuditOutcomeSuccess AuditOutcome = "success"
+	AuditOutcomeFailure AuditOutcome = "failure"
+)
+
+// AuditRecord describes an operation that has been performed on a secret. It never contains the data of the secret
+type AuditRecord struct {
+	Time time.Time `json:"time" bson:"time"`
+	// Actor is the principal of the API request that performed the operation
+	Actor     string         `json:"actor" bson:"actor"`
+	Operation AuditOperation `json:"operation" bson:"operation"`
+	Name      string         `json:"name" bson:"name"`
+	Scope     string         `json:"scope" bson:"scope"`
+	Project   string         `json:"project,omitempty" bson:"project,omitempty"`
+	Stage     string         `json:"stage,omitempty" bson:"stage,omitempty"`
+	Outcome   AuditOutcome   `json:"outcome" bson:"outcome"`
+	// Error is the reason for a failed operation
+	Error string `json:"error,omitempty" bson:"error,omitempty"`
+}
+
+type GetAuditRecordsResponse struct {
+	Records []AuditRecord `json:"records"`
+}
+
+type GetAuditRecordsQueryParams struct {
+	Name  string `form:"name,omitempty"`
+	Scope string `form:"scope,omitempty"`
+	// From and To are RFC 3339 timestamps limiting the time range of the records
+	From  string `form:"from,omitempty"`
+	To    string `form:"to,omitempty"`
+	Limit int    `form:"limit,omitempty" binding:"omitempty,min=1,max=1000"`
+}
+
+// AuditRecordFilter selects the audit records returned by an audit sink
+type AuditRecordFilter struct {
+	Name  string
+	Scope string
+	From  *time.Time
+	To    *time.Time
+	// Limit is the maximum number of records, starting with the most recent one
+	Limit int
+}
+
+// Matches returns true if the record matches all criteria of the filter
+func (f AuditRecordFilter) Matches(record AuditRecord) bool {
+	if f.Name != "" && record.Name != f.Name {
+		return false
+	}
+	if f.Scope != "" && record.Scope != f.Scope {
+		return false
+	}
+	if f.From != nil && record.Time.Before(*f.From) {
+		return false
+	}
+	if f.To != nil && record.Time.After(*f.To) {
+		return false
+	}
+	return true
+}
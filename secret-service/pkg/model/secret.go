@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 const DefaultSecretScope = "keptn-default"
 
 // Secret secret
@@ -15,11 +17,21 @@ type SecretMetadata struct {
 	Name string `json:"name" binding:"required"`
 	// Scope determines the scope of the secret (default="keptn-default")
 	Scope string `json:"scope,omitempty"`
+	// Project binds the secret to a Keptn project. Secrets without a project are available to all projects
+	Project string `json:"project,omitempty"`
+	// Stage additionally binds the secret to a stage of its project
+	Stage string `json:"stage,omitempty"`
+	// ExpiresAt is the point in time after which the secret must not be used anymore
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// RotateAfter is the point in time after which the secret is due for rotation
+	RotateAfter *time.Time `json:"rotateAfter,omitempty"`
 }
 
 type GetSecretResponseItem struct {
 	SecretMetadata
 	Keys []string `json:"keys"`
+	// Version is the current version of the secret
+	Version int `json:"version,omitempty"`
 }
 
 type GetSecretsResponse struct {
@@ -27,11 +39,54 @@ type GetSecretsResponse struct {
 }
 
 type GetSecretQueryParams struct {
-	Name  string `form:"name,omitempty"`
-	Scope string `form:"scope,omitempty"`
+	Name    string `form:"name,omitempty"`
+	Scope   string `form:"scope,omitempty"`
+	Project string `form:"project,omitempty"`
+	Stage   string `form:"stage,omitempty"`
 }
 
 type DeleteSecretQueryParams struct {
 	Name  string `form:"name" binding:"required"`
 	Scope string `form:"scope" binding:"required"`
 }
+
+// SecretVersion describes a version of a secret that has been retained by the secret backend
+type SecretVersion struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	Keys      []string  `json:"keys"`
+	// Current is true for the version that is currently in use
+	Current bool `json:"current"`
+}
+
+type GetSecretVersionsResponse struct {
+	Versions []SecretVersion `json:"versions"`
+}
+
+type GetSecretVersionsQueryParams struct {
+	Name  string `form:"name" binding:"required"`
+	Scope string `form:"scope" binding:"required"`
+}
+
+type RollbackSecretRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Scope string `json:"scope" binding:"required"`
+	// Version is the version whose data becomes the new version of the secret
+	Version int `json:"version" binding:"required,min=1"`
+}
+
+type SecretRotationStatus struct {
+	GetSecretResponseItem
+	Expired     bool `json:"expired"`
+	RotationDue bool `json:"rotationDue"`
+}
+
+type GetSecretRotationResponse struct {
+	Secrets []SecretRotationStatus `json:"secrets"`
+}
+
+type GetSecretRotationQueryParams struct {
+	Scope string `form:"scope,omitempty"`
+	// DueWithin additionally includes secrets that expire or are due for rotation within the given duration, e.g. 168h
+	DueWithin string `form:"dueWithin,omitempty"`
+}
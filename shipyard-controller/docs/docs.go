@@ -30,7 +30,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get triggered events by their type",
+                "description": "get triggered events by their type. The project, stage and service parameters accept comma separated lists of values.\nIf a subscriptionID (together with the integrationID of the owning integration) is provided, the filter of that subscription is applied for every parameter that is not set explicitly.\nEach response carries an ETag identifying the set of open events. Long polling is enabled by passing this ETag in the If-None-Match header together with a waitTimeout: the request is then held until the set of open events changes, or answered with 304 after the timeout.",
                 "consumes": [
                     "application/json"
                 ],
@@ -57,21 +57,45 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Project",
+                        "description": "Project(s), comma separated",
                         "name": "project",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Stage",
+                        "description": "Stage(s), comma separated",
                         "name": "stage",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Service",
+                        "description": "Service(s), comma separated",
                         "name": "service",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the integration owning the subscription",
+                        "name": "integrationID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the subscription whose filter should be applied",
+                        "name": "subscriptionID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Seconds to wait for changes of the open events (max 30), if If-None-Match is set",
+                        "name": "waitTimeout",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the open events already known by the caller",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -81,6 +105,9 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.KeptnContextExtendedCE"
                         }
                     },
+                    "304": {
+                        "description": "Not modified"
+                    },
                     "400": {
                         "description": "Invalid payload",
                         "schema": {
@@ -30,7 +30,9 @@ const (
 type EventFilter struct {
 	Type         string
 	Stage        *string
+	Stages       []string
 	Service      *string
+	Services     []string
 	ID           *string
 	TriggeredID  *string
 	Source       *string
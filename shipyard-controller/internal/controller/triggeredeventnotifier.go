@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"sync"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// TriggeredEventNotifier informs waiting callers (e.g. long polling requests of remote execution planes) about newly stored .triggered events
+type TriggeredEventNotifier struct {
+	mutex    sync.Mutex
+	notifyCh chan struct{}
+}
+
+// NewTriggeredEventNotifier creates a new TriggeredEventNotifier
+func NewTriggeredEventNotifier() *TriggeredEventNotifier {
+	return &TriggeredEventNotifier{
+		notifyCh: make(chan struct{}),
+	}
+}
+
+// OnSequenceTaskEvent notifies all waiting callers if the given event is a .triggered event
+func (n *TriggeredEventNotifier) OnSequenceTaskEvent(event apimodels.KeptnContextExtendedCE) {
+	if event.Type == nil || !keptnv2.IsTriggeredEventType(*event.Type) {
+		return
+	}
+	n.Notify()
+}
+
+// Notify wakes up all callers currently waiting on the channel returned by Wait
+func (n *TriggeredEventNotifier) Notify() {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	close(n.notifyCh)
+	n.notifyCh = make(chan struct{})
+}
+
+// Wait returns a channel that is closed as soon as the next .triggered event has been stored
+func (n *TriggeredEventNotifier) Wait() <-chan struct{} {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return n.notifyCh
+}
@@ -0,0 +1,39 @@
+package controller_test
+
+import (
+	"testing"
+	"time"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/shipyard-controller/internal/common"
+	"github.com/keptn/keptn/shipyard-controller/internal/controller"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggeredEventNotifier(t *testing.T) {
+	notifier := controller.NewTriggeredEventNotifier()
+
+	waitCh := notifier.Wait()
+
+	notifier.OnSequenceTaskEvent(apimodels.KeptnContextExtendedCE{Type: common.Stringp("sh.keptn.event.test.started")})
+	select {
+	case <-waitCh:
+		t.Fatal("waiting callers must not be notified about .started events")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	notifier.OnSequenceTaskEvent(apimodels.KeptnContextExtendedCE{Type: common.Stringp("sh.keptn.event.test.triggered")})
+	select {
+	case <-waitCh:
+	case <-time.After(time.Second):
+		t.Fatal("waiting callers have not been notified about .triggered event")
+	}
+
+	// subsequent callers wait for the next event
+	select {
+	case <-notifier.Wait():
+		t.Fatal("notification must not be received before the next .triggered event")
+	default:
+	}
+	require.NotEqual(t, waitCh, notifier.Wait())
+}
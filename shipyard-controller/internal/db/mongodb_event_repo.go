@@ -396,9 +396,13 @@ func getSearchOptions(filter common.EventFilter) bson.M {
 	}
 	if filter.Stage != nil && *filter.Stage != "" {
 		searchOptions["data.stage"] = *filter.Stage
+	} else if len(filter.Stages) > 0 {
+		searchOptions["data.stage"] = bson.M{"$in": filter.Stages}
 	}
 	if filter.Service != nil && *filter.Service != "" {
 		searchOptions["data.service"] = *filter.Service
+	} else if len(filter.Services) > 0 {
+		searchOptions["data.service"] = bson.M{"$in": filter.Services}
 	}
 	if filter.ID != nil && *filter.ID != "" {
 		searchOptions["id"] = *filter.ID
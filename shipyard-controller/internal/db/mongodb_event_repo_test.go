@@ -177,6 +177,22 @@ func TestMongoDBEventsRepo_InsertAndRetrieve(t *testing.T) {
 		require.Equal(t, "my-keptn-context-1", event.Shkeptncontext)
 	}
 
+	// check if multi-valued stage and service filters work
+	eventTraceResult, err = repo.GetEvents(projectName, common.EventFilter{
+		KeptnContext: common.Stringp("my-keptn-context-1"),
+		Stages:       []string{"other-stage", stageName},
+		Services:     []string{serviceName, "other-service"},
+	})
+
+	require.Nil(t, err)
+	require.Len(t, eventTraceResult, 3*numberOfTasksPerTrace+2)
+
+	_, err = repo.GetEvents(projectName, common.EventFilter{
+		KeptnContext: common.Stringp("my-keptn-context-1"),
+		Stages:       []string{"other-stage"},
+	})
+	require.Equal(t, db.ErrNoEventFound, err)
+
 	// test event deletion
 	events, err := repo.GetEvents(projectName, common.EventFilter{
 		ID: common.Stringp("my-root-event-id-1"),
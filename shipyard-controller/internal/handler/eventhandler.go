@@ -1,11 +1,16 @@
 package handler
 
 import (
+	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"github.com/keptn/keptn/shipyard-controller/internal/common"
 	"github.com/keptn/keptn/shipyard-controller/internal/controller"
+	"github.com/keptn/keptn/shipyard-controller/internal/db"
+	"go.mongodb.org/mongo-driver/mongo"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -14,37 +19,54 @@ import (
 	"github.com/keptn/keptn/shipyard-controller/models"
 )
 
+// ErrMissingIntegrationID indicates that a subscription has been referenced without the ID of its integration
+var ErrMissingIntegrationID = errors.New("integrationID must be set if a subscriptionID is provided")
+
 type IEventHandler interface {
 	GetTriggeredEvents(context *gin.Context)
 	HandleEvent(context *gin.Context)
 }
 
+const defaultLongPollingRecheckInterval = 5 * time.Second
+
 type EventHandler struct {
-	ShipyardController controller.IShipyardController
+	ShipyardController     controller.IShipyardController
+	UniformRepo            db.UniformRepo
+	TriggeredEventNotifier *controller.TriggeredEventNotifier
+	// LongPollingRecheckInterval is the interval in which open events are re-evaluated for long polling requests
+	LongPollingRecheckInterval time.Duration
 }
 
 // GetTriggeredEvents godoc
 // @Summary      Get triggered events
-// @Description  get triggered events by their type
+// @Description  get triggered events by their type. The project, stage and service parameters accept comma separated lists of values.
+// @Description  If a subscriptionID (together with the integrationID of the owning integration) is provided, the filter of that subscription is applied for every parameter that is not set explicitly.
+// @Description  Each response carries an ETag identifying the set of open events. Long polling is enabled by passing this ETag in the If-None-Match header together with a waitTimeout: the request is then held until the set of open events changes, or answered with 304 after the timeout.
 // @Tags         Events
 // @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
-// @Param        eventType  path      string                            true   "Event type"
-// @Param        eventID    query     string                            false  "Event ID"
-// @Param        project    query     string                            false  "Project"
-// @Param        stage      query     string                            false  "Stage"
-// @Param        service    query     string                            false  "Service"
-// @Success      200        {object}  apimodels.KeptnContextExtendedCE  "ok"
-// @Failure      400        {object}  models.Error                      "Invalid payload"
-// @Failure      404        {object}  models.Error                      "Not found"
-// @Failure      500        {object}  models.Error                      "Internal error"
+// @Param        eventType       path      string                            true   "Event type"
+// @Param        eventID         query     string                            false  "Event ID"
+// @Param        project         query     string                            false  "Project(s), comma separated"
+// @Param        stage           query     string                            false  "Stage(s), comma separated"
+// @Param        service         query     string                            false  "Service(s), comma separated"
+// @Param        integrationID   query     string                            false  "ID of the integration owning the subscription"
+// @Param        subscriptionID  query     string                            false  "ID of the subscription whose filter should be applied"
+// @Param        waitTimeout     query     integer                           false  "Seconds to wait for changes of the open events (max 30), if If-None-Match is set"
+// @Param        If-None-Match   header    string                            false  "ETag of the open events already known by the caller"
+// @Success      200             {object}  apimodels.KeptnContextExtendedCE  "ok"
+// @Success      304             "Not modified"
+// @Failure      400             {object}  models.Error                      "Invalid payload"
+// @Failure      404             {object}  models.Error                      "Not found"
+// @Failure      500             {object}  models.Error                      "Internal error"
 // @Router       /event/triggered/{eventType} [get]
 func (eh *EventHandler) GetTriggeredEvents(c *gin.Context) {
 	eventType := c.Param("eventType")
 	params := &models.GetTriggeredEventsParams{}
 	if err := c.ShouldBindQuery(params); err != nil {
 		SetBadRequestErrorResponse(c, fmt.Sprintf(common.InvalidRequestFormatMsg, err.Error()))
+		return
 	}
 
 	params.EventType = eventType
@@ -56,23 +78,28 @@ func (eh *EventHandler) GetTriggeredEvents(c *gin.Context) {
 		Events:      []*apimodels.KeptnContextExtendedCE{},
 	}
 
-	var events []apimodels.KeptnContextExtendedCE
-	var err error
-
-	eventFilter := common.EventFilter{
-		Type:    params.EventType,
-		Stage:   params.Stage,
-		Service: params.Service,
-		ID:      params.EventID,
-		Time:    time.Now().UTC(),
+	subscriptionFilter, err := eh.getSubscriptionFilter(params)
+	if err != nil {
+		if errors.Is(err, ErrMissingIntegrationID) {
+			SetBadRequestErrorResponse(c, err.Error())
+			return
+		}
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			SetNotFoundErrorResponse(c, err.Error())
+			return
+		}
+		SetInternalServerErrorResponse(c, err.Error())
+		return
 	}
 
-	if params.Project != nil && *params.Project != "" {
-		events, err = eh.ShipyardController.GetTriggeredEventsOfProject(*params.Project, eventFilter)
-	} else {
-		events, err = eh.ShipyardController.GetAllTriggeredEvents(eventFilter)
+	eventFilter := common.EventFilter{
+		Type:     params.EventType,
+		Stages:   subscriptionFilter.Stages,
+		Services: subscriptionFilter.Services,
+		ID:       params.EventID,
 	}
 
+	events, err := eh.getTriggeredEvents(subscriptionFilter.Projects, eventFilter)
 	if err != nil {
 		if errors.Is(err, common.ErrProjectNotFound) {
 			SetNotFoundErrorResponse(c, err.Error())
@@ -82,6 +109,23 @@ func (eh *EventHandler) GetTriggeredEvents(c *gin.Context) {
 		return
 	}
 
+	etag := getTriggeredEventsETag(events)
+	if params.WaitTimeout != nil && *params.WaitTimeout > 0 && c.GetHeader("If-None-Match") == etag {
+		// the caller already knows the currently open events - wait until this changes or the timeout is reached
+		waitTimeout := time.Duration(*params.WaitTimeout) * time.Second
+		events, etag, err = eh.waitForTriggeredEvents(c.Request.Context(), waitTimeout, etag, subscriptionFilter.Projects, eventFilter)
+		if err != nil {
+			SetInternalServerErrorResponse(c, err.Error())
+			return
+		}
+		if events == nil {
+			c.Header("ETag", etag)
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+	c.Header("ETag", etag)
+
 	paginationInfo := common.Paginate(len(events), params.PageSize, params.NextPageKey)
 
 	totalCount := len(events)
@@ -121,9 +165,137 @@ func (eh *EventHandler) HandleEvent(c *gin.Context) {
 
 }
 
+func (eh *EventHandler) getTriggeredEvents(projects []string, filter common.EventFilter) ([]apimodels.KeptnContextExtendedCE, error) {
+	filter.Time = time.Now().UTC()
+	if len(projects) == 1 {
+		return eh.ShipyardController.GetTriggeredEventsOfProject(projects[0], filter)
+	} else if len(projects) > 1 {
+		return eh.getTriggeredEventsOfProjects(projects, filter)
+	}
+	return eh.ShipyardController.GetAllTriggeredEvents(filter)
+}
+
+// waitForTriggeredEvents blocks until the set of open triggered events differs from the one identified by the given etag.
+// The events are re-evaluated whenever a new .triggered event has been stored by this instance, and periodically to
+// catch events stored by other instances or events with a delayed trigger time.
+// If nothing changed until the timeout is reached, the returned events are nil
+func (eh *EventHandler) waitForTriggeredEvents(ctx context.Context, timeout time.Duration, etag string, projects []string, filter common.EventFilter) ([]apimodels.KeptnContextExtendedCE, string, error) {
+	recheckInterval := eh.LongPollingRecheckInterval
+	if recheckInterval <= 0 {
+		recheckInterval = defaultLongPollingRecheckInterval
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for {
+		// register for notifications before re-evaluating the events, to not miss any event stored in the meantime
+		var notifyCh <-chan struct{}
+		if eh.TriggeredEventNotifier != nil {
+			notifyCh = eh.TriggeredEventNotifier.Wait()
+		}
+
+		events, err := eh.getTriggeredEvents(projects, filter)
+		if err != nil && !errors.Is(err, common.ErrProjectNotFound) {
+			return nil, etag, err
+		}
+		if newETag := getTriggeredEventsETag(events); newETag != etag {
+			if events == nil {
+				events = []apimodels.KeptnContextExtendedCE{}
+			}
+			return events, newETag, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, etag, nil
+		case <-deadline.C:
+			return nil, etag, nil
+		case <-notifyCh:
+		case <-time.After(recheckInterval):
+		}
+	}
+}
+
+// getTriggeredEventsETag returns an entity tag identifying the given set of events
+func getTriggeredEventsETag(events []apimodels.KeptnContextExtendedCE) string {
+	hash := sha256.New()
+	for _, event := range events {
+		hash.Write([]byte(event.ID))
+		hash.Write([]byte{0})
+	}
+	return fmt.Sprintf(`"%x"`, hash.Sum(nil)[:16])
+}
+
+// getSubscriptionFilter determines the projects, stages and services the triggered events should be filtered by.
+// Explicitly passed query parameters take precedence over the filter of a referenced subscription.
+func (eh *EventHandler) getSubscriptionFilter(params *models.GetTriggeredEventsParams) (*apimodels.EventSubscriptionFilter, error) {
+	filter := &apimodels.EventSubscriptionFilter{
+		Projects: splitQueryParam(params.Project),
+		Stages:   splitQueryParam(params.Stage),
+		Services: splitQueryParam(params.Service),
+	}
+
+	if params.SubscriptionID == nil || *params.SubscriptionID == "" {
+		return filter, nil
+	}
+	if params.IntegrationID == nil || *params.IntegrationID == "" {
+		return nil, ErrMissingIntegrationID
+	}
+
+	subscription, err := eh.UniformRepo.GetSubscription(*params.IntegrationID, *params.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(filter.Projects) == 0 {
+		filter.Projects = subscription.Filter.Projects
+	}
+	if len(filter.Stages) == 0 {
+		filter.Stages = subscription.Filter.Stages
+	}
+	if len(filter.Services) == 0 {
+		filter.Services = subscription.Filter.Services
+	}
+	return filter, nil
+}
+
+// getTriggeredEventsOfProjects retrieves the triggered events of multiple projects. Projects that do not exist are skipped
+func (eh *EventHandler) getTriggeredEventsOfProjects(projects []string, filter common.EventFilter) ([]apimodels.KeptnContextExtendedCE, error) {
+	allEvents := []apimodels.KeptnContextExtendedCE{}
+	for _, project := range projects {
+		events, err := eh.ShipyardController.GetTriggeredEventsOfProject(project, filter)
+		if err != nil {
+			if errors.Is(err, common.ErrProjectNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		allEvents = append(allEvents, events...)
+	}
+	return allEvents, nil
+}
+
+func splitQueryParam(param *string) []string {
+	if param == nil {
+		return nil
+	}
+	values := []string{}
+	for _, value := range strings.Split(*param, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
 // NewEventHandler creates a new EventHandler
-func NewEventHandler(shipyardController controller.IShipyardController) IEventHandler {
+func NewEventHandler(shipyardController controller.IShipyardController, uniformRepo db.UniformRepo, triggeredEventNotifier *controller.TriggeredEventNotifier) IEventHandler {
 	return &EventHandler{
-		ShipyardController: shipyardController,
+		ShipyardController:     shipyardController,
+		UniformRepo:            uniformRepo,
+		TriggeredEventNotifier: triggeredEventNotifier,
 	}
 }
@@ -6,15 +6,19 @@ import (
 	"github.com/keptn/keptn/shipyard-controller/internal/common"
 	"github.com/keptn/keptn/shipyard-controller/internal/controller"
 	"github.com/keptn/keptn/shipyard-controller/internal/controller/fake"
+	db_mock "github.com/keptn/keptn/shipyard-controller/internal/db/mock"
 	"github.com/keptn/keptn/shipyard-controller/internal/handler"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func TestEventHandler_HandleEvent(t *testing.T) {
@@ -177,3 +181,185 @@ func TestEventHandler_GetTriggeredEvents(t *testing.T) {
 		})
 	}
 }
+
+func TestEventHandler_GetTriggeredEventsWithMultipleValuesAndSubscription(t *testing.T) {
+	subscription := &apimodels.EventSubscription{
+		ID:    "my-subscription-id",
+		Event: "sh.keptn.event.test.triggered",
+		Filter: apimodels.EventSubscriptionFilter{
+			Projects: []string{"project-a", "project-b"},
+			Stages:   []string{"dev", "staging"},
+			Services: []string{"service-a"},
+		},
+	}
+	tests := []struct {
+		name             string
+		uniformRepo      *db_mock.UniformRepoMock
+		request          *http.Request
+		expectStatusCode int
+		expectProjects   []string
+		expectStages     []string
+		expectServices   []string
+	}{
+		{
+			name:             "comma separated filter values",
+			request:          httptest.NewRequest(http.MethodGet, "/event/triggered/sh.keptn.test.triggered?project=project-a,project-b&stage=dev,%20staging&service=service-a", nil),
+			expectStatusCode: http.StatusOK,
+			expectProjects:   []string{"project-a", "project-b"},
+			expectStages:     []string{"dev", "staging"},
+			expectServices:   []string{"service-a"},
+		},
+		{
+			name: "filter of subscription",
+			uniformRepo: &db_mock.UniformRepoMock{
+				GetSubscriptionFunc: func(integrationID string, subscriptionID string) (*apimodels.EventSubscription, error) {
+					return subscription, nil
+				},
+			},
+			request:          httptest.NewRequest(http.MethodGet, "/event/triggered/sh.keptn.test.triggered?integrationID=my-integration-id&subscriptionID=my-subscription-id", nil),
+			expectStatusCode: http.StatusOK,
+			expectProjects:   []string{"project-a", "project-b"},
+			expectStages:     []string{"dev", "staging"},
+			expectServices:   []string{"service-a"},
+		},
+		{
+			name: "explicit filter values take precedence over the filter of the subscription",
+			uniformRepo: &db_mock.UniformRepoMock{
+				GetSubscriptionFunc: func(integrationID string, subscriptionID string) (*apimodels.EventSubscription, error) {
+					return subscription, nil
+				},
+			},
+			request:          httptest.NewRequest(http.MethodGet, "/event/triggered/sh.keptn.test.triggered?integrationID=my-integration-id&subscriptionID=my-subscription-id&stage=prod", nil),
+			expectStatusCode: http.StatusOK,
+			expectProjects:   []string{"project-a", "project-b"},
+			expectStages:     []string{"prod"},
+			expectServices:   []string{"service-a"},
+		},
+		{
+			name:             "return 400 if subscriptionID is set without integrationID",
+			request:          httptest.NewRequest(http.MethodGet, "/event/triggered/sh.keptn.test.triggered?subscriptionID=my-subscription-id", nil),
+			expectStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "return 404 if subscription could not be found",
+			uniformRepo: &db_mock.UniformRepoMock{
+				GetSubscriptionFunc: func(integrationID string, subscriptionID string) (*apimodels.EventSubscription, error) {
+					return nil, mongo.ErrNoDocuments
+				},
+			},
+			request:          httptest.NewRequest(http.MethodGet, "/event/triggered/sh.keptn.test.triggered?integrationID=my-integration-id&subscriptionID=my-subscription-id", nil),
+			expectStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "return 500 if subscription could not be retrieved",
+			uniformRepo: &db_mock.UniformRepoMock{
+				GetSubscriptionFunc: func(integrationID string, subscriptionID string) (*apimodels.EventSubscription, error) {
+					return nil, errors.New("oops")
+				},
+			},
+			request:          httptest.NewRequest(http.MethodGet, "/event/triggered/sh.keptn.test.triggered?integrationID=my-integration-id&subscriptionID=my-subscription-id", nil),
+			expectStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shipyardController := &fake.IShipyardControllerMock{
+				GetTriggeredEventsOfProjectFunc: func(project string, filter common.EventFilter) ([]apimodels.KeptnContextExtendedCE, error) {
+					if project == "project-b" {
+						return nil, common.ErrProjectNotFound
+					}
+					return []apimodels.KeptnContextExtendedCE{{ID: "my-event-id"}}, nil
+				},
+			}
+			service := handler.NewEventHandler(shipyardController, tt.uniformRepo, nil)
+
+			router := gin.Default()
+			router.GET("/event/triggered/:eventType", func(c *gin.Context) {
+				service.GetTriggeredEvents(c)
+			})
+			w := performRequest(router, tt.request)
+			require.Equal(t, tt.expectStatusCode, w.Code)
+			if tt.expectStatusCode != http.StatusOK {
+				require.Empty(t, shipyardController.GetTriggeredEventsOfProjectCalls())
+				return
+			}
+
+			calls := shipyardController.GetTriggeredEventsOfProjectCalls()
+			require.Len(t, calls, len(tt.expectProjects))
+			for i, call := range calls {
+				require.Equal(t, tt.expectProjects[i], call.Project)
+				require.Equal(t, tt.expectStages, call.Filter.Stages)
+				require.Equal(t, tt.expectServices, call.Filter.Services)
+			}
+			require.Contains(t, w.Body.String(), "my-event-id")
+		})
+	}
+}
+
+func TestEventHandler_GetTriggeredEventsLongPolling(t *testing.T) {
+	var mutex sync.Mutex
+	openEvents := []apimodels.KeptnContextExtendedCE{{ID: "my-event-id"}}
+	getOpenEvents := func() []apimodels.KeptnContextExtendedCE {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return openEvents
+	}
+
+	shipyardController := &fake.IShipyardControllerMock{
+		GetAllTriggeredEventsFunc: func(filter common.EventFilter) ([]apimodels.KeptnContextExtendedCE, error) {
+			return getOpenEvents(), nil
+		},
+	}
+	notifier := controller.NewTriggeredEventNotifier()
+	service := &handler.EventHandler{
+		ShipyardController:         shipyardController,
+		TriggeredEventNotifier:     notifier,
+		LongPollingRecheckInterval: time.Hour,
+	}
+
+	router := gin.Default()
+	router.GET("/event/triggered/:eventType", func(c *gin.Context) {
+		service.GetTriggeredEvents(c)
+	})
+
+	longPollRequest := func(etag string, waitTimeout string) *http.Request {
+		request := httptest.NewRequest(http.MethodGet, "/event/triggered/sh.keptn.test.triggered?waitTimeout="+waitTimeout, nil)
+		request.Header.Set("If-None-Match", etag)
+		return request
+	}
+
+	// a regular request returns the current events together with their ETag
+	w := performRequest(router, httptest.NewRequest(http.MethodGet, "/event/triggered/sh.keptn.test.triggered", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	// an unknown ETag is answered immediately
+	w = performRequest(router, longPollRequest(`"unknown"`, "30"))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, etag, w.Header().Get("ETag"))
+
+	// without changes, the request is answered with 304 after the timeout
+	start := time.Now()
+	w = performRequest(router, longPollRequest(etag, "1"))
+	require.Equal(t, http.StatusNotModified, w.Code)
+	require.Equal(t, etag, w.Header().Get("ETag"))
+	require.GreaterOrEqual(t, time.Since(start), time.Second)
+
+	// a new .triggered event completes the request
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		mutex.Lock()
+		openEvents = append(openEvents, apimodels.KeptnContextExtendedCE{ID: "my-new-event-id"})
+		mutex.Unlock()
+		notifier.OnSequenceTaskEvent(apimodels.KeptnContextExtendedCE{Type: common.Stringp("sh.keptn.event.test.triggered")})
+	}()
+	w = performRequest(router, longPollRequest(etag, "30"))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotEqual(t, etag, w.Header().Get("ETag"))
+	require.Contains(t, w.Body.String(), "my-new-event-id")
+
+	// the wait timeout is limited
+	w = performRequest(router, longPollRequest(etag, "31"))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -190,7 +190,9 @@ func _main(env config.EnvConfig, kubeAPI kubernetes.Interface) {
 	serviceController := routing.NewServiceController(serviceHandler)
 	serviceController.Inject(apiV1)
 
-	eventHandler := handler.NewEventHandler(shipyardController)
+	triggeredEventNotifier := controller.NewTriggeredEventNotifier()
+	shipyardController.AddSequenceTaskEventHook(triggeredEventNotifier)
+	eventHandler := handler.NewEventHandler(shipyardController, uniformRepo, triggeredEventNotifier)
 	eventController := routing.NewEventController(eventHandler)
 	eventController.Inject(apiV1)
 
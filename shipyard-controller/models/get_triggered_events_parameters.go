@@ -25,6 +25,14 @@ type GetTriggeredEventsParams struct {
 	  In: query
 	*/
 	EventID *string `form:"eventID" json:"eventID"`
+	/*ID of the uniform integration owning the subscription
+	  In: query
+	*/
+	IntegrationID *string `form:"integrationID" json:"integrationID"`
+	/*ID of the subscription whose filter should be applied
+	  In: query
+	*/
+	SubscriptionID *string `form:"subscriptionID" json:"subscriptionID"`
 	/*Event type
 	  Required: true
 	  In: path
@@ -41,16 +49,21 @@ type GetTriggeredEventsParams struct {
 	  Default: 20
 	*/
 	PageSize *int64 `form:"pageSize" json:"pageSize"`
-	/*Project name
+	/*Project name(s), comma separated
 	  In: query
 	*/
 	Project *string `form:"project" json:"project"`
-	/*Service name
+	/*Service name(s), comma separated
 	  In: query
 	*/
 	Service *string `form:"service" json:"service"`
-	/*Stage name
+	/*Stage name(s), comma separated
 	  In: query
 	*/
 	Stage *string `form:"stage" json:"stage"`
+	/*Number of seconds to wait for changes of the open events if the If-None-Match header is set
+	  Maximum: 30
+	  In: query
+	*/
+	WaitTimeout *int64 `form:"waitTimeout" json:"waitTimeout" binding:"omitempty,min=0,max=30"`
 }
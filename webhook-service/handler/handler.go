@@ -62,7 +62,7 @@ func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (i
 
 	onError := th.getErrorCallbackForWebhookConfig(keptnHandler, event, eventAdapter, webhook)
 
-	secretEnvVars, err := th.gatherSecretEnvVars(*webhook)
+	secretEnvVars, err := th.gatherSecretEnvVars(*webhook, eventAdapter)
 	if err != nil {
 		onError(err, secretEnvVars)
 		return nil, sdkError(removeSecretsFromMessage(err.Error(), secretEnvVars), err)
@@ -245,10 +245,10 @@ func UnmarshalResponse(response string) interface{} {
 
 }
 
-func (th *TaskHandler) gatherSecretEnvVars(webhook lib.Webhook) (map[string]string, error) {
+func (th *TaskHandler) gatherSecretEnvVars(webhook lib.Webhook, eventAdapter *lib.EventDataAdapter) (map[string]string, error) {
 	secretEnvVars := map[string]string{}
 	for _, secretRef := range webhook.EnvFrom {
-		secretValue, err := th.secretReader.ReadSecret(secretRef.SecretRef.Name, secretRef.SecretRef.Key)
+		secretValue, err := th.secretReader.ReadSecret(secretRef.SecretRef.Name, secretRef.SecretRef.Key, eventAdapter.Project(), eventAdapter.Stage())
 		if err != nil {
 			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not read secret %s.%s", secretRef.SecretRef.Name, secretRef.SecretRef.Key))
 		}
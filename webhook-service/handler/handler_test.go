@@ -349,7 +349,7 @@ func Test_HandleIncomingTriggeredEvent(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -387,7 +387,7 @@ func Test_HandleIncomingTriggeredEvent(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -432,7 +432,7 @@ func Test_HandleIncomingStartedEvent(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -464,7 +464,7 @@ func Test_HandleIncomingStartedEvent(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -502,7 +502,7 @@ func Test_HandleIncomingStartedEventWithResultingError(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -537,7 +537,7 @@ func Test_HandleIncomingStartedEventWithResultingError(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -576,7 +576,7 @@ func Test_HandleIncomingFinishedEvent(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -610,7 +610,7 @@ func Test_HandleIncomingFinishedEvent(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -650,7 +650,7 @@ func Test_HandleIncomingFinishedEventWithResultingError(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -685,7 +685,7 @@ func Test_HandleIncomingFinishedEventWithResultingError(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -726,7 +726,7 @@ func Test_HandleIncomingFinishedEventWithJSON(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -816,7 +816,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequests(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -856,7 +856,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequests(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -903,7 +903,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinished(t *te
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -946,7 +946,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinished(t *te
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -993,7 +993,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableStarted(t *tes
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -1031,7 +1031,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableStarted(t *tes
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -1074,7 +1074,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinishedOneReq
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -1124,7 +1124,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinishedOneReq
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -1179,7 +1179,7 @@ func Test_HandleIncomingTriggeredEvent_NoMatchingWebhookFound(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -1217,7 +1217,7 @@ func Test_HandleIncomingTriggeredEvent_NoMatchingWebhookFound(t *testing.T) {
 		}}
 
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 
@@ -1343,7 +1343,7 @@ func TestTaskHandler_CannotReadSecret(t *testing.T) {
 	t.Run("TestTaskHandler_CannotReadSecret - ALPHA", func(t *testing.T) {
 		templateEngineMock := &fake.ITemplateEngineMock{}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "", errors.New("unable to read secret :(")
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1370,7 +1370,7 @@ func TestTaskHandler_CannotReadSecret(t *testing.T) {
 	t.Run("TestTaskHandler_CannotReadSecret - BETA", func(t *testing.T) {
 		templateEngineMock := &fake.ITemplateEngineMock{}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "", errors.New("unable to read secret :(")
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1405,7 +1405,7 @@ func TestTaskHandler_IncompleteDataForTemplate(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1441,7 +1441,7 @@ func TestTaskHandler_IncompleteDataForTemplate(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1483,7 +1483,7 @@ func TestTaskHandler_CurlExecutorFails(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1521,7 +1521,7 @@ func TestTaskHandler_CurlExecutorFails(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1563,7 +1563,7 @@ func TestTaskHandler_RequestValidatorFails(t *testing.T) {
 		return tplE.ParseTemplate(data, templateStr)
 	}}
 	secretReaderMock := &fake.ISecretReaderMock{}
-	secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+	secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 		return "my-secret-value", nil
 	}
 	curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1604,7 +1604,7 @@ func TestTaskHandler_CurlExecutorFailsHideSecret(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1646,7 +1646,7 @@ func TestTaskHandler_CurlExecutorFailsHideSecret(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1694,7 +1694,7 @@ func TestTaskHandler_Execute_WebhookConfigInService(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1747,7 +1747,7 @@ func TestTaskHandler_Execute_WebhookConfigInService(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1806,7 +1806,7 @@ func TestTaskHandler_Execute_WebhookConfigInStage(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1871,7 +1871,7 @@ func TestTaskHandler_Execute_WebhookConfigInStage(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -1941,7 +1941,7 @@ func TestTaskHandler_Execute_WebhookConfigInProject(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -2012,7 +2012,7 @@ func TestTaskHandler_Execute_WebhookConfigInProject(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
@@ -2089,7 +2089,7 @@ func TestTaskHandler_Execute_WebhookConfigInProjectFailsEmptyURL(t *testing.T) {
 			return tplE.ParseTemplate(data, templateStr)
 		}}
 		secretReaderMock := &fake.ISecretReaderMock{}
-		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		secretReaderMock.ReadSecretFunc = func(name string, key string, project string, stage string) (string, error) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
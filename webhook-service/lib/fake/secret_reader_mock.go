@@ -18,7 +18,7 @@ var _ lib.ISecretReader = &ISecretReaderMock{}
 //
 // 		// make and configure a mocked lib.ISecretReader
 // 		mockedISecretReader := &ISecretReaderMock{
-// 			ReadSecretFunc: func(name string, key string) (string, error) {
+// 			ReadSecretFunc: func(name string, key string, project string, stage string) (string, error) {
 // 				panic("mock out the ReadSecret method")
 // 			},
 // 		}
@@ -29,7 +29,7 @@ var _ lib.ISecretReader = &ISecretReaderMock{}
 // 	}
 type ISecretReaderMock struct {
 	// ReadSecretFunc mocks the ReadSecret method.
-	ReadSecretFunc func(name string, key string) (string, error)
+	ReadSecretFunc func(name string, key string, project string, stage string) (string, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -39,39 +39,51 @@ type ISecretReaderMock struct {
 			Name string
 			// Key is the key argument value.
 			Key string
+			// Project is the project argument value.
+			Project string
+			// Stage is the stage argument value.
+			Stage string
 		}
 	}
 	lockReadSecret sync.RWMutex
 }
 
 // ReadSecret calls ReadSecretFunc.
-func (mock *ISecretReaderMock) ReadSecret(name string, key string) (string, error) {
+func (mock *ISecretReaderMock) ReadSecret(name string, key string, project string, stage string) (string, error) {
 	if mock.ReadSecretFunc == nil {
 		panic("ISecretReaderMock.ReadSecretFunc: method is nil but ISecretReader.ReadSecret was just called")
 	}
 	callInfo := struct {
-		Name string
-		Key  string
+		Name    string
+		Key     string
+		Project string
+		Stage   string
 	}{
-		Name: name,
-		Key:  key,
+		Name:    name,
+		Key:     key,
+		Project: project,
+		Stage:   stage,
 	}
 	mock.lockReadSecret.Lock()
 	mock.calls.ReadSecret = append(mock.calls.ReadSecret, callInfo)
 	mock.lockReadSecret.Unlock()
-	return mock.ReadSecretFunc(name, key)
+	return mock.ReadSecretFunc(name, key, project, stage)
 }
 
 // ReadSecretCalls gets all the calls that were made to ReadSecret.
 // Check the length with:
 //     len(mockedISecretReader.ReadSecretCalls())
 func (mock *ISecretReaderMock) ReadSecretCalls() []struct {
-	Name string
-	Key  string
+	Name    string
+	Key     string
+	Project string
+	Stage   string
 } {
 	var calls []struct {
-		Name string
-		Key  string
+		Name    string
+		Key     string
+		Project string
+		Stage   string
 	}
 	mock.lockReadSecret.RLock()
 	calls = mock.calls.ReadSecret
@@ -3,13 +3,21 @@ package lib
 import (
 	"context"
 	"errors"
+	"fmt"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
+// labels set by Keptn's secret-service on secrets that are bound to a project and stage
+const secretProjectLabel = "keptn.sh/project"
+const secretStageLabel = "keptn.sh/stage"
+
 //go:generate moq  -pkg fake -out ./fake/secret_reader_mock.go . ISecretReader
 type ISecretReader interface {
-	ReadSecret(name, key string) (string, error)
+	// ReadSecret returns the value of the key of the secret. Secrets bound to a project or stage
+	// can only be read for webhooks of that project and stage
+	ReadSecret(name, key, project, stage string) (string, error)
 }
 
 type K8sSecretReater struct {
@@ -20,7 +28,7 @@ func NewK8sSecretReader(k8sClient kubernetes.Interface) *K8sSecretReater {
 	return &K8sSecretReater{k8sClient: k8sClient}
 }
 
-func (sr *K8sSecretReater) ReadSecret(name, key string) (string, error) {
+func (sr *K8sSecretReater) ReadSecret(name, key, project, stage string) (string, error) {
 	secret, err := sr.k8sClient.CoreV1().Secrets(GetNamespaceFromEnvVar()).Get(context.TODO(), name, metav1.GetOptions{})
 	if err != nil {
 		return "", err
@@ -29,5 +37,11 @@ func (sr *K8sSecretReater) ReadSecret(name, key string) (string, error) {
 	if secret.Labels["app.kubernetes.io/managed-by"] != "keptn-secret-service" {
 		return "", errors.New("only secrets managed by Keptn's secret-service can be referenced")
 	}
+	if secretProject := secret.Labels[secretProjectLabel]; secretProject != "" && secretProject != project {
+		return "", fmt.Errorf("secret %s is not available for project %s", name, project)
+	}
+	if secretStage := secret.Labels[secretStageLabel]; secretStage != "" && secretStage != stage {
+		return "", fmt.Errorf("secret %s is not available for stage %s", name, stage)
+	}
 	return string(secret.Data[key]), nil
 }
@@ -18,12 +18,12 @@ func TestK8sSecretReater_ReadSecret(t *testing.T) {
 		}),
 	))
 
-	secret, err := secretReader.ReadSecret("my-secret", "foo")
+	secret, err := secretReader.ReadSecret("my-secret", "foo", "my-project", "my-stage")
 
 	require.Nil(t, err)
 	require.Equal(t, "bar", secret)
 
-	secret, err = secretReader.ReadSecret("my-missing-secret", "foo")
+	secret, err = secretReader.ReadSecret("my-missing-secret", "foo", "my-project", "my-stage")
 
 	require.NotNil(t, err)
 	require.Empty(t, secret)
@@ -35,12 +35,38 @@ func TestK8sSecretReater_ReadSecretWithInvalidScope(t *testing.T) {
 		getK8sSecret(map[string]string{}),
 	))
 
-	secret, err := secretReader.ReadSecret("my-secret", "foo")
+	secret, err := secretReader.ReadSecret("my-secret", "foo", "my-project", "my-stage")
 
 	require.NotNil(t, err)
 	require.Equal(t, "", secret)
 }
 
+func TestK8sSecretReater_ReadSecretBoundToProjectAndStage(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "keptn")
+	secretReader := lib.NewK8sSecretReader(fake.NewSimpleClientset(
+		getK8sSecret(map[string]string{
+			"app.kubernetes.io/managed-by": "keptn-secret-service",
+			"keptn.sh/project":             "my-project",
+			"keptn.sh/stage":               "my-stage",
+		}),
+	))
+
+	secret, err := secretReader.ReadSecret("my-secret", "foo", "my-project", "my-stage")
+
+	require.Nil(t, err)
+	require.Equal(t, "bar", secret)
+
+	secret, err = secretReader.ReadSecret("my-secret", "foo", "my-other-project", "my-stage")
+
+	require.NotNil(t, err)
+	require.Empty(t, secret)
+
+	secret, err = secretReader.ReadSecret("my-secret", "foo", "my-project", "my-other-stage")
+
+	require.NotNil(t, err)
+	require.Empty(t, secret)
+}
+
 func getK8sSecret(labels map[string]string) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{